@@ -25,7 +25,9 @@ import (
 	"github.com/opentofu/opentofu/internal/command/views"
 	"github.com/opentofu/opentofu/internal/getmodules"
 	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/lang/funcplugin"
 	pluginDiscovery "github.com/opentofu/opentofu/internal/plugin/discovery"
+	"github.com/opentofu/opentofu/internal/tofu"
 )
 
 // runningInAutomationEnvName gives the name of an environment variable that
@@ -63,6 +65,8 @@ func initCommands(
 	providerSrc getproviders.Source,
 	providerDevOverrides map[addrs.Provider]getproviders.PackageLocalDir,
 	unmanagedProviders map[addrs.Provider]*plugin.ReattachConfig,
+	externalHooks []tofu.Hook,
+	functionPlugins map[string]*funcplugin.Plugin,
 ) {
 	var inAutomation bool
 	if v := os.Getenv(runningInAutomationEnvName); v != "" {
@@ -84,6 +88,11 @@ func initCommands(
 		configDir = "" // No config dir available (e.g. looking up a home directory failed)
 	}
 
+	// The CLI configuration was already validated by the time we get here,
+	// so any error here was already reported.
+	defaultRetryPolicy, _ := config.ApplyRetryPolicy()
+	savedPlanRequirements, _ := config.SavedPlanRequirements()
+
 	meta := command.Meta{
 		WorkingDir: wd,
 		View:       view.SetRunningInAutomation(inAutomation),
@@ -91,6 +100,8 @@ func initCommands(
 			RunningInAutomation:       inAutomation,
 			CLIConfigDir:              configDir,
 			PluginCacheDir:            config.PluginCacheDir,
+			ModuleCacheDir:            config.ModuleCacheDir,
+			ModuleInstallConcurrency:  config.ModuleInstallConcurrency,
 			GlobalPluginDirs:          globalPluginDirs(),
 			AllowExperimentalFeatures: experimentsAreAllowed(),
 			E2ETestingFeaturesEnabled: e2eTestingFeaturesEnabled(),
@@ -100,6 +111,14 @@ func initCommands(
 		BrowserLauncher: browserLauncher(),
 
 		PluginCacheMayBreakDependencyLockFile: config.PluginCacheMayBreakDependencyLockFile,
+		PluginCacheReadOnly:                   config.PluginCacheReadOnly,
+		ProviderInstallConcurrency:            config.ProviderInstallConcurrency,
+		DefaultRetryPolicy:                    defaultRetryPolicy,
+		SavedPlanRequirements:                 savedPlanRequirements,
+		ProviderConcurrency:                   config.ProviderConcurrencyLimits(),
+		ResourceTypeConcurrency:               config.ResourceTypeMaxConcurrency,
+		ExternalHooks:                         externalHooks,
+		FunctionPlugins:                       functionPlugins,
 
 		ShutdownCh:    makeShutdownCh(),
 		CallerContext: ctx,
@@ -144,6 +163,12 @@ func initCommands(
 			}, nil
 		},
 
+		"drift": func() (cli.Command, error) {
+			return &command.DriftCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"destroy": func() (cli.Command, error) {
 			return &command.ApplyCommand{
 				Meta:    meta,
@@ -186,6 +211,12 @@ func initCommands(
 			}, nil
 		},
 
+		"explain": func() (cli.Command, error) {
+			return &command.ExplainCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"fmt": func() (cli.Command, error) {
 			return &command.FmtCommand{
 				Meta: meta,
@@ -252,6 +283,18 @@ func initCommands(
 			}, nil
 		},
 
+		"plan approve": func() (cli.Command, error) {
+			return &command.PlanApproveCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"plan diff": func() (cli.Command, error) {
+			return &command.PlanDiffCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"providers": func() (cli.Command, error) {
 			return &command.ProvidersCommand{
 				Meta: meta,
@@ -288,6 +331,18 @@ func initCommands(
 			}, nil
 		},
 
+		"registry": func() (cli.Command, error) {
+			return &command.RegistryCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"registry serve": func() (cli.Command, error) {
+			return &command.RegistryServeCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"show": func() (cli.Command, error) {
 			return &command.ShowCommand{
 				Meta: meta,
@@ -0,0 +1,71 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/command/cliconfig"
+	"github.com/opentofu/opentofu/internal/command/externalhook"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// externalHooks builds the external hooks described by the "hook" blocks in
+// the CLI configuration.
+//
+// Any hook with an invalid configuration is reported in the diagnostics and
+// omitted from the result.
+func externalHooks(configs map[string]*cliconfig.ConfigHook) ([]tofu.Hook, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	if len(configs) == 0 {
+		return nil, diags
+	}
+
+	// We sort the hooks by name so that they always run in the same order.
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var ret []tofu.Hook
+	for _, name := range names {
+		config := configs[name]
+		hookConfig := externalhook.Config{
+			Name:    name,
+			Command: config.Command,
+			URL:     config.URL,
+		}
+		for _, event := range config.Events {
+			hookConfig.Events = append(hookConfig.Events, externalhook.Event(event))
+		}
+		if config.Timeout != "" {
+			timeout, err := time.ParseDuration(config.Timeout)
+			if err != nil {
+				diags = diags.Append(tfdiags.Sourceless(
+					tfdiags.Error,
+					"Invalid hook configuration",
+					fmt.Sprintf("The hook %q has an invalid timeout: %s.", name, err),
+				))
+				continue
+			}
+			hookConfig.Timeout = timeout
+		}
+
+		hook, err := externalhook.New(hookConfig)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid hook configuration",
+				fmt.Sprintf("The hook %q will not run: %s.", name, err),
+			))
+			continue
+		}
+		ret = append(ret, hook)
+	}
+	return ret, diags
+}
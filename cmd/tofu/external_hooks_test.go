@@ -0,0 +1,44 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/command/cliconfig"
+)
+
+func TestExternalHooks(t *testing.T) {
+	hooks, diags := externalHooks(map[string]*cliconfig.ConfigHook{
+		"valid": {
+			Events:  []string{"pre_apply"},
+			Command: []string{"check-change"},
+			Timeout: "5s",
+		},
+		"bad-timeout": {
+			Events:  []string{"pre_apply"},
+			Command: []string{"check-change"},
+			Timeout: "soon",
+		},
+		"remote": {
+			Events: []string{"post_apply"},
+			URL:    "https://example.com/hook",
+		},
+	})
+
+	if got, want := len(hooks), 1; got != want {
+		t.Errorf("wrong number of hooks %d; want %d", got, want)
+	}
+	if got, want := len(diags), 2; got != want {
+		t.Fatalf("wrong number of diagnostics %d; want %d\n%s", got, want, diags.Err())
+	}
+	// The diagnostics are in the same order as the sorted hook names.
+	if got := diags[0].Description().Detail; !strings.Contains(got, `The hook "bad-timeout" has an invalid timeout`) {
+		t.Errorf("wrong first diagnostic: %s", got)
+	}
+	if got := diags[1].Description().Detail; !strings.Contains(got, "the host must be localhost or a loopback address") {
+		t.Errorf("wrong second diagnostic: %s", got)
+	}
+}
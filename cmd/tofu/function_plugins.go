@@ -0,0 +1,42 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/opentofu/opentofu/internal/command/cliconfig"
+	"github.com/opentofu/opentofu/internal/lang/funcplugin"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// functionPlugins builds the function plugins described by the
+// "function_plugin" blocks in the CLI configuration.
+//
+// The plugins are not started here: each one runs only once a module that
+// requires it is evaluated. Any plugin with an invalid configuration is
+// reported in the diagnostics and omitted from the result.
+func functionPlugins(configs map[string]*cliconfig.ConfigFunctionPlugin) (map[string]*funcplugin.Plugin, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	if len(configs) == 0 {
+		return nil, diags
+	}
+
+	ret := make(map[string]*funcplugin.Plugin, len(configs))
+	for _, name := range slices.Sorted(maps.Keys(configs)) {
+		plugin, err := funcplugin.New(name, configs[name].Command)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid function plugin configuration",
+				fmt.Sprintf("The function plugin %q cannot be used: %s.", name, err),
+			))
+			continue
+		}
+		ret[name] = plugin
+	}
+	return ret, diags
+}
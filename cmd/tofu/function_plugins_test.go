@@ -0,0 +1,37 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/command/cliconfig"
+)
+
+func TestFunctionPlugins(t *testing.T) {
+	plugins, diags := functionPlugins(map[string]*cliconfig.ConfigFunctionPlugin{
+		"naming": {
+			Command: []string{"naming-functions"},
+		},
+		"no-command": {},
+		"not valid": {
+			Command: []string{"other-functions"},
+		},
+	})
+
+	if _, ok := plugins["naming"]; !ok || len(plugins) != 1 {
+		t.Errorf("wrong plugins %#v; want only \"naming\"", plugins)
+	}
+	if got, want := len(diags), 2; got != want {
+		t.Fatalf("wrong number of diagnostics %d; want %d\n%s", got, want, diags.Err())
+	}
+	// The diagnostics are in the same order as the sorted plugin names.
+	if got := diags[0].Description().Detail; !strings.Contains(got, "the command option is required") {
+		t.Errorf("wrong first diagnostic: %s", got)
+	}
+	if got := diags[1].Description().Detail; !strings.Contains(got, `"not valid" is not a valid plugin name`) {
+		t.Errorf("wrong second diagnostic: %s", got)
+	}
+}
@@ -231,13 +231,25 @@ func realMain() int {
 		}
 	}
 
+	hooks, diags := externalHooks(config.Hooks)
+	if len(diags) > 0 {
+		rv.Error("There are some problems with the hook configuration:")
+		rv.Diagnostics(diags)
+	}
+
+	funcPlugins, diags := functionPlugins(config.FunctionPlugins)
+	if len(diags) > 0 {
+		rv.Error("There are some problems with the function plugin configuration:")
+		rv.Diagnostics(diags)
+	}
+
 	// In tests, Commands may already be set to provide mock commands
 	if commands == nil {
 		// Commands get to hold on to the original working directory here,
 		// in case they need to refer back to it for any special reason, though
 		// they should primarily be working with the override working directory
 		// that we've now switched to above.
-		initCommands(ctx, wd, view, config, services, modulePkgFetcher, providerSrc, providerDevOverrides, unmanagedProviders)
+		initCommands(ctx, wd, view, config, services, modulePkgFetcher, providerSrc, providerDevOverrides, unmanagedProviders, hooks, funcPlugins)
 	}
 
 	// Attempt to ensure the config directory exists.
@@ -36,18 +36,26 @@ func DiagnosticOriginatesFromCheckRule(diag tfdiags.Diagnostic) (CheckRule, bool
 // It also implements the tfdiags.DiagnosticExtraDoNotConsolidate interface, to
 // stop diagnostics created by check blocks being consolidated.
 //
+// It also implements the tfdiags.DiagnosticExtraErrorCode interface, to expose
+// the error code given in the configuration of the check rule.
+//
 // It also implements the tfdiags.DiagnosticExtraUnwrapper interface, as nested
 // data blocks will attach this struct but do want to lose any extra info
 // embedded in the original diagnostic.
 type CheckRuleDiagnosticExtra struct {
 	CheckRule CheckRule
 
+	// ErrorCode is the error code given in the configuration of the check
+	// rule, or an empty string if it has none.
+	ErrorCode string
+
 	wrapped any
 }
 
 var (
 	_ DiagnosticExtraCheckRule                = (*CheckRuleDiagnosticExtra)(nil)
 	_ tfdiags.DiagnosticExtraDoNotConsolidate = (*CheckRuleDiagnosticExtra)(nil)
+	_ tfdiags.DiagnosticExtraErrorCode        = (*CheckRuleDiagnosticExtra)(nil)
 	_ tfdiags.DiagnosticExtraUnwrapper        = (*CheckRuleDiagnosticExtra)(nil)
 	_ tfdiags.DiagnosticExtraWrapper          = (*CheckRuleDiagnosticExtra)(nil)
 )
@@ -73,3 +81,7 @@ func (c *CheckRuleDiagnosticExtra) DoNotConsolidateDiagnostic() bool {
 func (c *CheckRuleDiagnosticExtra) DiagnosticOriginatesFromCheckRule() CheckRule {
 	return c.CheckRule
 }
+
+func (c *CheckRuleDiagnosticExtra) DiagnosticErrorCode() string {
+	return c.ErrorCode
+}
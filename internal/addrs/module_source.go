@@ -240,6 +240,30 @@ func ParseModuleSourceRegistry(raw string) (ModuleSource, error) {
 	}, nil
 }
 
+// ParseModuleSourceVersioned parses the source address of a module call that
+// also has a version constraint.
+//
+// Version constraints are primarily for module registry addresses, but they
+// are also accepted for remote addresses that explicitly select the git
+// getter using the "git::" prefix, in which case the module installer
+// resolves the constraint against the tags in the repository. Any other
+// address is parsed as a module registry address, as with
+// [ParseModuleSourceRegistry].
+func ParseModuleSourceVersioned(raw string) (ModuleSource, error) {
+	if !strings.HasPrefix(raw, "git::") {
+		return ParseModuleSourceRegistry(raw)
+	}
+
+	addr, err := parseModuleSourceRemote(raw)
+	if err != nil {
+		return nil, err
+	}
+	if getmodules.GitPackageHasRef(addr.Package.String()) {
+		return nil, fmt.Errorf("git source address %q selects a ref, which conflicts with the version constraint; remove the \"ref\" argument to select a tag matching the version constraint", raw)
+	}
+	return addr, nil
+}
+
 func (s ModuleSourceRegistry) moduleSource() {}
 
 func (s ModuleSourceRegistry) String() string {
@@ -659,6 +659,75 @@ func TestParseModuleSourceRegistry(t *testing.T) {
 	}
 }
 
+func TestParseModuleSourceVersioned(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    ModuleSource
+		wantErr string
+	}{
+		"registry address": {
+			input: "hashicorp/consul/aws",
+			want: ModuleSourceRegistry{
+				Package: ModuleRegistryPackage{
+					Host:         svchost.Hostname("registry.opentofu.org"),
+					Namespace:    "hashicorp",
+					Name:         "consul",
+					TargetSystem: "aws",
+				},
+			},
+		},
+		"git over HTTPS with subdir": {
+			input: "git::https://example.com/monorepo.git//modules/vpc",
+			want: ModuleSourceRemote{
+				Package: ModulePackage("git::https://example.com/monorepo.git"),
+				Subdir:  "modules/vpc",
+			},
+		},
+		"git over SSH": {
+			input: "git::ssh://git@example.com/monorepo.git",
+			want: ModuleSourceRemote{
+				Package: ModulePackage("git::ssh://git@example.com/monorepo.git"),
+			},
+		},
+		"git with ref": {
+			input:   "git::https://example.com/monorepo.git//modules/vpc?ref=v1.0.0",
+			wantErr: `git source address "git::https://example.com/monorepo.git//modules/vpc?ref=v1.0.0" selects a ref, which conflicts with the version constraint; remove the "ref" argument to select a tag matching the version constraint`,
+		},
+		"git without explicit getter": {
+			input:   "github.com/hashicorp/example",
+			wantErr: `source address must have three more components after the hostname: the namespace, the name, and the target system`,
+		},
+		"local path": {
+			input:   "./child",
+			wantErr: `can't use local directory "./child" as a module registry address`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			addr, err := ParseModuleSourceVersioned(test.input)
+
+			if test.wantErr != "" {
+				switch {
+				case err == nil:
+					t.Errorf("unexpected success\nwant error: %s", test.wantErr)
+				case err.Error() != test.wantErr:
+					t.Errorf("wrong error messages\ngot:  %s\nwant: %s", err.Error(), test.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if diff := cmp.Diff(test.want, addr); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
 func testDataAbsolutePath() (absolutePath string, modulePackage string) {
 	absolutePath = "/tmp/foo/example"
 	modulePackage = "file:///tmp/foo/example"
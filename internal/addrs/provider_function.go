@@ -39,11 +39,15 @@ type Function struct {
 const (
 	FunctionNamespaceProvider = "provider"
 	FunctionNamespaceCore     = "core"
+	FunctionNamespaceModule   = "module"
+	FunctionNamespacePlugin   = "plugin"
 )
 
 var FunctionNamespaces = []string{
 	FunctionNamespaceProvider,
 	FunctionNamespaceCore,
+	FunctionNamespaceModule,
+	FunctionNamespacePlugin,
 }
 
 func ParseFunction(input string) Function {
@@ -14,6 +14,7 @@ import (
 	"errors"
 	"log"
 	"os"
+	"time"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/opentofu/svchost"
@@ -300,6 +301,33 @@ type Operation struct {
 	Targets      []addrs.Targetable
 	Excludes     []addrs.Targetable
 	ForceReplace []addrs.AbsResourceInstance
+	// AllowDeferral allows planning to defer objects whose count or for_each
+	// is not yet known, instead of failing. See tofu.PlanOpts.AllowDeferral.
+	AllowDeferral bool
+	// Journal, for an apply operation, asks the backend to keep a record of
+	// which changes it has started and completed, so that the apply can be
+	// resumed if it gets interrupted.
+	Journal bool
+	// Resume, for an apply operation with a PlanFile, continues an earlier
+	// apply of the same plan that was interrupted, using the backend's
+	// record of which changes it had already started.
+	Resume bool
+	// Report, for an apply operation, asks the backend to report
+	// the outcome of every planned change in RunningOperation.ApplyReport,
+	// including those that were skipped. It doesn't change which changes
+	// the apply attempts.
+	Report bool
+	// PolicyDir, for a plan or apply operation, is a directory of policy
+	// files to evaluate against the plan before it is saved or applied.
+	PolicyDir string
+	// PlanExpiresIn, for a plan operation that saves the plan, is how long
+	// after its creation the saved plan can be applied. Zero means that the
+	// plan doesn't expire.
+	PlanExpiresIn time.Duration
+	// SavedPlanRequirements, for an apply operation with a PlanFile, are
+	// additional conditions that the saved plan must meet, such as being
+	// approved. This is nil if there are no such conditions.
+	SavedPlanRequirements *planfile.Requirements
 	// Injected by the command creating the operation (plan/apply/refresh/etc...)
 	Variables map[string]UnparsedVariableValue
 	RootCall  configs.StaticModuleCall
@@ -411,6 +439,12 @@ type RunningOperation struct {
 	// this state is managed by the backend. This should only be read
 	// after the operation completes to avoid read/write races.
 	State *states.State
+
+	// ApplyReport is populated after an apply operation with
+	// Operation.Report set, to describe which of the planned
+	// changes succeeded, failed, or were skipped. It is nil if the apply
+	// didn't start.
+	ApplyReport *tofu.ApplyReport
 }
 
 // OperationResult describes the result status of an operation.
@@ -80,4 +80,9 @@ type CLIOpts struct {
 	// some sort of workflow automation tool that abstracts away the
 	// exact commands that are being run.
 	RunningInAutomation bool
+
+	// DataDir is the directory where OpenTofu keeps data that is specific
+	// to the current working directory, such as the apply journal for
+	// a backend that stores state remotely.
+	DataDir string
 }
@@ -0,0 +1,297 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package local
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// DefaultJournalExtension is the suffix added to the state output path to
+// find the apply journal for a workspace whose state is stored locally.
+const DefaultJournalExtension = ".journal"
+
+const applyJournalVersion = 1
+
+// The events recorded in an apply journal.
+const (
+	// journalEventBegin is always the first entry, and identifies the plan
+	// being applied.
+	journalEventBegin = "begin"
+
+	// journalEventResume is recorded each time an apply of the same plan is
+	// resumed.
+	journalEventResume = "resume"
+
+	// journalEventStart is recorded before asking a provider to apply a
+	// change, and journalEventComplete or journalEventFail afterwards.
+	journalEventStart    = "start"
+	journalEventComplete = "complete"
+	journalEventFail     = "fail"
+
+	// journalEventState is recorded whenever the apply saves a state
+	// snapshot with a new serial, so that resuming can verify that nothing
+	// else has changed the state since.
+	journalEventState = "state"
+)
+
+// applyJournalEntry is a single line of an apply journal.
+//
+// An apply journal is a write-ahead log of the changes to resource instances
+// that an apply operation started, completed or failed, which allows a later
+// "tofu apply -resume" to work out which planned changes were in progress
+// if the apply was interrupted without a chance to record its results in the
+// state.
+type applyJournalEntry struct {
+	Event string    `json:"event"`
+	Time  time.Time `json:"time"`
+
+	// Version and Plan are set only for journalEventBegin. Plan is the
+	// result of applyJournalPlanID for the plan being applied.
+	Version int    `json:"version,omitempty"`
+	Plan    string `json:"plan,omitempty"`
+
+	// Serial is set only for journalEventState, to the serial of the state
+	// snapshot that the apply saved.
+	Serial *uint64 `json:"serial,omitempty"`
+
+	// The remaining fields are set only for the events about changes to
+	// individual resource instances. Action is the string representation
+	// of a plans.Action.
+	Addr    string `json:"addr,omitempty"`
+	Deposed string `json:"deposed,omitempty"`
+	Action  string `json:"action,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// applyJournal is an open apply journal file, which is safe to write to
+// concurrently.
+type applyJournal struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// createApplyJournal creates a new apply journal at the given path for
+// applying the given plan, replacing any journal already there.
+func createApplyJournal(path string, plan *plans.Plan) (*applyJournal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, err
+	}
+	j := &applyJournal{f: f}
+	err = j.record(applyJournalEntry{
+		Event:   journalEventBegin,
+		Version: applyJournalVersion,
+		Plan:    applyJournalPlanID(plan),
+	})
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return j, nil
+}
+
+// reopenApplyJournal opens the existing apply journal at the given path to
+// record a resumed apply.
+func reopenApplyJournal(path string) (*applyJournal, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	j := &applyJournal{f: f}
+	if err := j.record(applyJournalEntry{Event: journalEventResume}); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return j, nil
+}
+
+// record appends the given entry to the journal, and doesn't return until
+// it has been flushed to stable storage.
+func (j *applyJournal) record(entry applyJournalEntry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, err := j.f.Write(line); err != nil {
+		return fmt.Errorf("failed to write to apply journal: %w", err)
+	}
+	if err := j.f.Sync(); err != nil {
+		return fmt.Errorf("failed to write to apply journal: %w", err)
+	}
+	return nil
+}
+
+func (j *applyJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.f.Close()
+}
+
+// readApplyJournal reads all of the entries from the apply journal at the
+// given path.
+//
+// If the process writing the journal was terminated while writing an entry
+// then the last line might be incomplete, in which case it is ignored.
+func readApplyJournal(path string) ([]applyJournalEntry, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []applyJournalEntry
+	sc := bufio.NewScanner(bytes.NewReader(src))
+	sc.Buffer(nil, 1024*1024)
+	for sc.Scan() {
+		line := sc.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var entry applyJournalEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			if !bytes.HasSuffix(src, []byte{'\n'}) && bytes.HasSuffix(src, line) {
+				break // partially-written final entry
+			}
+			return nil, fmt.Errorf("invalid entry %d: %w", len(entries)+1, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 || entries[0].Event != journalEventBegin {
+		return nil, errors.New("missing initial entry")
+	}
+	if v := entries[0].Version; v != applyJournalVersion {
+		return nil, fmt.Errorf("unsupported journal format version %d", v)
+	}
+	return entries, nil
+}
+
+// lastJournalStateSerial returns the serial of the last state snapshot that
+// the apply recorded in the given journal entries, or false if it didn't
+// record any.
+func lastJournalStateSerial(entries []applyJournalEntry) (uint64, bool) {
+	for _, entry := range slices.Backward(entries) {
+		if entry.Event == journalEventState && entry.Serial != nil {
+			return *entry.Serial, true
+		}
+	}
+	return 0, false
+}
+
+// applyJournalPlanID returns a string that identifies the given plan, which
+// is recorded in an apply journal so that the journal is only used to
+// resume an apply of the same plan.
+func applyJournalPlanID(plan *plans.Plan) string {
+	lines := make([]string, 0, len(plan.Changes.Resources))
+	for _, rc := range plan.Changes.Resources {
+		lines = append(lines, fmt.Sprintf("%s %s %s", rc.Addr, rc.DeposedKey, rc.Action))
+	}
+	slices.Sort(lines)
+
+	h := sha256.New()
+	fmt.Fprintln(h, plan.Timestamp.UTC().Format(time.RFC3339))
+	for _, line := range lines {
+		fmt.Fprintln(h, line)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// resumeOptsFromApplyJournal determines which of the planned changes to
+// managed resource instances in the given plan were completed by an earlier
+// apply, and which were started but not completed, based on the entries of
+// its apply journal.
+func resumeOptsFromApplyJournal(entries []applyJournalEntry, plan *plans.Plan) (*tofu.ResumeOpts, error) {
+	type changeKey struct {
+		deposed string
+		action  string
+	}
+	// For each instance, we track the last event for each distinct change
+	// to it, since a resumed apply can retry a change that previously
+	// failed or was interrupted.
+	events := make(map[string]map[changeKey]string)
+	instAddrs := make(map[string]addrs.AbsResourceInstance)
+	for _, entry := range entries {
+		switch entry.Event {
+		case journalEventStart, journalEventComplete, journalEventFail:
+		default:
+			continue
+		}
+		if _, exists := instAddrs[entry.Addr]; !exists {
+			addr, diags := addrs.ParseAbsResourceInstanceStr(entry.Addr)
+			if diags.HasErrors() {
+				return nil, fmt.Errorf("invalid resource instance address %q: %w", entry.Addr, diags.Err())
+			}
+			instAddrs[entry.Addr] = addr
+			events[entry.Addr] = make(map[changeKey]string)
+		}
+		events[entry.Addr][changeKey{entry.Deposed, entry.Action}] = entry.Event
+	}
+
+	ret := &tofu.ResumeOpts{
+		Completed:  addrs.MakeSet[addrs.AbsResourceInstance](),
+		Incomplete: addrs.MakeSet[addrs.AbsResourceInstance](),
+	}
+	for key, addr := range instAddrs {
+		completedActions := make(map[string]bool)
+		done := true
+		for change, event := range events[key] {
+			if event != journalEventComplete {
+				done = false
+				break
+			}
+			completedActions[change.action] = true
+		}
+		if done {
+			// Replacing an object involves two separate changes, and so we
+			// must see both of them before we consider it completed.
+			for _, rc := range plan.Changes.Resources {
+				if !rc.Addr.Equal(addr) {
+					continue
+				}
+				needed := []plans.Action{rc.Action}
+				if rc.Action.IsReplace() {
+					needed = []plans.Action{plans.Create, plans.Delete}
+				}
+				for _, action := range needed {
+					if !completedActions[action.String()] {
+						done = false
+					}
+				}
+			}
+		}
+		if done {
+			ret.Completed.Add(addr)
+		} else {
+			ret.Incomplete.Add(addr)
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,168 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package local
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+func TestApplyJournal(t *testing.T) {
+	mustAddr := func(s string) addrs.AbsResourceInstance {
+		addr, diags := addrs.ParseAbsResourceInstanceStr(s)
+		if diags.HasErrors() {
+			t.Fatal(diags.Err())
+		}
+		return addr
+	}
+	plan := &plans.Plan{
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Changes: &plans.Changes{
+			Resources: []*plans.ResourceInstanceChangeSrc{
+				{Addr: mustAddr("test_instance.created"), ChangeSrc: plans.ChangeSrc{Action: plans.Create}},
+				{Addr: mustAddr("test_instance.failed"), ChangeSrc: plans.ChangeSrc{Action: plans.Update}},
+				{Addr: mustAddr("test_instance.inflight"), ChangeSrc: plans.ChangeSrc{Action: plans.Delete}},
+				{Addr: mustAddr("test_instance.replaced"), ChangeSrc: plans.ChangeSrc{Action: plans.DeleteThenCreate}},
+				{Addr: mustAddr("test_instance.half_replaced"), ChangeSrc: plans.ChangeSrc{Action: plans.CreateThenDelete}},
+				{Addr: mustAddr("test_instance.overlapped"), ChangeSrc: plans.ChangeSrc{Action: plans.CreateThenDelete}},
+				{Addr: mustAddr("test_instance.retried"), ChangeSrc: plans.ChangeSrc{Action: plans.Create}},
+				{Addr: mustAddr("test_instance.pending"), ChangeSrc: plans.ChangeSrc{Action: plans.Create}},
+			},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "terraform.tfstate.journal")
+	journal, err := createApplyJournal(path, plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateMgr := statemgr.NewFilesystem(filepath.Join(t.TempDir(), "terraform.tfstate"), encryption.StateEncryptionDisabled())
+	hook := &applyJournalHook{Journal: journal, StateMgr: stateMgr}
+	apply := func(addr string, gen states.Generation, action plans.Action, err error) {
+		t.Helper()
+		if _, hookErr := hook.PreApply(mustAddr(addr), gen, action, cty.NilVal, cty.NilVal); hookErr != nil {
+			t.Fatal(hookErr)
+		}
+		if _, hookErr := hook.PostApply(mustAddr(addr), gen, cty.NilVal, err); hookErr != nil {
+			t.Fatal(hookErr)
+		}
+	}
+	apply("test_instance.created", states.CurrentGen, plans.Create, nil)
+	apply("test_instance.failed", states.CurrentGen, plans.Update, errors.New("oops"))
+	apply("test_instance.replaced", states.CurrentGen, plans.Delete, nil)
+	apply("test_instance.replaced", states.CurrentGen, plans.Create, nil)
+	apply("test_instance.half_replaced", states.CurrentGen, plans.Create, nil)
+	apply("test_instance.retried", states.CurrentGen, plans.Create, errors.New("oops"))
+
+	// With create_before_destroy the new object can still be in progress
+	// while the deposed object is being destroyed.
+	deposed := states.DeposedKey("00000001")
+	if _, err := hook.PreApply(mustAddr("test_instance.overlapped"), states.CurrentGen, plans.Create, cty.NilVal, cty.NilVal); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hook.PreApply(mustAddr("test_instance.overlapped"), deposed, plans.Delete, cty.NilVal, cty.NilVal); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hook.PostApply(mustAddr("test_instance.overlapped"), states.CurrentGen, cty.NilVal, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hook.PostApply(mustAddr("test_instance.overlapped"), deposed, cty.NilVal, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := stateMgr.WriteState(states.NewState()); err != nil {
+		t.Fatal(err)
+	}
+	if err := stateMgr.PersistState(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hook.PostStateUpdate(nil); err != nil {
+		t.Fatal(err)
+	}
+	wantSerial := stateMgr.StateSnapshotMeta().Serial
+
+	if _, err := hook.PreApply(mustAddr("test_instance.inflight"), states.CurrentGen, plans.Delete, cty.NilVal, cty.NilVal); err != nil {
+		t.Fatal(err)
+	}
+	if err := journal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A resumed apply appends to the same journal.
+	journal, err = reopenApplyJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hook = &applyJournalHook{Journal: journal}
+	apply("test_instance.retried", states.CurrentGen, plans.Create, nil)
+	if err := journal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate being terminated while writing an entry.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"event":"sta`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	entries, err := readApplyJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := entries[0].Plan, applyJournalPlanID(plan); got != want {
+		t.Errorf("wrong plan ID %q; want %q", got, want)
+	}
+	if got, ok := lastJournalStateSerial(entries); !ok || got != wantSerial {
+		t.Errorf("wrong state serial %d (recorded %t); want %d", got, ok, wantSerial)
+	}
+
+	opts, err := resumeOptsFromApplyJournal(entries, plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotCompleted, gotIncomplete []string
+	for addr := range opts.Completed.All() {
+		gotCompleted = append(gotCompleted, addr.String())
+	}
+	for addr := range opts.Incomplete.All() {
+		gotIncomplete = append(gotIncomplete, addr.String())
+	}
+	slices.Sort(gotCompleted)
+	slices.Sort(gotIncomplete)
+	wantCompleted := []string{
+		"test_instance.created",
+		"test_instance.overlapped",
+		"test_instance.replaced",
+		"test_instance.retried",
+	}
+	wantIncomplete := []string{
+		"test_instance.failed",
+		"test_instance.half_replaced",
+		"test_instance.inflight",
+	}
+	if diff := cmp.Diff(wantCompleted, gotCompleted); diff != "" {
+		t.Errorf("wrong completed instances\n%s", diff)
+	}
+	if diff := cmp.Diff(wantIncomplete, gotIncomplete); diff != "" {
+		t.Errorf("wrong incomplete instances\n%s", diff)
+	}
+}
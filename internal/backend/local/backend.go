@@ -91,6 +91,10 @@ type Local struct {
 	// opLock locks operations
 	opLock sync.Mutex
 
+	// dataDir is the working directory's data directory, as set by CLIInit,
+	// which is used for files that can't be stored alongside the state.
+	dataDir string
+
 	encryption encryption.StateEncryption
 }
 
@@ -438,6 +442,27 @@ func (b *Local) StatePaths(name string) (stateIn, stateOut, backupOut string) {
 	return statePath, stateOutPath, backupPath
 }
 
+// ApplyJournalPath returns the path of the apply journal for the given
+// workspace, or an empty string if apply operations in this workspace should
+// not keep a journal.
+//
+// For a workspace whose state is stored locally the journal is alongside the
+// state output file. Otherwise it's in the working directory's data directory,
+// if known.
+func (b *Local) ApplyJournalPath(name string) string {
+	if b.Backend == nil {
+		_, stateOutPath, _ := b.StatePaths(name)
+		return stateOutPath + DefaultJournalExtension
+	}
+	if b.dataDir == "" {
+		return ""
+	}
+	if name == "" {
+		name = backend.DefaultStateName
+	}
+	return filepath.Join(b.dataDir, "apply-journals", name+DefaultJournalExtension)
+}
+
 // PathsConflictWith returns true if any state path used by a workspace in
 // the receiver is the same as any state path used by the other given
 // local backend instance.
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"slices"
 	"strconv"
 	"time"
 
@@ -84,8 +85,21 @@ func (b *Local) opApply(
 		return
 	}
 
+	policies, moreDiags := loadPolicies(op)
+	diags = diags.Append(moreDiags)
+	if moreDiags.HasErrors() {
+		op.ReportResult(runningOp, diags)
+		return
+	}
+
 	stateHook := new(StateHook)
-	op.Hooks = append(op.Hooks, stateHook)
+	journalHook := new(applyJournalHook)
+	op.Hooks = append(op.Hooks, stateHook, journalHook)
+	var reportHook *applyReportHook
+	if op.Report {
+		reportHook = new(applyReportHook)
+		op.Hooks = append(op.Hooks, reportHook)
+	}
 
 	// Get our context
 	lr, _, opState, contextDiags := b.localRun(ctx, stopCtx, op)
@@ -162,6 +176,15 @@ func (b *Local) opApply(
 		mustConfirm := hasUI && !op.AutoApprove && !trivialPlan
 		op.View.Plan(plan, schemas)
 
+		if policies != nil {
+			_, moreDiags = evaluatePolicies(policies, lr.Config, plan, schemas)
+			diags = diags.Append(moreDiags)
+			if moreDiags.HasErrors() {
+				op.ReportResult(runningOp, diags)
+				return
+			}
+		}
+
 		if testHookStopPlanApply != nil {
 			testHookStopPlanApply()
 		}
@@ -268,6 +291,25 @@ func (b *Local) opApply(
 			op.ReportResult(runningOp, diags)
 			return
 		}
+		if pf, ok := op.PlanFile.Local(); ok {
+			diags = diags.Append(checkSavedPolicyResults(pf))
+		}
+		if policies != nil {
+			_, moreDiags = evaluatePolicies(policies, lr.Config, plan, schemas)
+			diags = diags.Append(moreDiags)
+		}
+		if diags.HasErrors() {
+			op.ReportResult(runningOp, diags)
+			return
+		}
+		if op.Resume {
+			plan, moreDiags = b.resumePlan(ctx, lr, b.ApplyJournalPath(op.Workspace))
+			diags = diags.Append(moreDiags)
+			if moreDiags.HasErrors() {
+				op.ReportResult(runningOp, diags)
+				return
+			}
+		}
 		for _, change := range plan.Changes.Resources {
 			if change.Action != plans.NoOp {
 				op.View.PlannedChange(change)
@@ -278,6 +320,49 @@ func (b *Local) opApply(
 	// Set up our hook for continuous state updates
 	stateHook.StateMgr = opState
 
+	// Set up the journal that would allow resuming this apply if it gets
+	// interrupted before we can save the final state. We keep one only when
+	// asked to, because each entry must reach stable storage before the
+	// apply can continue, which slows it down.
+	journalPath := b.ApplyJournalPath(op.Workspace)
+	if op.Journal || op.Resume {
+		if journalPath == "" {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Cannot keep an apply journal",
+				"OpenTofu cannot keep an apply journal for this workspace, because it doesn't know where to store it.",
+			))
+			op.ReportResult(runningOp, diags)
+			return
+		}
+		var journal *applyJournal
+		var err error
+		if op.Resume {
+			journal, err = reopenApplyJournal(journalPath)
+		} else {
+			journal, err = createApplyJournal(journalPath, plan)
+		}
+		if err == nil {
+			defer journal.Close()
+			journalHook.Journal = journal
+			journalHook.StateMgr = opState
+			err = journalHook.RecordStateSerial()
+		}
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Failed to create apply journal",
+				fmt.Sprintf("Error writing apply journal %s: %s.", journalPath, err),
+			))
+			op.ReportResult(runningOp, diags)
+			return
+		}
+	}
+
+	// The apply removes changes from the plan as it completes them, so we
+	// need to take a copy of them for the apply report.
+	plannedChanges := slices.Clone(plan.Changes.Resources)
+
 	// Start to apply in a goroutine so that we can be interrupted.
 	var applyState *states.State
 	var applyDiags tfdiags.Diagnostics
@@ -291,9 +376,15 @@ func (b *Local) opApply(
 	}()
 
 	if b.opWait(doneCh, stopCtx, cancelCtx, lr.Core, opState, op.View) {
+		if err := journalHook.RecordStateSerial(); err != nil {
+			log.Printf("[ERROR] backend/local: failed to record state serial in apply journal: %s", err)
+		}
 		return
 	}
 	diags = diags.Append(applyDiags)
+	if reportHook != nil {
+		runningOp.ApplyReport = reportHook.Report(plannedChanges)
+	}
 
 	// Even on error with an empty state, the state value should not be nil.
 	// Return early here to prevent corrupting any existing state.
@@ -321,10 +412,26 @@ func (b *Local) opApply(
 	}
 
 	if applyDiags.HasErrors() {
+		// The journal is kept so that the apply can be resumed, and so it
+		// must know about the final state snapshot we just saved.
+		if err := journalHook.RecordStateSerial(); err != nil {
+			log.Printf("[ERROR] backend/local: failed to record state serial in apply journal: %s", err)
+		}
 		op.ReportResult(runningOp, diags)
 		return
 	}
 
+	// The final state is saved, so the journal is no longer needed.
+	if journalHook.Journal != nil {
+		journalHook.Lock()
+		closeErr := journalHook.Journal.Close()
+		journalHook.Journal = nil
+		journalHook.Unlock()
+		if err := errors.Join(closeErr, os.Remove(journalPath)); err != nil {
+			log.Printf("[WARN] backend/local: failed to remove apply journal %s: %s", journalPath, err)
+		}
+	}
+
 	// If we've accumulated any warnings along the way then we'll show them
 	// here just before we show the summary and next steps. If we encountered
 	// errors then we would've returned early at some other point above.
@@ -410,3 +517,91 @@ For resources that support import, it is possible to recover by manually importi
 
 This is a serious bug in OpenTofu and should be reported.
 `
+
+// checkResumeStateSerial returns an error if the given serial of the current
+// state snapshot isn't the serial of the last state snapshot saved by the
+// interrupted apply recorded in the workspace's apply journal, which means
+// that something else has changed the state since.
+func (b *Local) checkResumeStateSerial(workspace string, serial uint64) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	journalPath := b.ApplyJournalPath(workspace)
+	entries, err := readApplyJournal(journalPath)
+	if err != nil {
+		// resumePlan reports the problem with the journal itself.
+		return diags
+	}
+	want, ok := lastJournalStateSerial(entries)
+	switch {
+	case !ok:
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Cannot resume apply",
+			fmt.Sprintf("The apply journal %s doesn't record which state snapshot the interrupted apply saved, so OpenTofu cannot verify that the state wasn't changed by another operation since.", journalPath),
+		))
+	case want != serial:
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Saved plan is stale",
+			fmt.Sprintf("The given plan file can no longer be resumed because the state was changed by another operation after the interrupted apply saved it: the current state snapshot has serial %d, but the apply journal %s records serial %d. Create a new plan instead.", serial, journalPath, want),
+		))
+	}
+	return diags
+}
+
+// resumePlan returns a plan for resuming an interrupted apply of the plan in
+// the given local run, using the apply journal at the given path.
+func (b *Local) resumePlan(ctx context.Context, lr *backend.LocalRun, journalPath string) (*plans.Plan, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	if journalPath == "" {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Cannot resume apply",
+			"OpenTofu doesn't keep an apply journal for this workspace, so it cannot resume an interrupted apply.",
+		))
+		return nil, diags
+	}
+	entries, err := readApplyJournal(journalPath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"No interrupted apply to resume",
+			fmt.Sprintf("There is no apply journal at %s, so there is no interrupted apply to resume. Either the apply completed, or it was never started.", journalPath),
+		))
+		return nil, diags
+	case err != nil:
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid apply journal",
+			fmt.Sprintf("Failed to read apply journal %s: %s.", journalPath, err),
+		))
+		return nil, diags
+	}
+	if entries[0].Plan != applyJournalPlanID(lr.Plan) {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Apply journal does not match the plan",
+			fmt.Sprintf("The apply journal %s records an apply of a different plan, so it cannot be used to resume applying the given plan file.", journalPath),
+		))
+		return nil, diags
+	}
+
+	opts, err := resumeOptsFromApplyJournal(entries, lr.Plan)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid apply journal",
+			fmt.Sprintf("Failed to read apply journal %s: %s.", journalPath, err),
+		))
+		return nil, diags
+	}
+	log.Printf("[INFO] backend/local: resuming apply with %d completed and %d incomplete changes", len(opts.Completed), len(opts.Incomplete))
+	if lr.ApplyOpts != nil {
+		opts.SetVariables = lr.ApplyOpts.SetVariables
+	}
+	plan, moreDiags := lr.Core.PlanResume(ctx, lr.Plan, lr.Config, lr.InputState, opts)
+	diags = diags.Append(moreDiags)
+	return plan, diags
+}
@@ -10,10 +10,12 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/addrs"
@@ -31,6 +33,7 @@ import (
 	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"github.com/opentofu/opentofu/internal/terminal"
 	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/internal/tofu"
 )
 
 func TestLocal_applyBasic(t *testing.T) {
@@ -72,6 +75,10 @@ test_instance.foo:
   ami = bar
 `)
 
+	if _, err := os.Stat(b.ApplyJournalPath(backend.DefaultStateName)); !os.IsNotExist(err) {
+		t.Errorf("apply journal was not removed after a successful apply (stat error: %v)", err)
+	}
+
 	if errOutput := done(t).Stderr(); errOutput != "" {
 		t.Fatalf("unexpected error output:\n%s", errOutput)
 	}
@@ -226,6 +233,7 @@ func TestLocal_applyError(t *testing.T) {
 	}
 
 	op, done := testOperationApply(t, "./testdata/apply-error")
+	op.Journal = true
 
 	run, err := b.Operation(context.Background(), op)
 	if err != nil {
@@ -243,6 +251,43 @@ test_instance.foo:
   ami = bar
 	`)
 
+	// The apply journal is kept after a failed apply, and records which
+	// changes completed.
+	entries, err := readApplyJournal(b.ApplyJournalPath(backend.DefaultStateName))
+	if err != nil {
+		t.Fatalf("failed to read apply journal: %s", err)
+	}
+	var gotEntries []string
+	for _, entry := range entries[1:] {
+		if entry.Event == journalEventState {
+			continue
+		}
+		gotEntries = append(gotEntries, entry.Event+" "+entry.Addr+" "+entry.Action)
+	}
+	slices.Sort(gotEntries)
+	wantEntries := []string{
+		"complete test_instance.foo Create",
+		"fail test_instance.bar Create",
+		"start test_instance.bar Create",
+		"start test_instance.foo Create",
+	}
+	if diff := cmp.Diff(wantEntries, gotEntries); diff != "" {
+		t.Errorf("wrong apply journal entries\n%s", diff)
+	}
+
+	// The journal must record the final state snapshot, so that the apply
+	// can be resumed from it.
+	fs := statemgr.NewFilesystem(b.StateOutPath, encryption.StateEncryptionDisabled())
+	if err := fs.RefreshState(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if diags := b.checkResumeStateSerial(backend.DefaultStateName, fs.StateSnapshotMeta().Serial); diags.HasErrors() {
+		t.Errorf("unexpected errors checking the state serial: %s", diags.Err())
+	}
+	if diags := b.checkResumeStateSerial(backend.DefaultStateName, fs.StateSnapshotMeta().Serial+1); !diags.HasErrors() {
+		t.Errorf("no error for a state changed after the interrupted apply")
+	}
+
 	// the backend should be unlocked after a run
 	assertBackendStateUnlocked(t, b)
 
@@ -251,6 +296,133 @@ test_instance.foo:
 	}
 }
 
+func TestLocal_applyContextOptsHooks(t *testing.T) {
+	b := TestLocal(t)
+	p := TestLocalProvider(t, b, "test", applyFixtureSchema())
+	p.ApplyResourceChangeResponse = &providers.ApplyResourceChangeResponse{NewState: cty.ObjectVal(map[string]cty.Value{
+		"id":  cty.StringVal("yes"),
+		"ami": cty.StringVal("bar"),
+	})}
+
+	// Hooks in the backend's own context options, such as the external
+	// hooks from the CLI configuration, can veto changes.
+	b.ContextOpts.Hooks = []tofu.Hook{&vetoApplyHook{}}
+
+	op, done := testOperationApply(t, "./testdata/apply")
+	run, err := b.Operation(context.Background(), op)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	<-run.Done()
+	if run.Result == backend.OperationSuccess {
+		t.Fatal("operation succeeded; want failure")
+	}
+	if p.ApplyResourceChangeCalled {
+		t.Fatal("ApplyResourceChange called despite the veto")
+	}
+
+	assertBackendStateUnlocked(t, b)
+	if got, want := done(t).Stderr(), "change vetoed"; !strings.Contains(got, want) {
+		t.Fatalf("unexpected error output:\n%s\nwant: %s", got, want)
+	}
+}
+
+type vetoApplyHook struct {
+	tofu.NilHook
+}
+
+func (h *vetoApplyHook) PreApply(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, priorState, plannedNewState cty.Value) (tofu.HookAction, error) {
+	return tofu.HookActionHalt, errors.New("change vetoed")
+}
+
+func TestLocal_applyReport(t *testing.T) {
+	b := TestLocal(t)
+
+	schema := providers.ProviderSchema{
+		ResourceTypes: map[string]providers.Schema{
+			"test_instance": {
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"ami": {Type: cty.String, Optional: true},
+						"id":  {Type: cty.String, Computed: true},
+					},
+				},
+			},
+		},
+	}
+	p := TestLocalProvider(t, b, "test", schema)
+	p.ApplyResourceChangeFn = func(r providers.ApplyResourceChangeRequest) (resp providers.ApplyResourceChangeResponse) {
+		if r.Config.GetAttr("ami").RawEquals(cty.StringVal("error")) {
+			resp.Diagnostics = resp.Diagnostics.Append(errors.New("ami error"))
+			return resp
+		}
+		resp.NewState = cty.ObjectVal(map[string]cty.Value{
+			"id":  cty.StringVal("foo"),
+			"ami": cty.StringVal("bar"),
+		})
+		return resp
+	}
+
+	op, done := testOperationApply(t, "./testdata/apply-report")
+	op.Report = true
+
+	run, err := b.Operation(context.Background(), op)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	<-run.Done()
+	if run.Result == backend.OperationSuccess {
+		t.Fatal("operation succeeded; want failure")
+	}
+	if run.ApplyReport == nil {
+		t.Fatal("no apply report")
+	}
+
+	got := make(map[string]tofu.ApplyReportChange)
+	for _, change := range run.ApplyReport.Changes {
+		got[change.Addr.String()] = tofu.ApplyReportChange{Outcome: change.Outcome, SkipReason: change.SkipReason}
+	}
+	want := map[string]tofu.ApplyReportChange{
+		"test_instance.foo": {Outcome: tofu.ApplySucceeded},
+		"test_instance.bar": {Outcome: tofu.ApplyFailed},
+		"test_instance.baz": {Outcome: tofu.ApplySkipped, SkipReason: tofu.ApplySkipDependencyFailed},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong apply report\n%s", diff)
+	}
+
+	assertBackendStateUnlocked(t, b)
+	if got, want := done(t).Stderr(), "Error: ami error"; !strings.Contains(got, want) {
+		t.Fatalf("unexpected error output:\n%s\nwant: %s", got, want)
+	}
+}
+
+func TestLocal_applyPolicy(t *testing.T) {
+	b := TestLocal(t)
+	p := TestLocalProvider(t, b, "test", applyFixtureSchema())
+
+	op, done := testOperationApply(t, "./testdata/apply")
+	op.PolicyDir = "./testdata/plan-policy"
+	op.AutoApprove = true
+
+	run, err := b.Operation(context.Background(), op)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	<-run.Done()
+	if run.Result == backend.OperationSuccess {
+		t.Fatal("operation succeeded; want failure from mandatory policy")
+	}
+	if p.ApplyResourceChangeCalled {
+		t.Fatal("ApplyResourceChange called despite failing mandatory policy")
+	}
+
+	assertBackendStateUnlocked(t, b)
+	if got, want := done(t).Stderr(), `Policy "approved_amis" failed`; !strings.Contains(got, want) {
+		t.Fatalf("unexpected error output:\n%s\nwant: %s", got, want)
+	}
+}
+
 func TestLocal_applyBackendFail(t *testing.T) {
 	b := TestLocal(t)
 
@@ -10,8 +10,10 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/zclconf/go-cty/cty"
 
@@ -83,7 +85,9 @@ func (b *Local) localRun(ctx context.Context, stopCtx context.Context, op *backe
 		coreOpts = *v
 	}
 	coreOpts.UIInput = op.UIIn
-	coreOpts.Hooks = op.Hooks
+	// Hooks from the backend's own context options, such as external
+	// hooks from the CLI configuration, run before the operation's hooks.
+	coreOpts.Hooks = append(slices.Clone(coreOpts.Hooks), op.Hooks...)
 	coreOpts.Encryption = op.Encryption
 
 	var ctxDiags tfdiags.Diagnostics
@@ -108,6 +112,14 @@ func (b *Local) localRun(ctx context.Context, stopCtx context.Context, op *backe
 			diags = diags.Append(ctxDiags)
 			return nil, nil, nil, diags
 		}
+		if op.Resume {
+			// A resumed apply continues from the state left behind by the
+			// interrupted apply, rather than from the plan's prior state.
+			ret.InputState = s.State()
+			if ret.InputState == nil {
+				ret.InputState = states.NewState()
+			}
+		}
 
 		// Write sources into the cache of the main loader so that they are
 		// available if we need to generate diagnostic message snippets.
@@ -120,6 +132,7 @@ func (b *Local) localRun(ctx context.Context, stopCtx context.Context, op *backe
 	if diags.HasErrors() {
 		return nil, nil, nil, diags
 	}
+	setHookSchemas(ctx, ret, coreOpts.Hooks)
 
 	// If we have an operation, then we automatically do the input/validate
 	// here since every option requires this.
@@ -208,6 +221,7 @@ func (b *Local) localRunDirect(ctx context.Context, stopCtx context.Context, op
 		Targets:            op.Targets,
 		Excludes:           op.Excludes,
 		ForceReplace:       op.ForceReplace,
+		AllowDeferral:      op.AllowDeferral,
 		SetVariables:       variables,
 		SkipRefresh:        op.Type != backend.OperationTypeRefresh && !op.PlanRefresh,
 		GenerateConfigPath: op.GenerateConfigOut,
@@ -387,6 +401,12 @@ func (b *Local) localRunForPlanFile(ctx context.Context, op *backend.Operation,
 				"The given plan file can not be applied because it was created from a different state lineage.",
 			))
 
+		// When resuming an interrupted apply the state has already been
+		// changed by the apply that we're continuing, so it must instead
+		// match the last state snapshot recorded in the apply journal.
+		case op.Resume:
+			diags = diags.Append(b.checkResumeStateSerial(op.Workspace, currentStateMeta.Serial))
+
 		case priorStateFile.Serial != currentStateMeta.Serial:
 			diags = diags.Append(tfdiags.Sourceless(
 				tfdiags.Error,
@@ -395,6 +415,12 @@ func (b *Local) localRunForPlanFile(ctx context.Context, op *backend.Operation,
 			))
 		}
 	}
+	if op.Type == backend.OperationTypeApply {
+		// This also applies when resuming an interrupted apply, because the
+		// apply journal is an unsigned local file and so we can't trust it
+		// to say when the interrupted apply started.
+		diags = diags.Append(checkSavedPlanRequirements(pf, op.SavedPlanRequirements, time.Now()))
+	}
 	// When we're applying a saved plan, the input state is the "prior state"
 	// recorded in the plan, which incorporates the result of all of the
 	// refreshing we did while building the plan.
@@ -22,6 +22,7 @@ import (
 	"github.com/opentofu/opentofu/internal/command/views"
 	"github.com/opentofu/opentofu/internal/configs/configload"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/depsfile"
 	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/initwd"
 	"github.com/opentofu/opentofu/internal/plans"
@@ -322,3 +323,103 @@ func (s *stateStorageThatFailsRefresh) RefreshState(_ context.Context) error {
 func (s *stateStorageThatFailsRefresh) PersistState(_ context.Context, schemas *tofu.Schemas) error {
 	return fmt.Errorf("unimplemented")
 }
+
+func TestLocalRun_expiredPlan(t *testing.T) {
+	configDir := "./testdata/apply"
+	created := time.Now().Add(-2 * time.Hour)
+
+	tests := map[string]struct {
+		opType      backend.OperationType
+		resume      bool
+		journalTime time.Time
+		wantErr     string
+	}{
+		"apply": {
+			opType:  backend.OperationTypeApply,
+			wantErr: "Saved plan has expired",
+		},
+		"resume with journal claiming to start before expiry": {
+			// The apply journal isn't signed, so a forged or leftover
+			// journal must not allow applying an expired plan.
+			opType:      backend.OperationTypeApply,
+			resume:      true,
+			journalTime: created.Add(30 * time.Minute),
+			wantErr:     "Saved plan has expired",
+		},
+		"resume apply started after expiry": {
+			opType:      backend.OperationTypeApply,
+			resume:      true,
+			journalTime: created.Add(90 * time.Minute),
+			wantErr:     "Saved plan has expired",
+		},
+		"not an apply": {
+			// For example, "tofu show" reads a saved plan using LocalRun.
+			opType: backend.OperationTypeInvalid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := TestLocal(t)
+			_, configLoader := initwd.MustLoadConfigForTests(t, configDir, "tests")
+
+			sf, err := os.Create(b.StatePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := statefile.Write(statefile.New(states.NewState(), "boop", 1), sf, encryption.StateEncryptionDisabled()); err != nil {
+				t.Fatal(err)
+			}
+			sf.Close()
+
+			if test.resume {
+				journal := fmt.Sprintf(
+					"{\"event\":%q,\"time\":%q,\"version\":%d}\n{\"event\":%q,\"time\":%q,\"serial\":1}\n",
+					journalEventBegin, test.journalTime.Format(time.RFC3339Nano), applyJournalVersion,
+					journalEventState, test.journalTime.Format(time.RFC3339Nano),
+				)
+				if err := os.WriteFile(b.ApplyJournalPath(backend.DefaultStateName), []byte(journal), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			planPath := testSavedPlanFile(t, filepath.Join(t.TempDir(), "plan.tfplan"), planfile.NewMetadata(created, time.Hour))
+			planFile, err := planfile.OpenWrapped(planPath, encryption.PlanEncryptionDisabled())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			streams, _ := terminal.StreamsForTesting(t)
+			backendView := views.NewBackendHuman(views.NewView(streams))
+			op := &backend.Operation{
+				Type:            test.opType,
+				ConfigDir:       configDir,
+				ConfigLoader:    configLoader,
+				PlanFile:        planFile,
+				Resume:          test.resume,
+				Workspace:       backend.DefaultStateName,
+				StateLocker:     clistate.NewLocker(0, backendView.StateLocker()),
+				DependencyLocks: depsfile.NewLocks(),
+			}
+
+			// LocalRun always uses OperationTypeInvalid, so we call localRun
+			// directly to check what an apply would do.
+			_, _, _, diags := b.localRun(context.Background(), t.Context(), op)
+			if test.wantErr == "" {
+				if diags.HasErrors() {
+					t.Fatalf("unexpected errors: %s", diags.Err())
+				}
+				if err := op.StateLocker.Unlock(); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if !diags.HasErrors() {
+				t.Fatalf("expected error containing %q, got none", test.wantErr)
+			}
+			if got := diags.Err().Error(); !strings.Contains(got, test.wantErr) {
+				t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.wantErr)
+			}
+		})
+	}
+}
@@ -10,12 +10,14 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"time"
 
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/genconfig"
 	"github.com/opentofu/opentofu/internal/logging"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/plans/planfile"
+	"github.com/opentofu/opentofu/internal/policy"
 	"github.com/opentofu/opentofu/internal/states/statefile"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -83,6 +85,13 @@ func (b *Local) opPlan(
 		}
 	}
 
+	policies, moreDiags := loadPolicies(op)
+	diags = diags.Append(moreDiags)
+	if moreDiags.HasErrors() {
+		op.ReportResult(runningOp, diags)
+		return
+	}
+
 	if b.ContextOpts == nil {
 		b.ContextOpts = new(tofu.ContextOpts)
 	}
@@ -145,6 +154,22 @@ func (b *Local) opPlan(
 	// Record whether this plan includes any side-effects that could be applied.
 	runningOp.PlanEmpty = !plan.CanApply()
 
+	schemas, moreDiags := lr.Core.Schemas(ctx, lr.Config, lr.InputState)
+	diags = diags.Append(moreDiags)
+	if moreDiags.HasErrors() {
+		op.ReportResult(runningOp, diags)
+		return
+	}
+
+	// Evaluate any policies before saving the plan, so that the saved plan
+	// records their results. There's no point evaluating them against a
+	// plan that is incomplete because of errors.
+	var policyResults *policy.Results
+	if policies != nil && !planDiags.HasErrors() {
+		policyResults, moreDiags = evaluatePolicies(policies, lr.Config, plan, schemas)
+		diags = diags.Append(moreDiags)
+	}
+
 	// Save the plan to disk
 	if path := op.PlanOutPath; path != "" {
 		if op.PlanOutBackend == nil {
@@ -182,6 +207,8 @@ func (b *Local) opPlan(
 			StateFile:            plannedStateFile,
 			Plan:                 plan,
 			DependencyLocks:      op.DependencyLocks,
+			PolicyResults:        policyResults,
+			Metadata:             planfile.NewMetadata(time.Now(), op.PlanExpiresIn),
 		}, op.Encryption.Plan())
 		if err != nil {
 			diags = diags.Append(tfdiags.Sourceless(
@@ -194,15 +221,6 @@ func (b *Local) opPlan(
 		}
 	}
 
-	// Render the plan, if we produced one.
-	// (This might potentially be a partial plan with Errored set to true)
-	schemas, moreDiags := lr.Core.Schemas(ctx, lr.Config, lr.InputState)
-	diags = diags.Append(moreDiags)
-	if moreDiags.HasErrors() {
-		op.ReportResult(runningOp, diags)
-		return
-	}
-
 	// Write out any generated config, before we render the plan.
 	wroteConfig, moreDiags := maybeWriteGeneratedConfig(plan, op.GenerateConfigOut)
 	diags = diags.Append(moreDiags)
@@ -211,6 +229,8 @@ func (b *Local) opPlan(
 		return
 	}
 
+	// Render the plan, if we produced one.
+	// (This might potentially be a partial plan with Errored set to true)
 	op.View.Plan(plan, schemas)
 
 	// If we've accumulated any diagnostics along the way then we'll show them
@@ -220,7 +240,9 @@ func (b *Local) opPlan(
 	// creating it.
 	op.ReportResult(runningOp, diags)
 
-	if !runningOp.PlanEmpty {
+	// A plan that failed a mandatory policy can't be applied, so there's
+	// no next step to suggest.
+	if !runningOp.PlanEmpty && !diags.HasErrors() {
 		if wroteConfig {
 			op.View.PlanNextStep(op.PlanOutPath, op.GenerateConfigOut)
 		} else {
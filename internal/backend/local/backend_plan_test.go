@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/zclconf/go-cty/cty"
 
@@ -705,6 +706,132 @@ func TestLocal_planOutPathNoChange(t *testing.T) {
 	}
 }
 
+func TestLocal_planPolicy(t *testing.T) {
+	b := TestLocal(t)
+	TestLocalProvider(t, b, "test", planFixtureSchema())
+
+	planPath := filepath.Join(t.TempDir(), "plan.tfplan")
+	op, done := testOperationPlan(t, "./testdata/plan")
+	op.PolicyDir = "./testdata/plan-policy"
+	op.PlanOutPath = planPath
+	cfg := cty.ObjectVal(map[string]cty.Value{
+		"path": cty.StringVal(b.StatePath),
+	})
+	cfgRaw, err := plans.NewDynamicValue(cfg, cfg.Type())
+	if err != nil {
+		t.Fatal(err)
+	}
+	op.PlanOutBackend = &plans.Backend{
+		Type:   "local",
+		Config: cfgRaw,
+	}
+
+	run, err := b.Operation(context.Background(), op)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	<-run.Done()
+	if run.Result == backend.OperationSuccess {
+		t.Fatal("plan operation succeeded; want failure from mandatory policy")
+	}
+	output := done(t)
+	if got, want := output.Stderr(), `Policy "approved_amis" failed`; !strings.Contains(got, want) {
+		t.Errorf("wrong error output\ngot:\n%s\nwant output containing: %s", got, want)
+	}
+	if got, want := output.Stdout(), "tofu apply"; strings.Contains(got, want) {
+		t.Errorf("output suggests applying a plan that failed a mandatory policy:\n%s", got)
+	}
+
+	// The saved plan records the failure, so that it can't be applied.
+	pf, err := planfile.Open(planPath, encryption.PlanEncryptionDisabled())
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := pf.ReadPolicyResults()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(results.Blocking()), 1; got != want {
+		t.Fatalf("wrong number of blocking policy results %d; want %d", got, want)
+	}
+
+	wpf, err := planfile.OpenWrapped(planPath, encryption.PlanEncryptionDisabled())
+	if err != nil {
+		t.Fatal(err)
+	}
+	applyOp, applyDone := testOperationApply(t, "./testdata/plan")
+	applyOp.PlanFile = wpf
+	applyOp.AutoApprove = true
+	run, err = b.Operation(context.Background(), applyOp)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	<-run.Done()
+	if run.Result == backend.OperationSuccess {
+		t.Fatal("apply of saved plan succeeded; want failure from recorded policy results")
+	}
+	if got, want := applyDone(t).Stderr(), "Saved plan failed mandatory policies"; !strings.Contains(got, want) {
+		t.Errorf("wrong error output\ngot:\n%s\nwant output containing: %s", got, want)
+	}
+
+	assertBackendStateUnlocked(t, b)
+}
+
+func TestLocal_planExpiresIn(t *testing.T) {
+	b := TestLocal(t)
+	TestLocalProvider(t, b, "test", planFixtureSchema())
+
+	planPath := filepath.Join(t.TempDir(), "plan.tfplan")
+	op, done := testOperationPlan(t, "./testdata/plan")
+	op.PlanOutPath = planPath
+	op.PlanExpiresIn = time.Hour
+	cfg := cty.ObjectVal(map[string]cty.Value{
+		"path": cty.StringVal(b.StatePath),
+	})
+	cfgRaw, err := plans.NewDynamicValue(cfg, cfg.Type())
+	if err != nil {
+		t.Fatal(err)
+	}
+	op.PlanOutBackend = &plans.Backend{
+		Type:   "local",
+		Config: cfgRaw,
+	}
+
+	before := time.Now()
+	run, err := b.Operation(context.Background(), op)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	<-run.Done()
+	if run.Result != backend.OperationSuccess {
+		t.Fatalf("plan operation failed\n%s", done(t).Stderr())
+	}
+
+	pf, err := planfile.Open(planPath, encryption.PlanEncryptionDisabled())
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta, err := pf.ReadMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta == nil {
+		t.Fatal("plan file has no metadata")
+	}
+	if meta.CreatedAt.Before(before.Truncate(time.Second)) {
+		t.Errorf("wrong creation time %s; want after %s", meta.CreatedAt, before)
+	}
+	if got, want := meta.ExpiresAt.Sub(meta.CreatedAt), time.Hour; got != want {
+		t.Errorf("plan expires %s after its creation; want %s", got, want)
+	}
+	if meta.Expired(time.Now()) {
+		t.Error("new plan has already expired")
+	}
+	if !meta.Expired(time.Now().Add(2 * time.Hour)) {
+		t.Error("plan has not expired after two hours")
+	}
+}
+
 func testOperationPlan(t *testing.T, configDir string) (*backend.Operation, func(*testing.T) *terminal.TestOutput) {
 	t.Helper()
 
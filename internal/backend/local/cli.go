@@ -16,6 +16,7 @@ func (b *Local) CLIInit(opts *backend.CLIOpts) error {
 	b.ContextOpts = opts.ContextOpts
 	b.OpInput = opts.Input
 	b.OpValidation = opts.Validation
+	b.dataDir = opts.DataDir
 
 	// configure any new cli options
 	if opts.StateArgs.StatePath != "" {
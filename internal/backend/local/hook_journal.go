@@ -0,0 +1,154 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package local
+
+import (
+	"log"
+	"sync"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// applyJournalHook is a hook that records the progress of an apply operation
+// in an apply journal.
+type applyJournalHook struct {
+	tofu.NilHook
+	sync.Mutex
+
+	// Journal is the journal to write to. The hook does nothing until this
+	// is set, since it must be created only once we know which plan is
+	// being applied.
+	Journal *applyJournal
+
+	// StateMgr is where the apply saves its state snapshots. If it can
+	// report the serial of the latest snapshot then the hook records each
+	// new serial in the journal.
+	StateMgr statemgr.Writer
+
+	// inProgress tracks the changes that have started but not yet finished,
+	// since PostApply doesn't tell us which change it's reporting on.
+	inProgress map[applyJournalChangeKey]applyJournalEntry
+
+	// serial is the last state serial recorded in the journal, if any.
+	serial *uint64
+}
+
+// applyJournalChangeKey identifies a change in progress. A replace action can
+// have changes to both the current object and a deposed object of the same
+// resource instance in progress at the same time.
+type applyJournalChangeKey struct {
+	addr    string
+	deposed string
+}
+
+var _ tofu.Hook = (*applyJournalHook)(nil)
+
+func (h *applyJournalHook) PreApply(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, priorState, plannedNewState cty.Value) (tofu.HookAction, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.Journal == nil || addr.Resource.Resource.Mode != addrs.ManagedResourceMode {
+		return tofu.HookActionContinue, nil
+	}
+
+	entry := applyJournalEntry{
+		Event:   journalEventStart,
+		Addr:    addr.String(),
+		Deposed: journalDeposedKey(gen),
+		Action:  action.String(),
+	}
+	if h.inProgress == nil {
+		h.inProgress = make(map[applyJournalChangeKey]applyJournalEntry)
+	}
+	h.inProgress[applyJournalChangeKey{entry.Addr, entry.Deposed}] = entry
+
+	// The "start" entry must be durable before the provider gets a chance
+	// to make any changes, so we'll halt if we can't write it.
+	if err := h.Journal.record(entry); err != nil {
+		return tofu.HookActionHalt, err
+	}
+	return tofu.HookActionContinue, nil
+}
+
+func (h *applyJournalHook) PostApply(addr addrs.AbsResourceInstance, gen states.Generation, newState cty.Value, err error) (tofu.HookAction, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.Journal == nil {
+		return tofu.HookActionContinue, nil
+	}
+
+	key := applyJournalChangeKey{addr.String(), journalDeposedKey(gen)}
+	entry, ok := h.inProgress[key]
+	if !ok {
+		return tofu.HookActionContinue, nil
+	}
+	delete(h.inProgress, key)
+
+	entry.Event = journalEventComplete
+	if err != nil {
+		entry.Event = journalEventFail
+		entry.Error = err.Error()
+	}
+	if err := h.Journal.record(entry); err != nil {
+		return tofu.HookActionHalt, err
+	}
+	return tofu.HookActionContinue, nil
+}
+
+func (h *applyJournalHook) PostStateUpdate(mutate func(*states.SyncState)) (tofu.HookAction, error) {
+	// The StateHook, which runs before this one, has already saved the
+	// update by now.
+	if err := h.RecordStateSerial(); err != nil {
+		return tofu.HookActionHalt, err
+	}
+	return tofu.HookActionContinue, nil
+}
+
+func (h *applyJournalHook) Stopping() {
+	// The StateHook, which runs before this one, tries to persist the latest
+	// state snapshot when stopping.
+	if err := h.RecordStateSerial(); err != nil {
+		log.Printf("[ERROR] Failed to record state serial in apply journal: %s", err)
+	}
+}
+
+// RecordStateSerial records the serial of the latest state snapshot saved by
+// StateMgr in the journal, unless it's already recorded.
+func (h *applyJournalHook) RecordStateSerial() error {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.Journal == nil {
+		return nil
+	}
+	mgr, ok := h.StateMgr.(statemgr.PersistentMeta)
+	if !ok {
+		return nil
+	}
+	serial := mgr.StateSnapshotMeta().Serial
+	if h.serial != nil && *h.serial == serial {
+		return nil
+	}
+	if err := h.Journal.record(applyJournalEntry{Event: journalEventState, Serial: &serial}); err != nil {
+		return err
+	}
+	h.serial = &serial
+	return nil
+}
+
+// journalDeposedKey returns the string to record in an apply journal for the
+// given generation of a resource instance object.
+func journalDeposedKey(gen states.Generation) string {
+	if dk, ok := gen.(states.DeposedKey); ok {
+		return dk.String()
+	}
+	return ""
+}
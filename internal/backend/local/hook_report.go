@@ -0,0 +1,186 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package local
+
+import (
+	"sync"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// applyReportHook is a hook that records which changes an apply operation
+// attempted and whether they succeeded, for building a [tofu.ApplyReport].
+//
+// The hook records what happened to each resource instance object, and
+// [applyReportHook.Report] then matches those objects up with the planned
+// changes. A single planned change can affect more than one object: a
+// create_before_destroy replace creates the new object as the current one and
+// then destroys the old object under a new deposed key, which isn't part of
+// the plan.
+type applyReportHook struct {
+	tofu.NilHook
+	sync.Mutex
+
+	// inProgress tracks the objects whose changes have started but not yet
+	// finished. A replace action can have changes to both the current object
+	// and a deposed object of the same resource instance in progress at the
+	// same time.
+	inProgress map[applyReportKey]struct{}
+	outcomes   map[applyReportKey]tofu.ApplyOutcome
+
+	// skipped tracks the objects whose changes weren't attempted because
+	// something they depend on failed.
+	skipped map[applyReportKey]struct{}
+
+	// stopped is set once the apply operation is interrupted.
+	stopped bool
+}
+
+type applyReportKey struct {
+	addr    string
+	deposed states.DeposedKey
+}
+
+var _ tofu.Hook = (*applyReportHook)(nil)
+
+func (h *applyReportHook) PreApply(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, priorState, plannedNewState cty.Value) (tofu.HookAction, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.inProgress == nil {
+		h.inProgress = make(map[applyReportKey]struct{})
+	}
+	h.inProgress[makeApplyReportKey(addr, gen)] = struct{}{}
+	return tofu.HookActionContinue, nil
+}
+
+func (h *applyReportHook) PostApply(addr addrs.AbsResourceInstance, gen states.Generation, newState cty.Value, err error) (tofu.HookAction, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	key := makeApplyReportKey(addr, gen)
+	if _, ok := h.inProgress[key]; !ok {
+		return tofu.HookActionContinue, nil
+	}
+	delete(h.inProgress, key)
+
+	if h.outcomes == nil {
+		h.outcomes = make(map[applyReportKey]tofu.ApplyOutcome)
+	}
+	if err != nil {
+		h.outcomes[key] = tofu.ApplyFailed
+	} else {
+		h.outcomes[key] = tofu.ApplySucceeded
+	}
+	return tofu.HookActionContinue, nil
+}
+
+func (h *applyReportHook) ApplySkipped(addr addrs.AbsResourceInstance, gen states.Generation) (tofu.HookAction, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.skipped == nil {
+		h.skipped = make(map[applyReportKey]struct{})
+	}
+	h.skipped[makeApplyReportKey(addr, gen)] = struct{}{}
+	return tofu.HookActionContinue, nil
+}
+
+func (h *applyReportHook) Stopping() {
+	h.Lock()
+	defer h.Unlock()
+
+	h.stopped = true
+}
+
+// Report returns the outcome of each of the given planned changes, based on
+// the changes the hook has seen so far.
+//
+// A planned change that affected more than one object failed if the change
+// to any of those objects failed. Any change that the hook didn't see finish
+// is reported as skipped, and it's reported as skipped because something it
+// depends on failed only if OpenTofu told the hook so.
+//
+// Once the apply is interrupted, OpenTofu doesn't start any more changes, so
+// all of the skipped changes in an interrupted apply are reported as skipped
+// because of the interruption, even if something they depend on had also
+// failed.
+func (h *applyReportHook) Report(changes []*plans.ResourceInstanceChangeSrc) *tofu.ApplyReport {
+	h.Lock()
+	defer h.Unlock()
+
+	planned := make(map[applyReportKey]struct{}, len(changes))
+	for _, change := range changes {
+		planned[applyReportKey{change.Addr.String(), change.DeposedKey}] = struct{}{}
+	}
+	// changeKey returns the key of the planned change that affected the
+	// object with the given key.
+	changeKey := func(key applyReportKey) applyReportKey {
+		if _, ok := planned[key]; ok || key.deposed == states.NotDeposed {
+			return key
+		}
+		// This is the prior object of a create_before_destroy replace,
+		// deposed during the apply.
+		return applyReportKey{addr: key.addr, deposed: states.NotDeposed}
+	}
+
+	outcomes := make(map[applyReportKey]tofu.ApplyOutcome, len(h.outcomes))
+	for key, outcome := range h.outcomes {
+		key = changeKey(key)
+		if outcomes[key] != tofu.ApplyFailed {
+			outcomes[key] = outcome
+		}
+	}
+	skipped := make(map[applyReportKey]struct{}, len(h.skipped))
+	for key := range h.skipped {
+		skipped[changeKey(key)] = struct{}{}
+	}
+
+	ret := &tofu.ApplyReport{}
+	for _, change := range changes {
+		if !tofu.ApplyReportable(change) {
+			continue
+		}
+		key := applyReportKey{change.Addr.String(), change.DeposedKey}
+		outcome, ok := outcomes[key]
+		if _, skip := skipped[key]; skip && outcome != tofu.ApplyFailed {
+			// Some of the objects this change affects may have already been
+			// changed, but the change as a whole is incomplete.
+			ok = false
+		}
+		reason := tofu.ApplySkipNone
+		if !ok {
+			outcome = tofu.ApplySkipped
+			switch _, skip := skipped[key]; {
+			case h.stopped:
+				reason = tofu.ApplySkipInterrupted
+			case skip:
+				reason = tofu.ApplySkipDependencyFailed
+			default:
+				reason = tofu.ApplySkipNotAttempted
+			}
+		}
+		ret.Changes = append(ret.Changes, tofu.ApplyReportChange{
+			Addr:       change.Addr,
+			DeposedKey: change.DeposedKey,
+			Action:     change.Action,
+			Outcome:    outcome,
+			SkipReason: reason,
+		})
+	}
+	return ret
+}
+
+func makeApplyReportKey(addr addrs.AbsResourceInstance, gen states.Generation) applyReportKey {
+	key := applyReportKey{addr: addr.String(), deposed: states.NotDeposed}
+	if dk, ok := gen.(states.DeposedKey); ok {
+		key.deposed = dk
+	}
+	return key
+}
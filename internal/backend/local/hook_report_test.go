@@ -0,0 +1,121 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package local
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+func TestApplyReportHook(t *testing.T) {
+	mustAddr := func(s string) addrs.AbsResourceInstance {
+		addr, diags := addrs.ParseAbsResourceInstanceStr(s)
+		if diags.HasErrors() {
+			t.Fatal(diags.Err())
+		}
+		return addr
+	}
+	deposed := states.DeposedKey("00000001")
+	// The prior objects of create_before_destroy replaces are deposed with
+	// new keys during the apply, which don't match any planned change.
+	newDeposedC := states.DeposedKey("00000002")
+	newDeposedF := states.DeposedKey("00000003")
+	changes := []*plans.ResourceInstanceChangeSrc{
+		{Addr: mustAddr("test_instance.a"), ChangeSrc: plans.ChangeSrc{Action: plans.Create}},
+		{Addr: mustAddr("test_instance.b"), ChangeSrc: plans.ChangeSrc{Action: plans.Update}},
+		{Addr: mustAddr("test_instance.c"), ChangeSrc: plans.ChangeSrc{Action: plans.CreateThenDelete}},
+		{Addr: mustAddr("test_instance.c"), DeposedKey: deposed, ChangeSrc: plans.ChangeSrc{Action: plans.Delete}},
+		{Addr: mustAddr("test_instance.d"), ChangeSrc: plans.ChangeSrc{Action: plans.Create}},
+		{Addr: mustAddr("test_instance.e"), ChangeSrc: plans.ChangeSrc{Action: plans.Create}},
+		{Addr: mustAddr("test_instance.f"), ChangeSrc: plans.ChangeSrc{Action: plans.CreateThenDelete}},
+		{Addr: mustAddr("test_instance.g"), ChangeSrc: plans.ChangeSrc{Action: plans.Create}},
+	}
+
+	hook := new(applyReportHook)
+	pre := func(addr string, gen states.Generation, action plans.Action) {
+		t.Helper()
+		if _, err := hook.PreApply(mustAddr(addr), gen, action, cty.NilVal, cty.NilVal); err != nil {
+			t.Fatal(err)
+		}
+	}
+	post := func(addr string, gen states.Generation, err error) {
+		t.Helper()
+		if _, hookErr := hook.PostApply(mustAddr(addr), gen, cty.NilVal, err); hookErr != nil {
+			t.Fatal(hookErr)
+		}
+	}
+	pre("test_instance.a", states.CurrentGen, plans.Create)
+	post("test_instance.a", states.CurrentGen, nil)
+	pre("test_instance.b", states.CurrentGen, plans.Update)
+	post("test_instance.b", states.CurrentGen, errors.New("oops"))
+	// With create_before_destroy the new object can still be in progress
+	// while the deposed object is being destroyed.
+	pre("test_instance.c", states.CurrentGen, plans.Create)
+	pre("test_instance.c", newDeposedC, plans.Delete)
+	post("test_instance.c", newDeposedC, nil)
+	post("test_instance.c", states.CurrentGen, nil)
+	pre("test_instance.c", deposed, plans.Delete)
+	post("test_instance.c", deposed, nil)
+	// The replace fails if destroying the prior object fails, even though
+	// creating the new object succeeded.
+	pre("test_instance.f", states.CurrentGen, plans.Create)
+	post("test_instance.f", states.CurrentGen, nil)
+	pre("test_instance.f", newDeposedF, plans.Delete)
+	post("test_instance.f", newDeposedF, errors.New("oops"))
+	// test_instance.d depends on test_instance.b, while test_instance.e and
+	// test_instance.g were never attempted for some other reason.
+	if _, err := hook.ApplySkipped(mustAddr("test_instance.d"), states.CurrentGen); err != nil {
+		t.Fatal(err)
+	}
+
+	type result struct {
+		Outcome    tofu.ApplyOutcome
+		SkipReason tofu.ApplySkipReason
+	}
+	report := func() map[string]result {
+		ret := make(map[string]result)
+		for _, change := range hook.Report(changes).Changes {
+			key := change.Addr.String()
+			if change.DeposedKey != states.NotDeposed {
+				key += " " + change.DeposedKey.String()
+			}
+			ret[key] = result{change.Outcome, change.SkipReason}
+		}
+		return ret
+	}
+
+	want := map[string]result{
+		"test_instance.a":          {tofu.ApplySucceeded, tofu.ApplySkipNone},
+		"test_instance.b":          {tofu.ApplyFailed, tofu.ApplySkipNone},
+		"test_instance.c":          {tofu.ApplySucceeded, tofu.ApplySkipNone},
+		"test_instance.c 00000001": {tofu.ApplySucceeded, tofu.ApplySkipNone},
+		"test_instance.d":          {tofu.ApplySkipped, tofu.ApplySkipDependencyFailed},
+		"test_instance.e":          {tofu.ApplySkipped, tofu.ApplySkipNotAttempted},
+		"test_instance.f":          {tofu.ApplyFailed, tofu.ApplySkipNone},
+		"test_instance.g":          {tofu.ApplySkipped, tofu.ApplySkipNotAttempted},
+	}
+	if diff := cmp.Diff(want, report()); diff != "" {
+		t.Errorf("wrong report before interrupt\n%s", diff)
+	}
+
+	// Once the apply is interrupted, the change in progress is halted and
+	// no further changes are started.
+	pre("test_instance.e", states.CurrentGen, plans.Create)
+	hook.Stopping()
+
+	want["test_instance.d"] = result{tofu.ApplySkipped, tofu.ApplySkipInterrupted}
+	want["test_instance.e"] = result{tofu.ApplySkipped, tofu.ApplySkipInterrupted}
+	want["test_instance.g"] = result{tofu.ApplySkipped, tofu.ApplySkipInterrupted}
+	if diff := cmp.Diff(want, report()); diff != "" {
+		t.Errorf("wrong report after interrupt\n%s", diff)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package local
+
+import (
+	"context"
+	"log"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// schemaHook is implemented by hooks that need the schemas of the resource
+// types that they are notified about, such as the external hooks from the CLI
+// configuration, which use them to avoid sending sensitive values to other
+// programs.
+type schemaHook interface {
+	SetSchemas(func(addrs.AbsResourceInstance) *configschema.Block)
+}
+
+// setHookSchemas gives each of the given hooks that implements schemaHook
+// the schemas of the resource types in the run's configuration and input
+// state.
+func setHookSchemas(ctx context.Context, run *backend.LocalRun, hooks []tofu.Hook) {
+	var schemaHooks []schemaHook
+	for _, h := range hooks {
+		if sh, ok := h.(schemaHook); ok {
+			schemaHooks = append(schemaHooks, sh)
+		}
+	}
+	if len(schemaHooks) == 0 {
+		return
+	}
+
+	schemas, diags := run.Core.Schemas(ctx, run.Config, run.InputState)
+	if diags.HasErrors() {
+		// The hooks then treat every schema as unknown, which is safer than
+		// sending values without knowing which of them are sensitive.
+		log.Printf("[WARN] backend/local: failed to load schemas for hooks: %s", diags.Err())
+		schemas = nil
+	}
+	lookup := resourceInstanceSchemas(schemas, run.Config, run.InputState)
+	for _, sh := range schemaHooks {
+		sh.SetSchemas(lookup)
+	}
+}
+
+// resourceInstanceSchemas returns a function that returns the schema of the
+// resource type of a resource instance in the given configuration or state,
+// or nil if it isn't known.
+//
+// The providers of the resources are collected up front, because the state
+// can change while the hooks are being called.
+func resourceInstanceSchemas(schemas *tofu.Schemas, config *configs.Config, state *states.State) func(addrs.AbsResourceInstance) *configschema.Block {
+	configProviders := make(map[string]addrs.Provider)
+	if config != nil {
+		config.DeepEach(func(c *configs.Config) {
+			for _, rcs := range []map[string]*configs.Resource{c.Module.ManagedResources, c.Module.DataResources} {
+				for _, rc := range rcs {
+					configProviders[rc.Addr().InModule(c.Path).String()] = rc.Provider
+				}
+			}
+		})
+	}
+	stateProviders := make(map[string]addrs.Provider)
+	if state != nil {
+		for _, ms := range state.Modules {
+			for _, rs := range ms.Resources {
+				stateProviders[rs.Addr.String()] = rs.ProviderConfig.Provider
+			}
+		}
+	}
+
+	return func(addr addrs.AbsResourceInstance) *configschema.Block {
+		if schemas == nil {
+			return nil
+		}
+		provider, ok := configProviders[addr.ConfigResource().String()]
+		if !ok {
+			// Resources that are no longer in the configuration use the
+			// provider recorded in the state.
+			provider, ok = stateProviders[addr.ContainingResource().String()]
+		}
+		if !ok {
+			return nil
+		}
+		schema, _ := schemas.ResourceTypeConfig(provider, addr.Resource.Resource.Mode, addr.Resource.Resource.Type)
+		if schema == nil {
+			return nil
+		}
+		return schema.Block
+	}
+}
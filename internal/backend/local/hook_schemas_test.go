@@ -0,0 +1,93 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package local
+
+import (
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+func TestResourceInstanceSchemas(t *testing.T) {
+	testProvider := addrs.NewDefaultProvider("test")
+	otherProvider := addrs.NewDefaultProvider("other")
+	instanceSchema := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"password": {Type: cty.String, Optional: true, Sensitive: true},
+		},
+	}
+	schemas := &tofu.Schemas{
+		Providers: map[addrs.Provider]providers.ProviderSchema{
+			testProvider: {
+				ResourceTypes: map[string]providers.Schema{
+					"test_instance": {Block: instanceSchema},
+				},
+			},
+		},
+	}
+
+	config := &configs.Config{
+		Module: &configs.Module{
+			ManagedResources: map[string]*configs.Resource{
+				"test_instance.a": {
+					Mode:     addrs.ManagedResourceMode,
+					Type:     "test_instance",
+					Name:     "a",
+					Provider: testProvider,
+				},
+				"test_instance.b": {
+					Mode:     addrs.ManagedResourceMode,
+					Type:     "test_instance",
+					Name:     "b",
+					Provider: otherProvider,
+				},
+			},
+		},
+	}
+	state := states.BuildState(func(ss *states.SyncState) {
+		ss.SetResourceInstanceCurrent(
+			mustResourceInstanceAddr("test_instance.removed"),
+			&states.ResourceInstanceObjectSrc{
+				Status:    states.ObjectReady,
+				AttrsJSON: []byte(`{"password":"secret"}`),
+			},
+			addrs.AbsProviderConfig{
+				Provider: testProvider,
+				Module:   addrs.RootModule,
+			},
+			addrs.NoKey,
+		)
+	})
+
+	lookup := resourceInstanceSchemas(schemas, config, state)
+	tests := map[string]*configschema.Block{
+		// The schema comes from the provider of the resource in the
+		// configuration, or in the state if the resource has been removed
+		// from the configuration.
+		"test_instance.a":       instanceSchema,
+		"test_instance.a[1]":    instanceSchema,
+		"test_instance.removed": instanceSchema,
+		"test_instance.b":       nil,
+		"test_instance.unknown": nil,
+	}
+	for addr, want := range tests {
+		t.Run(addr, func(t *testing.T) {
+			if got := lookup(mustResourceInstanceAddr(addr)); got != want {
+				t.Errorf("wrong schema %#v; want %#v", got, want)
+			}
+		})
+	}
+
+	// Without schemas, no schema is known.
+	if got := resourceInstanceSchemas(nil, config, state)(mustResourceInstanceAddr("test_instance.a")); got != nil {
+		t.Errorf("got schema %#v without schemas", got)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package local
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/command/jsonplan"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/plans/planfile"
+	"github.com/opentofu/opentofu/internal/policy"
+	"github.com/opentofu/opentofu/internal/states/statefile"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// loadPolicies loads the policies from the operation's policy directory, or
+// returns nil if the operation doesn't have one.
+func loadPolicies(op *backend.Operation) (*policy.Set, tfdiags.Diagnostics) {
+	if op.PolicyDir == "" {
+		return nil, nil
+	}
+	return policy.LoadDir(op.PolicyDir)
+}
+
+// evaluatePolicies evaluates the given policies against the JSON
+// representation of the plan, as "tofu show -json" would produce it.
+func evaluatePolicies(policies *policy.Set, config *configs.Config, plan *plans.Plan, schemas *tofu.Schemas) (*policy.Results, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	planJSON, err := jsonplan.Marshal(config, plan, &statefile.File{State: plan.PriorState}, schemas)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to evaluate policies",
+			fmt.Sprintf("Could not prepare the plan for policy evaluation: %s.", err),
+		))
+		return nil, diags
+	}
+	return policies.Evaluate(planJSON)
+}
+
+// checkSavedPolicyResults returns an error if any mandatory policy failed
+// when the given saved plan was created.
+//
+// The policies themselves already reported the details of the failures
+// when the plan was created, so this only summarizes them.
+func checkSavedPolicyResults(pf *planfile.Reader) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	results, err := pf.ReadPolicyResults()
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to read policy results",
+			fmt.Sprintf("Could not read the policy results recorded in the saved plan: %s.", err),
+		))
+		return diags
+	}
+
+	blocking := results.Blocking()
+	if len(blocking) == 0 {
+		return diags
+	}
+	var b strings.Builder
+	for _, result := range blocking {
+		for _, failure := range result.Failures {
+			fmt.Fprintf(&b, "\n  - %s (%s:%d): %s", result.Name, failure.Filename, failure.Line, failure.Message)
+		}
+	}
+	diags = diags.Append(tfdiags.Sourceless(
+		tfdiags.Error,
+		"Saved plan failed mandatory policies",
+		fmt.Sprintf("The following mandatory policies did not pass when this plan was created, so it cannot be applied:\n%s", b.String()),
+	))
+	return diags
+}
@@ -0,0 +1,96 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package local
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/plans/planfile"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// checkSavedPlanRequirements returns errors if the given saved plan has
+// expired, or doesn't meet the given requirements for saved plans, if any.
+func checkSavedPlanRequirements(pf *planfile.Reader, reqs *planfile.Requirements, now time.Time) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	meta, err := pf.ReadMetadata()
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid plan file",
+			fmt.Sprintf("Failed to read metadata from plan file: %s.", err),
+		))
+		return diags
+	}
+
+	switch {
+	case meta != nil && meta.Expired(now):
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Saved plan has expired",
+			fmt.Sprintf(
+				"The given plan file was created at %s and expired at %s, so it can no longer be applied. Create a new plan.",
+				meta.CreatedAt.Format(time.RFC3339), meta.ExpiresAt.Format(time.RFC3339),
+			),
+		))
+	case reqs != nil && reqs.MaxAge > 0 && meta == nil:
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Saved plan has no creation time",
+			"The CLI configuration limits how long after its creation a saved plan can be applied, but the given plan file doesn't record when it was created because it was created by an earlier version of OpenTofu. Create a new plan.",
+		))
+	case reqs != nil && reqs.MaxAge > 0 && now.Sub(meta.CreatedAt) > reqs.MaxAge:
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Saved plan is too old",
+			fmt.Sprintf(
+				"The given plan file was created at %s, more than %s ago, which is the longest time after its creation that the CLI configuration allows applying a saved plan. Create a new plan.",
+				meta.CreatedAt.Format(time.RFC3339), reqs.MaxAge,
+			),
+		))
+	}
+
+	if reqs == nil || !reqs.RequireApproval {
+		return diags
+	}
+
+	approvals, err := pf.VerifiedApprovals(reqs.ApprovalKeys)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid plan file",
+			fmt.Sprintf("Failed to verify the approvals of the plan file: %s.", err),
+		))
+		return diags
+	}
+	if len(approvals) > 0 {
+		return diags
+	}
+
+	all, _ := pf.ReadApprovals()
+	if len(all) == 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Saved plan is not approved",
+			"The CLI configuration requires saved plans to be approved before they are applied, but the given plan file has no approvals. Use \"tofu plan approve\" to approve the plan.",
+		))
+		return diags
+	}
+	var b strings.Builder
+	for _, approval := range all {
+		fmt.Fprintf(&b, "\n  - %s at %s", approval.Approver, approval.ApprovedAt.Format(time.RFC3339))
+	}
+	diags = diags.Append(tfdiags.Sourceless(
+		tfdiags.Error,
+		"Saved plan is not approved",
+		fmt.Sprintf(
+			"The CLI configuration requires saved plans to be approved before they are applied, but none of the approvals of the given plan file has a valid signature by one of the configured approval keys:\n%s\n\nEither the plan file was changed after it was approved, or it was approved with a key that is not trusted.",
+			b.String(),
+		),
+	))
+	return diags
+}
@@ -0,0 +1,171 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/configs/configload"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/plans/planfile"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/states/statefile"
+)
+
+func TestCheckSavedPlanRequirements(t *testing.T) {
+	created := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	dir := t.TempDir()
+
+	keyPath := filepath.Join(dir, "approval.key")
+	if err := os.WriteFile(keyPath, []byte(strings.Repeat("s", 32)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	key, err := planfile.LoadApprovalKey(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKeyPath := filepath.Join(dir, "other.key")
+	if err := os.WriteFile(otherKeyPath, []byte(strings.Repeat("o", 32)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := planfile.LoadApprovalKey(otherKeyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unapproved := testSavedPlanFile(t, filepath.Join(dir, "unapproved.tfplan"), planfile.NewMetadata(created, 0))
+	expiring := testSavedPlanFile(t, filepath.Join(dir, "expiring.tfplan"), planfile.NewMetadata(created, time.Hour))
+	legacy := testSavedPlanFile(t, filepath.Join(dir, "legacy.tfplan"), nil)
+	approved := testSavedPlanFile(t, filepath.Join(dir, "approved.tfplan"), planfile.NewMetadata(created, 0))
+	if _, err := planfile.Approve(approved, key, "alice", created.Add(time.Minute), encryption.PlanEncryptionDisabled()); err != nil {
+		t.Fatal(err)
+	}
+
+	requireApproval := &planfile.Requirements{
+		RequireApproval: true,
+		ApprovalKeys:    []*planfile.ApprovalKey{key},
+	}
+
+	tests := map[string]struct {
+		path    string
+		reqs    *planfile.Requirements
+		now     time.Time
+		wantErr string
+	}{
+		"no requirements": {
+			path: unapproved,
+			now:  created.Add(24 * time.Hour),
+		},
+		"before expiration": {
+			path: expiring,
+			now:  created.Add(30 * time.Minute),
+		},
+		"expired": {
+			path:    expiring,
+			now:     created.Add(2 * time.Hour),
+			wantErr: "Saved plan has expired",
+		},
+		"young enough": {
+			path: unapproved,
+			reqs: &planfile.Requirements{MaxAge: time.Hour},
+			now:  created.Add(30 * time.Minute),
+		},
+		"too old": {
+			path:    unapproved,
+			reqs:    &planfile.Requirements{MaxAge: time.Hour},
+			now:     created.Add(2 * time.Hour),
+			wantErr: "Saved plan is too old",
+		},
+		"no creation time": {
+			path:    legacy,
+			reqs:    &planfile.Requirements{MaxAge: time.Hour},
+			now:     created,
+			wantErr: "Saved plan has no creation time",
+		},
+		"approved": {
+			path: approved,
+			reqs: requireApproval,
+			now:  created.Add(time.Hour),
+		},
+		"not approved": {
+			path:    unapproved,
+			reqs:    requireApproval,
+			now:     created.Add(time.Hour),
+			wantErr: "tofu plan approve",
+		},
+		"approved with untrusted key": {
+			path: approved,
+			reqs: &planfile.Requirements{
+				RequireApproval: true,
+				ApprovalKeys:    []*planfile.ApprovalKey{otherKey},
+			},
+			now:     created.Add(time.Hour),
+			wantErr: "none of the approvals",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pf, err := planfile.Open(test.path, encryption.PlanEncryptionDisabled())
+			if err != nil {
+				t.Fatal(err)
+			}
+			diags := checkSavedPlanRequirements(pf, test.reqs, test.now)
+			if test.wantErr == "" {
+				if diags.HasErrors() {
+					t.Fatalf("unexpected errors: %s", diags.Err())
+				}
+				return
+			}
+			if !diags.HasErrors() {
+				t.Fatalf("expected error containing %q, got none", test.wantErr)
+			}
+			if got := diags.Err().Error(); !strings.Contains(got, test.wantErr) {
+				t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.wantErr)
+			}
+		})
+	}
+}
+
+func testSavedPlanFile(t *testing.T, path string, meta *planfile.Metadata) string {
+	t.Helper()
+
+	backendConfig := cty.ObjectVal(map[string]cty.Value{
+		"path": cty.NullVal(cty.String),
+	})
+	backendConfigRaw, err := plans.NewDynamicValue(backendConfig, backendConfig.Type())
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan := &plans.Plan{
+		UIMode:  plans.NormalMode,
+		Changes: plans.NewChanges(),
+		Backend: plans.Backend{
+			Type:   "local",
+			Config: backendConfigRaw,
+		},
+		PrevRunState: states.NewState(),
+		PriorState:   states.NewState(),
+	}
+	err = planfile.Create(path, planfile.CreateArgs{
+		ConfigSnapshot:       configload.NewEmptySnapshot(),
+		PreviousRunStateFile: statefile.New(plan.PrevRunState, "boop", 1),
+		StateFile:            statefile.New(plan.PriorState, "boop", 1),
+		Plan:                 plan,
+		DependencyLocks:      depsfile.NewLocks(),
+		Metadata:             meta,
+	}, encryption.PlanEncryptionDisabled())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,11 @@
+resource "test_instance" "foo" {
+    ami = "bar"
+}
+
+resource "test_instance" "bar" {
+    ami = "error"
+}
+
+resource "test_instance" "baz" {
+    ami = test_instance.bar.id
+}
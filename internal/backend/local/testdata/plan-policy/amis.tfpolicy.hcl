@@ -0,0 +1,11 @@
+policy "approved_amis" {
+  enforcement = mandatory
+
+  assert {
+    condition = alltrue([
+      for rc in plan.resource_changes : rc.change.after.ami != "bar"
+      if rc.type == "test_instance"
+    ])
+    error_message = "The AMI \"bar\" is not approved."
+  }
+}
@@ -0,0 +1,31 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package checks
+
+import (
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// Failure describes a single failed check, as reported to
+// State.ReportCheckFailure.
+type Failure struct {
+	// Message is the author-specified error message for the check, which
+	// may be empty if the message couldn't be evaluated.
+	Message string
+
+	// ErrorCode is the machine-readable error code given in the
+	// configuration of the check, or an empty string if it has none.
+	ErrorCode string
+
+	// Severity is the severity of the diagnostic that was reported for the
+	// failure, which is tfdiags.Warning for checks that don't block the
+	// operation.
+	Severity tfdiags.Severity
+}
+
+// isEmpty returns true if the failure has nothing to report beyond the
+// failure itself.
+func (f Failure) isEmpty() bool {
+	return f.Message == "" && f.ErrorCode == ""
+}
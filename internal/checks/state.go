@@ -35,9 +35,9 @@ import (
 // This container type is concurrency-safe for both reads and writes through
 // its various methods.
 type State struct {
-	mu          sync.Mutex
-	statuses    addrs.Map[addrs.ConfigCheckable, *configCheckableState]
-	failureMsgs addrs.Map[addrs.CheckRule, string]
+	mu       sync.Mutex
+	statuses addrs.Map[addrs.ConfigCheckable, *configCheckableState]
+	failures addrs.Map[addrs.CheckRule, Failure]
 }
 
 // configCheckableState is an internal part of type State that represents
@@ -228,17 +228,18 @@ func (c *State) ObjectCheckStatus(addr addrs.Checkable) Status {
 	return summarizeCheckStatuses(errorCount, failCount, unknownCount)
 }
 
-// ObjectFailureMessages returns the zero or more failure messages reported
-// for the object with the given address.
+// ObjectFailures returns the zero or more failures reported for the object
+// with the given address, omitting any that have neither a message nor an
+// error code.
 //
-// Failure messages are recorded only for checks whose status is StatusFail,
+// Failures are recorded only for checks whose status is StatusFail,
 // but since this aggregates together the results of all of the checks
 // on the given object it's possible for there to be a mixture of failures
 // and errors at the same time, which would aggregate as StatusError in
 // ObjectCheckStatus's result because errors are defined as "stronger"
 // than failures.
-func (c *State) ObjectFailureMessages(addr addrs.Checkable) []string {
-	var ret []string
+func (c *State) ObjectFailures(addr addrs.Checkable) []Failure {
+	var ret []Failure
 
 	configAddr := addr.ConfigCheckable()
 
@@ -258,17 +259,22 @@ func (c *State) ObjectFailureMessages(addr addrs.Checkable) []string {
 		for i, status := range checks {
 			if status == StatusFail {
 				checkAddr := addrs.NewCheckRule(addr, checkType, i)
-				msg := c.failureMsgs.Get(checkAddr)
-				if msg != "" {
-					ret = append(ret, msg)
+				failure := c.failures.Get(checkAddr)
+				if !failure.isEmpty() {
+					ret = append(ret, failure)
 				}
 			}
 		}
 	}
 
-	// We always return the messages in a lexical sort order just so that
+	// We always return the failures in a lexical sort order just so that
 	// it'll be consistent between runs if we still have the same problems.
-	sort.Strings(ret)
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Message != ret[j].Message {
+			return ret[i].Message < ret[j].Message
+		}
+		return ret[i].ErrorCode < ret[j].ErrorCode
+	})
 
 	return ret
 }
@@ -76,22 +76,22 @@ func (c *State) ReportCheckResult(objectAddr addrs.Checkable, checkType addrs.Ch
 
 // ReportCheckFailure is a more specialized version of ReportCheckResult which
 // captures a failure outcome in particular, giving the opportunity to capture
-// an author-specified error message string along with the failure.
+// the author-specified error message and error code along with the failure.
 //
 // This always records the given check as having StatusFail. Don't use this for
 // situations where the check condition was itself invalid, because that
 // should be represented by StatusError instead, and the error signalled via
 // diagnostics as normal.
-func (c *State) ReportCheckFailure(objectAddr addrs.Checkable, checkType addrs.CheckRuleType, index int, errorMessage string) {
+func (c *State) ReportCheckFailure(objectAddr addrs.Checkable, checkType addrs.CheckRuleType, index int, failure Failure) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	c.reportCheckResult(objectAddr, checkType, index, StatusFail)
-	if c.failureMsgs.Elems == nil {
-		c.failureMsgs = addrs.MakeMap[addrs.CheckRule, string]()
+	if c.failures.Elems == nil {
+		c.failures = addrs.MakeMap[addrs.CheckRule, Failure]()
 	}
 	checkAddr := addrs.NewCheckRule(objectAddr, checkType, index)
-	c.failureMsgs.Put(checkAddr, errorMessage)
+	c.failures.Put(checkAddr, failure)
 }
 
 // reportCheckResult is shared between both ReportCheckResult and
@@ -13,6 +13,7 @@ import (
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configload"
+	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
 func TestChecksHappyPath(t *testing.T) {
@@ -260,3 +261,62 @@ func TestChecksHappyPath(t *testing.T) {
 		}
 	}
 }
+
+func TestChecksObjectFailures(t *testing.T) {
+	const fixtureDir = "testdata/happypath"
+
+	t.Chdir(fixtureDir)
+
+	loader := configload.NewLazy(&configload.Config{
+		ModulesDir: ".terraform/modules/",
+	})
+
+	cfg, hclDiags := loader.LoadConfig(t.Context(), ".", configs.RootModuleCallForTesting())
+	if hclDiags.HasErrors() {
+		t.Fatalf("invalid configuration: %s", hclDiags.Error())
+	}
+
+	resourceA := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "null_resource",
+		Name: "a",
+	}.InModule(addrs.RootModule)
+	resourceInstA := resourceA.Resource.Absolute(addrs.RootModuleInstance).Instance(addrs.NoKey)
+
+	checks := NewState(cfg)
+	checks.ReportCheckableObjects(resourceA, addrs.MakeSet[addrs.Checkable](resourceInstA))
+	checks.ReportCheckFailure(resourceInstA, addrs.ResourcePrecondition, 0, Failure{
+		Message:   "Impossible.",
+		ErrorCode: "IMPOSSIBLE",
+		Severity:  tfdiags.Error,
+	})
+	checks.ReportCheckFailure(resourceInstA, addrs.ResourcePrecondition, 1, Failure{
+		Message:  "Also impossible.",
+		Severity: tfdiags.Warning,
+	})
+	// A failure with neither a message nor an error code isn't included in
+	// the result, but still counts as a failure.
+	checks.ReportCheckFailure(resourceInstA, addrs.ResourcePostcondition, 0, Failure{
+		Severity: tfdiags.Error,
+	})
+
+	if got, want := checks.ObjectCheckStatus(resourceInstA), StatusFail; got != want {
+		t.Errorf("incorrect check status for %s: %s, but want %s", resourceInstA, got, want)
+	}
+
+	got := checks.ObjectFailures(resourceInstA)
+	want := []Failure{
+		{
+			Message:  "Also impossible.",
+			Severity: tfdiags.Warning,
+		},
+		{
+			Message:   "Impossible.",
+			ErrorCode: "IMPOSSIBLE",
+			Severity:  tfdiags.Error,
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong failures\n%s", diff)
+	}
+}
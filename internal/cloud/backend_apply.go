@@ -87,6 +87,46 @@ func (b *Cloud) opApply(ctx, stopCtx, cancelCtx context.Context, op *backend.Ope
 		))
 	}
 
+	if op.AllowDeferral {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"-allow-deferral option is not supported",
+			"The -allow-deferral option is not currently supported for remote plans.",
+		))
+	}
+
+	if op.Journal {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"-journal option is not supported",
+			"The -journal option is not currently supported for remote applies.",
+		))
+	}
+
+	if op.Resume {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"-resume option is not supported",
+			"The -resume option is not currently supported for remote applies.",
+		))
+	}
+
+	if op.Report {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"-report option is not supported",
+			"The -report option is not currently supported for remote applies.",
+		))
+	}
+
+	if op.PolicyDir != "" {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"-policy option is not supported",
+			"The -policy option is not currently supported for remote applies.",
+		))
+	}
+
 	// Return if there are any errors.
 	if diags.HasErrors() {
 		return nil, diags.Err()
@@ -128,6 +128,10 @@ func (c *ApplyCommand) Run(rawArgs []string) int {
 		return 1
 	}
 
+	if op.ApplyReport != nil {
+		view.ApplyReport(op.ApplyReport)
+	}
+
 	if op.Result != backend.OperationSuccess {
 		return op.Result.ExitStatus()
 	}
@@ -269,10 +273,16 @@ func (c *ApplyCommand) OperationRequest(
 		opReq.Hooks = append(opReq.Hooks, &e2eTestingApplyHook{})
 	}
 	opReq.PlanFile = planFile
+	opReq.SavedPlanRequirements = c.SavedPlanRequirements
+	opReq.Journal = applyArgs.Journal
+	opReq.Resume = applyArgs.Resume
+	opReq.Report = applyArgs.Report
+	opReq.PolicyDir = applyArgs.PolicyDir
 	opReq.PlanRefresh = applyArgs.Operation.Refresh
 	opReq.Targets = applyArgs.Operation.Targets
 	opReq.Excludes = applyArgs.Operation.Excludes
 	opReq.ForceReplace = applyArgs.Operation.ForceReplace
+	opReq.AllowDeferral = applyArgs.Operation.AllowDeferral
 	opReq.Type = backend.OperationTypeApply
 	opReq.View = view.Operation()
 
@@ -347,6 +357,11 @@ Options:
 
   -input=true                  Ask for input for variables if not directly set.
 
+  -journal                     Keep a journal of the changes started and
+                               completed, so that the apply can be continued
+                               with -resume if it gets interrupted. This makes
+                               the apply slower.
+
   -no-color                    If specified, output won't contain any color.
 
   -concise                     Disables progress-related messages in the output.
@@ -354,6 +369,11 @@ Options:
   -parallelism=n               Limit the number of parallel resource operations.
                                Defaults to 10.
 
+  -policy=dir                  Evaluate the policies in the *.tfpolicy.hcl
+                               files in the given directory against the plan
+                               before applying it. A failing mandatory policy
+                               prevents the apply.
+
   -state=path                  Path to read and save state (unless state-out
                                is specified). Defaults to "terraform.tfstate".
 
@@ -361,6 +381,14 @@ Options:
                                "-state". This can be used to preserve the old
                                state.
 
+  -report                      Print a summary of the changes that succeeded,
+                               failed, or were skipped once the apply finishes.
+
+  -resume                      Continue applying the given saved plan after an
+                               earlier apply of it with -journal was
+                               interrupted, skipping the changes that were
+                               already completed.
+
   -show-sensitive              If specified, sensitive values will be displayed.
 
   -suppress-forget-errors      Suppress the error that occurs when a destroy
@@ -413,6 +441,9 @@ Usage: tofu [global options] destroy [options]
 
 Options:
 
+  -report                      Print a summary of the changes that succeeded,
+                               failed, or were skipped once the apply finishes.
+
   -suppress-forget-errors      Suppress the error that occurs when a destroy
                                operation completes successfully but leaves
                                forgotten instances behind.
@@ -25,6 +25,21 @@ type Apply struct {
 	// PlanPath contains an optional path to a stored plan file
 	PlanPath string
 
+	// Journal keeps a journal of the changes started and completed by the
+	// apply, which allows resuming it if it gets interrupted.
+	Journal bool
+
+	// Resume continues an interrupted apply of the plan file in PlanPath.
+	Resume bool
+
+	// Report requests a summary of the planned changes that succeeded,
+	// failed, or were skipped once the apply finishes.
+	Report bool
+
+	// PolicyDir is an optional directory of policy files to evaluate
+	// against the plan before applying it.
+	PolicyDir string
+
 	// ViewOptions specifies which view options to use
 	ViewOptions ViewOptions
 
@@ -50,6 +65,10 @@ func ParseApply(args []string) (*Apply, func(), tfdiags.Diagnostics) {
 	cmdFlags := extendedFlagSet("apply", apply.Operation, apply.Vars)
 	cmdFlags.BoolVar(&apply.AutoApprove, "auto-approve", false, "auto-approve")
 	cmdFlags.BoolVar(&apply.ShowSensitive, "show-sensitive", false, "displays sensitive values")
+	cmdFlags.BoolVar(&apply.Journal, "journal", false, "journal")
+	cmdFlags.BoolVar(&apply.Resume, "resume", false, "resume")
+	cmdFlags.BoolVar(&apply.Report, "report", false, "report")
+	cmdFlags.StringVar(&apply.PolicyDir, "policy", "", "policy")
 	cmdFlags.BoolVar(&apply.SuppressForgetErrorsDuringDestroy, "suppress-forget-errors", false, "suppress errors in destroy mode due to resources being forgotten")
 
 	apply.State.addFlags(cmdFlags, stateFlagAll)
@@ -77,6 +96,14 @@ func ParseApply(args []string) (*Apply, func(), tfdiags.Diagnostics) {
 		))
 	}
 
+	if apply.Resume && apply.PlanPath == "" {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Plan file required",
+			"The -resume option requires the saved plan file whose apply was interrupted.",
+		))
+	}
+
 	// JSON view cannot confirm apply, so we require either a plan file or
 	// auto-approve to be specified. We intentionally fail here rather than
 	// override auto-approve, which would be dangerous.
@@ -179,6 +179,51 @@ func TestParseApply_tooManyArguments(t *testing.T) {
 	}
 }
 
+func TestParseApply_resume(t *testing.T) {
+	got, _, diags := ParseApply([]string{"-resume", "saved.tfplan"})
+	if len(diags) > 0 {
+		t.Fatalf("unexpected diags: %v", diags)
+	}
+	if !got.Resume || got.PlanPath != "saved.tfplan" {
+		t.Fatalf("wrong result: Resume=%t, PlanPath=%q", got.Resume, got.PlanPath)
+	}
+
+	_, _, diags = ParseApply([]string{"-resume"})
+	if got, want := diags.Err().Error(), "Plan file required"; !strings.Contains(got, want) {
+		t.Fatalf("wrong diags\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestParseApply_journal(t *testing.T) {
+	got, _, diags := ParseApply([]string{"-journal"})
+	if len(diags) > 0 {
+		t.Fatalf("unexpected diags: %v", diags)
+	}
+	if !got.Journal {
+		t.Fatal("Journal should be true")
+	}
+}
+
+func TestParseApply_report(t *testing.T) {
+	got, _, diags := ParseApply([]string{"-report"})
+	if len(diags) > 0 {
+		t.Fatalf("unexpected diags: %v", diags)
+	}
+	if !got.Report {
+		t.Fatal("Report should be true")
+	}
+}
+
+func TestParseApply_policy(t *testing.T) {
+	got, _, diags := ParseApply([]string{"-policy=policies"})
+	if len(diags) > 0 {
+		t.Fatalf("unexpected diags: %v", diags)
+	}
+	if got, want := got.PolicyDir, "policies"; got != want {
+		t.Fatalf("wrong PolicyDir %q; want %q", got, want)
+	}
+}
+
 func TestParseApply_targets(t *testing.T) {
 	foobarbaz, _ := addrs.ParseTargetStr("foo_bar.baz")
 	boop, _ := addrs.ParseTargetStr("module.boop")
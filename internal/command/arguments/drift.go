@@ -0,0 +1,75 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"github.com/opentofu/opentofu/internal/command/flags"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// Drift represents the command-line arguments for the drift command.
+type Drift struct {
+	// State and Vars are the common extended flags
+	State *State
+	Vars  *Vars
+
+	// Parallelism is the limit OpenTofu places on total parallel operations
+	// as it refreshes the resources in each workspace.
+	Parallelism int
+
+	// AllWorkspaces checks every workspace instead of only the current one.
+	AllWorkspaces bool
+
+	// Ignore are glob patterns for resource instances and attributes whose
+	// drift should not be reported.
+	Ignore []string
+
+	// ReportPath is an optional path to write a JSON drift report to.
+	ReportPath string
+
+	// ViewOptions specifies which view options to use
+	ViewOptions ViewOptions
+}
+
+// ParseDrift processes CLI arguments, returning a Drift value, a closer
+// function, and errors. If errors are encountered, a Drift value is still
+// returned representing the best effort interpretation of the arguments.
+func ParseDrift(args []string) (*Drift, func(), tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	drift := &Drift{
+		State: &State{},
+		Vars:  &Vars{},
+	}
+
+	cmdFlags := extendedFlagSet("drift", nil, drift.Vars)
+	drift.State.addFlags(cmdFlags, stateFlagLock|stateFlagStateIn)
+	cmdFlags.IntVar(&drift.Parallelism, "parallelism", DefaultParallelism, "parallelism")
+	cmdFlags.BoolVar(&drift.AllWorkspaces, "all-workspaces", false, "all-workspaces")
+	cmdFlags.Var((*flags.FlagStringSlice)(&drift.Ignore), "ignore", "ignore")
+	cmdFlags.StringVar(&drift.ReportPath, "report", "", "report")
+
+	drift.ViewOptions.AddFlags(cmdFlags, true)
+
+	if err := cmdFlags.Parse(args); err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to parse command-line flags",
+			err.Error(),
+		))
+	}
+
+	args = cmdFlags.Args()
+	if len(args) > 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Too many command line arguments",
+			"To specify a working directory for the drift check, use the global -chdir flag.",
+		))
+	}
+
+	closer, moreDiags := drift.ViewOptions.Parse()
+	diags = diags.Append(moreDiags)
+
+	return drift, closer, diags
+}
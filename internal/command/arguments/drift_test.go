@@ -0,0 +1,113 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestParseDrift_valid(t *testing.T) {
+	testCases := map[string]struct {
+		args []string
+		want *Drift
+	}{
+		"defaults": {
+			nil,
+			&Drift{
+				Parallelism: DefaultParallelism,
+				ViewOptions: ViewOptions{
+					InputEnabled: true,
+					ViewType:     ViewHuman,
+				},
+			},
+		},
+		"all workspaces with report": {
+			[]string{"-all-workspaces", "-report=drift.json"},
+			&Drift{
+				Parallelism:   DefaultParallelism,
+				AllWorkspaces: true,
+				ReportPath:    "drift.json",
+				ViewOptions: ViewOptions{
+					InputEnabled: true,
+					ViewType:     ViewHuman,
+				},
+			},
+		},
+		"ignore patterns": {
+			[]string{"-ignore=aws_instance.*.tags", "-ignore", "null_resource.*"},
+			&Drift{
+				Parallelism: DefaultParallelism,
+				Ignore:      []string{"aws_instance.*.tags", "null_resource.*"},
+				ViewOptions: ViewOptions{
+					InputEnabled: true,
+					ViewType:     ViewHuman,
+				},
+			},
+		},
+		"JSON view disables input": {
+			[]string{"-json", "-parallelism=5"},
+			&Drift{
+				Parallelism: 5,
+				ViewOptions: ViewOptions{
+					InputEnabled: false,
+					ViewType:     ViewJSON,
+				},
+			},
+		},
+	}
+
+	cmpOpts := cmp.Options{
+		cmpopts.IgnoreFields(Drift{}, "State", "Vars"),
+		cmpopts.IgnoreUnexported(ViewOptions{}),
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, closer, diags := ParseDrift(tc.args)
+			defer closer()
+			if len(diags) > 0 {
+				t.Fatalf("unexpected diags: %v", diags)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpOpts); diff != "" {
+				t.Errorf("unexpected result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseDrift_invalid(t *testing.T) {
+	testCases := map[string]struct {
+		args    []string
+		wantErr string
+	}{
+		"unknown flag": {
+			[]string{"-frob"},
+			"flag provided but not defined",
+		},
+		"too many arguments": {
+			[]string{"foo"},
+			"Too many command line arguments",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, closer, diags := ParseDrift(tc.args)
+			defer closer()
+			if len(diags) == 0 {
+				t.Fatal("expected diags but got none")
+			}
+			if got, want := diags.Err().Error(), tc.wantErr; !strings.Contains(got, want) {
+				t.Fatalf("wrong diags\n got: %s\nwant: %s", got, want)
+			}
+			if got.ViewOptions.ViewType != ViewHuman {
+				t.Fatalf("wrong view type, got %#v, want %#v", got.ViewOptions.ViewType, ViewHuman)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// Explain represents the command-line arguments for the explain command.
+type Explain struct {
+	// PlanPath is the path of the saved plan file containing the change to
+	// explain.
+	PlanPath string
+
+	// Addr is the address of the resource instance whose planned change
+	// should be explained.
+	Addr addrs.AbsResourceInstance
+
+	// ViewOptions specifies which view options to use
+	ViewOptions ViewOptions
+
+	Vars *Vars
+}
+
+// ParseExplain processes CLI arguments, returning an Explain value, a closer
+// function, and errors. If errors are encountered, an Explain value is still
+// returned representing the best effort interpretation of the arguments.
+func ParseExplain(args []string) (*Explain, func(), tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	explain := &Explain{
+		Vars: &Vars{},
+	}
+
+	cmdFlags := extendedFlagSet("explain", nil, explain.Vars)
+	cmdFlags.StringVar(&explain.PlanPath, "plan", "", "plan")
+	explain.ViewOptions.AddFlags(cmdFlags, false)
+
+	if err := cmdFlags.Parse(args); err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to parse command-line flags",
+			err.Error(),
+		))
+	}
+
+	if explain.PlanPath == "" {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Missing saved plan file",
+			"The -plan option is required, to specify the saved plan file containing the change to explain.",
+		))
+	}
+
+	args = cmdFlags.Args()
+	if len(args) != 1 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid number of command line arguments",
+			"Expected exactly one argument: the address of the resource instance whose planned change to explain.",
+		))
+	} else {
+		addr, addrDiags := addrs.ParseAbsResourceInstanceStr(args[0])
+		diags = diags.Append(addrDiags)
+		explain.Addr = addr
+	}
+
+	closer, moreDiags := explain.ViewOptions.Parse()
+	diags = diags.Append(moreDiags)
+
+	return explain, closer, diags
+}
@@ -0,0 +1,111 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+func TestParseExplain_valid(t *testing.T) {
+	testCases := map[string]struct {
+		args []string
+		want *Explain
+	}{
+		"resource instance": {
+			[]string{"-plan=saved.tfplan", `module.app.test_instance.web["a"]`},
+			&Explain{
+				PlanPath: "saved.tfplan",
+				Addr:     mustResourceInstanceAddr(`module.app.test_instance.web["a"]`),
+				ViewOptions: ViewOptions{
+					InputEnabled: false,
+					ViewType:     ViewHuman,
+				},
+			},
+		},
+		"json": {
+			[]string{"-json", "-plan=saved.tfplan", "test_instance.web"},
+			&Explain{
+				PlanPath: "saved.tfplan",
+				Addr:     mustResourceInstanceAddr("test_instance.web"),
+				ViewOptions: ViewOptions{
+					InputEnabled: false,
+					ViewType:     ViewJSON,
+				},
+			},
+		},
+	}
+
+	cmpOpts := cmp.Options{
+		cmpopts.IgnoreFields(Explain{}, "Vars"),
+		cmpopts.IgnoreUnexported(ViewOptions{}),
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, closer, diags := ParseExplain(tc.args)
+			defer closer()
+			if len(diags) > 0 {
+				t.Fatalf("unexpected diags: %v", diags)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpOpts); diff != "" {
+				t.Errorf("unexpected result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseExplain_invalid(t *testing.T) {
+	testCases := map[string]struct {
+		args    []string
+		wantErr string
+	}{
+		"unknown flag": {
+			[]string{"-frob", "-plan=saved.tfplan", "test_instance.web"},
+			"flag provided but not defined",
+		},
+		"no plan": {
+			[]string{"test_instance.web"},
+			"Missing saved plan file",
+		},
+		"no address": {
+			[]string{"-plan=saved.tfplan"},
+			"Invalid number of command line arguments",
+		},
+		"two addresses": {
+			[]string{"-plan=saved.tfplan", "test_instance.web", "test_instance.db"},
+			"Invalid number of command line arguments",
+		},
+		"invalid address": {
+			[]string{"-plan=saved.tfplan", "module.app"},
+			"Invalid address",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, closer, diags := ParseExplain(tc.args)
+			defer closer()
+			if len(diags) == 0 {
+				t.Fatal("expected diags but got none")
+			}
+			if got, want := diags.Err().Error(), tc.wantErr; !strings.Contains(got, want) {
+				t.Fatalf("wrong diags\n got: %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func mustResourceInstanceAddr(s string) addrs.AbsResourceInstance {
+	addr, diags := addrs.ParseAbsResourceInstanceStr(s)
+	if diags.HasErrors() {
+		panic(diags.Err())
+	}
+	return addr
+}
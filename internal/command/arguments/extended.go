@@ -116,6 +116,10 @@ type Operation struct {
 	// than a set of excluded resource addresses and resources dependent on them.
 	Excludes []addrs.Targetable
 
+	// AllowDeferral allows the plan to defer any resources or modules whose
+	// count or for_each cannot be determined yet, rather than failing.
+	AllowDeferral bool
+
 	// ForceReplace addresses cause OpenTofu to force a particular set of
 	// resource instances to generate "replace" actions in any plan where they
 	// would normally have generated "no-op" or "update" actions.
@@ -262,6 +266,14 @@ func (o *Operation) Parse() tfdiags.Diagnostics {
 	o.Targets, o.Excludes, parseDiags = parseRawTargetsAndExcludes(o.targetsRaw, o.excludesRaw, o.targetsFilesRaw, o.excludesFilesRaw)
 	diags = diags.Append(parseDiags)
 
+	if o.AllowDeferral && len(o.Targets) > 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid combination of arguments",
+			"The -allow-deferral option cannot be used together with -target or -target-file.",
+		))
+	}
+
 	for _, raw := range o.forceReplaceRaw {
 		traversal, syntaxDiags := hclsyntax.ParseTraversalAbs([]byte(raw), "", hcl.Pos{Line: 1, Column: 1})
 		if syntaxDiags.HasErrors() {
@@ -366,6 +378,7 @@ func extendedFlagSet(name string, operation *Operation, vars *Vars) *flag.FlagSe
 		f.Var((*flags.FlagStringSlice)(&operation.excludesRaw), "exclude", "exclude")
 		f.Var((*flags.FlagStringSlice)(&operation.excludesFilesRaw), "exclude-file", "exclude-file")
 		f.Var((*flags.FlagStringSlice)(&operation.forceReplaceRaw), "replace", "replace")
+		f.BoolVar(&operation.AllowDeferral, "allow-deferral", false, "allow-deferral")
 	}
 
 	// Gather all -var and -var-file arguments into one heterogeneous structure
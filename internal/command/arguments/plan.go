@@ -6,6 +6,8 @@
 package arguments
 
 import (
+	"time"
+
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
@@ -28,6 +30,14 @@ type Plan struct {
 	// be written to.
 	GenerateConfigPath string
 
+	// PolicyDir is an optional directory of policy files to evaluate
+	// against the plan.
+	PolicyDir string
+
+	// ExpiresIn is an optional duration after which the plan saved to
+	// OutPath can no longer be applied.
+	ExpiresIn time.Duration
+
 	// ViewOptions specifies which view options to use
 	ViewOptions ViewOptions
 
@@ -51,6 +61,8 @@ func ParsePlan(args []string) (*Plan, func(), tfdiags.Diagnostics) {
 	cmdFlags.BoolVar(&plan.DetailedExitCode, "detailed-exitcode", false, "detailed-exitcode")
 	cmdFlags.StringVar(&plan.OutPath, "out", "", "out")
 	cmdFlags.StringVar(&plan.GenerateConfigPath, "generate-config-out", "", "generate-config-out")
+	cmdFlags.StringVar(&plan.PolicyDir, "policy", "", "policy")
+	cmdFlags.DurationVar(&plan.ExpiresIn, "expires-in", 0, "expires-in")
 	cmdFlags.BoolVar(&plan.ShowSensitive, "show-sensitive", false, "displays sensitive values")
 
 	plan.ViewOptions.AddFlags(cmdFlags, true)
@@ -73,6 +85,20 @@ func ParsePlan(args []string) (*Plan, func(), tfdiags.Diagnostics) {
 		))
 	}
 
+	if plan.ExpiresIn < 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid plan expiration",
+			"The -expires-in option must be a positive duration, such as \"4h\".",
+		))
+	} else if plan.ExpiresIn > 0 && plan.OutPath == "" {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Plan expiration requires a saved plan",
+			"The -expires-in option only applies to a plan saved with the -out option.",
+		))
+	}
+
 	diags = diags.Append(plan.Operation.Parse())
 	closer, moreDiags := plan.ViewOptions.Parse()
 	diags = diags.Append(moreDiags)
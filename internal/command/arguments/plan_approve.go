@@ -0,0 +1,67 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// PlanApprove represents the command-line arguments for the plan approve
+// command.
+type PlanApprove struct {
+	// PlanPath is the path of the saved plan file to approve.
+	PlanPath string
+
+	// KeyPath is the path of the approval key to sign the approval with.
+	KeyPath string
+
+	// Approver is the name recorded as the approver of the plan. If it is
+	// empty, the command uses the name of the current user.
+	Approver string
+
+	Vars *Vars
+}
+
+// ParsePlanApprove processes CLI arguments, returning a PlanApprove value and
+// errors. If errors are encountered, a PlanApprove value is still returned
+// representing the best effort interpretation of the arguments.
+func ParsePlanApprove(args []string) (*PlanApprove, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	planApprove := &PlanApprove{
+		Vars: &Vars{},
+	}
+
+	cmdFlags := extendedFlagSet("plan approve", nil, planApprove.Vars)
+	cmdFlags.StringVar(&planApprove.KeyPath, "key", "", "key")
+	cmdFlags.StringVar(&planApprove.Approver, "approver", "", "approver")
+
+	if err := cmdFlags.Parse(args); err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to parse command-line flags",
+			err.Error(),
+		))
+	}
+
+	args = cmdFlags.Args()
+	if len(args) != 1 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid number of command line arguments",
+			"Expected exactly one argument: the path of the saved plan file to approve.",
+		))
+	} else {
+		planApprove.PlanPath = args[0]
+	}
+
+	if planApprove.KeyPath == "" {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Approval key required",
+			"Use the -key option to specify the key to sign the approval with.",
+		))
+	}
+
+	return planApprove, diags
+}
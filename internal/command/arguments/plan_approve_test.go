@@ -0,0 +1,83 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestParsePlanApprove_valid(t *testing.T) {
+	testCases := map[string]struct {
+		args []string
+		want *PlanApprove
+	}{
+		"key only": {
+			[]string{"-key=approval.key", "saved.tfplan"},
+			&PlanApprove{
+				PlanPath: "saved.tfplan",
+				KeyPath:  "approval.key",
+				Vars:     &Vars{},
+			},
+		},
+		"approver": {
+			[]string{"-key=approval.key", "-approver=alice", "saved.tfplan"},
+			&PlanApprove{
+				PlanPath: "saved.tfplan",
+				KeyPath:  "approval.key",
+				Approver: "alice",
+				Vars:     &Vars{},
+			},
+		},
+	}
+
+	cmpOpts := cmpopts.IgnoreUnexported(Vars{})
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, diags := ParsePlanApprove(tc.args)
+			if len(diags) > 0 {
+				t.Fatalf("unexpected diags: %v", diags)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpOpts); diff != "" {
+				t.Errorf("unexpected result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParsePlanApprove_invalid(t *testing.T) {
+	testCases := map[string]struct {
+		args    []string
+		wantErr string
+	}{
+		"no plan": {
+			args:    []string{"-key=approval.key"},
+			wantErr: "Invalid number of command line arguments",
+		},
+		"no key": {
+			args:    []string{"saved.tfplan"},
+			wantErr: "Approval key required",
+		},
+		"unknown flag": {
+			args:    []string{"-frob", "saved.tfplan"},
+			wantErr: "flag provided but not defined",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, diags := ParsePlanApprove(tc.args)
+			if len(diags) == 0 {
+				t.Fatal("expected diags but got none")
+			}
+			if got := diags.Err().Error(); !strings.Contains(got, tc.wantErr) {
+				t.Fatalf("wrong diags\n got: %s\nwant: %s", got, tc.wantErr)
+			}
+		})
+	}
+}
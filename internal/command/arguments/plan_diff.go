@@ -0,0 +1,58 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// PlanDiff represents the command-line arguments for the plan diff command.
+type PlanDiff struct {
+	// PlanA and PlanB are the paths of the two saved plan files to compare.
+	// PlanA is usually the plan that was reviewed, and PlanB a plan that
+	// was created later for the same changes.
+	PlanA, PlanB string
+
+	// ViewOptions specifies which view options to use
+	ViewOptions ViewOptions
+
+	Vars *Vars
+}
+
+// ParsePlanDiff processes CLI arguments, returning a PlanDiff value, a closer
+// function, and errors. If errors are encountered, a PlanDiff value is still
+// returned representing the best effort interpretation of the arguments.
+func ParsePlanDiff(args []string) (*PlanDiff, func(), tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	planDiff := &PlanDiff{
+		Vars: &Vars{},
+	}
+
+	cmdFlags := extendedFlagSet("plan diff", nil, planDiff.Vars)
+	planDiff.ViewOptions.AddFlags(cmdFlags, false)
+
+	if err := cmdFlags.Parse(args); err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to parse command-line flags",
+			err.Error(),
+		))
+	}
+
+	args = cmdFlags.Args()
+	if len(args) != 2 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid number of command line arguments",
+			"Expected exactly two arguments: the paths of the two saved plan files to compare.",
+		))
+	} else {
+		planDiff.PlanA, planDiff.PlanB = args[0], args[1]
+	}
+
+	closer, moreDiags := planDiff.ViewOptions.Parse()
+	diags = diags.Append(moreDiags)
+
+	return planDiff, closer, diags
+}
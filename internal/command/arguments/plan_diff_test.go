@@ -0,0 +1,97 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestParsePlanDiff_valid(t *testing.T) {
+	testCases := map[string]struct {
+		args []string
+		want *PlanDiff
+	}{
+		"two plans": {
+			[]string{"a.tfplan", "b.tfplan"},
+			&PlanDiff{
+				PlanA: "a.tfplan",
+				PlanB: "b.tfplan",
+				ViewOptions: ViewOptions{
+					InputEnabled: false,
+					ViewType:     ViewHuman,
+				},
+			},
+		},
+		"json": {
+			[]string{"-json", "a.tfplan", "b.tfplan"},
+			&PlanDiff{
+				PlanA: "a.tfplan",
+				PlanB: "b.tfplan",
+				ViewOptions: ViewOptions{
+					InputEnabled: false,
+					ViewType:     ViewJSON,
+				},
+			},
+		},
+	}
+
+	cmpOpts := cmp.Options{
+		cmpopts.IgnoreFields(PlanDiff{}, "Vars"),
+		cmpopts.IgnoreUnexported(ViewOptions{}),
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, closer, diags := ParsePlanDiff(tc.args)
+			defer closer()
+			if len(diags) > 0 {
+				t.Fatalf("unexpected diags: %v", diags)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpOpts); diff != "" {
+				t.Errorf("unexpected result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParsePlanDiff_invalid(t *testing.T) {
+	testCases := map[string]struct {
+		args    []string
+		wantErr string
+	}{
+		"unknown flag": {
+			[]string{"-frob", "a.tfplan", "b.tfplan"},
+			"flag provided but not defined",
+		},
+		"no plans": {
+			nil,
+			"Invalid number of command line arguments",
+		},
+		"one plan": {
+			[]string{"a.tfplan"},
+			"Invalid number of command line arguments",
+		},
+		"three plans": {
+			[]string{"a.tfplan", "b.tfplan", "c.tfplan"},
+			"Invalid number of command line arguments",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, closer, diags := ParsePlanDiff(tc.args)
+			defer closer()
+			if len(diags) == 0 {
+				t.Fatal("expected diags but got none")
+			}
+			if got, want := diags.Err().Error(), tc.wantErr; !strings.Contains(got, want) {
+				t.Fatalf("wrong diags\n got: %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ package arguments
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/opentofu/opentofu/internal/command/flags"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -43,16 +44,18 @@ func TestParsePlan_basicValid(t *testing.T) {
 			},
 		},
 		"setting all options": {
-			[]string{"-destroy", "-detailed-exitcode", "-input=false", "-out=saved.tfplan"},
+			[]string{"-destroy", "-detailed-exitcode", "-input=false", "-out=saved.tfplan", "-policy=policies", "-expires-in=4h"},
 			&Plan{
 				DetailedExitCode: true,
 				ViewOptions: ViewOptions{
 					InputEnabled: false,
 					ViewType:     ViewHuman,
 				},
-				OutPath: "saved.tfplan",
-				State:   &State{Lock: true},
-				Vars:    &Vars{},
+				OutPath:   "saved.tfplan",
+				PolicyDir: "policies",
+				ExpiresIn: 4 * time.Hour,
+				State:     &State{Lock: true},
+				Vars:      &Vars{},
 				Operation: &Operation{
 					PlanMode:    plans.DestroyMode,
 					Parallelism: 10,
@@ -121,6 +124,34 @@ func TestParsePlan_tooManyArguments(t *testing.T) {
 	}
 }
 
+func TestParsePlan_expiresIn(t *testing.T) {
+	testCases := map[string]struct {
+		args    []string
+		wantErr string
+	}{
+		"negative duration": {
+			args:    []string{"-out=saved.tfplan", "-expires-in=-1h"},
+			wantErr: "Invalid plan expiration",
+		},
+		"without saved plan": {
+			args:    []string{"-expires-in=1h"},
+			wantErr: "Plan expiration requires a saved plan",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, _, diags := ParsePlan(tc.args)
+			if len(diags) == 0 {
+				t.Fatal("expected diags but got none")
+			}
+			if got := diags.Err().Error(); !strings.Contains(got, tc.wantErr) {
+				t.Fatalf("wrong diags\n got: %s\nwant: %s", got, tc.wantErr)
+			}
+		})
+	}
+}
+
 func TestParsePlan_targets(t *testing.T) {
 	foobarbaz, _ := addrs.ParseTargetStr("foo_bar.baz")
 	boop, _ := addrs.ParseTargetStr("module.boop")
@@ -609,6 +640,28 @@ func TestParsePlan_excludeAndTarget(t *testing.T) {
 	}
 }
 
+func TestParsePlan_allowDeferral(t *testing.T) {
+	got, _, gotDiags := ParsePlan([]string{"-allow-deferral", "-exclude=module.baz"})
+	if len(gotDiags) > 0 {
+		t.Fatalf("unexpected diags: %v", gotDiags)
+	}
+	if !got.Operation.AllowDeferral {
+		t.Errorf("expected AllowDeferral to be set")
+	}
+
+	_, _, gotDiags = ParsePlan([]string{"-allow-deferral", "-target=foo.bar"})
+	wantDiags := tfdiags.Diagnostics{
+		tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid combination of arguments",
+			"The -allow-deferral option cannot be used together with -target or -target-file.",
+		),
+	}
+	if diff := cmp.Diff(wantDiags.ForRPC(), gotDiags.ForRPC()); diff != "" {
+		t.Errorf("wrong diagnostics\n%s", diff)
+	}
+}
+
 func TestParsePlan_vars(t *testing.T) {
 	testCases := map[string]struct {
 		args []string
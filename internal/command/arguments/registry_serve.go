@@ -0,0 +1,75 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// DefaultRegistryServeAddress is the address that 'registry serve' listens
+// on when no -address option is given.
+const DefaultRegistryServeAddress = "localhost:8080"
+
+// RegistryServe represents the command-line arguments for the 'registry serve' command.
+type RegistryServe struct {
+	// Directory is the directory containing the modules and providers to serve.
+	Directory string
+	// Address is the TCP address to listen on, in the form accepted by net.Listen.
+	Address string
+	// TLSCertFile and TLSKeyFile are the paths to a PEM-encoded certificate and
+	// private key to serve HTTPS with. Either both or neither are set.
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// ViewOptions specifies which view options to use
+	ViewOptions ViewOptions
+}
+
+// ParseRegistryServe processes CLI arguments, returning a RegistryServe value, a closer function, and errors.
+// If errors are encountered, a RegistryServe value is still returned representing
+// the best effort interpretation of the arguments.
+func ParseRegistryServe(args []string) (*RegistryServe, func(), tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	arguments := &RegistryServe{}
+
+	cmdFlags := defaultFlagSet("registry serve")
+	cmdFlags.StringVar(&arguments.Directory, "dir", "", "dir")
+	cmdFlags.StringVar(&arguments.Address, "address", DefaultRegistryServeAddress, "address")
+	cmdFlags.StringVar(&arguments.TLSCertFile, "tls-cert", "", "tls-cert")
+	cmdFlags.StringVar(&arguments.TLSKeyFile, "tls-key", "", "tls-key")
+	arguments.ViewOptions.AddFlags(cmdFlags, false)
+	if err := cmdFlags.Parse(args); err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to parse command-line flags",
+			err.Error(),
+		))
+	}
+	if len(cmdFlags.Args()) != 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Too many command line arguments",
+			"The registry serve command does not accept positional arguments. Use the -dir option to select the directory to serve.",
+		))
+	}
+	if arguments.Directory == "" {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Missing directory",
+			"The registry serve command requires the -dir option, giving the directory containing the modules and providers to serve.",
+		))
+	}
+	if (arguments.TLSCertFile == "") != (arguments.TLSKeyFile == "") {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Incomplete TLS configuration",
+			"The -tls-cert and -tls-key options must be used together.",
+		))
+	}
+
+	closer, moreDiags := arguments.ViewOptions.Parse()
+	diags = diags.Append(moreDiags)
+
+	return arguments, closer, diags
+}
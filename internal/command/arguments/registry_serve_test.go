@@ -0,0 +1,106 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestParseRegistryServe(t *testing.T) {
+	testCases := map[string]struct {
+		args        []string
+		want        *RegistryServe
+		wantErrText string
+	}{
+		"no directory": {
+			args:        nil,
+			want:        registryServeArgsWithDefaults(nil),
+			wantErrText: "Missing directory",
+		},
+		"directory": {
+			args: []string{"-dir=/srv/registry"},
+			want: registryServeArgsWithDefaults(func(v *RegistryServe) {
+				v.Directory = "/srv/registry"
+			}),
+		},
+		"address": {
+			args: []string{"-dir=/srv/registry", "-address=:9443"},
+			want: registryServeArgsWithDefaults(func(v *RegistryServe) {
+				v.Directory = "/srv/registry"
+				v.Address = ":9443"
+			}),
+		},
+		"tls": {
+			args: []string{"-dir=/srv/registry", "-tls-cert=cert.pem", "-tls-key=key.pem"},
+			want: registryServeArgsWithDefaults(func(v *RegistryServe) {
+				v.Directory = "/srv/registry"
+				v.TLSCertFile = "cert.pem"
+				v.TLSKeyFile = "key.pem"
+			}),
+		},
+		"tls cert without key": {
+			args: []string{"-dir=/srv/registry", "-tls-cert=cert.pem"},
+			want: registryServeArgsWithDefaults(func(v *RegistryServe) {
+				v.Directory = "/srv/registry"
+				v.TLSCertFile = "cert.pem"
+			}),
+			wantErrText: "The -tls-cert and -tls-key options must be used together.",
+		},
+		"positional argument": {
+			args: []string{"-dir=/srv/registry", "extra"},
+			want: registryServeArgsWithDefaults(func(v *RegistryServe) {
+				v.Directory = "/srv/registry"
+			}),
+			wantErrText: "Too many command line arguments",
+		},
+		"json": {
+			args: []string{"-dir=/srv/registry", "-json"},
+			want: registryServeArgsWithDefaults(func(v *RegistryServe) {
+				v.Directory = "/srv/registry"
+				v.ViewOptions.ViewType = ViewJSON
+			}),
+		},
+	}
+
+	cmpOpts := cmpopts.IgnoreUnexported(ViewOptions{})
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, closer, diags := ParseRegistryServe(tc.args)
+			defer closer()
+
+			if tc.wantErrText != "" && len(diags) == 0 {
+				t.Errorf("test wanted error but got nothing")
+			} else if tc.wantErrText == "" && len(diags) > 0 {
+				t.Errorf("test didn't expect errors but got some: %s", diags.ErrWithWarnings())
+			} else if tc.wantErrText != "" && len(diags) > 0 {
+				errStr := diags.ErrWithWarnings().Error()
+				if !strings.Contains(errStr, tc.wantErrText) {
+					t.Errorf("the returned diagnostics does not contain the expected error message.\ndiags:\n\t%s\nwanted:\n\t%s\n", errStr, tc.wantErrText)
+				}
+			}
+			if diff := cmp.Diff(tc.want, got, cmpOpts); diff != "" {
+				t.Errorf("unexpected result\n%s", diff)
+			}
+		})
+	}
+}
+
+func registryServeArgsWithDefaults(mutate func(v *RegistryServe)) *RegistryServe {
+	ret := &RegistryServe{
+		Address: DefaultRegistryServeAddress,
+		ViewOptions: ViewOptions{
+			ViewType:     ViewHuman,
+			InputEnabled: false,
+		},
+	}
+	if mutate != nil {
+		mutate(ret)
+	}
+	return ret
+}
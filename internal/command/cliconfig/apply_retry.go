@@ -0,0 +1,29 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package cliconfig
+
+import (
+	"github.com/opentofu/opentofu/internal/configs"
+)
+
+// ConfigApplyRetry is the structure of the "apply_retry" nested block within
+// the CLI configuration, which sets the default policy for retrying failed
+// changes to managed resources that don't have a "retry" block in their
+// "lifecycle" block.
+type ConfigApplyRetry struct {
+	MaxAttempts     int      `hcl:"max_attempts"`
+	Backoff         string   `hcl:"backoff"`
+	OnErrorMatching []string `hcl:"on_error_matching"`
+}
+
+// ApplyRetryPolicy returns the default retry policy described by the
+// "apply_retry" block in the configuration, or nil if there is no such
+// block.
+func (c *Config) ApplyRetryPolicy() (*configs.RetryPolicy, error) {
+	if c.ApplyRetry == nil {
+		return nil, nil
+	}
+	block := c.ApplyRetry
+	return configs.NewRetryPolicy(block.MaxAttempts, block.Backoff, block.OnErrorMatching)
+}
@@ -33,6 +33,10 @@ import (
 
 const pluginCacheDirEnvVar = "TF_PLUGIN_CACHE_DIR"
 const pluginCacheMayBreakLockFileEnvVar = "TF_PLUGIN_CACHE_MAY_BREAK_DEPENDENCY_LOCK_FILE"
+const pluginCacheReadOnlyEnvVar = "TF_PLUGIN_CACHE_READONLY"
+const providerInstallConcurrencyEnvVar = "TF_PROVIDER_INSTALL_CONCURRENCY"
+const moduleCacheDirEnvVar = "TF_MODULE_CACHE_DIR"
+const moduleInstallConcurrencyEnvVar = "TF_MODULE_INSTALL_CONCURRENCY"
 
 // Config is the structure of the configuration for the OpenTofu CLI.
 //
@@ -54,11 +58,57 @@ type Config struct {
 	// over the requirements of the dependency lock file.
 	PluginCacheMayBreakDependencyLockFile bool `hcl:"plugin_cache_may_break_dependency_lock_file"`
 
+	// PluginCacheReadOnly, if set, means that OpenTofu will only link
+	// providers from PluginCacheDir and will never add new packages to it,
+	// so that the cache can be shared by processes without write access.
+	PluginCacheReadOnly bool `hcl:"plugin_cache_readonly"`
+
+	// ProviderInstallConcurrency limits how many provider packages the
+	// provider installer will query and fetch at the same time. Zero means
+	// that there is no limit.
+	ProviderInstallConcurrency int `hcl:"provider_install_concurrency"`
+
+	// If set, enables caching of module packages selected by version in
+	// this directory, so that they are downloaded only once even when used
+	// by many working directories.
+	ModuleCacheDir string `hcl:"module_cache_dir"`
+
+	// ModuleInstallConcurrency limits how many module packages the module
+	// installer will fetch at the same time. Zero means that the installer
+	// chooses its default limit.
+	ModuleInstallConcurrency int `hcl:"module_install_concurrency"`
+
+	// ProviderMaxConcurrency limits how many operations OpenTofu will run at
+	// once on resource instances belonging to each of the given providers,
+	// keyed by provider source address.
+	ProviderMaxConcurrency map[string]int `hcl:"provider_max_concurrency"`
+
+	// ResourceTypeMaxConcurrency limits how many operations OpenTofu will
+	// run at once on resource instances of each of the given resource types.
+	ResourceTypeMaxConcurrency map[string]int `hcl:"resource_type_max_concurrency"`
+
+	// ApplyRetry represents the apply_retry block in the configuration, if
+	// any. When merging configurations, the first block found wins.
+	ApplyRetry *ConfigApplyRetry `hcl:"apply_retry"`
+
+	// SavedPlans represents the saved_plans block in the configuration, if
+	// any. When merging configurations, the first block found wins.
+	SavedPlans *ConfigSavedPlans `hcl:"saved_plans"`
+
 	Hosts map[string]*ConfigHost `hcl:"host"`
 
 	Credentials        map[string]map[string]any           `hcl:"credentials"`
 	CredentialsHelpers map[string]*ConfigCredentialsHelper `hcl:"credentials_helper"`
 
+	// Hooks are external programs or local HTTP endpoints to notify about
+	// plan and apply lifecycle events, keyed by the label of their block.
+	Hooks map[string]*ConfigHook `hcl:"hook"`
+
+	// FunctionPlugins are external programs that provide functions to
+	// modules that list them in "required_functions", keyed by the label of
+	// their block.
+	FunctionPlugins map[string]*ConfigFunctionPlugin `hcl:"function_plugin"`
+
 	// RegistryProtocols contains some settings for tailoring the request
 	// timeout and retry count for metadata requests made by our registry
 	// protocol clients.
@@ -98,6 +148,23 @@ type ConfigCredentialsHelper struct {
 	Args []string `hcl:"args"`
 }
 
+// ConfigHook is the structure of the "hook" nested block within the CLI
+// configuration. Its settings are validated when the hook is created, by
+// package externalhook.
+type ConfigHook struct {
+	Events  []string `hcl:"events"`
+	Command []string `hcl:"command"`
+	URL     string   `hcl:"url"`
+	Timeout string   `hcl:"timeout"`
+}
+
+// ConfigFunctionPlugin is the structure of the "function_plugin" nested block
+// within the CLI configuration. Its settings are validated when the plugin is
+// created, by package funcplugin.
+type ConfigFunctionPlugin struct {
+	Command []string `hcl:"command"`
+}
+
 // BuiltinConfig is the built-in defaults for the configuration. These
 // can be overridden by user configurations.
 var BuiltinConfig Config
@@ -242,6 +309,9 @@ func (cl *ConfigLoader) loadConfigFile(path string) (*Config, tfdiags.Diagnostic
 	if result.PluginCacheDir != "" {
 		result.PluginCacheDir = os.ExpandEnv(result.PluginCacheDir)
 	}
+	if result.ModuleCacheDir != "" {
+		result.ModuleCacheDir = os.ExpandEnv(result.ModuleCacheDir)
+	}
 
 	return result, diags
 }
@@ -304,6 +374,32 @@ func envConfig(env map[string]string) *Config {
 		config.PluginCacheMayBreakDependencyLockFile = true
 	}
 
+	if envReadOnly := env[pluginCacheReadOnlyEnvVar]; envReadOnly != "" && envReadOnly != "0" {
+		// As with TF_PLUGIN_CACHE_MAY_BREAK_DEPENDENCY_LOCK_FILE, there is
+		// no way to override this back to false once either this or the
+		// config file setting enables it.
+		config.PluginCacheReadOnly = true
+	}
+
+	if envConcurrency := env[providerInstallConcurrencyEnvVar]; envConcurrency != "" {
+		// Invalid values are ignored here, consistent with how we treat
+		// the other numeric environment variables.
+		if concurrency, err := strconv.Atoi(envConcurrency); err == nil && concurrency > 0 {
+			config.ProviderInstallConcurrency = concurrency
+		}
+	}
+
+	if envModuleCacheDir := env[moduleCacheDirEnvVar]; envModuleCacheDir != "" {
+		// No ExpandEnv here, for the same reason as for TF_PLUGIN_CACHE_DIR.
+		config.ModuleCacheDir = envModuleCacheDir
+	}
+
+	if envConcurrency := env[moduleInstallConcurrencyEnvVar]; envConcurrency != "" {
+		if concurrency, err := strconv.Atoi(envConcurrency); err == nil && concurrency > 0 {
+			config.ModuleInstallConcurrency = concurrency
+		}
+	}
+
 	// The environment config _always_ has opinions about the registry
 	// protocols, because we include the default values in here if the
 	// relevant environment variables aren't set.
@@ -372,6 +468,34 @@ func (c *Config) Validate() tfdiags.Diagnostics {
 		)
 	}
 
+	if c.ProviderInstallConcurrency < 0 {
+		diags = diags.Append(
+			fmt.Errorf("The provider_install_concurrency setting must not be negative"),
+		)
+	}
+
+	if c.ModuleInstallConcurrency < 0 {
+		diags = diags.Append(
+			fmt.Errorf("The module_install_concurrency setting must not be negative"),
+		)
+	}
+
+	for _, err := range c.validateConcurrencyLimits() {
+		diags = diags.Append(err)
+	}
+
+	if _, err := c.ApplyRetryPolicy(); err != nil {
+		diags = diags.Append(
+			fmt.Errorf("The apply_retry block is invalid: %w", err),
+		)
+	}
+
+	if _, err := c.SavedPlanRequirements(); err != nil {
+		diags = diags.Append(
+			fmt.Errorf("The saved_plans block is invalid: %w", err),
+		)
+	}
+
 	// Should have zero or one "provider_installation" blocks
 	if len(c.ProviderInstallation) > 1 {
 		diags = diags.Append(
@@ -409,6 +533,15 @@ func (c *Config) Validate() tfdiags.Diagnostics {
 		}
 	}
 
+	if c.ModuleCacheDir != "" {
+		_, err := os.Stat(c.ModuleCacheDir)
+		if err != nil {
+			diags = diags.Append(
+				fmt.Errorf("The specified module cache dir %s cannot be opened: %w", c.ModuleCacheDir, err),
+			)
+		}
+	}
+
 	return diags
 }
 
@@ -428,6 +561,48 @@ func (c *Config) Merge(c2 *Config) *Config {
 		result.PluginCacheMayBreakDependencyLockFile = true
 	}
 
+	if c.PluginCacheReadOnly || c2.PluginCacheReadOnly {
+		// This setting also saturates to "on".
+		result.PluginCacheReadOnly = true
+	}
+
+	result.ProviderInstallConcurrency = c.ProviderInstallConcurrency
+	if result.ProviderInstallConcurrency == 0 {
+		result.ProviderInstallConcurrency = c2.ProviderInstallConcurrency
+	}
+
+	result.ModuleCacheDir = c.ModuleCacheDir
+	if result.ModuleCacheDir == "" {
+		result.ModuleCacheDir = c2.ModuleCacheDir
+	}
+
+	result.ModuleInstallConcurrency = c.ModuleInstallConcurrency
+	if result.ModuleInstallConcurrency == 0 {
+		result.ModuleInstallConcurrency = c2.ModuleInstallConcurrency
+	}
+
+	if (len(c.ProviderMaxConcurrency) + len(c2.ProviderMaxConcurrency)) > 0 {
+		result.ProviderMaxConcurrency = make(map[string]int)
+		maps.Copy(result.ProviderMaxConcurrency, c2.ProviderMaxConcurrency)
+		maps.Copy(result.ProviderMaxConcurrency, c.ProviderMaxConcurrency)
+	}
+
+	if (len(c.ResourceTypeMaxConcurrency) + len(c2.ResourceTypeMaxConcurrency)) > 0 {
+		result.ResourceTypeMaxConcurrency = make(map[string]int)
+		maps.Copy(result.ResourceTypeMaxConcurrency, c2.ResourceTypeMaxConcurrency)
+		maps.Copy(result.ResourceTypeMaxConcurrency, c.ResourceTypeMaxConcurrency)
+	}
+
+	result.ApplyRetry = c.ApplyRetry
+	if result.ApplyRetry == nil {
+		result.ApplyRetry = c2.ApplyRetry
+	}
+
+	result.SavedPlans = c.SavedPlans
+	if result.SavedPlans == nil {
+		result.SavedPlans = c2.SavedPlans
+	}
+
 	if (len(c.Hosts) + len(c2.Hosts)) > 0 {
 		result.Hosts = make(map[string]*ConfigHost)
 		maps.Copy(result.Hosts, c.Hosts)
@@ -449,6 +624,18 @@ func (c *Config) Merge(c2 *Config) *Config {
 		maps.Copy(result.CredentialsHelpers, c2.CredentialsHelpers)
 	}
 
+	if (len(c.Hooks) + len(c2.Hooks)) > 0 {
+		result.Hooks = make(map[string]*ConfigHook)
+		maps.Copy(result.Hooks, c.Hooks)
+		maps.Copy(result.Hooks, c2.Hooks)
+	}
+
+	if (len(c.FunctionPlugins) + len(c2.FunctionPlugins)) > 0 {
+		result.FunctionPlugins = make(map[string]*ConfigFunctionPlugin)
+		maps.Copy(result.FunctionPlugins, c.FunctionPlugins)
+		maps.Copy(result.FunctionPlugins, c2.FunctionPlugins)
+	}
+
 	result.RegistryProtocols = mergeRegistryProtocolConfigs(c2.RegistryProtocols, c.RegistryProtocols)
 
 	if (len(c.ProviderInstallation) + len(c2.ProviderInstallation)) > 0 {
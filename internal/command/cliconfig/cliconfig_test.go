@@ -11,10 +11,13 @@ import (
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/google/go-cmp/cmp"
+	"github.com/opentofu/svchost"
 
+	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
@@ -110,6 +113,50 @@ func TestEnvConfig(t *testing.T) {
 				PluginCacheMayBreakDependencyLockFile: true,
 			},
 		},
+		"TF_PLUGIN_CACHE_READONLY=1": {
+			map[string]string{
+				"TF_PLUGIN_CACHE_READONLY": "1",
+			},
+			&Config{
+				PluginCacheReadOnly: true,
+			},
+		},
+		"TF_PLUGIN_CACHE_READONLY=0": {
+			map[string]string{
+				"TF_PLUGIN_CACHE_READONLY": "0",
+			},
+			&Config{},
+		},
+		"TF_PROVIDER_INSTALL_CONCURRENCY=4": {
+			map[string]string{
+				"TF_PROVIDER_INSTALL_CONCURRENCY": "4",
+			},
+			&Config{
+				ProviderInstallConcurrency: 4,
+			},
+		},
+		"TF_PROVIDER_INSTALL_CONCURRENCY=invalid": {
+			map[string]string{
+				"TF_PROVIDER_INSTALL_CONCURRENCY": "lots",
+			},
+			&Config{},
+		},
+		"TF_MODULE_CACHE_DIR": {
+			map[string]string{
+				"TF_MODULE_CACHE_DIR": "/tofu-module-cache",
+			},
+			&Config{
+				ModuleCacheDir: "/tofu-module-cache",
+			},
+		},
+		"TF_MODULE_INSTALL_CONCURRENCY=2": {
+			map[string]string{
+				"TF_MODULE_INSTALL_CONCURRENCY": "2",
+			},
+			&Config{
+				ModuleInstallConcurrency: 2,
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -225,6 +272,167 @@ func TestLoadConfig_hosts(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_applyRetry(t *testing.T) {
+	got, diags := loadConfigFile(filepath.Join(fixtureDir, "apply-retry"))
+	if len(diags) != 0 {
+		t.Fatalf("%s", diags.Err())
+	}
+
+	want := &Config{
+		ApplyRetry: &ConfigApplyRetry{
+			MaxAttempts:     3,
+			Backoff:         "2s",
+			OnErrorMatching: []string{"Throttling", "(?i)rate exceeded"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("wrong result\ngot:  %swant: %s", spew.Sdump(got), spew.Sdump(want))
+	}
+
+	policy, err := got.ApplyRetryPolicy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := policy.MaxAttempts, 3; got != want {
+		t.Errorf("wrong max attempts %d; want %d", got, want)
+	}
+	if got, want := policy.Backoff, 2*time.Second; got != want {
+		t.Errorf("wrong backoff %s; want %s", got, want)
+	}
+	if !policy.MatchesError("Error", "Rate Exceeded") {
+		t.Errorf("policy does not match a rate limiting error")
+	}
+
+	diags = (&Config{
+		ApplyRetry: &ConfigApplyRetry{MaxAttempts: 0},
+	}).Validate()
+	if !diags.HasErrors() {
+		t.Errorf("no error for invalid apply_retry block")
+	}
+}
+
+func TestLoadConfig_savedPlans(t *testing.T) {
+	got, diags := loadConfigFile(filepath.Join(fixtureDir, "saved-plans"))
+	if len(diags) != 0 {
+		t.Fatalf("%s", diags.Err())
+	}
+
+	want := &Config{
+		SavedPlans: &ConfigSavedPlans{
+			MaxAge:          "8h",
+			RequireApproval: true,
+			ApprovalKeys:    []string{"testdata/saved-plans-approval.key"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("wrong result\ngot:  %swant: %s", spew.Sdump(got), spew.Sdump(want))
+	}
+
+	reqs, err := got.SavedPlanRequirements()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := reqs.MaxAge, 8*time.Hour; got != want {
+		t.Errorf("wrong max age %s; want %s", got, want)
+	}
+	if !reqs.RequireApproval {
+		t.Errorf("approval is not required")
+	}
+	if got, want := len(reqs.ApprovalKeys), 1; got != want {
+		t.Errorf("wrong number of approval keys %d; want %d", got, want)
+	}
+
+	for name, block := range map[string]*ConfigSavedPlans{
+		"invalid max_age":       {MaxAge: "soon"},
+		"negative max_age":      {MaxAge: "-1h"},
+		"approval without keys": {RequireApproval: true},
+		"missing approval key":  {ApprovalKeys: []string{"testdata/nonexistent.key"}},
+	} {
+		diags := (&Config{SavedPlans: block}).Validate()
+		if !diags.HasErrors() {
+			t.Errorf("%s: no error for invalid saved_plans block", name)
+		}
+	}
+}
+
+func TestLoadConfig_maxConcurrency(t *testing.T) {
+	got, diags := loadConfigFile(filepath.Join(fixtureDir, "max-concurrency"))
+	if len(diags) != 0 {
+		t.Fatalf("%s", diags.Err())
+	}
+
+	want := &Config{
+		ProviderMaxConcurrency: map[string]int{
+			"hashicorp/aws":         50,
+			"example.com/acme/saas": 2,
+		},
+		ResourceTypeMaxConcurrency: map[string]int{
+			"aws_iam_role": 5,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("wrong result\ngot:  %swant: %s", spew.Sdump(got), spew.Sdump(want))
+	}
+
+	wantLimits := map[addrs.Provider]int{
+		addrs.NewDefaultProvider("aws"):                                    50,
+		addrs.NewProvider(svchost.Hostname("example.com"), "acme", "saas"): 2,
+	}
+	if diff := cmp.Diff(wantLimits, got.ProviderConcurrencyLimits()); diff != "" {
+		t.Errorf("wrong provider limits\n%s", diff)
+	}
+
+	diags = (&Config{
+		ProviderMaxConcurrency:     map[string]int{"not a provider!": 1, "hashicorp/aws": 0},
+		ResourceTypeMaxConcurrency: map[string]int{"aws_iam_role": -1},
+	}).Validate()
+	if got, want := len(diags), 3; got != want {
+		t.Errorf("wrong number of diagnostics %d; want %d\n%s", got, want, diags.Err())
+	}
+}
+
+func TestLoadConfig_hooks(t *testing.T) {
+	got, diags := loadConfigFile(filepath.Join(fixtureDir, "hooks"))
+	if len(diags) != 0 {
+		t.Fatalf("%s", diags.Err())
+	}
+
+	want := &Config{
+		Hooks: map[string]*ConfigHook{
+			"guard": {
+				Events:  []string{"pre_apply"},
+				Command: []string{"/usr/local/bin/check-change", "--strict"},
+				Timeout: "10s",
+			},
+			"notify": {
+				Events: []string{"post_apply", "apply_errored"},
+				URL:    "http://localhost:8080/tofu",
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong result\ngot:  %swant: %s", spew.Sdump(got), spew.Sdump(want))
+	}
+}
+
+func TestLoadConfig_functionPlugins(t *testing.T) {
+	got, diags := loadConfigFile(filepath.Join(fixtureDir, "function-plugins"))
+	if len(diags) != 0 {
+		t.Fatalf("%s", diags.Err())
+	}
+
+	want := &Config{
+		FunctionPlugins: map[string]*ConfigFunctionPlugin{
+			"naming": {
+				Command: []string{"/usr/local/bin/naming-functions", "--region", "eu"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong result\ngot:  %swant: %s", spew.Sdump(got), spew.Sdump(want))
+	}
+}
+
 func TestLoadConfig_credentials(t *testing.T) {
 	got, err := loadConfigFile(filepath.Join(fixtureDir, "credentials"))
 	if err != nil {
@@ -348,6 +556,24 @@ func TestConfigValidate(t *testing.T) {
 			},
 			1, // The specified plugin cache dir %s cannot be opened
 		},
+		"provider_install_concurrency negative": {
+			&Config{
+				ProviderInstallConcurrency: -1,
+			},
+			1, // The provider_install_concurrency setting must not be negative
+		},
+		"module_install_concurrency negative": {
+			&Config{
+				ModuleInstallConcurrency: -1,
+			},
+			1, // The module_install_concurrency setting must not be negative
+		},
+		"module_cache_dir does not exist": {
+			&Config{
+				ModuleCacheDir: "fake",
+			},
+			1, // The specified module cache dir %s cannot be opened
+		},
 	}
 
 	for name, test := range tests {
@@ -430,6 +656,8 @@ func TestConfig_Merge(t *testing.T) {
 			},
 		},
 		PluginCacheMayBreakDependencyLockFile: true,
+		ProviderInstallConcurrency:            4,
+		ModuleInstallConcurrency:              2,
 		OCIDefaultCredentials: []*OCIDefaultCredentials{
 			{
 				DefaultDockerCredentialHelper: "osxkeychain",
@@ -487,6 +715,8 @@ func TestConfig_Merge(t *testing.T) {
 			},
 		},
 		PluginCacheMayBreakDependencyLockFile: true,
+		ProviderInstallConcurrency:            4,
+		ModuleInstallConcurrency:              2,
 		OCIDefaultCredentials: []*OCIDefaultCredentials{
 			{
 				DiscoverAmbientCredentials: false,
@@ -0,0 +1,49 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package cliconfig
+
+import (
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+// ProviderConcurrencyLimits returns the limits from the
+// provider_max_concurrency setting, keyed by provider source address.
+//
+// Entries with invalid provider source addresses are ignored, because
+// [Config.Validate] reports them as errors.
+func (c *Config) ProviderConcurrencyLimits() map[addrs.Provider]int {
+	if len(c.ProviderMaxConcurrency) == 0 {
+		return nil
+	}
+	ret := make(map[addrs.Provider]int, len(c.ProviderMaxConcurrency))
+	for source, limit := range c.ProviderMaxConcurrency {
+		provider, diags := addrs.ParseProviderSourceString(source)
+		if diags.HasErrors() {
+			continue
+		}
+		ret[provider] = limit
+	}
+	return ret
+}
+
+func (c *Config) validateConcurrencyLimits() []error {
+	var errs []error
+	for source, limit := range c.ProviderMaxConcurrency {
+		if _, diags := addrs.ParseProviderSourceString(source); diags.HasErrors() {
+			errs = append(errs, fmt.Errorf("The provider_max_concurrency setting has an invalid provider source address %q: %w", source, diags.Err()))
+			continue
+		}
+		if limit < 1 {
+			errs = append(errs, fmt.Errorf("The provider_max_concurrency limit for %q must be greater than zero", source))
+		}
+	}
+	for resourceType, limit := range c.ResourceTypeMaxConcurrency {
+		if limit < 1 {
+			errs = append(errs, fmt.Errorf("The resource_type_max_concurrency limit for %q must be greater than zero", resourceType))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,57 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package cliconfig
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/plans/planfile"
+)
+
+// ConfigSavedPlans is the structure of the "saved_plans" nested block within
+// the CLI configuration, which sets requirements that a saved plan must meet
+// before "tofu apply" will apply it.
+type ConfigSavedPlans struct {
+	MaxAge          string   `hcl:"max_age"`
+	RequireApproval bool     `hcl:"require_approval"`
+	ApprovalKeys    []string `hcl:"approval_keys"`
+}
+
+// SavedPlanRequirements returns the requirements described by the
+// "saved_plans" block in the configuration, or nil if there is no such
+// block.
+func (c *Config) SavedPlanRequirements() (*planfile.Requirements, error) {
+	if c.SavedPlans == nil {
+		return nil, nil
+	}
+	block := c.SavedPlans
+	ret := &planfile.Requirements{
+		RequireApproval: block.RequireApproval,
+	}
+
+	if block.MaxAge != "" {
+		maxAge, err := time.ParseDuration(block.MaxAge)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max_age %q: %w", block.MaxAge, err)
+		}
+		if maxAge <= 0 {
+			return nil, fmt.Errorf("max_age must be a positive duration")
+		}
+		ret.MaxAge = maxAge
+	}
+
+	for _, filename := range block.ApprovalKeys {
+		key, err := planfile.LoadApprovalKey(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load approval key: %w", err)
+		}
+		ret.ApprovalKeys = append(ret.ApprovalKeys, key)
+	}
+	if ret.RequireApproval && len(ret.ApprovalKeys) == 0 {
+		return nil, fmt.Errorf("require_approval needs at least one key in approval_keys to verify approvals with")
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,305 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/jsondrift"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/configs/configload"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// DriftCommand is a Command implementation that refreshes the state of one
+// or more workspaces and reports which resources have changed outside of
+// OpenTofu, without changing the state.
+type DriftCommand struct {
+	Meta
+}
+
+func (c *DriftCommand) Run(rawArgs []string) int {
+	ctx := c.CommandContext()
+
+	// Parse and apply global view arguments
+	common, rawArgs := arguments.ParseView(rawArgs)
+	c.View.Configure(common)
+
+	// Parse and validate flags
+	args, closer, diags := arguments.ParseDrift(rawArgs)
+	defer closer()
+
+	// Instantiate the view, even if there are flag errors, so that we render
+	// diagnostics according to the desired view
+	view := views.NewDrift(args.ViewOptions, c.View)
+
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		view.HelpPrompt()
+		return 1
+	}
+
+	// Check for user-supplied plugin path
+	var err error
+	if c.pluginPath, err = c.loadPluginPath(); err != nil {
+		diags = diags.Append(err)
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	// FIXME: the -input and -parallelism flag values are needed to initialize
+	// the backend and the operation, but there is no clear path to pass
+	// these values down, so we continue to mutate the Meta object state for
+	// now.
+	c.Meta.input = args.ViewOptions.InputEnabled
+	c.Meta.parallelism = args.Parallelism
+	c.Meta.stateArgs = *args.State
+
+	// Inject variables from args into meta for static evaluation
+	c.Meta.variableArgs = args.Vars.All()
+
+	// Load the encryption configuration
+	enc, encDiags := c.Encryption(ctx)
+	diags = diags.Append(encDiags)
+	if encDiags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	backendConfig, backendDiags := c.loadBackendConfig(ctx, ".")
+	diags = diags.Append(backendDiags)
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	b, backendDiags := c.Backend(ctx, &BackendOpts{
+		Config: backendConfig,
+		View:   view.Backend(),
+	}, enc.State())
+	diags = diags.Append(backendDiags)
+	if backendDiags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	// Checking for drift requires refreshing locally.
+	local, ok := b.(backend.Local)
+	if !ok {
+		view.Diagnostics(diags) // in case of any warnings in here
+		view.UnsupportedLocalOp()
+		return 1
+	}
+
+	// This command doesn't change the state.
+	c.ignoreRemoteVersionConflict(b)
+
+	var workspaces []string
+	if args.AllWorkspaces {
+		workspaces, err = b.Workspaces(ctx)
+		if err != nil {
+			diags = diags.Append(fmt.Errorf("Failed to list workspaces: %w", err))
+			view.Diagnostics(diags)
+			return 1
+		}
+		sort.Strings(workspaces)
+	} else {
+		workspace, err := c.Workspace(ctx)
+		if err != nil {
+			diags = diags.Append(fmt.Errorf("Error selecting workspace: %w", err))
+			view.Diagnostics(diags)
+			return 1
+		}
+		workspaces = []string{workspace}
+	}
+
+	ignore := jsondrift.NewIgnore(args.Ignore)
+	report := &jsondrift.Report{
+		FormatVersion: jsondrift.FormatVersion,
+	}
+	failed := false
+	for _, workspace := range workspaces {
+		ws, wsDiags := c.checkWorkspace(ctx, b, local, view, enc, workspace, ignore)
+		diags = diags.Append(wsDiags)
+		if wsDiags.HasErrors() {
+			failed = true
+			ws = &jsondrift.Workspace{
+				Name:  workspace,
+				Error: wsDiags.Err().Error(),
+			}
+		}
+		report.Workspaces = append(report.Workspaces, ws)
+	}
+
+	view.Diagnostics(diags)
+
+	if args.ReportPath != "" {
+		src, err := json.MarshalIndent(report, "", "  ")
+		if err == nil {
+			err = os.WriteFile(args.ReportPath, append(src, '\n'), 0644)
+		}
+		if err != nil {
+			view.Diagnostics(tfdiags.Diagnostics{tfdiags.Sourceless(
+				tfdiags.Error,
+				"Failed to write drift report",
+				fmt.Sprintf("The drift report could not be written to %s: %s.", args.ReportPath, err),
+			)})
+			return 1
+		}
+	}
+
+	view.Report(report)
+
+	switch {
+	case failed:
+		return 1
+	case report.Drifted():
+		return 2
+	default:
+		return 0
+	}
+}
+
+// checkWorkspace refreshes the given workspace without saving the result,
+// and describes any drift that it finds.
+func (c *DriftCommand) checkWorkspace(ctx context.Context, b backend.Backend, local backend.Local, view views.Drift, enc encryption.Encryption, workspace string, ignore jsondrift.Ignore) (ws *jsondrift.Workspace, diags tfdiags.Diagnostics) {
+	opReq := c.Operation(ctx, b, view.Backend(), enc)
+	opReq.Type = backend.OperationTypePlan
+	opReq.Workspace = workspace
+	opReq.ConfigDir = "."
+	opReq.PlanMode = plans.RefreshOnlyMode
+	opReq.PlanRefresh = true
+
+	var err error
+	opReq.ConfigLoader, err = configload.Initialise(c.configLoader())
+	if err != nil {
+		diags = diags.Append(fmt.Errorf("Failed to initialize config loader: %w", err))
+		return nil, diags
+	}
+
+	var moreDiags tfdiags.Diagnostics
+	opReq.Variables, moreDiags = c.collectVariableValues()
+	diags = diags.Append(moreDiags)
+	opReq.RootCall, moreDiags = c.rootModuleCall(ctx, opReq.ConfigDir)
+	diags = diags.Append(moreDiags)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	stopCtx, cancel := c.InterruptibleContext(ctx)
+	defer cancel()
+	lr, _, moreDiags := local.LocalRun(ctx, stopCtx, opReq)
+	diags = diags.Append(moreDiags)
+	if moreDiags.HasErrors() {
+		return nil, diags
+	}
+	// Successfully creating the context can result in a lock, so ensure we
+	// release it. The diagnostics are a named result so that the caller
+	// sees any errors from unlocking.
+	defer func() {
+		diags = diags.Append(opReq.StateLocker.Unlock())
+	}()
+
+	plan, moreDiags := lr.Core.Plan(ctx, lr.Config, lr.InputState, lr.PlanOpts)
+	diags = diags.Append(moreDiags)
+	if moreDiags.HasErrors() {
+		return nil, diags
+	}
+	schemas, moreDiags := lr.Core.Schemas(ctx, lr.Config, lr.InputState)
+	diags = diags.Append(moreDiags)
+	if moreDiags.HasErrors() {
+		return nil, diags
+	}
+
+	ws, err = jsondrift.NewWorkspace(workspace, plan, schemas, ignore)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to describe drift",
+			fmt.Sprintf("The drift in workspace %q could not be described: %s.", workspace, err),
+		))
+		return nil, diags
+	}
+	return ws, diags
+}
+
+func (c *DriftCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] drift [options]
+
+  Checks whether the remote objects tracked in the state have been changed
+  outside of OpenTofu, by refreshing them in the same way as
+  "tofu plan -refresh-only" but without proposing any changes. The state is
+  not modified.
+
+  The exit code describes the outcome, which makes this command suitable
+  for scheduled drift detection:
+    0 - No drift was detected
+    1 - OpenTofu could not check for drift because of an error
+    2 - Drift was detected
+
+Options:
+
+  -all-workspaces         Check every workspace of the backend instead of
+                          only the currently-selected workspace.
+
+  -ignore=pattern         Don't report drift of resource instances or
+                          attributes that match the given glob pattern,
+                          such as 'aws_instance.*' or
+                          'aws_instance.web.tags["LastScanned"]'. Use this
+                          option more than once to ignore several patterns.
+
+  -report=path            Write a JSON report describing the drift in each
+                          workspace, down to the attributes that changed, to
+                          the given path.
+
+  -input=true             Ask for input for variables if not directly set.
+
+  -lock=false             Don't hold a state lock during the operation. This
+                          is dangerous if others might concurrently run
+                          commands against the same workspace.
+
+  -lock-timeout=0s        Duration to retry a state lock.
+
+  -no-color               If specified, output won't contain any color.
+
+  -parallelism=n          Limit the number of concurrent operations.
+                          Defaults to 10.
+
+  -var 'foo=bar'          Set a variable in the OpenTofu configuration. This
+                          flag can be set multiple times.
+
+  -var-file=foo           Set variables in the OpenTofu configuration from
+                          a file. If "terraform.tfvars" or any ".auto.tfvars"
+                          files are present, they will be automatically
+                          loaded.
+
+  -json                   Produce output in a machine-readable JSON format,
+                          suitable for use in text editor integrations and
+                          other automated systems. Always disables color.
+
+  -json-into=out.json     Produce the same output as -json, but sent directly
+                          to the given file. This allows automation to
+                          preserve the original human-readable output streams,
+                          while capturing more detailed logs for machine
+                          analysis.
+
+  -state is a legacy option supported for the local backend only. For more
+  information, see the local backend's documentation.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *DriftCommand) Synopsis() string {
+	return "Check for changes made outside of OpenTofu"
+}
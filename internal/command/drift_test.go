@@ -0,0 +1,202 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/command/jsondrift"
+	"github.com/opentofu/opentofu/internal/command/workdir"
+	"github.com/opentofu/opentofu/internal/providers"
+)
+
+func TestDrift_noDrift(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("refresh"), td)
+	t.Chdir(td)
+
+	statePath := testStateFile(t, testState())
+
+	p := testProvider()
+	p.GetProviderSchemaResponse = refreshFixtureSchema()
+	p.ReadResourceFn = nil
+	p.ReadResourceResponse = &providers.ReadResourceResponse{
+		NewState: cty.ObjectVal(map[string]cty.Value{
+			"id":  cty.StringVal("bar"),
+			"ami": cty.NullVal(cty.String),
+		}),
+	}
+
+	view, done := testView(t)
+	c := &DriftCommand{
+		Meta: Meta{
+			WorkingDir:       workdir.NewDir("."),
+			testingOverrides: metaOverridesForProvider(p),
+			View:             view,
+		},
+	}
+
+	code := c.Run([]string{"-no-color", "-state", statePath})
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("unexpected exit code %d\n\n%s", code, output.Stderr())
+	}
+	if !p.ReadResourceCalled {
+		t.Fatal("ReadResource should have been called")
+	}
+	if got, want := output.Stdout(), `Workspace "default": No drift detected.`; !strings.Contains(got, want) {
+		t.Fatalf("output does not contain %q:\n%s", want, got)
+	}
+}
+
+func TestDrift_drift(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("refresh"), td)
+	t.Chdir(td)
+
+	statePath := testStateFile(t, testState())
+	stateBefore, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := testProvider()
+	p.GetProviderSchemaResponse = refreshFixtureSchema()
+	p.ReadResourceFn = nil
+	p.ReadResourceResponse = &providers.ReadResourceResponse{
+		NewState: cty.ObjectVal(map[string]cty.Value{
+			"id":  cty.StringVal("bar"),
+			"ami": cty.StringVal("changed"),
+		}),
+	}
+
+	view, done := testView(t)
+	c := &DriftCommand{
+		Meta: Meta{
+			WorkingDir:       workdir.NewDir("."),
+			testingOverrides: metaOverridesForProvider(p),
+			View:             view,
+		},
+	}
+
+	code := c.Run([]string{"-no-color", "-state", statePath, "-report", "drift.json"})
+	output := done(t)
+	if code != 2 {
+		t.Fatalf("unexpected exit code %d\n\n%s", code, output.Stderr())
+	}
+	for _, want := range []string{
+		`Workspace "default": 1 resource changed outside of OpenTofu.`,
+		`test_instance.foo has been changed`,
+		`ami: null -> "changed"`,
+	} {
+		if got := output.Stdout(); !strings.Contains(got, want) {
+			t.Errorf("output does not contain %q:\n%s", want, got)
+		}
+	}
+
+	// Checking for drift must not update the state.
+	stateAfter, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(stateBefore) != string(stateAfter) {
+		t.Fatalf("state was modified:\n%s", stateAfter)
+	}
+
+	src, err := os.ReadFile("drift.json")
+	if err != nil {
+		t.Fatalf("failed to read report: %s", err)
+	}
+	var report jsondrift.Report
+	if err := json.Unmarshal(src, &report); err != nil {
+		t.Fatalf("invalid report: %s", err)
+	}
+	if len(report.Workspaces) != 1 {
+		t.Fatalf("expected 1 workspace in report, got %d", len(report.Workspaces))
+	}
+	ws := report.Workspaces[0]
+	if !ws.Drifted || len(ws.Resources) != 1 {
+		t.Fatalf("expected one drifted resource, got %#v", ws)
+	}
+	if got, want := ws.Resources[0].Resource.Addr, "test_instance.foo"; got != want {
+		t.Errorf("wrong resource %q; want %q", got, want)
+	}
+	attrs := ws.Resources[0].Attributes
+	if len(attrs) != 1 || attrs[0].Path != "ami" || string(attrs[0].After) != `"changed"` {
+		t.Errorf("wrong attributes in report: %s", src)
+	}
+}
+
+func TestDrift_ignore(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("refresh"), td)
+	t.Chdir(td)
+
+	statePath := testStateFile(t, testState())
+
+	p := testProvider()
+	p.GetProviderSchemaResponse = refreshFixtureSchema()
+	p.ReadResourceFn = nil
+	p.ReadResourceResponse = &providers.ReadResourceResponse{
+		NewState: cty.ObjectVal(map[string]cty.Value{
+			"id":  cty.StringVal("bar"),
+			"ami": cty.StringVal("changed"),
+		}),
+	}
+
+	view, done := testView(t)
+	c := &DriftCommand{
+		Meta: Meta{
+			WorkingDir:       workdir.NewDir("."),
+			testingOverrides: metaOverridesForProvider(p),
+			View:             view,
+		},
+	}
+
+	code := c.Run([]string{"-no-color", "-state", statePath, "-ignore", "test_instance.*.ami"})
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("unexpected exit code %d\n\n%s%s", code, output.Stdout(), output.Stderr())
+	}
+}
+
+func TestDrift_error(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("refresh"), td)
+	t.Chdir(td)
+
+	statePath := testStateFile(t, testState())
+
+	p := testProvider()
+	p.GetProviderSchemaResponse = refreshFixtureSchema()
+	p.ReadResourceFn = func(req providers.ReadResourceRequest) providers.ReadResourceResponse {
+		var resp providers.ReadResourceResponse
+		resp.Diagnostics = resp.Diagnostics.Append(errors.New("read failed"))
+		return resp
+	}
+
+	view, done := testView(t)
+	c := &DriftCommand{
+		Meta: Meta{
+			WorkingDir:       workdir.NewDir("."),
+			testingOverrides: metaOverridesForProvider(p),
+			View:             view,
+		},
+	}
+
+	code := c.Run([]string{"-no-color", "-state", statePath})
+	output := done(t)
+	if code != 1 {
+		t.Fatalf("unexpected exit code %d\n\n%s", code, output.Stdout())
+	}
+	if got, want := output.Stderr(), "read failed"; !strings.Contains(got, want) {
+		t.Fatalf("error output does not contain %q:\n%s", want, got)
+	}
+}
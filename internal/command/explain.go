@@ -0,0 +1,202 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/jsonexplain"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/lang/globalref"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ExplainCommand is a Command implementation that explains why a saved plan
+// proposes a change to a particular resource instance.
+type ExplainCommand struct {
+	Meta
+}
+
+func (c *ExplainCommand) Run(rawArgs []string) int {
+	ctx := c.CommandContext()
+
+	// Parse and apply global view arguments
+	common, rawArgs := arguments.ParseView(rawArgs)
+	c.View.Configure(common)
+
+	// Parse and validate flags
+	args, closer, diags := arguments.ParseExplain(rawArgs)
+	defer closer()
+
+	// Instantiate the view, even if there are flag errors, so that we render
+	// diagnostics according to the desired view
+	view := views.NewExplain(args.ViewOptions, c.View)
+
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		view.HelpPrompt()
+		return 1
+	}
+
+	// Check for user-supplied plugin path, which we need in order to load
+	// the provider schemas.
+	var err error
+	if c.pluginPath, err = c.loadPluginPath(); err != nil {
+		diags = diags.Append(fmt.Errorf("error loading plugin path: %w", err))
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	// Inject variables from args into meta for static evaluation
+	c.Meta.variableArgs = args.Vars.All()
+
+	// Load the encryption configuration, which is needed to read encrypted
+	// plan files.
+	enc, encDiags := c.Encryption(ctx)
+	diags = diags.Append(encDiags)
+	if encDiags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	explanation, moreDiags := c.explain(ctx, args, enc)
+	diags = diags.Append(moreDiags)
+	if moreDiags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+	return view.Display(explanation)
+}
+
+func (c *ExplainCommand) explain(ctx context.Context, args *arguments.Explain, enc encryption.Encryption) (*jsonexplain.Explanation, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	fail := func(err error) (*jsonexplain.Explanation, tfdiags.Diagnostics) {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			fmt.Sprintf("Failed to load %q as a plan file", args.PlanPath),
+			fmt.Sprintf("Error: %s", err),
+		))
+		return nil, diags
+	}
+
+	pf, err := c.PlanFile(args.PlanPath, enc.Plan())
+	if err != nil {
+		return fail(err)
+	}
+	if pf == nil {
+		return fail(fmt.Errorf("the specified path is a directory, not a plan file"))
+	}
+	lp, ok := pf.Local()
+	if !ok {
+		return fail(fmt.Errorf("plans created by a remote operation cannot be explained"))
+	}
+
+	rootCall, moreDiags := c.rootModuleCall(ctx, ".")
+	diags = diags.Append(moreDiags)
+	if moreDiags.HasErrors() {
+		return nil, diags
+	}
+	plan, stateFile, config, err := getDataFromPlanfileReader(ctx, lp, rootCall)
+	if err != nil {
+		return fail(err)
+	}
+
+	change := explainResourceInstanceChange(plan.Changes, args)
+	if change == nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"No planned change for resource instance",
+			fmt.Sprintf("The plan in %q doesn't propose any change to %s, so there is nothing to explain.", args.PlanPath, args.Addr),
+		))
+		return nil, diags
+	}
+
+	schemas, moreDiags := c.MaybeGetSchemas(ctx, stateFile.State, config)
+	diags = diags.Append(moreDiags)
+	if moreDiags.HasErrors() {
+		return nil, diags
+	}
+	if schemas == nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to load provider schemas",
+			"The provider schemas are required to explain a planned change. Run \"tofu init\" in the directory where the plan was created and try again.",
+		))
+		return nil, diags
+	}
+
+	azr := globalref.NewAnalyzer(config, schemas.Providers)
+	explanation, err := jsonexplain.NewExplanation(change, plan.Changes, schemas, azr)
+	if err != nil {
+		diags = diags.Append(err)
+		return nil, diags
+	}
+	return explanation, diags
+}
+
+// explainResourceInstanceChange returns the planned change to the current
+// object of the requested resource instance if there is one, or otherwise to
+// one of its deposed objects, ignoring any that have no effect.
+func explainResourceInstanceChange(changes *plans.Changes, args *arguments.Explain) *plans.ResourceInstanceChangeSrc {
+	if rc := changes.ResourceInstance(args.Addr); rc != nil && rc.Action != plans.NoOp {
+		return rc
+	}
+	for _, rc := range changes.Resources {
+		if rc.Addr.Equal(args.Addr) && rc.Action != plans.NoOp {
+			return rc
+		}
+	}
+	return nil
+}
+
+func (c *ExplainCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] explain -plan=FILE [options] ADDRESS
+
+  Explains why a saved plan proposes a change to the resource instance at
+  the given address.
+
+  For each attribute whose value the change would alter, this shows the
+  chain of references in the configuration that contribute to its value,
+  back to root module input variables, data sources and other resources,
+  along with their locations in the configuration and whether the plan also
+  proposes changes to those other resources.
+
+Options:
+
+  -plan=FILE              The saved plan file containing the change to
+                          explain. Required.
+
+  -json                   Produce the explanation in a machine-readable JSON
+                          format, suitable for use in automated systems.
+                          Always disables color.
+
+  -json-into=out.json     Produce the same output as -json, but sent directly
+                          to the given file, while the human-readable output
+                          is still written to the terminal.
+
+  -no-color               If specified, output won't contain any color.
+
+  -var 'foo=bar'          Set a variable in the OpenTofu configuration. This
+                          flag can be set multiple times. Variables are only
+                          used to evaluate the encryption configuration
+                          needed to read encrypted plan files.
+
+  -var-file=foo           Set variables in the OpenTofu configuration from
+                          a file. If "terraform.tfvars" or any ".auto.tfvars"
+                          files are present, they will be automatically
+                          loaded.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ExplainCommand) Synopsis() string {
+	return "Explain why a saved plan changes a resource"
+}
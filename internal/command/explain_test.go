@@ -0,0 +1,153 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/command/jsonexplain"
+	"github.com/opentofu/opentofu/internal/command/workdir"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+func TestExplain(t *testing.T) {
+	p := testExplainPlan(t)
+
+	view, done := testView(t)
+	c := &ExplainCommand{
+		Meta: Meta{
+			WorkingDir:       workdir.NewDir("."),
+			testingOverrides: metaOverridesForProvider(p),
+			View:             view,
+		},
+	}
+
+	code := c.Run([]string{"-no-color", "-plan=tofu.plan", "test_instance.web"})
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("unexpected exit code %d\n\n%s%s", code, output.Stdout(), output.Stderr())
+	}
+	want := `test_instance.web will be created.
+
+ami:
+  local.ami (main.tf:19,9-18)
+    var.ami_version (main.tf:11,16-31), a root module input variable
+    data.test_data_source.base.id (main.tf:11,35-61), a data source
+
+network_interface:
+  test_instance.upstream.id (main.tf:23,20-42), a resource that will be created
+`
+	if got := output.Stdout(); got != want {
+		t.Fatalf("wrong output\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestExplain_json(t *testing.T) {
+	p := testExplainPlan(t)
+
+	view, done := testView(t)
+	c := &ExplainCommand{
+		Meta: Meta{
+			WorkingDir:       workdir.NewDir("."),
+			testingOverrides: metaOverridesForProvider(p),
+			View:             view,
+		},
+	}
+
+	code := c.Run([]string{"-json", "-plan=tofu.plan", "test_instance.web"})
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("unexpected exit code %d\n\n%s%s", code, output.Stdout(), output.Stderr())
+	}
+
+	var got jsonexplain.Explanation
+	if err := json.Unmarshal([]byte(output.Stdout()), &got); err != nil {
+		t.Fatalf("invalid JSON output: %s\n%s", err, output.Stdout())
+	}
+	if got.Address != "test_instance.web" || got.Action != "create" {
+		t.Errorf("wrong change: %s", output.Stdout())
+	}
+	if len(got.Attributes) != 2 || got.Attributes[0].Name != "ami" {
+		t.Fatalf("wrong attributes: %s", output.Stdout())
+	}
+	refs := got.Attributes[0].References
+	if len(refs) != 1 || refs[0].Kind != jsonexplain.LocalValue || len(refs[0].References) != 2 {
+		t.Fatalf("wrong references for ami: %s", output.Stdout())
+	}
+	if ref := refs[0].References[1]; ref.Kind != jsonexplain.DataResource || ref.Range == nil || ref.Range.Start.Line != 11 {
+		t.Errorf("wrong data source reference: %s", output.Stdout())
+	}
+}
+
+func TestExplain_noChange(t *testing.T) {
+	p := testExplainPlan(t)
+
+	view, done := testView(t)
+	c := &ExplainCommand{
+		Meta: Meta{
+			WorkingDir:       workdir.NewDir("."),
+			testingOverrides: metaOverridesForProvider(p),
+			View:             view,
+		},
+	}
+
+	code := c.Run([]string{"-plan=tofu.plan", "test_instance.nonexistent"})
+	output := done(t)
+	if code != 1 {
+		t.Fatalf("unexpected exit code %d\n\n%s", code, output.Stdout())
+	}
+	if got, want := output.Stderr(), "No planned change for resource instance"; !strings.Contains(got, want) {
+		t.Fatalf("error output does not contain %q:\n%s", want, got)
+	}
+}
+
+func TestExplain_notAPlan(t *testing.T) {
+	testCwdTemp(t)
+
+	view, done := testView(t)
+	c := &ExplainCommand{
+		Meta: Meta{
+			WorkingDir: workdir.NewDir("."),
+			View:       view,
+		},
+	}
+
+	code := c.Run([]string{"-plan=nonexistent.tfplan", "test_instance.web"})
+	output := done(t)
+	if code != 1 {
+		t.Fatalf("unexpected exit code %d\n\n%s", code, output.Stdout())
+	}
+	if got, want := output.Stderr(), `Failed to load "nonexistent.tfplan" as a plan file`; !strings.Contains(got, want) {
+		t.Fatalf("error output does not contain %q:\n%s", want, got)
+	}
+}
+
+// testExplainPlan creates a saved plan named "tofu.plan" from the "explain"
+// fixture in a new temporary working directory, returning the provider to
+// use with it.
+func testExplainPlan(t *testing.T) *tofu.MockProvider {
+	t.Helper()
+
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("explain"), td)
+	t.Chdir(td)
+
+	p := planFixtureProvider()
+	view, done := testView(t)
+	c := &PlanCommand{
+		Meta: Meta{
+			WorkingDir:       workdir.NewDir("."),
+			testingOverrides: metaOverridesForProvider(p),
+			View:             view,
+		},
+	}
+	code := c.Run([]string{"-out=tofu.plan"})
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("plan failed\n%s", output.Stderr())
+	}
+	return p
+}
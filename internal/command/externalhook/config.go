@@ -0,0 +1,97 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package externalhook
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+)
+
+// Event is the name of a lifecycle event that an external hook can be
+// notified about.
+type Event string
+
+const (
+	EventPreRefresh   Event = "pre_refresh"
+	EventPostRefresh  Event = "post_refresh"
+	EventPrePlan      Event = "pre_plan"
+	EventPostPlan     Event = "post_plan"
+	EventPreApply     Event = "pre_apply"
+	EventPostApply    Event = "post_apply"
+	EventApplyErrored Event = "apply_errored"
+)
+
+// Events are all of the events that external hooks can be notified about.
+var Events = []Event{
+	EventPreRefresh,
+	EventPostRefresh,
+	EventPrePlan,
+	EventPostPlan,
+	EventPreApply,
+	EventPostApply,
+	EventApplyErrored,
+}
+
+// Validate returns an error if the configuration is not valid.
+func (c *Config) Validate() error {
+	if len(c.Events) == 0 {
+		return fmt.Errorf("hook %q must select at least one event", c.Name)
+	}
+	for _, event := range c.Events {
+		if !validEvent(event) {
+			return fmt.Errorf("hook %q has unsupported event %q", c.Name, event)
+		}
+	}
+
+	switch {
+	case len(c.Command) != 0 && c.URL != "":
+		return fmt.Errorf("hook %q must set only one of command and url", c.Name)
+	case len(c.Command) != 0:
+		if c.Command[0] == "" {
+			return fmt.Errorf("hook %q has an empty command", c.Name)
+		}
+	case c.URL != "":
+		if err := validateLocalURL(c.URL); err != nil {
+			return fmt.Errorf("hook %q has invalid url: %w", c.Name, err)
+		}
+	default:
+		return fmt.Errorf("hook %q must set either command or url", c.Name)
+	}
+
+	if c.Timeout < 0 {
+		return fmt.Errorf("hook %q timeout must not be negative", c.Name)
+	}
+	return nil
+}
+
+func validEvent(event Event) bool {
+	for _, e := range Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// validateLocalURL checks that the given URL refers to an HTTP endpoint on
+// the local host, since the payloads can include details of the
+// infrastructure that we should not send across the network.
+func validateLocalURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("the scheme must be http or https")
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("the host must be localhost or a loopback address")
+}
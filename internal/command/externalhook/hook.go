@@ -0,0 +1,195 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package externalhook implements [tofu.Hook] by reporting selected plan and
+// apply lifecycle events to an external command or a local HTTP endpoint,
+// as configured by "hook" blocks in the CLI configuration.
+//
+// The external program receives a JSON description of each event, and can
+// halt the operation by rejecting it, which allows operators to enforce
+// local guardrails such as refusing to delete certain resources.
+package externalhook
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// DefaultTimeout is how long OpenTofu waits for an external hook to respond
+// when its configuration doesn't specify a timeout.
+const DefaultTimeout = 30 * time.Second
+
+// Config describes an external hook.
+type Config struct {
+	// Name is the label of the hook block in the CLI configuration, used
+	// to identify the hook in the payload and in error messages.
+	Name string
+
+	// Events are the events that the hook should be notified about.
+	Events []Event
+
+	// Exactly one of Command and URL must be set. Command is a program
+	// and its arguments, which receives each event on its standard input.
+	// URL is a local HTTP endpoint that receives each event as a POST
+	// request.
+	Command []string
+	URL     string
+
+	// Timeout is how long to wait for the hook to respond to each event,
+	// or zero to use DefaultTimeout.
+	Timeout time.Duration
+}
+
+// Hook is a [tofu.Hook] that reports the events it is configured for to an
+// external command or HTTP endpoint.
+//
+// If the external program rejects an event, or can't be run at all, the hook
+// returns [tofu.HookActionHalt] with an error describing why, which causes
+// the operation on that resource instance to fail.
+type Hook struct {
+	tofu.NilHook
+
+	name      string
+	events    map[Event]bool
+	transport transport
+	timeout   time.Duration
+	now       func() time.Time
+
+	mu sync.Mutex
+	// actions tracks the action of each in-progress apply, since PostApply
+	// doesn't include it.
+	actions map[string]plans.Action
+	// schemas returns the schema of the resource type of a resource
+	// instance, as set by SetSchemas.
+	schemas func(addrs.AbsResourceInstance) *configschema.Block
+}
+
+var _ tofu.Hook = (*Hook)(nil)
+
+// New returns a hook for the given configuration, or an error if the
+// configuration is invalid.
+func New(config Config) (*Hook, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	ret := &Hook{
+		name:    config.Name,
+		events:  make(map[Event]bool, len(config.Events)),
+		timeout: config.Timeout,
+		now:     time.Now,
+		actions: make(map[string]plans.Action),
+	}
+	for _, event := range config.Events {
+		ret.events[event] = true
+	}
+	if ret.timeout == 0 {
+		ret.timeout = DefaultTimeout
+	}
+	if len(config.Command) != 0 {
+		ret.transport = commandTransport{args: config.Command}
+	} else {
+		ret.transport = newHTTPTransport(config.URL)
+	}
+	return ret, nil
+}
+
+// SetSchemas sets the function that the hook calls to find the schema of the
+// resource type of a resource instance, or nil if it isn't known, so that it
+// can replace the attributes that the schema declares as sensitive by null in
+// the objects it sends. The hook sends no objects for resource instances
+// whose schema isn't known, including all of them until this is called.
+func (h *Hook) SetSchemas(schemas func(addrs.AbsResourceInstance) *configschema.Block) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.schemas = schemas
+}
+
+func (h *Hook) PreRefresh(addr addrs.AbsResourceInstance, gen states.Generation, priorState cty.Value) (tofu.HookAction, error) {
+	return h.notify(EventPreRefresh, addr, gen, plans.NoOp, priorState, cty.NilVal, nil)
+}
+
+func (h *Hook) PostRefresh(addr addrs.AbsResourceInstance, gen states.Generation, priorState cty.Value, newState cty.Value) (tofu.HookAction, error) {
+	return h.notify(EventPostRefresh, addr, gen, plans.NoOp, priorState, newState, nil)
+}
+
+func (h *Hook) PreDiff(addr addrs.AbsResourceInstance, gen states.Generation, priorState, proposedNewState cty.Value) (tofu.HookAction, error) {
+	return h.notify(EventPrePlan, addr, gen, plans.NoOp, priorState, proposedNewState, nil)
+}
+
+func (h *Hook) PostDiff(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, priorState, plannedNewState cty.Value) (tofu.HookAction, error) {
+	return h.notify(EventPostPlan, addr, gen, action, priorState, plannedNewState, nil)
+}
+
+func (h *Hook) PreApply(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, priorState, plannedNewState cty.Value) (tofu.HookAction, error) {
+	h.mu.Lock()
+	h.actions[actionKey(addr, gen)] = action
+	h.mu.Unlock()
+
+	return h.notify(EventPreApply, addr, gen, action, priorState, plannedNewState, nil)
+}
+
+func (h *Hook) PostApply(addr addrs.AbsResourceInstance, gen states.Generation, newState cty.Value, err error) (tofu.HookAction, error) {
+	key := actionKey(addr, gen)
+	h.mu.Lock()
+	action, ok := h.actions[key]
+	delete(h.actions, key)
+	h.mu.Unlock()
+	if !ok {
+		action = plans.NoOp
+	}
+
+	if err != nil && h.events[EventApplyErrored] {
+		if hookAction, hookErr := h.notify(EventApplyErrored, addr, gen, action, cty.NilVal, newState, err); hookErr != nil {
+			return hookAction, hookErr
+		}
+	}
+	return h.notify(EventPostApply, addr, gen, action, cty.NilVal, newState, err)
+}
+
+func (h *Hook) notify(event Event, addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, before, after cty.Value, applyErr error) (tofu.HookAction, error) {
+	if !h.events[event] {
+		return tofu.HookActionContinue, nil
+	}
+
+	h.mu.Lock()
+	schemas := h.schemas
+	h.mu.Unlock()
+	var schema *configschema.Block
+	if schemas != nil {
+		schema = schemas(addr)
+	}
+
+	payload, err := newPayload(h.name, event, h.now(), addr, gen, action, schema, before, after, applyErr).marshal()
+	if err != nil {
+		return tofu.HookActionHalt, fmt.Errorf("failed to prepare the %s event for hook %q: %w", event, h.name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	log.Printf("[TRACE] externalhook: sending %s event for %s to hook %q", event, addr, h.name)
+	if err := h.transport.send(ctx, payload); err != nil {
+		log.Printf("[WARN] externalhook: hook %q halted the %s event for %s: %s", h.name, event, addr, err)
+		return tofu.HookActionHalt, fmt.Errorf("hook %q halted the operation at the %s event for %s: %w", h.name, event, addr, err)
+	}
+	return tofu.HookActionContinue, nil
+}
+
+func actionKey(addr addrs.AbsResourceInstance, gen states.Generation) string {
+	if dk, ok := gen.(states.DeposedKey); ok {
+		return addr.String() + " " + dk.String()
+	}
+	return addr.String()
+}
@@ -0,0 +1,294 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package externalhook
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/lang/marks"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+func TestHook_http(t *testing.T) {
+	var mu sync.Mutex
+	var got []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]any
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("invalid payload: %s", err)
+		}
+		mu.Lock()
+		got = append(got, payload)
+		mu.Unlock()
+
+		// Refuse to delete anything tagged as protected.
+		if payload["action"] == "delete" {
+			before, _ := payload["before"].(map[string]any)
+			if tags, _ := before["tags"].(map[string]any); tags["protected"] == "true" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte("test_instance.a is protected\n"))
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	hook, err := New(Config{
+		Name:   "guard",
+		Events: []Event{EventPreApply},
+		URL:    server.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hook.now = func() time.Time {
+		return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	}
+	hook.SetSchemas(testSchemas)
+
+	addr := mustResourceInstanceAddr("test_instance.a")
+	prior := cty.ObjectVal(map[string]cty.Value{
+		"id":       cty.StringVal("i-abc123"),
+		"password": cty.StringVal("secret").Mark(marks.Sensitive),
+		"tags": cty.MapVal(map[string]cty.Value{
+			"protected": cty.StringVal("true"),
+		}),
+	})
+
+	// An update is allowed.
+	action, err := hook.PreApply(addr, states.CurrentGen, plans.Update, prior, prior)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if action != tofu.HookActionContinue {
+		t.Fatalf("wrong action %v; want continue", action)
+	}
+
+	// A delete is vetoed.
+	action, err = hook.PreApply(addr, states.CurrentGen, plans.Delete, prior, cty.NullVal(prior.Type()))
+	if action != tofu.HookActionHalt {
+		t.Fatalf("wrong action %v; want halt", action)
+	}
+	if err == nil {
+		t.Fatal("no error for vetoed delete")
+	}
+	if got, want := err.Error(), `hook "guard" halted the operation at the pre_apply event for test_instance.a: test_instance.a is protected`; got != want {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+
+	// Events that the hook didn't select are not sent.
+	if _, err := hook.PostApply(addr, states.CurrentGen, prior, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, want := len(got), 2; got != want {
+		t.Fatalf("wrong number of events %d; want %d", got, want)
+	}
+	want := map[string]any{
+		"hook":       "guard",
+		"event":      "pre_apply",
+		"@timestamp": "2024-01-02T03:04:05Z",
+		"resource": map[string]any{
+			"addr":             "test_instance.a",
+			"module":           "",
+			"resource":         "test_instance.a",
+			"implied_provider": "test",
+			"resource_type":    "test_instance",
+			"resource_name":    "a",
+			"resource_key":     nil,
+		},
+		"action": "delete",
+		"before": map[string]any{
+			"id":       "i-abc123",
+			"password": nil,
+			"tags": map[string]any{
+				"protected": "true",
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got[1]); diff != "" {
+		t.Errorf("wrong payload\n%s", diff)
+	}
+}
+
+func TestHook_schemaSensitive(t *testing.T) {
+	var got []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]any
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("invalid payload: %s", err)
+		}
+		got = append(got, payload)
+	}))
+	defer server.Close()
+
+	hook, err := New(Config{
+		Name:   "audit",
+		Events: []Event{EventPostRefresh},
+		URL:    server.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Values that come from the provider aren't marked as sensitive, so the
+	// hook must use the schema to find the sensitive attributes.
+	addr := mustResourceInstanceAddr("test_instance.a")
+	prior := cty.ObjectVal(map[string]cty.Value{
+		"id":       cty.StringVal("i-abc123"),
+		"password": cty.StringVal("secret"),
+		"tags":     cty.NullVal(cty.Map(cty.String)),
+	})
+
+	// Until the hook has the schemas, it sends no objects at all.
+	if _, err := hook.PostRefresh(addr, states.CurrentGen, prior, prior); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	hook.SetSchemas(testSchemas)
+	if _, err := hook.PostRefresh(addr, states.CurrentGen, prior, prior); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Nor does it send objects whose schema isn't known.
+	if _, err := hook.PostRefresh(mustResourceInstanceAddr("other_instance.a"), states.CurrentGen, prior, prior); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("wrong number of events %d; want 3", len(got))
+	}
+	for _, i := range []int{0, 2} {
+		if _, ok := got[i]["before"]; ok {
+			t.Errorf("event %d includes the object without a schema: %v", i, got[i]["before"])
+		}
+	}
+	want := map[string]any{
+		"id":       "i-abc123",
+		"password": nil,
+		"tags":     nil,
+	}
+	for _, key := range []string{"before", "after"} {
+		if diff := cmp.Diff(want, got[1][key]); diff != "" {
+			t.Errorf("wrong %s object\n%s", key, diff)
+		}
+	}
+}
+
+func TestHook_command(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("this test uses a POSIX shell")
+	}
+
+	hook, err := New(Config{
+		Name:    "errors",
+		Events:  []Event{EventApplyErrored},
+		Command: []string{"sh", "-c", `grep -q '"error":"boom"' && echo "noted"; exit 3`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := mustResourceInstanceAddr("test_instance.a")
+	if _, err := hook.PreApply(addr, states.CurrentGen, plans.Create, cty.NullVal(cty.DynamicPseudoType), cty.EmptyObjectVal); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := hook.PostApply(addr, states.CurrentGen, cty.EmptyObjectVal, nil); err != nil {
+		t.Fatalf("unexpected error for successful apply: %s", err)
+	}
+
+	_, err = hook.PostApply(addr, states.CurrentGen, cty.NullVal(cty.DynamicPseudoType), errors.New("boom"))
+	if err == nil {
+		t.Fatal("no error from failing hook command")
+	}
+	if got, want := err.Error(), `hook "errors" halted the operation at the apply_errored event for test_instance.a: noted`; got != want {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]struct {
+		config  Config
+		wantErr string
+	}{
+		"valid command": {
+			config: Config{Name: "a", Events: []Event{EventPreApply}, Command: []string{"check"}},
+		},
+		"valid url": {
+			config: Config{Name: "a", Events: []Event{EventPostPlan}, URL: "http://127.0.0.1:8080/hook"},
+		},
+		"no events": {
+			config:  Config{Name: "a", Command: []string{"check"}},
+			wantErr: `hook "a" must select at least one event`,
+		},
+		"unsupported event": {
+			config:  Config{Name: "a", Events: []Event{"pre_destroy"}, Command: []string{"check"}},
+			wantErr: `hook "a" has unsupported event "pre_destroy"`,
+		},
+		"both command and url": {
+			config:  Config{Name: "a", Events: []Event{EventPreApply}, Command: []string{"check"}, URL: "http://localhost/"},
+			wantErr: `hook "a" must set only one of command and url`,
+		},
+		"neither command nor url": {
+			config:  Config{Name: "a", Events: []Event{EventPreApply}},
+			wantErr: `hook "a" must set either command or url`,
+		},
+		"remote url": {
+			config:  Config{Name: "a", Events: []Event{EventPreApply}, URL: "https://example.com/hook"},
+			wantErr: `hook "a" has invalid url: the host must be localhost or a loopback address`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.config.Validate()
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("wrong error\ngot:  %v\nwant: %s", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func testSchemas(addr addrs.AbsResourceInstance) *configschema.Block {
+	if addr.Resource.Resource.Type != "test_instance" {
+		return nil
+	}
+	return &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"id":       {Type: cty.String, Computed: true},
+			"password": {Type: cty.String, Optional: true, Sensitive: true},
+			"tags":     {Type: cty.Map(cty.String), Optional: true},
+		},
+	}
+}
+
+func mustResourceInstanceAddr(s string) addrs.AbsResourceInstance {
+	addr, diags := addrs.ParseAbsResourceInstanceStr(s)
+	if diags.HasErrors() {
+		panic(diags.Err())
+	}
+	return addr
+}
@@ -0,0 +1,110 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package externalhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/command/jsonentities"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/lang/marks"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+// Payload is the JSON description of an event that is sent to an external
+// hook.
+type Payload struct {
+	Hook       string                    `json:"hook"`
+	Event      Event                     `json:"event"`
+	Timestamp  string                    `json:"@timestamp"`
+	Resource   jsonentities.ResourceAddr `json:"resource"`
+	DeposedKey string                    `json:"deposed_key,omitempty"`
+	Action     jsonentities.ChangeAction `json:"action,omitempty"`
+
+	// Before and After are the object before and after the event, where
+	// relevant. Sensitive, ephemeral, and unknown values are null, and both
+	// are omitted if the schema of the resource type isn't known.
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+
+	// Error is the error message for a failed apply.
+	Error string `json:"error,omitempty"`
+}
+
+func newPayload(hook string, event Event, now time.Time, addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, schema *configschema.Block, before, after cty.Value, err error) *Payload {
+	ret := &Payload{
+		Hook:      hook,
+		Event:     event,
+		Timestamp: now.Format(time.RFC3339Nano),
+		Resource:  jsonentities.NewResourceAddr(addr),
+		Before:    marshalValue(before, schema),
+		After:     marshalValue(after, schema),
+	}
+	if dk, ok := gen.(states.DeposedKey); ok {
+		ret.DeposedKey = dk.String()
+	}
+	switch event {
+	case EventPostPlan, EventPreApply, EventPostApply, EventApplyErrored:
+		ret.Action = jsonentities.ParseChangeAction(action)
+	}
+	if err != nil {
+		ret.Error = err.Error()
+	}
+	return ret
+}
+
+func (p *Payload) marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// marshalValue returns the JSON representation of the given object, with
+// any values that must not leave OpenTofu replaced by null, or nil if there
+// is no object.
+//
+// Values only carry the marks that come from the configuration, so the
+// attributes that the given schema of the object's resource type declares as
+// sensitive are replaced by null too. Without a schema we can't tell which
+// attributes are sensitive, so we return nil.
+func marshalValue(v cty.Value, schema *configschema.Block) json.RawMessage {
+	if v == cty.NilVal || !v.IsKnown() || schema == nil {
+		return nil
+	}
+	v, pvms := v.UnmarkDeepWithPaths()
+	if v.IsNull() {
+		return nil
+	}
+	pvms = append(pvms, schema.ValueMarks(v, nil, nil)...)
+
+	v, err := cty.Transform(v, func(path cty.Path, v cty.Value) (cty.Value, error) {
+		if !v.IsKnown() {
+			return cty.NullVal(v.Type()), nil
+		}
+		for _, pvm := range pvms {
+			if !pvm.Path.Equals(path) {
+				continue
+			}
+			_, sensitive := pvm.Marks[marks.Sensitive]
+			_, ephemeral := pvm.Marks[marks.Ephemeral]
+			if sensitive || ephemeral {
+				return cty.NullVal(v.Type()), nil
+			}
+		}
+		return v, nil
+	})
+	if err != nil {
+		return nil
+	}
+
+	ret, err := ctyjson.Marshal(v, v.Type())
+	if err != nil {
+		return nil
+	}
+	return ret
+}
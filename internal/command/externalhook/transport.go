@@ -0,0 +1,99 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package externalhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+)
+
+// maxResponseMessage limits how much of an external hook's response we
+// include in an error message when it rejects an event.
+const maxResponseMessage = 4096
+
+// transport delivers event payloads to an external hook. It returns an error
+// if the hook rejected the event or could not be reached.
+type transport interface {
+	send(ctx context.Context, payload []byte) error
+}
+
+// commandTransport runs a command for each event, with the payload on its
+// standard input. The command rejects the event by exiting with a non-zero
+// status, optionally writing a reason to its standard output or standard
+// error.
+type commandTransport struct {
+	args []string
+}
+
+func (t commandTransport) send(ctx context.Context, payload []byte) error {
+	cmd := exec.CommandContext(ctx, t.args[0], t.args[1:]...)
+	cmd.Stdin = bytes.NewReader(payload)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return fmt.Errorf("the hook command did not respond in time")
+	}
+	if err != nil {
+		if msg := responseMessage(output.Bytes()); msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// httpTransport posts each event to an HTTP endpoint. The endpoint rejects
+// the event by responding with a status code other than 2xx, optionally
+// including a reason in the response body.
+type httpTransport struct {
+	url    string
+	client *http.Client
+}
+
+func newHTTPTransport(url string) httpTransport {
+	return httpTransport{
+		url:    url,
+		client: &http.Client{},
+	}
+}
+
+func (t httpTransport) send(ctx context.Context, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseMessage))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		if msg := responseMessage(body); msg != "" {
+			return errors.New(msg)
+		}
+		return fmt.Errorf("the hook endpoint responded with %s", resp.Status)
+	}
+	return nil
+}
+
+func responseMessage(output []byte) string {
+	msg := strings.TrimSpace(string(output))
+	if len(msg) > maxResponseMessage {
+		msg = msg[:maxResponseMessage] + "..."
+	}
+	return msg
+}
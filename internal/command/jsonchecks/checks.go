@@ -11,6 +11,7 @@ import (
 	"sort"
 
 	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
 // MarshalCheckStates is the main entry-point for this package, which takes
@@ -28,14 +29,23 @@ func MarshalCheckStates(results *states.CheckResults) []byte {
 			dynamicAddr := elem.Key
 			result := elem.Value
 
-			problems := make([]checkProblem, 0, len(result.FailureMessages))
-			for _, msg := range result.FailureMessages {
+			problems := make([]checkProblem, 0, len(result.Failures))
+			for _, failure := range result.Failures {
+				severity := "error"
+				if failure.Severity == tfdiags.Warning {
+					severity = "warning"
+				}
 				problems = append(problems, checkProblem{
-					Message: msg,
+					Message:   failure.Message,
+					ErrorCode: failure.ErrorCode,
+					Severity:  severity,
 				})
 			}
 			sort.Slice(problems, func(i, j int) bool {
-				return problems[i].Message < problems[j].Message
+				if problems[i].Message != problems[j].Message {
+					return problems[i].Message < problems[j].Message
+				}
+				return problems[i].ErrorCode < problems[j].ErrorCode
 			})
 
 			objects = append(objects, checkResultDynamic{
@@ -115,6 +125,14 @@ type checkProblem struct {
 	// Message is the condition error message provided by the author.
 	Message string `json:"message"`
 
+	// ErrorCode is the machine-readable error code provided by the author,
+	// if any.
+	ErrorCode string `json:"error_code,omitempty"`
+
+	// Severity is either "error" or "warning", depending on whether the
+	// failure blocked the operation.
+	Severity string `json:"severity"`
+
 	// We don't currently have any other problem-related data, but this is
 	// intentionally an object to allow us to add other data over time, such
 	// as the source location where the failing condition was defined.
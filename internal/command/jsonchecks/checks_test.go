@@ -13,6 +13,7 @@ import (
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/checks"
 	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
 func TestMarshalCheckStates(t *testing.T) {
@@ -70,9 +71,9 @@ func TestMarshalCheckStates(t *testing.T) {
 						ObjectResults: addrs.MakeMap(
 							addrs.MakeMapElem(resourceAInstAddr, &states.CheckResultObject{
 								Status: checks.StatusFail,
-								FailureMessages: []string{
-									"Not enough boops.",
-									"Too many beeps.",
+								Failures: []checks.Failure{
+									{Message: "Not enough boops.", Severity: tfdiags.Error},
+									{Message: "Too many beeps.", ErrorCode: "TOO_MANY_BEEPS", Severity: tfdiags.Error},
 								},
 							}),
 						),
@@ -82,8 +83,8 @@ func TestMarshalCheckStates(t *testing.T) {
 						ObjectResults: addrs.MakeMap(
 							addrs.MakeMapElem(resourceBInstAddr, &states.CheckResultObject{
 								Status: checks.StatusFail,
-								FailureMessages: []string{
-									"Splines are too pointy.",
+								Failures: []checks.Failure{
+									{Message: "Splines are too pointy.", Severity: tfdiags.Error},
 								},
 							}),
 						),
@@ -101,8 +102,8 @@ func TestMarshalCheckStates(t *testing.T) {
 						ObjectResults: addrs.MakeMap(
 							addrs.MakeMapElem(outputBInstAddr, &states.CheckResultObject{
 								Status: checks.StatusFail,
-								FailureMessages: []string{
-									"Not object-oriented enough.",
+								Failures: []checks.Failure{
+									{Message: "Not object-oriented enough.", Severity: tfdiags.Error},
 								},
 							}),
 						),
@@ -112,8 +113,8 @@ func TestMarshalCheckStates(t *testing.T) {
 						ObjectResults: addrs.MakeMap(
 							addrs.MakeMapElem(checkBlockAInstAddr, &states.CheckResultObject{
 								Status: checks.StatusFail,
-								FailureMessages: []string{
-									"Couldn't reverse the polarity.",
+								Failures: []checks.Failure{
+									{Message: "Couldn't reverse the polarity.", Severity: tfdiags.Warning},
 								},
 							}),
 						),
@@ -123,8 +124,8 @@ func TestMarshalCheckStates(t *testing.T) {
 						ObjectResults: addrs.MakeMap(
 							addrs.MakeMapElem(ephemeralAInstAddr, &states.CheckResultObject{
 								Status: checks.StatusFail,
-								FailureMessages: []string{
-									"foo",
+								Failures: []checks.Failure{
+									{Message: "foo", Severity: tfdiags.Error},
 								},
 							}),
 						),
@@ -145,7 +146,8 @@ func TestMarshalCheckStates(t *testing.T) {
 							},
 							"problems": []any{
 								map[string]any{
-									"message": "Couldn't reverse the polarity.",
+									"message":  "Couldn't reverse the polarity.",
+									"severity": "warning",
 								},
 							},
 							"status": "fail",
@@ -168,7 +170,8 @@ func TestMarshalCheckStates(t *testing.T) {
 							},
 							"problems": []any{
 								map[string]any{
-									"message": "foo",
+									"message":  "foo",
+									"severity": "error",
 								},
 							},
 							"status": "fail",
@@ -191,7 +194,8 @@ func TestMarshalCheckStates(t *testing.T) {
 							},
 							"problems": []any{
 								map[string]any{
-									"message": "Not object-oriented enough.",
+									"message":  "Not object-oriented enough.",
+									"severity": "error",
 								},
 							},
 							"status": "fail",
@@ -216,7 +220,8 @@ func TestMarshalCheckStates(t *testing.T) {
 							},
 							"problems": []any{
 								map[string]any{
-									"message": "Splines are too pointy.",
+									"message":  "Splines are too pointy.",
+									"severity": "error",
 								},
 							},
 							"status": "fail",
@@ -256,10 +261,13 @@ func TestMarshalCheckStates(t *testing.T) {
 							},
 							"problems": []any{
 								map[string]any{
-									"message": "Not enough boops.",
+									"message":  "Not enough boops.",
+									"severity": "error",
 								},
 								map[string]any{
-									"message": "Too many beeps.",
+									"message":    "Too many beeps.",
+									"error_code": "TOO_MANY_BEEPS",
+									"severity":   "error",
 								},
 							},
 							"status": "fail",
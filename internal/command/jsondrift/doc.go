@@ -0,0 +1,8 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package jsondrift implements the JSON drift report produced by the
+// "tofu drift" command, which describes the changes made to managed
+// resources outside of OpenTofu down to the individual attributes that
+// changed.
+package jsondrift
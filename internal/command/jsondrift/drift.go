@@ -0,0 +1,297 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package jsondrift
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/opentofu/opentofu/internal/command/jsonentities"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/lang/marks"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// FormatVersion represents the version of the json format and will be
+// incremented for any change to this format that requires changes to a
+// consuming parser.
+const FormatVersion = "1.0"
+
+// Report is the top-level object of a drift report, describing the drift
+// found in one or more workspaces.
+type Report struct {
+	FormatVersion string       `json:"format_version"`
+	Workspaces    []*Workspace `json:"workspaces"`
+}
+
+// Drifted returns true if drift was found in any of the workspaces in the
+// report.
+func (r *Report) Drifted() bool {
+	for _, ws := range r.Workspaces {
+		if ws.Drifted {
+			return true
+		}
+	}
+	return false
+}
+
+// Workspace describes the drift found in a single workspace.
+type Workspace struct {
+	Name    string `json:"name"`
+	Drifted bool   `json:"drifted"`
+
+	// Error is set if OpenTofu could not check the workspace for drift, in
+	// which case Resources is empty.
+	Error string `json:"error,omitempty"`
+
+	Resources []*Resource `json:"resources,omitempty"`
+}
+
+// Resource describes a resource instance that was changed outside of
+// OpenTofu, using the same action and addresses as the "resource_drift"
+// messages in the machine-readable UI.
+type Resource struct {
+	Resource         jsonentities.ResourceAddr  `json:"resource"`
+	PreviousResource *jsonentities.ResourceAddr `json:"previous_resource,omitempty"`
+	Action           jsonentities.ChangeAction  `json:"action"`
+
+	// Attributes describe each changed attribute of a resource instance
+	// that was updated outside of OpenTofu.
+	Attributes []*Attribute `json:"attributes,omitempty"`
+}
+
+// Attribute describes a single changed attribute. Path is the path to the
+// attribute within the resource instance, such as tags["Name"].
+//
+// Before and After are omitted if the attribute is sensitive.
+type Attribute struct {
+	Path      string          `json:"path"`
+	Before    json.RawMessage `json:"before,omitempty"`
+	After     json.RawMessage `json:"after,omitempty"`
+	Sensitive bool            `json:"sensitive,omitempty"`
+}
+
+// Ignore is a set of glob patterns describing drift to leave out of a report.
+//
+// A pattern that matches the address of a resource instance ignores all
+// drift of that resource instance. A pattern that matches the address of a
+// resource instance followed by a dot and the path of an attribute, such as
+// aws_instance.web.tags["LastScanned"], ignores changes to that attribute.
+// In patterns, "*" matches any sequence of characters and "?" matches any
+// single character.
+type Ignore []*regexp.Regexp
+
+// NewIgnore returns an Ignore for the given glob patterns.
+func NewIgnore(patterns []string) Ignore {
+	ret := make(Ignore, 0, len(patterns))
+	for _, pattern := range patterns {
+		expr := regexp.QuoteMeta(pattern)
+		expr = strings.ReplaceAll(expr, `\*`, `.*`)
+		expr = strings.ReplaceAll(expr, `\?`, `.`)
+		ret = append(ret, regexp.MustCompile("^"+expr+"$"))
+	}
+	return ret
+}
+
+// Match returns true if any of the patterns match the given string.
+func (ig Ignore) Match(s string) bool {
+	for _, re := range ig {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewWorkspace describes the drift recorded in the given refresh-only plan,
+// leaving out any drift that matches the ignore patterns.
+func NewWorkspace(name string, plan *plans.Plan, schemas *tofu.Schemas, ignore Ignore) (*Workspace, error) {
+	ret := &Workspace{Name: name}
+
+	for _, dr := range plan.DriftedResources {
+		change := jsonentities.NewResourceInstanceChange(dr)
+		if change.Action == jsonentities.ActionNoOp {
+			continue
+		}
+		addr := dr.Addr.String()
+		if ignore.Match(addr) {
+			continue
+		}
+
+		resource := &Resource{
+			Resource:         change.Resource,
+			PreviousResource: change.PreviousResource,
+			Action:           change.Action,
+		}
+
+		if dr.Action == plans.Update {
+			schema, _ := schemas.ResourceTypeConfig(
+				dr.ProviderAddr.Provider,
+				dr.Addr.Resource.Resource.Mode,
+				dr.Addr.Resource.Resource.Type,
+			)
+			if schema == nil {
+				return nil, fmt.Errorf("no schema found for %s (in provider %s)", addr, dr.ProviderAddr.Provider)
+			}
+			decoded, err := dr.Decode(schema)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode drift of %s: %w", addr, err)
+			}
+			attrs, err := diffAttributes(schema.Block, decoded.Before, decoded.After)
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe drift of %s: %w", addr, err)
+			}
+			for _, attr := range attrs {
+				if !ignore.Match(addr + "." + attr.Path) {
+					resource.Attributes = append(resource.Attributes, attr)
+				}
+			}
+			if len(resource.Attributes) == 0 {
+				// Every change to this resource instance was ignored.
+				continue
+			}
+		}
+
+		ret.Resources = append(ret.Resources, resource)
+	}
+
+	ret.Drifted = len(ret.Resources) > 0
+	return ret, nil
+}
+
+// diffAttributes returns a description of each leaf value that differs
+// between the two given objects, which conform to the given schema.
+func diffAttributes(schema *configschema.Block, before, after cty.Value) ([]*Attribute, error) {
+	before, beforeMarks := before.UnmarkDeepWithPaths()
+	after, afterMarks := after.UnmarkDeepWithPaths()
+	// The values only carry the marks that were recorded in the plan, so we
+	// also need the attributes that the schema declares as sensitive.
+	if schema.ContainsMarks() {
+		beforeMarks = append(beforeMarks, schema.ValueMarks(before, nil, nil)...)
+		afterMarks = append(afterMarks, schema.ValueMarks(after, nil, nil)...)
+	}
+	var sensitivePaths []cty.Path
+	for _, pvm := range append(beforeMarks, afterMarks...) {
+		if _, ok := pvm.Marks[marks.Sensitive]; ok {
+			sensitivePaths = append(sensitivePaths, pvm.Path)
+		}
+	}
+	isSensitive := func(path cty.Path) bool {
+		for _, sp := range sensitivePaths {
+			if path.HasPrefix(sp) || sp.HasPrefix(path) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var ret []*Attribute
+	var walk func(path cty.Path, before, after cty.Value) error
+	walk = func(path cty.Path, before, after cty.Value) error {
+		if before.RawEquals(after) {
+			return nil
+		}
+
+		ty := before.Type()
+		if before.IsKnown() && after.IsKnown() && !before.IsNull() && !after.IsNull() && ty.Equals(after.Type()) {
+			switch {
+			case ty.IsObjectType():
+				names := make([]string, 0, len(ty.AttributeTypes()))
+				for name := range ty.AttributeTypes() {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				for _, name := range names {
+					if err := walk(path.GetAttr(name), before.GetAttr(name), after.GetAttr(name)); err != nil {
+						return err
+					}
+				}
+				return nil
+
+			case ty.IsMapType():
+				keys := make(map[string]struct{})
+				for k := range before.AsValueMap() {
+					keys[k] = struct{}{}
+				}
+				for k := range after.AsValueMap() {
+					keys[k] = struct{}{}
+				}
+				sorted := make([]string, 0, len(keys))
+				for k := range keys {
+					sorted = append(sorted, k)
+				}
+				sort.Strings(sorted)
+				null := cty.NullVal(ty.ElementType())
+				for _, k := range sorted {
+					key := cty.StringVal(k)
+					b, a := null, null
+					if before.HasIndex(key).True() {
+						b = before.Index(key)
+					}
+					if after.HasIndex(key).True() {
+						a = after.Index(key)
+					}
+					if err := walk(path.Index(key), b, a); err != nil {
+						return err
+					}
+				}
+				return nil
+
+			case ty.IsListType():
+				bs, as := before.AsValueSlice(), after.AsValueSlice()
+				null := cty.NullVal(ty.ElementType())
+				for i := 0; i < max(len(bs), len(as)); i++ {
+					b, a := null, null
+					if i < len(bs) {
+						b = bs[i]
+					}
+					if i < len(as) {
+						a = as[i]
+					}
+					if err := walk(path.IndexInt(i), b, a); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+		}
+
+		attr := &Attribute{
+			Path: strings.TrimPrefix(tfdiags.FormatCtyPath(path), "."),
+		}
+		if isSensitive(path) {
+			attr.Sensitive = true
+		} else {
+			var err error
+			if attr.Before, err = marshalValue(before); err != nil {
+				return err
+			}
+			if attr.After, err = marshalValue(after); err != nil {
+				return err
+			}
+		}
+		ret = append(ret, attr)
+		return nil
+	}
+
+	if err := walk(nil, before, after); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func marshalValue(v cty.Value) (json.RawMessage, error) {
+	if !v.IsWhollyKnown() {
+		return json.RawMessage("null"), nil
+	}
+	return ctyjson.Marshal(v, v.Type())
+}
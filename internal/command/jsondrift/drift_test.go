@@ -0,0 +1,89 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package jsondrift
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/lang/marks"
+)
+
+func TestIgnore(t *testing.T) {
+	ignore := NewIgnore([]string{
+		"aws_instance.*",
+		`aws_s3_bucket.logs.tags["Last?canned"]`,
+	})
+
+	tests := map[string]bool{
+		"aws_instance.web":                          true,
+		"module.a.aws_instance.web":                 false,
+		"aws_instance.web.tags":                     true,
+		`aws_s3_bucket.logs.tags["LastScanned"]`:    true,
+		`aws_s3_bucket.logs.tags["Owner"]`:          false,
+		"aws_s3_bucket.logs":                        false,
+		"aws_instance_profile.web":                  false,
+		`aws_s3_bucket.logs.tags["LastScanned"].x`:  false,
+		`aws_s3_bucket.logs.tags["Last(canned"]`:    true,
+		`aws_s3_bucket.logs.tags["LastScannedNow"]`: false,
+	}
+	for input, want := range tests {
+		if got := ignore.Match(input); got != want {
+			t.Errorf("Match(%q) = %t; want %t", input, got, want)
+		}
+	}
+}
+
+func TestDiffAttributes(t *testing.T) {
+	before := cty.ObjectVal(map[string]cty.Value{
+		"id": cty.StringVal("i-abc123"),
+		"tags": cty.MapVal(map[string]cty.Value{
+			"Name":  cty.StringVal("web"),
+			"Owner": cty.StringVal("ops"),
+		}),
+		"ports":    cty.ListVal([]cty.Value{cty.NumberIntVal(80)}),
+		"password": cty.StringVal("hunter2").Mark(marks.Sensitive),
+		"token":    cty.StringVal("abc"),
+	})
+	after := cty.ObjectVal(map[string]cty.Value{
+		"id": cty.StringVal("i-abc123"),
+		"tags": cty.MapVal(map[string]cty.Value{
+			"Name":        cty.StringVal("web"),
+			"LastScanned": cty.StringVal("today"),
+		}),
+		"ports":    cty.ListVal([]cty.Value{cty.NumberIntVal(80), cty.NumberIntVal(443)}),
+		"password": cty.StringVal("correct horse").Mark(marks.Sensitive),
+		"token":    cty.StringVal("def"),
+	})
+	schema := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"id":       {Type: cty.String, Computed: true},
+			"tags":     {Type: cty.Map(cty.String), Optional: true},
+			"ports":    {Type: cty.List(cty.Number), Optional: true},
+			"password": {Type: cty.String, Optional: true},
+			// The schema marks this attribute as sensitive even though the
+			// values recorded in the plan aren't marked.
+			"token": {Type: cty.String, Optional: true, Sensitive: true},
+		},
+	}
+
+	got, err := diffAttributes(schema, before, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Attribute{
+		{Path: "password", Sensitive: true},
+		{Path: "ports[1]", Before: json.RawMessage("null"), After: json.RawMessage("443")},
+		{Path: `tags["LastScanned"]`, Before: json.RawMessage("null"), After: json.RawMessage(`"today"`)},
+		{Path: `tags["Owner"]`, Before: json.RawMessage(`"ops"`), After: json.RawMessage("null")},
+		{Path: "token", Sensitive: true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+}
@@ -41,6 +41,7 @@ type Diagnostic struct {
 	Summary    string             `json:"summary"`
 	Detail     string             `json:"detail"`
 	Address    string             `json:"address,omitempty"`
+	Code       string             `json:"code,omitempty"`
 	Range      *DiagnosticRange   `json:"range,omitempty"`
 	Snippet    *DiagnosticSnippet `json:"snippet,omitempty"`
 	Difference *jsonplan.Change   `json:"difference,omitempty"`
@@ -172,6 +173,7 @@ func NewDiagnostic(diag tfdiags.Diagnostic, sources map[string]*hcl.File) *Diagn
 		Summary:    desc.Summary,
 		Detail:     desc.Detail,
 		Address:    desc.Address,
+		Code:       tfdiags.DiagnosticErrorCode(diag),
 		Range:      newDiagnosticRange(highlightRange),
 		Snippet:    snippet,
 		Difference: difference,
@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hcltest"
+	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/command/jsonplan"
 	"github.com/opentofu/opentofu/internal/lang/marks"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -89,6 +90,23 @@ func TestNewDiagnostic(t *testing.T) {
 				Detail:   "Something is broken",
 			},
 		},
+		"error with error code": {
+			&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid value for variable",
+				Detail:   "The quota has been exceeded.",
+				Extra: &addrs.CheckRuleDiagnosticExtra{
+					CheckRule: addrs.NewCheckRule(addrs.RootModuleInstance.InputVariable("quota"), addrs.InputValidation, 0),
+					ErrorCode: "QUOTA_EXCEEDED",
+				},
+			},
+			&Diagnostic{
+				Severity: "error",
+				Summary:  "Invalid value for variable",
+				Detail:   "The quota has been exceeded.",
+				Code:     "QUOTA_EXCEEDED",
+			},
+		},
 		"error with source code unavailable": {
 			&hcl.Diagnostic{
 				Severity: hcl.DiagError,
@@ -0,0 +1,7 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package jsonexplain implements the explanation of a planned change to a
+// resource instance produced by the "tofu explain" command, and its JSON
+// representation.
+package jsonexplain
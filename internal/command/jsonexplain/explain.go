@@ -0,0 +1,270 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package jsonexplain
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/command/jsonentities"
+	"github.com/opentofu/opentofu/internal/lang/globalref"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+// FormatVersion represents the version of the json format and will be
+// incremented for any change to this format that requires changes to a
+// consuming parser.
+const FormatVersion = "1.0"
+
+// ReferenceKind describes what kind of object a reference refers to.
+type ReferenceKind string
+
+const (
+	InputVariable   ReferenceKind = "input_variable"
+	LocalValue      ReferenceKind = "local_value"
+	ModuleOutput    ReferenceKind = "module_output"
+	ModuleCall      ReferenceKind = "module_call"
+	ManagedResource ReferenceKind = "managed_resource"
+	DataResource    ReferenceKind = "data_resource"
+	CountIndex      ReferenceKind = "count_index"
+	ForEachValue    ReferenceKind = "for_each_value"
+	Other           ReferenceKind = "other"
+)
+
+// Explanation is the top-level object describing why a resource instance
+// has the change that a saved plan proposes for it.
+type Explanation struct {
+	FormatVersion string                    `json:"format_version"`
+	Address       string                    `json:"address"`
+	Deposed       string                    `json:"deposed,omitempty"`
+	Action        jsonentities.ChangeAction `json:"action"`
+	Reason        jsonentities.ChangeReason `json:"reason,omitempty"`
+
+	// Attributes describes each of the top-level attributes and nested
+	// block types of the resource instance whose values the change would
+	// alter, in lexical order by name.
+	Attributes []*Attribute `json:"attributes"`
+}
+
+// Attribute describes a top-level attribute or nested block type whose value
+// the change would alter, along with the references that contribute to its
+// value in the configuration.
+type Attribute struct {
+	Name string `json:"name"`
+
+	// RequiresReplace is true if the provider reported that a change to
+	// this attribute can't be made in-place.
+	RequiresReplace bool `json:"requires_replace,omitempty"`
+
+	// References are the references in the configuration of the attribute,
+	// which is empty if the attribute is set only to a literal value or is
+	// chosen by the provider.
+	References []*Reference `json:"references"`
+}
+
+// Reference describes a reference that contributes to the value of an
+// attribute, either directly or through another reference, along with the
+// references that in turn contribute to the object it refers to.
+//
+// The tree of references ends at references to resources, to root module
+// input variables, and to anything else whose definition has no references.
+type Reference struct {
+	// Address is the reference as it would be written in the module that
+	// contains it, prefixed with the address of that module if it isn't the
+	// root module.
+	Address string `json:"address"`
+
+	// Module is the address of the module instance that contains the
+	// reference, which is empty for the root module.
+	Module string `json:"module,omitempty"`
+
+	Kind  ReferenceKind                 `json:"kind"`
+	Range *jsonentities.DiagnosticRange `json:"range,omitempty"`
+
+	// Action is the planned action for the resource instance that a
+	// reference to a resource refers to, if the plan includes a change to
+	// it. For a reference to a whole resource with multiple instances, this
+	// is the action for the first instance whose action isn't "noop".
+	Action jsonentities.ChangeAction `json:"action,omitempty"`
+
+	// Cycle is true if the reference refers to an object that is already
+	// on the path to it, which can only happen in an invalid configuration.
+	Cycle bool `json:"cycle,omitempty"`
+
+	References []*Reference `json:"references,omitempty"`
+}
+
+// NewExplanation explains the given planned change to a resource instance,
+// using the given analyzer for the configuration that the plan was created
+// from.
+func NewExplanation(change *plans.ResourceInstanceChangeSrc, changes *plans.Changes, schemas *tofu.Schemas, azr *globalref.Analyzer) (*Explanation, error) {
+	addr := change.Addr
+	schema, _ := schemas.ResourceTypeConfig(change.ProviderAddr.Provider, addr.Resource.Resource.Mode, addr.Resource.Resource.Type)
+	if schema == nil {
+		return nil, fmt.Errorf("no schema available for %s; this is a bug in OpenTofu - please report it", addr)
+	}
+	decoded, err := change.Decode(schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the planned change for %s: %w", addr, err)
+	}
+
+	ret := &Explanation{
+		FormatVersion: FormatVersion,
+		Address:       addr.String(),
+		Deposed:       string(change.DeposedKey),
+		Action:        jsonentities.ParseChangeAction(change.Action),
+		Reason:        jsonentities.NewResourceInstanceChange(change).Reason,
+		Attributes:    []*Attribute{},
+	}
+
+	before, _ := decoded.Before.UnmarkDeep()
+	after, _ := decoded.After.UnmarkDeep()
+	for _, name := range attributeNames(schema) {
+		if attributeValue(before, name).RawEquals(attributeValue(after, name)) {
+			continue
+		}
+		path := cty.GetAttrPath(name)
+		refs := azr.ReferencesFromResourceAttr(addr, path)
+		ret.Attributes = append(ret.Attributes, &Attribute{
+			Name:            name,
+			RequiresReplace: requiresReplace(change.RequiredReplace, name),
+			References:      newReferences(azr.ReferenceTrees(refs...), changes),
+		})
+	}
+	return ret, nil
+}
+
+// attributeNames returns the names of all of the top-level attributes and
+// nested block types in the given schema, in lexical order.
+func attributeNames(schema *providers.Schema) []string {
+	var ret []string
+	for name := range schema.Block.Attributes {
+		ret = append(ret, name)
+	}
+	for name := range schema.Block.BlockTypes {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// attributeValue returns the value of the given attribute of an object value
+// that might be null, as is the case for the prior value of an object that
+// is being created or the planned value of an object that is being deleted.
+func attributeValue(obj cty.Value, name string) cty.Value {
+	if obj.IsNull() || !obj.IsKnown() {
+		return cty.NullVal(obj.Type().AttributeType(name))
+	}
+	return obj.GetAttr(name)
+}
+
+func requiresReplace(paths cty.PathSet, name string) bool {
+	for _, path := range paths.List() {
+		if len(path) == 0 {
+			continue
+		}
+		if step, ok := path[0].(cty.GetAttrStep); ok && step.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func newReferences(trees []*globalref.ReferenceTree, changes *plans.Changes) []*Reference {
+	ret := make([]*Reference, 0, len(trees))
+	for _, tree := range trees {
+		ref := tree.Ref
+		module := ref.ModuleAddr()
+		jsonRef := &Reference{
+			Address:    ref.LocalRef.DisplayString(),
+			Kind:       referenceKind(ref.LocalRef.Subject),
+			Cycle:      tree.Cycle,
+			References: newReferences(tree.Contributors, changes),
+		}
+		if !module.IsRoot() {
+			jsonRef.Module = module.String()
+			jsonRef.Address = jsonRef.Module + "." + jsonRef.Address
+		}
+		if rng := ref.LocalRef.SourceRange; rng.Filename != "" {
+			jsonRef.Range = &jsonentities.DiagnosticRange{
+				Filename: rng.Filename,
+				Start: jsonentities.Pos{
+					Line:   rng.Start.Line,
+					Column: rng.Start.Column,
+					Byte:   rng.Start.Byte,
+				},
+				End: jsonentities.Pos{
+					Line:   rng.End.Line,
+					Column: rng.End.Column,
+					Byte:   rng.End.Byte,
+				},
+			}
+		}
+		if len(jsonRef.References) == 0 {
+			jsonRef.References = nil
+		}
+		jsonRef.Action = resourceAction(ref, changes)
+		ret = append(ret, jsonRef)
+	}
+	return ret
+}
+
+func referenceKind(subject addrs.Referenceable) ReferenceKind {
+	switch subject := subject.(type) {
+	case addrs.InputVariable:
+		return InputVariable
+	case addrs.LocalValue:
+		return LocalValue
+	case addrs.ModuleCallInstanceOutput:
+		return ModuleOutput
+	case addrs.ModuleCall, addrs.ModuleCallInstance:
+		return ModuleCall
+	case addrs.Resource:
+		return resourceKind(subject.Mode)
+	case addrs.ResourceInstance:
+		return resourceKind(subject.Resource.Mode)
+	case addrs.CountAttr:
+		return CountIndex
+	case addrs.ForEachAttr:
+		return ForEachValue
+	default:
+		return Other
+	}
+}
+
+func resourceKind(mode addrs.ResourceMode) ReferenceKind {
+	if mode == addrs.DataResourceMode {
+		return DataResource
+	}
+	return ManagedResource
+}
+
+// resourceAction returns the planned action for the resource that the given
+// reference refers to, or an empty string if it doesn't refer to a resource
+// or the plan has no change for it.
+func resourceAction(ref globalref.Reference, changes *plans.Changes) jsonentities.ChangeAction {
+	var rcs []*plans.ResourceInstanceChangeSrc
+	switch subject := ref.LocalRef.Subject.(type) {
+	case addrs.Resource:
+		rcs = changes.InstancesForAbsResource(subject.Absolute(ref.ModuleAddr()))
+	case addrs.ResourceInstance:
+		if rc := changes.ResourceInstance(subject.Absolute(ref.ModuleAddr())); rc != nil {
+			rcs = append(rcs, rc)
+		}
+	}
+	if len(rcs) == 0 {
+		return ""
+	}
+	for _, rc := range rcs {
+		if rc.Action != plans.NoOp {
+			return jsonentities.ParseChangeAction(rc.Action)
+		}
+	}
+	return jsonentities.ActionNoOp
+}
@@ -0,0 +1,58 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package jsonexplain
+
+import (
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+func TestReferenceKind(t *testing.T) {
+	tests := map[string]ReferenceKind{
+		"var.foo":                        InputVariable,
+		"local.foo":                      LocalValue,
+		"module.foo":                     ModuleCall,
+		"module.foo.bar":                 ModuleOutput,
+		"test_instance.foo":              ManagedResource,
+		"test_instance.foo[0]":           ManagedResource,
+		"data.test_data_source.foo":      DataResource,
+		`data.test_data_source.foo["a"]`: DataResource,
+		"count.index":                    CountIndex,
+		"each.value":                     ForEachValue,
+		"path.module":                    Other,
+	}
+
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			ref, diags := addrs.ParseRefStr(input)
+			if diags.HasErrors() {
+				t.Fatalf("invalid reference: %s", diags.Err())
+			}
+			if got := referenceKind(ref.Subject); got != want {
+				t.Errorf("wrong kind\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestRequiresReplace(t *testing.T) {
+	paths := cty.NewPathSet(
+		cty.GetAttrPath("ami"),
+		cty.GetAttrPath("network_interface").IndexInt(0).GetAttr("device_index"),
+	)
+
+	tests := map[string]bool{
+		"ami":               true,
+		"network_interface": true,
+		"id":                false,
+	}
+	for name, want := range tests {
+		if got := requiresReplace(paths, name); got != want {
+			t.Errorf("wrong result for %q: got %t, want %t", name, got, want)
+		}
+	}
+}
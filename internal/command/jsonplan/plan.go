@@ -67,6 +67,7 @@ type Plan struct {
 	PriorState         json.RawMessage   `json:"prior_state,omitempty"`
 	Config             json.RawMessage   `json:"configuration,omitempty"`
 	RelevantAttributes []ResourceAttr    `json:"relevant_attributes,omitempty"`
+	DeferredChanges    []DeferredChange  `json:"deferred_changes,omitempty"`
 	Checks             json.RawMessage   `json:"checks,omitempty"`
 	Timestamp          string            `json:"timestamp,omitempty"`
 	Errored            bool              `json:"errored"`
@@ -85,6 +86,18 @@ type ResourceAttr struct {
 	Attr     json.RawMessage `json:"attribute"`
 }
 
+// DeferredChange describes a resource or module call that was left out of the
+// plan because its instances could not be determined yet.
+type DeferredChange struct {
+	// Address is the address of the resource or module call, which may be
+	// either an absolute resource address or an absolute module instance
+	// address.
+	Address string `json:"address"`
+
+	// Reason is one of "unknown_count" or "unknown_for_each".
+	Reason string `json:"reason"`
+}
+
 // Change is the representation of a proposed change for an object.
 type Change struct {
 	// Actions are the actions that will be taken on the object selected by the
@@ -276,6 +289,8 @@ func MarshalForLog(
 		return nil, fmt.Errorf("error marshaling relevant attributes for external changes: %w", err)
 	}
 
+	output.DeferredChanges = MarshalDeferredChanges(p.DeferredChanges)
+
 	// output.ResourceChanges
 	if p.Changes != nil {
 		output.ResourceChanges, err = MarshalResourceChanges(p.Changes.Resources, schemas)
@@ -795,6 +810,19 @@ func (p *Plan) marshalRelevantAttrs(plan *plans.Plan) error {
 	return nil
 }
 
+// MarshalDeferredChanges returns the JSON representation of the given
+// deferred changes.
+func MarshalDeferredChanges(deferred []*plans.DeferredChange) []DeferredChange {
+	var ret []DeferredChange
+	for _, dc := range deferred {
+		ret = append(ret, DeferredChange{
+			Address: dc.Addr.String(),
+			Reason:  dc.Reason.JSONName(),
+		})
+	}
+	return ret
+}
+
 // omitUnknowns recursively walks the src cty.Value and returns a new cty.Value,
 // omitting any unknowns.
 //
@@ -0,0 +1,533 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package jsonplandiff
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/command/jsonentities"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states/statefile"
+)
+
+// FormatVersion represents the version of the json format and will be
+// incremented for any change to this format that requires changes to a
+// consuming parser.
+const FormatVersion = "1.0"
+
+// Plan is the content of a saved plan file that takes part in a comparison.
+type Plan struct {
+	// Path is the path of the saved plan file, used only to describe the
+	// plan to the user.
+	Path string
+
+	Plan       *plans.Plan
+	PriorState *statefile.File
+	Locks      *depsfile.Locks
+}
+
+// Difference describes how an item differs between the two plans that were
+// compared. The first plan is usually the one that was reviewed, and the
+// second one a plan that was created again later.
+type Difference string
+
+const (
+	// Added means that the item is only present in the second plan.
+	Added Difference = "added"
+
+	// Removed means that the item is only present in the first plan.
+	Removed Difference = "removed"
+
+	// Changed means that the item is present in both plans but differs.
+	Changed Difference = "changed"
+)
+
+// Diff is the top-level object describing the differences between two
+// saved plans.
+type Diff struct {
+	FormatVersion string `json:"format_version"`
+	PlanA         string `json:"plan_a"`
+	PlanB         string `json:"plan_b"`
+
+	// Equivalent is true if no differences were found, in which case all of
+	// the other fields are empty.
+	Equivalent bool `json:"equivalent"`
+
+	ResourceChanges []*ResourceChange `json:"resource_changes,omitempty"`
+	DeferredChanges []*DeferredChange `json:"deferred_changes,omitempty"`
+	OutputChanges   []*OutputChange   `json:"output_changes,omitempty"`
+	Options         []*Option         `json:"options,omitempty"`
+	Variables       []*Variable       `json:"variables,omitempty"`
+	Providers       []*Provider       `json:"providers,omitempty"`
+	PriorState      *PriorState       `json:"prior_state,omitempty"`
+}
+
+// ResourceChange describes a planned change to a resource instance object
+// that is only present in one of the plans, or whose action, planned values
+// or other properties differ between them.
+type ResourceChange struct {
+	Address    string     `json:"address"`
+	Deposed    string     `json:"deposed,omitempty"`
+	Difference Difference `json:"difference"`
+
+	// ActionA and ActionB are the planned actions in each plan, and are
+	// omitted for a plan that doesn't include the change.
+	ActionA jsonentities.ChangeAction `json:"action_a,omitempty"`
+	ActionB jsonentities.ChangeAction `json:"action_b,omitempty"`
+
+	// ValuesDiffer is true if the change is present in both plans but the
+	// prior or planned values of the object differ.
+	ValuesDiffer bool `json:"values_differ,omitempty"`
+
+	// PropertiesDiffer lists the other properties of the change that differ
+	// between the plans, if it is present in both.
+	PropertiesDiffer []ChangeProperty `json:"properties_differ,omitempty"`
+}
+
+// ChangeProperty is a property of a planned change to a resource instance
+// object, other than its action and values, that affects how it is applied.
+type ChangeProperty string
+
+const (
+	// PropertyProvider is the provider configuration that applies the
+	// change.
+	PropertyProvider ChangeProperty = "provider"
+
+	// PropertyPreviousAddress is the address the object had in the previous
+	// run, which differs from its current address if it is being moved.
+	PropertyPreviousAddress ChangeProperty = "previous_address"
+
+	// PropertyImporting is the ID or identity of an object being imported.
+	PropertyImporting ChangeProperty = "importing"
+
+	// PropertyActionReason is the reason OpenTofu gives for the action.
+	PropertyActionReason ChangeProperty = "action_reason"
+
+	// PropertyReplacePaths are the attributes that require replacing the
+	// object.
+	PropertyReplacePaths ChangeProperty = "replace_paths"
+
+	// PropertyGeneratedConfig is the configuration generated for an object
+	// being imported.
+	PropertyGeneratedConfig ChangeProperty = "generated_config"
+
+	// PropertyIdentity is the prior or planned identity of the object.
+	PropertyIdentity ChangeProperty = "identity"
+)
+
+// DeferredChange describes an object whose planning was deferred in only one
+// of the plans, or for a different reason in each.
+type DeferredChange struct {
+	Address    string     `json:"address"`
+	Difference Difference `json:"difference"`
+
+	// ReasonA and ReasonB are the reasons the object was deferred in each
+	// plan, and are omitted for a plan that didn't defer it.
+	ReasonA string `json:"reason_a,omitempty"`
+	ReasonB string `json:"reason_b,omitempty"`
+}
+
+// OutputChange describes a planned change to a root module output value that
+// is only present in one of the plans, or whose action or planned value
+// differs between them.
+type OutputChange struct {
+	Address    string                    `json:"address"`
+	Difference Difference                `json:"difference"`
+	ActionA    jsonentities.ChangeAction `json:"action_a,omitempty"`
+	ActionB    jsonentities.ChangeAction `json:"action_b,omitempty"`
+
+	ValuesDiffer bool `json:"values_differ,omitempty"`
+}
+
+// Option describes a plan-wide option that the plans were created with, and
+// whose value differs between them. Options with several values are sorted.
+type Option struct {
+	Name   OptionName `json:"name"`
+	ValueA []string   `json:"value_a"`
+	ValueB []string   `json:"value_b"`
+}
+
+// OptionName identifies a plan-wide option in an [Option].
+type OptionName string
+
+const (
+	// OptionMode is the planning mode, such as normal, destroy or
+	// refresh-only.
+	OptionMode OptionName = "mode"
+
+	// OptionTarget are the addresses given with -target.
+	OptionTarget OptionName = "target"
+
+	// OptionExclude are the addresses given with -exclude.
+	OptionExclude OptionName = "exclude"
+
+	// OptionReplace are the addresses given with -replace.
+	OptionReplace OptionName = "replace"
+)
+
+// Variable describes a root module input variable whose value differs
+// between the plans. The values themselves are not included, because they
+// may be sensitive.
+type Variable struct {
+	Name       string     `json:"name"`
+	Difference Difference `json:"difference"`
+}
+
+// Provider describes a provider whose selected version, as recorded in the
+// dependency lock file snapshot of each plan, differs between the plans.
+type Provider struct {
+	Address    string     `json:"address"`
+	Difference Difference `json:"difference"`
+	VersionA   string     `json:"version_a,omitempty"`
+	VersionB   string     `json:"version_b,omitempty"`
+}
+
+// PriorState describes the state snapshots that each plan was created from,
+// and is only present if they differ.
+type PriorState struct {
+	SerialA  uint64 `json:"serial_a"`
+	SerialB  uint64 `json:"serial_b"`
+	LineageA string `json:"lineage_a"`
+	LineageB string `json:"lineage_b"`
+}
+
+// NewDiff compares the two given plans.
+func NewDiff(a, b *Plan) *Diff {
+	ret := &Diff{
+		FormatVersion:   FormatVersion,
+		PlanA:           a.Path,
+		PlanB:           b.Path,
+		ResourceChanges: diffResourceChanges(a.Plan.Changes, b.Plan.Changes),
+		DeferredChanges: diffDeferredChanges(a.Plan.DeferredChanges, b.Plan.DeferredChanges),
+		OutputChanges:   diffOutputChanges(a.Plan.Changes, b.Plan.Changes),
+		Options:         diffOptions(a.Plan, b.Plan),
+		Variables:       diffVariables(a.Plan.VariableValues, b.Plan.VariableValues),
+		Providers:       diffProviders(a.Locks, b.Locks),
+		PriorState:      diffPriorState(a.PriorState, b.PriorState),
+	}
+	ret.Equivalent = len(ret.ResourceChanges) == 0 &&
+		len(ret.DeferredChanges) == 0 &&
+		len(ret.OutputChanges) == 0 &&
+		len(ret.Options) == 0 &&
+		len(ret.Variables) == 0 &&
+		len(ret.Providers) == 0 &&
+		ret.PriorState == nil
+	return ret
+}
+
+func diffResourceChanges(a, b *plans.Changes) []*ResourceChange {
+	type key struct {
+		addr, deposed string
+	}
+	index := func(changes *plans.Changes) map[key]*plans.ResourceInstanceChangeSrc {
+		ret := make(map[key]*plans.ResourceInstanceChangeSrc)
+		if changes == nil {
+			return ret
+		}
+		for _, rc := range changes.Resources {
+			// A no-op change that doesn't move or import anything is
+			// equivalent to the plan not mentioning the object at all.
+			if rc.Action == plans.NoOp && !rc.Moved() && rc.Importing == nil {
+				continue
+			}
+			ret[key{rc.Addr.String(), rc.DeposedKey.String()}] = rc
+		}
+		return ret
+	}
+	as, bs := index(a), index(b)
+
+	keys := make([]key, 0, len(as)+len(bs))
+	for k := range as {
+		keys = append(keys, k)
+	}
+	for k := range bs {
+		if _, ok := as[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].addr != keys[j].addr {
+			return keys[i].addr < keys[j].addr
+		}
+		return keys[i].deposed < keys[j].deposed
+	})
+
+	var ret []*ResourceChange
+	for _, k := range keys {
+		ac, bc := as[k], bs[k]
+		rc := &ResourceChange{
+			Address: k.addr,
+			Deposed: k.deposed,
+		}
+		if ac != nil {
+			rc.ActionA = jsonentities.NewResourceInstanceChange(ac).Action
+		}
+		if bc != nil {
+			rc.ActionB = jsonentities.NewResourceInstanceChange(bc).Action
+		}
+		switch {
+		case ac == nil:
+			rc.Difference = Added
+		case bc == nil:
+			rc.Difference = Removed
+		default:
+			rc.ValuesDiffer = !changeValuesEqual(&ac.ChangeSrc, &bc.ChangeSrc)
+			rc.PropertiesDiffer = diffChangeProperties(ac, bc)
+			if rc.ActionA == rc.ActionB && ac.Action == bc.Action && !rc.ValuesDiffer && len(rc.PropertiesDiffer) == 0 {
+				continue
+			}
+			rc.Difference = Changed
+		}
+		ret = append(ret, rc)
+	}
+	return ret
+}
+
+// diffChangeProperties returns the properties other than the action and
+// values that differ between the two changes to the same object.
+func diffChangeProperties(a, b *plans.ResourceInstanceChangeSrc) []ChangeProperty {
+	var ret []ChangeProperty
+	if a.ProviderAddr.String() != b.ProviderAddr.String() {
+		ret = append(ret, PropertyProvider)
+	}
+	if !a.PrevRunAddr.Equal(b.PrevRunAddr) {
+		ret = append(ret, PropertyPreviousAddress)
+	}
+	if !importingEqual(a.Importing, b.Importing) {
+		ret = append(ret, PropertyImporting)
+	}
+	if a.ActionReason != b.ActionReason {
+		ret = append(ret, PropertyActionReason)
+	}
+	if !a.RequiredReplace.Equal(b.RequiredReplace) {
+		ret = append(ret, PropertyReplacePaths)
+	}
+	if a.GeneratedConfig != b.GeneratedConfig {
+		ret = append(ret, PropertyGeneratedConfig)
+	}
+	if !bytes.Equal(a.BeforeIdentity, b.BeforeIdentity) || !bytes.Equal(a.AfterIdentity, b.AfterIdentity) {
+		ret = append(ret, PropertyIdentity)
+	}
+	return ret
+}
+
+func importingEqual(a, b *plans.ImportingSrc) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.ID == b.ID && bytes.Equal(a.Identity, b.Identity)
+}
+
+func diffDeferredChanges(a, b []*plans.DeferredChange) []*DeferredChange {
+	index := func(changes []*plans.DeferredChange) map[string]string {
+		ret := make(map[string]string, len(changes))
+		for _, dc := range changes {
+			ret[dc.Addr.String()] = dc.Reason.JSONName()
+		}
+		return ret
+	}
+	as, bs := index(a), index(b)
+
+	var ret []*DeferredChange
+	for _, addr := range unionKeys(as, bs) {
+		ar, aok := as[addr]
+		br, bok := bs[addr]
+		dc := &DeferredChange{Address: addr, ReasonA: ar, ReasonB: br}
+		switch {
+		case !aok:
+			dc.Difference = Added
+		case !bok:
+			dc.Difference = Removed
+		case ar != br:
+			dc.Difference = Changed
+		default:
+			continue
+		}
+		ret = append(ret, dc)
+	}
+	return ret
+}
+
+func diffOptions(a, b *plans.Plan) []*Option {
+	var ret []*Option
+	add := func(name OptionName, av, bv []string) {
+		if !slices.Equal(av, bv) {
+			ret = append(ret, &Option{Name: name, ValueA: av, ValueB: bv})
+		}
+	}
+	add(OptionMode, []string{a.UIMode.UIName()}, []string{b.UIMode.UIName()})
+	add(OptionTarget, sortedStrings(a.TargetAddrs), sortedStrings(b.TargetAddrs))
+	add(OptionExclude, sortedStrings(a.ExcludeAddrs), sortedStrings(b.ExcludeAddrs))
+	add(OptionReplace, sortedStrings(a.ForceReplaceAddrs), sortedStrings(b.ForceReplaceAddrs))
+	return ret
+}
+
+func diffOutputChanges(a, b *plans.Changes) []*OutputChange {
+	index := func(changes *plans.Changes) map[string]*plans.OutputChangeSrc {
+		ret := make(map[string]*plans.OutputChangeSrc)
+		if changes == nil {
+			return ret
+		}
+		for _, oc := range changes.Outputs {
+			if !oc.Addr.Module.IsRoot() || oc.Action == plans.NoOp {
+				continue
+			}
+			ret[oc.Addr.String()] = oc
+		}
+		return ret
+	}
+	as, bs := index(a), index(b)
+
+	var ret []*OutputChange
+	for _, addr := range unionKeys(as, bs) {
+		ac, bc := as[addr], bs[addr]
+		oc := &OutputChange{Address: addr}
+		if ac != nil {
+			oc.ActionA = jsonentities.ParseChangeAction(ac.Action)
+		}
+		if bc != nil {
+			oc.ActionB = jsonentities.ParseChangeAction(bc.Action)
+		}
+		switch {
+		case ac == nil:
+			oc.Difference = Added
+		case bc == nil:
+			oc.Difference = Removed
+		default:
+			oc.ValuesDiffer = !changeValuesEqual(&ac.ChangeSrc, &bc.ChangeSrc) || ac.Sensitive != bc.Sensitive
+			if ac.Action == bc.Action && !oc.ValuesDiffer {
+				continue
+			}
+			oc.Difference = Changed
+		}
+		ret = append(ret, oc)
+	}
+	return ret
+}
+
+func diffVariables(a, b map[string]plans.DynamicValue) []*Variable {
+	var ret []*Variable
+	for _, name := range unionKeys(a, b) {
+		av, aok := a[name]
+		bv, bok := b[name]
+		switch {
+		case !aok:
+			ret = append(ret, &Variable{Name: name, Difference: Added})
+		case !bok:
+			ret = append(ret, &Variable{Name: name, Difference: Removed})
+		case !bytes.Equal(av, bv):
+			ret = append(ret, &Variable{Name: name, Difference: Changed})
+		}
+	}
+	return ret
+}
+
+func diffProviders(a, b *depsfile.Locks) []*Provider {
+	versions := func(locks *depsfile.Locks) map[string]string {
+		ret := make(map[string]string)
+		if locks == nil {
+			return ret
+		}
+		for addr, lock := range locks.AllProviders() {
+			ret[addr.String()] = lock.Version().String()
+		}
+		return ret
+	}
+	as, bs := versions(a), versions(b)
+
+	var ret []*Provider
+	for _, addr := range unionKeys(as, bs) {
+		av, aok := as[addr]
+		bv, bok := bs[addr]
+		p := &Provider{Address: addr, VersionA: av, VersionB: bv}
+		switch {
+		case !aok:
+			p.Difference = Added
+		case !bok:
+			p.Difference = Removed
+		case av != bv:
+			p.Difference = Changed
+		default:
+			continue
+		}
+		ret = append(ret, p)
+	}
+	return ret
+}
+
+func diffPriorState(a, b *statefile.File) *PriorState {
+	var ret PriorState
+	if a != nil {
+		ret.SerialA, ret.LineageA = a.Serial, a.Lineage
+	}
+	if b != nil {
+		ret.SerialB, ret.LineageB = b.Serial, b.Lineage
+	}
+	if ret.SerialA == ret.SerialB && ret.LineageA == ret.LineageB {
+		return nil
+	}
+	return &ret
+}
+
+// changeValuesEqual returns true if the prior and planned values of the two
+// changes are identical, including which parts of them are sensitive.
+//
+// Values are compared in their serialized form, which is deterministic for a
+// given value and type.
+func changeValuesEqual(a, b *plans.ChangeSrc) bool {
+	if !bytes.Equal(a.Before, b.Before) || !bytes.Equal(a.After, b.After) {
+		return false
+	}
+	return marksEqual(a.BeforeValMarks, b.BeforeValMarks) && marksEqual(a.AfterValMarks, b.AfterValMarks)
+}
+
+// marksEqual returns true if the two sets of marks are equal, regardless of
+// their order.
+func marksEqual(a, b []cty.PathValueMarks) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, am := range a {
+		found := false
+		for _, bm := range b {
+			if am.Equal(bm) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedStrings[T fmt.Stringer](items []T) []string {
+	ret := make([]string, len(items))
+	for i, item := range items {
+		ret[i] = item.String()
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	ret := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		ret = append(ret, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			ret = append(ret, k)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
@@ -0,0 +1,336 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package jsonplandiff
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/command/jsonentities"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/states/statefile"
+)
+
+func TestNewDiff_equivalent(t *testing.T) {
+	a := testPlan(t, "a.tfplan", 1, "1.0.0", map[string]cty.Value{
+		"test_instance.foo": cty.StringVal("foo"),
+	})
+	b := testPlan(t, "b.tfplan", 1, "1.0.0", map[string]cty.Value{
+		"test_instance.foo": cty.StringVal("foo"),
+	})
+
+	got := NewDiff(a, b)
+	want := &Diff{
+		FormatVersion: FormatVersion,
+		PlanA:         "a.tfplan",
+		PlanB:         "b.tfplan",
+		Equivalent:    true,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+}
+
+func TestNewDiff_differences(t *testing.T) {
+	a := testPlan(t, "a.tfplan", 1, "1.0.0", map[string]cty.Value{
+		"test_instance.changed": cty.StringVal("foo"),
+		"test_instance.removed": cty.StringVal("foo"),
+		"test_instance.same":    cty.StringVal("foo"),
+	})
+	a.Plan.VariableValues["region"] = mustDynamicValue(t, cty.StringVal("eu-west-1"))
+	a.Plan.VariableValues["removed"] = mustDynamicValue(t, cty.True)
+	b := testPlan(t, "b.tfplan", 2, "1.1.0", map[string]cty.Value{
+		"test_instance.added":   cty.StringVal("foo"),
+		"test_instance.changed": cty.StringVal("bar"),
+		"test_instance.same":    cty.StringVal("foo"),
+	})
+	b.Plan.VariableValues["region"] = mustDynamicValue(t, cty.StringVal("us-east-1"))
+
+	// Replacing an object instead of updating it is a different action.
+	for _, rc := range b.Plan.Changes.Resources {
+		if rc.Addr.String() == "test_instance.same" {
+			rc.Action = plans.DeleteThenCreate
+		}
+	}
+
+	got := NewDiff(a, b)
+	want := &Diff{
+		FormatVersion: FormatVersion,
+		PlanA:         "a.tfplan",
+		PlanB:         "b.tfplan",
+		ResourceChanges: []*ResourceChange{
+			{
+				Address:    "test_instance.added",
+				Difference: Added,
+				ActionB:    jsonentities.ActionUpdate,
+			},
+			{
+				Address:      "test_instance.changed",
+				Difference:   Changed,
+				ActionA:      jsonentities.ActionUpdate,
+				ActionB:      jsonentities.ActionUpdate,
+				ValuesDiffer: true,
+			},
+			{
+				Address:    "test_instance.removed",
+				Difference: Removed,
+				ActionA:    jsonentities.ActionUpdate,
+			},
+			{
+				Address:    "test_instance.same",
+				Difference: Changed,
+				ActionA:    jsonentities.ActionUpdate,
+				ActionB:    jsonentities.ActionReplace,
+			},
+		},
+		Variables: []*Variable{
+			{Name: "region", Difference: Changed},
+			{Name: "removed", Difference: Removed},
+		},
+		Providers: []*Provider{
+			{
+				Address:    "registry.opentofu.org/hashicorp/test",
+				Difference: Changed,
+				VersionA:   "1.0.0",
+				VersionB:   "1.1.0",
+			},
+		},
+		PriorState: &PriorState{
+			SerialA:  1,
+			SerialB:  2,
+			LineageA: "lineage",
+			LineageB: "lineage",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+}
+
+func TestNewDiff_outputs(t *testing.T) {
+	a := testPlan(t, "a.tfplan", 1, "1.0.0", nil)
+	b := testPlan(t, "b.tfplan", 1, "1.0.0", nil)
+	a.Plan.Changes.Outputs = []*plans.OutputChangeSrc{
+		testOutputChange(t, "changed", cty.StringVal("foo"), false),
+		testOutputChange(t, "sensitive", cty.StringVal("foo"), false),
+	}
+	b.Plan.Changes.Outputs = []*plans.OutputChangeSrc{
+		testOutputChange(t, "changed", cty.StringVal("bar"), false),
+		testOutputChange(t, "sensitive", cty.StringVal("foo"), true),
+	}
+
+	got := NewDiff(a, b)
+	want := []*OutputChange{
+		{
+			Address:      "output.changed",
+			Difference:   Changed,
+			ActionA:      jsonentities.ActionCreate,
+			ActionB:      jsonentities.ActionCreate,
+			ValuesDiffer: true,
+		},
+		{
+			Address:      "output.sensitive",
+			Difference:   Changed,
+			ActionA:      jsonentities.ActionCreate,
+			ActionB:      jsonentities.ActionCreate,
+			ValuesDiffer: true,
+		},
+	}
+	if diff := cmp.Diff(want, got.OutputChanges); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+	if got.Equivalent {
+		t.Error("plans with different outputs should not be equivalent")
+	}
+}
+
+func TestNewDiff_providerConfig(t *testing.T) {
+	a := testPlan(t, "a.tfplan", 1, "1.0.0", map[string]cty.Value{
+		"test_instance.foo": cty.StringVal("foo"),
+	})
+	b := testPlan(t, "b.tfplan", 1, "1.0.0", map[string]cty.Value{
+		"test_instance.foo": cty.StringVal("foo"),
+	})
+	// Same action and values, but applied with another provider
+	// configuration.
+	b.Plan.Changes.Resources[0].ProviderAddr.Alias = "other"
+
+	got := NewDiff(a, b)
+	want := []*ResourceChange{
+		{
+			Address:          "test_instance.foo",
+			Difference:       Changed,
+			ActionA:          jsonentities.ActionUpdate,
+			ActionB:          jsonentities.ActionUpdate,
+			PropertiesDiffer: []ChangeProperty{PropertyProvider},
+		},
+	}
+	if diff := cmp.Diff(want, got.ResourceChanges); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+	if got.Equivalent {
+		t.Error("plans using different provider configurations should not be equivalent")
+	}
+}
+
+func TestNewDiff_moveSource(t *testing.T) {
+	a := testPlan(t, "a.tfplan", 1, "1.0.0", map[string]cty.Value{
+		"test_instance.foo": cty.StringVal("foo"),
+	})
+	b := testPlan(t, "b.tfplan", 1, "1.0.0", map[string]cty.Value{
+		"test_instance.foo": cty.StringVal("foo"),
+	})
+	// Same action and values, but the object is moved from another address.
+	prev, diags := addrs.ParseAbsResourceInstanceStr("test_instance.old")
+	if diags.HasErrors() {
+		t.Fatal(diags.Err())
+	}
+	b.Plan.Changes.Resources[0].PrevRunAddr = prev
+
+	got := NewDiff(a, b)
+	want := []*ResourceChange{
+		{
+			Address:          "test_instance.foo",
+			Difference:       Changed,
+			ActionA:          jsonentities.ActionUpdate,
+			ActionB:          jsonentities.ActionUpdate,
+			PropertiesDiffer: []ChangeProperty{PropertyPreviousAddress},
+		},
+	}
+	if diff := cmp.Diff(want, got.ResourceChanges); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+	if got.Equivalent {
+		t.Error("plans moving objects from different addresses should not be equivalent")
+	}
+}
+
+func TestNewDiff_importing(t *testing.T) {
+	a := testPlan(t, "a.tfplan", 1, "1.0.0", map[string]cty.Value{
+		"test_instance.foo": cty.StringVal("foo"),
+	})
+	b := testPlan(t, "b.tfplan", 1, "1.0.0", map[string]cty.Value{
+		"test_instance.foo": cty.StringVal("foo"),
+	})
+	a.Plan.Changes.Resources[0].Importing = &plans.ImportingSrc{ID: "i-abc123"}
+	b.Plan.Changes.Resources[0].Importing = &plans.ImportingSrc{ID: "i-def456"}
+	b.Plan.Changes.Resources[0].ActionReason = plans.ResourceInstanceReplaceBecauseTainted
+
+	got := NewDiff(a, b)
+	want := []ChangeProperty{PropertyImporting, PropertyActionReason}
+	if len(got.ResourceChanges) != 1 {
+		t.Fatalf("wrong number of resource changes %d; want 1", len(got.ResourceChanges))
+	}
+	if diff := cmp.Diff(want, got.ResourceChanges[0].PropertiesDiffer); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+}
+
+func TestNewDiff_planOptions(t *testing.T) {
+	a := testPlan(t, "a.tfplan", 1, "1.0.0", nil)
+	b := testPlan(t, "b.tfplan", 1, "1.0.0", nil)
+	target, diags := addrs.ParseTargetStr("test_instance.foo")
+	if diags.HasErrors() {
+		t.Fatal(diags.Err())
+	}
+	b.Plan.UIMode = plans.DestroyMode
+	b.Plan.TargetAddrs = []addrs.Targetable{target.Subject}
+	b.Plan.DeferredChanges = []*plans.DeferredChange{
+		{Addr: target.Subject, Reason: plans.DeferredBecauseUnknownCount},
+	}
+
+	got := NewDiff(a, b)
+	wantOptions := []*Option{
+		{Name: OptionMode, ValueA: []string{"normal"}, ValueB: []string{"destroy"}},
+		{Name: OptionTarget, ValueA: []string{}, ValueB: []string{"test_instance.foo"}},
+	}
+	if diff := cmp.Diff(wantOptions, got.Options); diff != "" {
+		t.Errorf("wrong options\n%s", diff)
+	}
+	wantDeferred := []*DeferredChange{
+		{Address: "test_instance.foo", Difference: Added, ReasonB: "unknown_count"},
+	}
+	if diff := cmp.Diff(wantDeferred, got.DeferredChanges); diff != "" {
+		t.Errorf("wrong deferred changes\n%s", diff)
+	}
+	if got.Equivalent {
+		t.Error("plans with different options should not be equivalent")
+	}
+}
+
+func testPlan(t *testing.T, path string, serial uint64, providerVersion string, resources map[string]cty.Value) *Plan {
+	t.Helper()
+
+	provider := addrs.NewDefaultProvider("test")
+	changes := plans.NewChanges()
+	for addrStr, value := range resources {
+		addr, diags := addrs.ParseAbsResourceInstanceStr(addrStr)
+		if diags.HasErrors() {
+			t.Fatal(diags.Err())
+		}
+		before := cty.ObjectVal(map[string]cty.Value{"value": cty.StringVal("before")})
+		after := cty.ObjectVal(map[string]cty.Value{"value": value})
+		changes.Resources = append(changes.Resources, &plans.ResourceInstanceChangeSrc{
+			Addr:        addr,
+			PrevRunAddr: addr,
+			DeposedKey:  states.NotDeposed,
+			ProviderAddr: addrs.AbsProviderConfig{
+				Provider: provider,
+				Module:   addrs.RootModule,
+			},
+			ChangeSrc: plans.ChangeSrc{
+				Action: plans.Update,
+				Before: mustDynamicValue(t, before),
+				After:  mustDynamicValue(t, after),
+			},
+		})
+	}
+
+	locks := depsfile.NewLocks()
+	locks.SetProvider(provider, getproviders.MustParseVersion(providerVersion), nil, nil)
+
+	return &Plan{
+		Path: path,
+		Plan: &plans.Plan{
+			Changes:        changes,
+			VariableValues: map[string]plans.DynamicValue{},
+		},
+		PriorState: &statefile.File{
+			Serial:  serial,
+			Lineage: "lineage",
+			State:   states.NewState(),
+		},
+		Locks: locks,
+	}
+}
+
+func testOutputChange(t *testing.T, name string, value cty.Value, sensitive bool) *plans.OutputChangeSrc {
+	t.Helper()
+
+	return &plans.OutputChangeSrc{
+		Addr: addrs.OutputValue{Name: name}.Absolute(addrs.RootModuleInstance),
+		ChangeSrc: plans.ChangeSrc{
+			Action: plans.Create,
+			Before: mustDynamicValue(t, cty.NullVal(cty.DynamicPseudoType)),
+			After:  mustDynamicValue(t, value),
+		},
+		Sensitive: sensitive,
+	}
+}
+
+func mustDynamicValue(t *testing.T, v cty.Value) plans.DynamicValue {
+	t.Helper()
+
+	ret, err := plans.NewDynamicValue(v, cty.DynamicPseudoType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ret
+}
@@ -0,0 +1,6 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package jsonplandiff implements the comparison of two saved plans produced
+// by the "tofu plan diff" command, and its JSON representation.
+package jsonplandiff
@@ -36,6 +36,8 @@ import (
 	"github.com/opentofu/opentofu/internal/configs/configload"
 	"github.com/opentofu/opentofu/internal/getmodules"
 	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/lang/funcplugin"
+	"github.com/opentofu/opentofu/internal/plans/planfile"
 	"github.com/opentofu/opentofu/internal/plugins"
 	"github.com/opentofu/opentofu/internal/providers"
 	"github.com/opentofu/opentofu/internal/provisioners"
@@ -91,6 +93,39 @@ type Meta struct {
 	// longer any compelling reasons for folks to not lock their dependencies.
 	PluginCacheMayBreakDependencyLockFile bool
 
+	// PluginCacheReadOnly, if set, prevents provider installation from
+	// writing to the global plugin cache directory, so that it can only be
+	// used to link in providers that are already present there.
+	PluginCacheReadOnly bool
+
+	// ProviderInstallConcurrency limits how many providers the provider
+	// installer will query or fetch at the same time. Zero means no limit.
+	ProviderInstallConcurrency int
+
+	// DefaultRetryPolicy, if set, is the policy for retrying failed changes
+	// to managed resources that don't have a retry policy of their own, as
+	// set in the CLI configuration.
+	DefaultRetryPolicy *configs.RetryPolicy
+
+	// SavedPlanRequirements, if set, are the requirements that a saved plan
+	// must meet before it can be applied, as set in the CLI configuration.
+	SavedPlanRequirements *planfile.Requirements
+
+	// ProviderConcurrency and ResourceTypeConcurrency limit how many
+	// operations on resource instances of a particular provider or resource
+	// type can run at once, as set in the CLI configuration.
+	ProviderConcurrency     map[addrs.Provider]int
+	ResourceTypeConcurrency map[string]int
+
+	// ExternalHooks are the hooks configured by "hook" blocks in the CLI
+	// configuration, which run alongside the built-in hooks for operations
+	// that a backend runs locally.
+	ExternalHooks []tofu.Hook
+
+	// FunctionPlugins are the function plugins configured by
+	// "function_plugin" blocks in the CLI configuration, keyed by name.
+	FunctionPlugins map[string]*funcplugin.Plugin
+
 	// ProviderSource allows determining the available versions of a provider
 	// and determines where a distribution package for a particular
 	// provider version can be obtained.
@@ -389,6 +424,10 @@ func (m *Meta) contextOpts(ctx context.Context) (*tofu.ContextOpts, error) {
 
 	opts.UIInput = m.UIInput()
 	opts.Parallelism = m.parallelism
+	opts.DefaultRetryPolicy = m.DefaultRetryPolicy
+	opts.ProviderConcurrency = m.ProviderConcurrency
+	opts.ResourceTypeConcurrency = m.ResourceTypeConcurrency
+	opts.FunctionPlugins = m.FunctionPlugins
 
 	// If testingOverrides are set, we'll skip the plugin discovery process
 	// and just work with what we've been given, thus allowing the tests
@@ -428,7 +428,13 @@ func (m *Meta) backendCLIOpts(ctx context.Context) (*backend.CLIOpts, error) {
 	if contextOpts == nil && err != nil {
 		return nil, err
 	}
+	contextOpts.Hooks = m.ExternalHooks
+	var dataDir string
+	if m.WorkingDir != nil {
+		dataDir = m.WorkingDir.DataDir()
+	}
 	return &backend.CLIOpts{
+		DataDir:             dataDir,
 		View:                views.NewBackendRemote(m.View),
 		StateArgs:           m.stateArgs,
 		ContextOpts:         contextOpts,
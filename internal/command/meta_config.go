@@ -247,6 +247,8 @@ func (m *Meta) installModules(ctx context.Context, rootDir, testsDir string, upg
 	}
 
 	inst := initwd.NewModuleInstaller(m.WorkingDir.ModulesDir(), loader, m.registryClient(ctx), m.ModulePackageFetcher)
+	inst.SetMaxConcurrency(m.SystemCfg.ModuleInstallConcurrency)
+	inst.SetGlobalCacheDir(m.SystemCfg.ModuleCacheDir)
 	if m.NewRuntimeEnabled() {
 		// Tell the module installer it should use
 		// the configuration for the new runtime instead
@@ -69,9 +69,11 @@ func (m *Meta) providerInstallerCustomSource(source getproviders.Source) *provid
 	targetDir := providercache.NewDir(m.WorkingDir.ProviderLocalCacheDir())
 	globalCacheDir := m.providerGlobalCacheDir()
 	inst := providercache.NewInstaller(targetDir, source)
+	inst.SetMaxConcurrency(m.ProviderInstallConcurrency)
 	if globalCacheDir != nil {
 		inst.SetGlobalCacheDir(globalCacheDir)
 		inst.SetGlobalCacheDirMayBreakDependencyLockFile(m.PluginCacheMayBreakDependencyLockFile)
+		inst.SetGlobalCacheDirReadOnly(m.PluginCacheReadOnly)
 	}
 	var builtinProviderTypes []string
 	for ty := range m.internalProviders() {
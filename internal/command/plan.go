@@ -96,6 +96,8 @@ func (c *PlanCommand) Run(rawArgs []string) int {
 		view.Diagnostics(diags)
 		return 1
 	}
+	opReq.PolicyDir = args.PolicyDir
+	opReq.PlanExpiresIn = args.ExpiresIn
 
 	// Before we delegate to the backend, we'll print any warning diagnostics
 	// we've accumulated here, since the backend will start fresh with its own
@@ -164,6 +166,7 @@ func (c *PlanCommand) OperationRequest(
 	opReq.Targets = args.Targets
 	opReq.Excludes = args.Excludes
 	opReq.ForceReplace = args.ForceReplace
+	opReq.AllowDeferral = args.AllowDeferral
 	opReq.Type = backend.OperationTypePlan
 	opReq.View = view.Operation()
 
@@ -236,6 +239,13 @@ Plan Customization Options:
   -exclude-file=filename  Similar to -exclude, but specifies zero or more
                           resource addresses from a file.
 
+  -allow-deferral         If the "count" or "for_each" argument of a resource
+                          or module call depends on values that won't be known
+                          until apply, defer planning that object and anything
+                          that depends on it instead of failing, so that the
+                          rest of the changes can be applied first. Cannot be
+                          used alongside the -target option.
+
   -var 'foo=bar'          Set a value for one of the input variables in the
                           root module of the configuration. Use this option
                           more than once to set more than one variable.
@@ -265,6 +275,11 @@ Other Options:
                                  1 - Planning failed with an error
                                  2 - Succeeded and changes are proposed
 
+  -expires-in=duration         Record that the plan saved with -out can no
+                               longer be applied after the given duration,
+                               such as "4h". "tofu apply" refuses to apply
+                               an expired plan.
+
   -generate-config-out=path    (Experimental) If import blocks are present in
                                configuration, instructs OpenTofu to generate
                                HCL for any imported resources not already
@@ -293,6 +308,12 @@ Other Options:
   -parallelism=n               Limit the number of concurrent operations.
                                Defaults to 10.
 
+  -policy=dir                  Evaluate the policies in the *.tfpolicy.hcl
+                               files in the given directory against the plan.
+                               A failing mandatory policy makes the plan fail,
+                               and its result is recorded in any saved plan so
+                               that the plan can't be applied.
+
   -state=statefile             A legacy option used for the local backend only.
                                Refer to the local backend's documentation for
                                more information.
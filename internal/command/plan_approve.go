@@ -0,0 +1,147 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"os/user"
+	"strings"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/plans/planfile"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// PlanApproveCommand is a Command implementation that adds a signed approval
+// to a saved plan file.
+type PlanApproveCommand struct {
+	Meta
+}
+
+func (c *PlanApproveCommand) Run(rawArgs []string) int {
+	ctx := c.CommandContext()
+
+	// Parse and apply global view arguments
+	common, rawArgs := arguments.ParseView(rawArgs)
+	c.View.Configure(common)
+
+	// Parse and validate flags
+	args, diags := arguments.ParsePlanApprove(rawArgs)
+
+	view := views.NewPlanApprove(c.View)
+
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		view.HelpPrompt()
+		return 1
+	}
+
+	// Inject variables from args into meta for static evaluation
+	c.Meta.variableArgs = args.Vars.All()
+
+	approver := args.Approver
+	if approver == "" {
+		current, err := user.Current()
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Failed to determine approver",
+				fmt.Sprintf("The name of the current user could not be determined: %s. Use the -approver option to specify the name to record.", err),
+			))
+			view.Diagnostics(diags)
+			return 1
+		}
+		approver = current.Username
+	}
+
+	key, err := planfile.LoadApprovalKey(args.KeyPath)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to load approval key",
+			err.Error(),
+		))
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	// Load the encryption configuration, which is needed to read and write
+	// encrypted plan files.
+	enc, encDiags := c.Encryption(ctx)
+	diags = diags.Append(encDiags)
+	if encDiags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	fail := func(err error) int {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			fmt.Sprintf("Failed to approve %q", args.PlanPath),
+			fmt.Sprintf("Error: %s", err),
+		))
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	pf, err := c.PlanFile(args.PlanPath, enc.Plan())
+	if err != nil {
+		return fail(err)
+	}
+	if pf == nil {
+		return fail(fmt.Errorf("the specified path is a directory, not a plan file"))
+	}
+	if _, ok := pf.Local(); !ok {
+		return fail(fmt.Errorf("plans created by a remote operation cannot be approved"))
+	}
+
+	approval, err := planfile.Approve(args.PlanPath, key, approver, time.Now(), enc.Plan())
+	if err != nil {
+		return fail(err)
+	}
+
+	view.Diagnostics(diags)
+	view.Approved(args.PlanPath, approval)
+	return 0
+}
+
+func (c *PlanApproveCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] plan approve [options] PLAN
+
+  Records that the saved plan file PLAN has been reviewed and approved, by
+  adding an approval signed with the given key to the plan file.
+
+  When the "saved_plans" block of the CLI configuration requires approval,
+  "tofu apply" only applies a saved plan that has an approval signed with
+  one of the configured approval keys. Any change to the plan file after it
+  was approved invalidates the approval.
+
+Options:
+
+  -key=path               The key to sign the approval with: either a file
+                          containing a shared secret of at least 32 bytes,
+                          or a PEM-encoded Ed25519 private key. Required.
+
+  -approver=name          The name to record as the approver of the plan.
+                          Defaults to the name of the current user.
+
+  -var 'foo=bar'          Set a variable in the OpenTofu configuration. This
+                          flag can be set multiple times. Variables are only
+                          used to evaluate the encryption configuration
+                          needed to read and write encrypted plan files.
+
+  -var-file=foo           Set variables in the OpenTofu configuration from
+                          a file. If "terraform.tfvars" or any ".auto.tfvars"
+                          files are present, they will be automatically
+                          loaded.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *PlanApproveCommand) Synopsis() string {
+	return "Approve a saved plan"
+}
@@ -0,0 +1,160 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/command/workdir"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/plans/planfile"
+)
+
+func TestPlanApprove(t *testing.T) {
+	testCwdTemp(t)
+
+	planPath := applyFixturePlanFile(t)
+	keyPath := testPlanApproveKey(t)
+
+	view, done := testView(t)
+	c := &PlanApproveCommand{
+		Meta: Meta{
+			WorkingDir: workdir.NewDir("."),
+			View:       view,
+		},
+	}
+
+	code := c.Run([]string{"-key", keyPath, "-approver", "alice", planPath})
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("unexpected exit code %d\n\n%s", code, output.Stderr())
+	}
+	if got, want := output.Stdout(), "approved by alice"; !strings.Contains(got, want) {
+		t.Fatalf("output does not contain %q:\n%s", want, got)
+	}
+
+	key, err := planfile.LoadApprovalKey(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pf, err := planfile.Open(planPath, encryption.PlanEncryptionDisabled())
+	if err != nil {
+		t.Fatal(err)
+	}
+	approvals, err := pf.VerifiedApprovals([]*planfile.ApprovalKey{key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(approvals) != 1 || approvals[0].Approver != "alice" {
+		t.Fatalf("wrong approvals: %#v", approvals)
+	}
+}
+
+func TestPlanApprove_missingKey(t *testing.T) {
+	testCwdTemp(t)
+
+	planPath := applyFixturePlanFile(t)
+
+	view, done := testView(t)
+	c := &PlanApproveCommand{
+		Meta: Meta{
+			WorkingDir: workdir.NewDir("."),
+			View:       view,
+		},
+	}
+
+	code := c.Run([]string{"-key", "nonexistent.key", planPath})
+	output := done(t)
+	if code != 1 {
+		t.Fatalf("unexpected exit code %d\n\n%s", code, output.Stdout())
+	}
+	if got, want := output.Stderr(), "Failed to load approval key"; !strings.Contains(got, want) {
+		t.Fatalf("error output does not contain %q:\n%s", want, got)
+	}
+}
+
+func TestApply_planRequiresApproval(t *testing.T) {
+	testCwdTemp(t)
+
+	keyPath := testPlanApproveKey(t)
+	key, err := planfile.LoadApprovalKey(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs := &planfile.Requirements{
+		RequireApproval: true,
+		ApprovalKeys:    []*planfile.ApprovalKey{key},
+	}
+
+	planPath := applyFixturePlanFile(t)
+	statePath := testTempFile(t)
+
+	p := applyFixtureProvider()
+	view, done := testView(t)
+	c := &ApplyCommand{
+		Meta: Meta{
+			WorkingDir:            workdir.NewDir("."),
+			testingOverrides:      metaOverridesForProvider(p),
+			View:                  view,
+			SavedPlanRequirements: reqs,
+		},
+	}
+
+	code := c.Run([]string{"-state-out", statePath, planPath})
+	output := done(t)
+	if code != 1 {
+		t.Fatalf("unexpected exit code %d\n\n%s", code, output.Stdout())
+	}
+	if got, want := output.Stderr(), "Saved plan is not approved"; !strings.Contains(got, want) {
+		t.Fatalf("error output does not contain %q:\n%s", want, got)
+	}
+	if p.ApplyResourceChangeCalled {
+		t.Fatal("unapproved plan was applied")
+	}
+
+	view, done = testView(t)
+	approve := &PlanApproveCommand{
+		Meta: Meta{
+			WorkingDir: workdir.NewDir("."),
+			View:       view,
+		},
+	}
+	if code := approve.Run([]string{"-key", keyPath, "-approver", "alice", planPath}); code != 0 {
+		t.Fatalf("failed to approve plan\n\n%s", done(t).Stderr())
+	}
+	done(t)
+
+	view, done = testView(t)
+	c = &ApplyCommand{
+		Meta: Meta{
+			WorkingDir:            workdir.NewDir("."),
+			testingOverrides:      metaOverridesForProvider(p),
+			View:                  view,
+			SavedPlanRequirements: reqs,
+		},
+	}
+
+	code = c.Run([]string{"-state-out", statePath, planPath})
+	output = done(t)
+	if code != 0 {
+		t.Fatalf("unexpected exit code %d\n\n%s", code, output.Stderr())
+	}
+	if !p.ApplyResourceChangeCalled {
+		t.Fatal("approved plan was not applied")
+	}
+}
+
+// testPlanApproveKey writes an approval key for signing approvals with
+// HMAC-SHA256, returning its path.
+func testPlanApproveKey(t *testing.T) string {
+	t.Helper()
+
+	path := testTempFile(t)
+	if err := os.WriteFile(path, []byte("a-shared-secret-for-testing-plan-approvals\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,169 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/jsonplandiff"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// PlanDiffCommand is a Command implementation that compares two saved plan
+// files.
+type PlanDiffCommand struct {
+	Meta
+}
+
+func (c *PlanDiffCommand) Run(rawArgs []string) int {
+	ctx := c.CommandContext()
+
+	// Parse and apply global view arguments
+	common, rawArgs := arguments.ParseView(rawArgs)
+	c.View.Configure(common)
+
+	// Parse and validate flags
+	args, closer, diags := arguments.ParsePlanDiff(rawArgs)
+	defer closer()
+
+	// Instantiate the view, even if there are flag errors, so that we render
+	// diagnostics according to the desired view
+	view := views.NewPlanDiff(args.ViewOptions, c.View)
+
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		view.HelpPrompt()
+		return 1
+	}
+
+	// Inject variables from args into meta for static evaluation
+	c.Meta.variableArgs = args.Vars.All()
+
+	// Load the encryption configuration, which is needed to read encrypted
+	// plan files.
+	enc, encDiags := c.Encryption(ctx)
+	diags = diags.Append(encDiags)
+	if encDiags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	planA, moreDiags := c.readPlan(args.PlanA, enc)
+	diags = diags.Append(moreDiags)
+	planB, moreDiags := c.readPlan(args.PlanB, enc)
+	diags = diags.Append(moreDiags)
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	diff := jsonplandiff.NewDiff(planA, planB)
+	if code := view.Display(diff); code != 0 {
+		return code
+	}
+	if !diff.Equivalent {
+		return 2
+	}
+	return 0
+}
+
+// readPlan reads the parts of a local saved plan file that take part in the
+// comparison.
+func (c *PlanDiffCommand) readPlan(path string, enc encryption.Encryption) (*jsonplandiff.Plan, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	fail := func(err error) (*jsonplandiff.Plan, tfdiags.Diagnostics) {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			fmt.Sprintf("Failed to load %q as a plan file", path),
+			fmt.Sprintf("Error: %s", err),
+		))
+		return nil, diags
+	}
+
+	pf, err := c.PlanFile(path, enc.Plan())
+	if err != nil {
+		return fail(err)
+	}
+	if pf == nil {
+		return fail(fmt.Errorf("the specified path is a directory, not a plan file"))
+	}
+	lp, ok := pf.Local()
+	if !ok {
+		return fail(fmt.Errorf("plans created by a remote operation cannot be compared"))
+	}
+
+	plan, err := lp.ReadPlan()
+	if err != nil {
+		return fail(err)
+	}
+	priorState, err := lp.ReadStateFile()
+	if err != nil {
+		return fail(err)
+	}
+	locks, moreDiags := lp.ReadDependencyLocks()
+	diags = diags.Append(moreDiags)
+	if moreDiags.HasErrors() {
+		return nil, diags
+	}
+
+	return &jsonplandiff.Plan{
+		Path:       path,
+		Plan:       plan,
+		PriorState: priorState,
+		Locks:      locks,
+	}, diags
+}
+
+func (c *PlanDiffCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] plan diff [options] PLAN_A PLAN_B
+
+  Compares two saved plan files and reports any differences that would make
+  applying one of them have a different effect than applying the other.
+
+  This is intended for automation that must decide whether a plan created
+  again after review is the same as the plan that was reviewed, with PLAN_A
+  being the reviewed plan and PLAN_B the new one.
+
+  The comparison covers the planned changes to resource instances and root
+  module output values, the values of input variables, the versions of the
+  providers selected in the dependency lock file, and the state snapshot that
+  each plan was created from.
+
+  The exit code describes the outcome:
+    0 - The plans are equivalent
+    1 - The plans could not be compared because of an error
+    2 - The plans differ
+
+Options:
+
+  -json                   Produce the comparison in a machine-readable JSON
+                          format, suitable for use in automated systems.
+                          Always disables color.
+
+  -json-into=out.json     Produce the same output as -json, but sent directly
+                          to the given file, while the human-readable output
+                          is still written to the terminal.
+
+  -var 'foo=bar'          Set a variable in the OpenTofu configuration. This
+                          flag can be set multiple times. Variables are only
+                          used to evaluate the encryption configuration
+                          needed to read encrypted plan files.
+
+  -var-file=foo           Set variables in the OpenTofu configuration from
+                          a file. If "terraform.tfvars" or any ".auto.tfvars"
+                          files are present, they will be automatically
+                          loaded.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *PlanDiffCommand) Synopsis() string {
+	return "Compare two saved plans"
+}
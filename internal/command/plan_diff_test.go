@@ -0,0 +1,194 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/command/jsonplandiff"
+	"github.com/opentofu/opentofu/internal/command/workdir"
+	"github.com/opentofu/opentofu/internal/configs/configload"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+func TestPlanDiff_equivalent(t *testing.T) {
+	testCwdTemp(t)
+
+	planA := testPlanDiffPlanFile(t, "ami-123", 1)
+	planB := testPlanDiffPlanFile(t, "ami-123", 1)
+
+	view, done := testView(t)
+	c := &PlanDiffCommand{
+		Meta: Meta{
+			WorkingDir: workdir.NewDir("."),
+			View:       view,
+		},
+	}
+
+	code := c.Run([]string{"-no-color", planA, planB})
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("unexpected exit code %d\n\n%s%s", code, output.Stdout(), output.Stderr())
+	}
+	if got, want := output.Stdout(), "are equivalent"; !strings.Contains(got, want) {
+		t.Fatalf("output does not contain %q:\n%s", want, got)
+	}
+}
+
+func TestPlanDiff_differences(t *testing.T) {
+	testCwdTemp(t)
+
+	planA := testPlanDiffPlanFile(t, "ami-123", 1)
+	planB := testPlanDiffPlanFile(t, "ami-456", 2)
+
+	view, done := testView(t)
+	c := &PlanDiffCommand{
+		Meta: Meta{
+			WorkingDir: workdir.NewDir("."),
+			View:       view,
+		},
+	}
+
+	code := c.Run([]string{"-no-color", planA, planB})
+	output := done(t)
+	if code != 2 {
+		t.Fatalf("unexpected exit code %d\n\n%s%s", code, output.Stdout(), output.Stderr())
+	}
+	for _, want := range []string{
+		"differ.",
+		"test_instance.foo: create with different values",
+		"var.ami: value differs",
+		"serial: 1 in " + planA + ", 2 in " + planB,
+	} {
+		if got := output.Stdout(); !strings.Contains(got, want) {
+			t.Errorf("output does not contain %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestPlanDiff_json(t *testing.T) {
+	testCwdTemp(t)
+
+	planA := testPlanDiffPlanFile(t, "ami-123", 1)
+	planB := testPlanDiffPlanFile(t, "ami-456", 1)
+
+	view, done := testView(t)
+	c := &PlanDiffCommand{
+		Meta: Meta{
+			WorkingDir: workdir.NewDir("."),
+			View:       view,
+		},
+	}
+
+	code := c.Run([]string{"-json", planA, planB})
+	output := done(t)
+	if code != 2 {
+		t.Fatalf("unexpected exit code %d\n\n%s%s", code, output.Stdout(), output.Stderr())
+	}
+
+	var got jsonplandiff.Diff
+	if err := json.Unmarshal([]byte(output.Stdout()), &got); err != nil {
+		t.Fatalf("invalid JSON output: %s\n%s", err, output.Stdout())
+	}
+	if got.Equivalent {
+		t.Error("plans should not be equivalent")
+	}
+	if len(got.ResourceChanges) != 1 || !got.ResourceChanges[0].ValuesDiffer {
+		t.Errorf("wrong resource changes: %s", output.Stdout())
+	}
+	if len(got.Variables) != 1 || got.Variables[0].Name != "ami" {
+		t.Errorf("wrong variables: %s", output.Stdout())
+	}
+	if got.PriorState != nil {
+		t.Errorf("unexpected prior state difference: %s", output.Stdout())
+	}
+}
+
+func TestPlanDiff_notAPlan(t *testing.T) {
+	testCwdTemp(t)
+
+	planA := testPlanDiffPlanFile(t, "ami-123", 1)
+
+	view, done := testView(t)
+	c := &PlanDiffCommand{
+		Meta: Meta{
+			WorkingDir: workdir.NewDir("."),
+			View:       view,
+		},
+	}
+
+	code := c.Run([]string{planA, "nonexistent.tfplan"})
+	output := done(t)
+	if code != 1 {
+		t.Fatalf("unexpected exit code %d\n\n%s", code, output.Stdout())
+	}
+	if got, want := output.Stderr(), `Failed to load "nonexistent.tfplan" as a plan file`; !strings.Contains(got, want) {
+		t.Fatalf("error output does not contain %q:\n%s", want, got)
+	}
+}
+
+// testPlanDiffPlanFile creates a plan file that creates a single resource
+// instance with the given ami, which is also recorded as the value of the
+// "ami" variable, from a prior state with the given serial.
+func testPlanDiffPlanFile(t *testing.T, ami string, serial uint64) string {
+	t.Helper()
+
+	snap := &configload.Snapshot{
+		Modules: map[string]*configload.SnapshotModule{
+			"": {
+				Dir: ".",
+				Files: map[string][]byte{
+					"main.tf": nil,
+				},
+			},
+		},
+	}
+
+	plan := testPlan(t)
+	amiVal, err := plans.NewDynamicValue(cty.StringVal(ami), cty.DynamicPseudoType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan.VariableValues = map[string]plans.DynamicValue{"ami": amiVal}
+
+	ty := cty.Object(map[string]cty.Type{"ami": cty.String})
+	before, err := plans.NewDynamicValue(cty.NullVal(ty), ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := plans.NewDynamicValue(cty.ObjectVal(map[string]cty.Value{"ami": cty.StringVal(ami)}), ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "test_instance",
+		Name: "foo",
+	}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance)
+	plan.Changes.Resources = append(plan.Changes.Resources, &plans.ResourceInstanceChangeSrc{
+		Addr:        addr,
+		PrevRunAddr: addr,
+		ProviderAddr: addrs.AbsProviderConfig{
+			Provider: addrs.NewDefaultProvider("test"),
+			Module:   addrs.RootModule,
+		},
+		ChangeSrc: plans.ChangeSrc{
+			Action: plans.Create,
+			Before: before,
+			After:  after,
+		},
+	})
+
+	return testPlanFileMatchState(t, snap, states.NewState(), plan, statemgr.SnapshotMeta{
+		Lineage: "plan-diff",
+		Serial:  serial,
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"sync"
 
 	"github.com/mitchellh/cli"
 	"github.com/opentofu/opentofu/internal/addrs"
@@ -243,9 +244,25 @@ func (c *ProvidersLockCommand) Run(rawArgs []string) int {
 	// Because our Installer abstraction is a per-platform idea, we'll
 	// instantiate one for each of the platforms the user requested, and then
 	// merge all of the generated locks together at the end.
+	//
+	// The installers for each platform run concurrently. They are all clones
+	// of a single prototype so that they share the configured concurrency
+	// limit, rather than each being allowed that many workers of its own.
+	protoInstaller := providercache.NewInstaller(nil, source)
+	protoInstaller.SetMaxConcurrency(c.ProviderInstallConcurrency)
 	updatedLocks := map[getproviders.Platform]*depsfile.Locks{}
 	selectedVersions := map[addrs.Provider]getproviders.Version{}
-	for _, platform := range platforms {
+	// resultsMu guards updatedLocks, selectedVersions, and diags while the
+	// per-platform installers are running, and also serializes calls into
+	// the view from the installer events.
+	var resultsMu sync.Mutex
+	var wg sync.WaitGroup
+
+	// We create all of the temporary directories before starting any of the
+	// installers, so that we don't need to wait for the installers that
+	// were already started if we fail to create one.
+	tempDirs := make([]string, 0, len(platforms))
+	for range platforms {
 		tempDir, err := os.MkdirTemp("", "terraform-providers-lock")
 		if err != nil {
 			diags = diags.Append(tfdiags.Sourceless(
@@ -256,11 +273,23 @@ func (c *ProvidersLockCommand) Run(rawArgs []string) int {
 			break
 		}
 		defer os.RemoveAll(tempDir)
+		tempDirs = append(tempDirs, tempDir)
+	}
+	if diags.HasErrors() {
+		tracing.SetSpanError(span, diags)
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	for i, platform := range platforms {
+		tempDir := tempDirs[i]
 
 		evts := &providercache.InstallerEvents{
 			// Our output from this command is minimal just to show that
 			// we're making progress, rather than just silently hanging.
 			FetchPackageBegin: func(provider addrs.Provider, version getproviders.Version, loc getproviders.PackageLocation, inCacheDirectory bool) {
+				resultsMu.Lock()
+				defer resultsMu.Unlock()
 				view.InstallationFetching(provider.ForDisplay(), version.String(), platform.String())
 				if prevVersion, exists := selectedVersions[provider]; exists && version != prevVersion {
 					// This indicates a weird situation where we ended up
@@ -287,6 +316,8 @@ func (c *ProvidersLockCommand) Run(rawArgs []string) int {
 				selectedVersions[provider] = version
 			},
 			FetchPackageSuccess: func(provider addrs.Provider, version getproviders.Version, localDir string, auth *getproviders.PackageAuthenticationResult) {
+				resultsMu.Lock()
+				defer resultsMu.Unlock()
 				var keyID string
 				if auth != nil && auth.Signed() {
 					keyID = auth.GPGKeyIDsString()
@@ -294,24 +325,28 @@ func (c *ProvidersLockCommand) Run(rawArgs []string) int {
 				view.FetchPackageSuccess(keyID, provider.ForDisplay(), version.String(), platform.String(), auth.String())
 			},
 		}
-		// Ensure that events emitted on multiple routines do not trigger race conditions
-		evts = evts.Sync()
 		ctx := evts.OnContext(ctx)
 
 		dir := providercache.NewDirWithPlatform(tempDir, platform)
-		installer := providercache.NewInstaller(dir, source)
+		installer := protoInstaller.Clone(dir)
 
-		newLocks, err := installer.EnsureProviderVersions(ctx, oldLocks, reqs, providercache.InstallNewProvidersForce)
-		if err != nil {
-			diags = diags.Append(tfdiags.Sourceless(
-				tfdiags.Error,
-				"Could not retrieve providers for locking",
-				fmt.Sprintf("OpenTofu failed to fetch the requested providers for %s in order to calculate their checksums: %s.", platform, err),
-			))
-			break
-		}
-		updatedLocks[platform] = newLocks
+		wg.Go(func() {
+			newLocks, err := installer.EnsureProviderVersions(ctx, oldLocks, reqs, providercache.InstallNewProvidersForce)
+
+			resultsMu.Lock()
+			defer resultsMu.Unlock()
+			if err != nil {
+				diags = diags.Append(tfdiags.Sourceless(
+					tfdiags.Error,
+					"Could not retrieve providers for locking",
+					fmt.Sprintf("OpenTofu failed to fetch the requested providers for %s in order to calculate their checksums: %s.", platform, err),
+				))
+				return
+			}
+			updatedLocks[platform] = newLocks
+		})
 	}
+	wg.Wait()
 
 	// If we have any error diagnostics from installation then we won't
 	// proceed to merging and updating the lock file on disk.
@@ -342,7 +377,14 @@ func (c *ProvidersLockCommand) Run(rawArgs []string) int {
 			constraints = oldLock.VersionConstraints()
 			hashes = append(hashes, oldLock.AllHashes()...)
 		}
-		for platform, platformLocks := range updatedLocks {
+		// We visit the platforms in the order they were requested, rather
+		// than the order the installers finished in, so that the output
+		// is consistent between runs.
+		for _, platform := range platforms {
+			platformLocks, ok := updatedLocks[platform]
+			if !ok {
+				continue
+			}
 			platformLock := platformLocks.Provider(provider)
 			if platformLock == nil {
 				continue // weird, but we'll tolerate it to avoid crashing
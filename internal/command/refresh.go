@@ -145,6 +145,7 @@ func (c *RefreshCommand) OperationRequest(ctx context.Context, be backend.Enhanc
 	opReq.Hooks = view.Hooks()
 	opReq.Targets = args.Targets
 	opReq.Excludes = args.Excludes
+	opReq.AllowDeferral = args.AllowDeferral
 	opReq.Type = backend.OperationTypeRefresh
 	opReq.View = view.Operation()
 
@@ -183,6 +184,11 @@ Options:
                          will be performed. All locations, for all errors
                          will be listed. Disabled by default
 
+  -allow-deferral        If the instances of a resource or module cannot be
+                         determined yet, skip it and everything that depends
+                         on it rather than failing. Cannot be used alongside
+                         the -target flag.
+
   -exclude=resource      Resource to exclude. Operation will be limited to all
                          resources that are not excluded or dependent on excluded
                          resources. This flag can be used multiple times. Cannot
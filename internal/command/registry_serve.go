@@ -0,0 +1,160 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/registry/localserver"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// RegistryServeCommand is a Command implementation that implements the
+// "tofu registry serve" command, which publishes the modules and providers
+// in a local directory using the registry and network mirror protocols.
+type RegistryServeCommand struct {
+	Meta
+}
+
+func (c *RegistryServeCommand) Synopsis() string {
+	return "Serve modules and providers from a local directory"
+}
+
+func (c *RegistryServeCommand) Run(rawArgs []string) int {
+	common, rawArgs := arguments.ParseView(rawArgs)
+	c.View.Configure(common)
+
+	args, closer, diags := arguments.ParseRegistryServe(rawArgs)
+	defer closer()
+
+	view := views.NewRegistryServe(args.ViewOptions, c.View)
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		if args.ViewOptions.ViewType == arguments.ViewJSON {
+			return 1 // in case it's json, do not print the help of the command
+		}
+		return cli.RunResultHelp
+	}
+
+	if info, err := os.Stat(args.Directory); err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid registry directory",
+			fmt.Sprintf("Cannot read the directory given in the -dir option: %s.", err),
+		))
+	} else if !info.IsDir() {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid registry directory",
+			fmt.Sprintf("The path %s given in the -dir option is not a directory.", args.Directory),
+		))
+	}
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	listener, err := net.Listen("tcp", args.Address)
+	if err != nil {
+		view.Diagnostics(diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to start registry server",
+			fmt.Sprintf("Cannot listen on %s: %s.", args.Address, err),
+		)))
+		return 1
+	}
+
+	server := &http.Server{
+		Handler:           localserver.New(args.Directory),
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+
+	// The server runs until it's interrupted, at which point we allow any
+	// in-progress requests a little time to complete.
+	ctx, done := c.InterruptibleContext(c.CommandContext())
+	defer done()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	scheme := "http"
+	if args.TLSCertFile != "" {
+		scheme = "https"
+	}
+	view.Serving(args.Directory, scheme+"://"+listener.Addr().String())
+
+	if args.TLSCertFile != "" {
+		err = server.ServeTLS(listener, args.TLSCertFile, args.TLSKeyFile)
+	} else {
+		err = server.Serve(listener)
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		view.Diagnostics(diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Registry server failed",
+			err.Error(),
+		)))
+		return 1
+	}
+
+	view.Stopped()
+	return 0
+}
+
+func (c *RegistryServeCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] registry serve -dir=PATH [options]
+
+  Serves the modules and providers in a local directory using the module
+  registry protocol, the provider registry protocol, and the provider
+  network mirror protocol. This is intended for testing and for environments
+  without access to a public registry.
+
+  The directory must use the following layout:
+
+    modules/NAMESPACE/NAME/SYSTEM/VERSION/
+    modules/NAMESPACE/NAME/SYSTEM/VERSION.zip (or .tar.gz)
+    providers/NAMESPACE/TYPE/terraform-provider-TYPE_VERSION_OS_ARCH.zip
+
+  Providers served through the registry protocol must also have a signed
+  checksums file and a signing-key.asc file alongside the packages. Unsigned
+  providers can be installed using the network mirror protocol instead, at
+  the path /v1/mirror/.
+
+  OpenTofu requires HTTPS for registry service discovery and for network
+  mirrors, so in most cases the -tls-cert and -tls-key options must be used.
+
+Options:
+
+  -dir=PATH           The directory containing the modules and providers to
+                      serve. Required.
+
+  -address=ADDR       The address to listen on. Defaults to localhost:8080.
+
+  -tls-cert=FILE      A PEM-encoded certificate to serve HTTPS with.
+
+  -tls-key=FILE       The PEM-encoded private key for the -tls-cert
+                      certificate.
+
+  -json               Produce output in a machine-readable JSON format,
+                      suitable for use in text editor integrations and other
+                      automated systems.
+
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+// More thorough tests for the server itself can be found in the
+// internal/registry/localserver package.
+func TestRegistryServe(t *testing.T) {
+	t.Run("serve until interrupted", func(t *testing.T) {
+		view, done := testView(t)
+		shutdownCh := make(chan struct{})
+		c := &RegistryServeCommand{
+			Meta: Meta{
+				View:       view,
+				ShutdownCh: shutdownCh,
+			},
+		}
+		close(shutdownCh)
+		code := c.Run([]string{"-no-color", "-dir", t.TempDir(), "-address", "127.0.0.1:0"})
+		output := done(t)
+		if code != 0 {
+			t.Fatalf("wrong exit code. expected 0, got %d\ngot output:\n%s", code, output.All())
+		}
+		got := output.Stdout()
+		if !strings.Contains(got, "Serving modules and providers from") || !strings.Contains(got, "Registry server stopped.") {
+			t.Errorf("unexpected output:\n%s", got)
+		}
+	})
+
+	t.Run("missing dir option", func(t *testing.T) {
+		view, done := testView(t)
+		c := &RegistryServeCommand{
+			Meta: Meta{
+				View: view,
+			},
+		}
+		code := c.Run([]string{"-no-color"})
+		output := done(t)
+		if code != cli.RunResultHelp {
+			t.Fatalf("wrong exit code. expected %d, got %d", cli.RunResultHelp, code)
+		}
+		if got := output.Stderr(); !strings.Contains(got, "Error: Missing directory") {
+			t.Fatalf("missing directory error from output, got:\n%s\n", got)
+		}
+	})
+
+	t.Run("nonexistent dir", func(t *testing.T) {
+		view, done := testView(t)
+		c := &RegistryServeCommand{
+			Meta: Meta{
+				View: view,
+			},
+		}
+		code := c.Run([]string{"-no-color", "-dir", filepath.Join(t.TempDir(), "nope")})
+		output := done(t)
+		if code != 1 {
+			t.Fatalf("wrong exit code. expected 1, got %d", code)
+		}
+		if got := output.Stderr(); !strings.Contains(got, "Error: Invalid registry directory") {
+			t.Fatalf("missing directory error from output, got:\n%s\n", got)
+		}
+	})
+}
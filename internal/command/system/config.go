@@ -37,6 +37,15 @@ type Config struct {
 	// into the given directory.
 	PluginCacheDir string
 
+	// ModuleCacheDir, if non-empty, enables caching of downloaded module
+	// packages that were selected by version into the given directory.
+	ModuleCacheDir string
+
+	// ModuleInstallConcurrency limits how many module packages the module
+	// installer will fetch at the same time. Zero selects the installer's
+	// default limit.
+	ModuleInstallConcurrency int
+
 	// GlobalPluginDirs contains additional paths to search for plugins
 	GlobalPluginDirs []string
 
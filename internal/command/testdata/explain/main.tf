@@ -0,0 +1,25 @@
+variable "ami_version" {
+  type    = string
+  default = "2024"
+}
+
+data "test_data_source" "base" {
+  id = "base"
+}
+
+locals {
+  ami = "ami-${var.ami_version}-${data.test_data_source.base.id}"
+}
+
+resource "test_instance" "upstream" {
+  ami = "upstream"
+}
+
+resource "test_instance" "web" {
+  ami = local.ami
+
+  network_interface {
+    device_index = "0"
+    description  = test_instance.upstream.id
+  }
+}
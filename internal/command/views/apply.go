@@ -11,6 +11,7 @@ import (
 	"github.com/opentofu/opentofu/internal/command/arguments"
 	"github.com/opentofu/opentofu/internal/command/format"
 	"github.com/opentofu/opentofu/internal/command/views/json"
+	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/opentofu/opentofu/internal/tofu"
@@ -19,6 +20,7 @@ import (
 // The Apply view is used for the apply command.
 type Apply interface {
 	ResourceCount(stateOutPath string)
+	ApplyReport(report *tofu.ApplyReport)
 	Outputs(outputValues map[string]*states.OutputValue)
 
 	Operation() Operation
@@ -72,6 +74,12 @@ func (m ApplyMulti) ResourceCount(stateOutPath string) {
 	}
 }
 
+func (m ApplyMulti) ApplyReport(report *tofu.ApplyReport) {
+	for _, a := range m {
+		a.ApplyReport(report)
+	}
+}
+
 func (m ApplyMulti) Outputs(outputValues map[string]*states.OutputValue) {
 	for _, a := range m {
 		a.Outputs(outputValues)
@@ -173,6 +181,39 @@ func (v *ApplyHuman) ResourceCount(stateOutPath string) {
 	}
 }
 
+func (v *ApplyHuman) ApplyReport(report *tofu.ApplyReport) {
+	v.view.streams.Printf(
+		v.view.colorize.Color("[reset][bold]\nApply report: %d succeeded, %d failed, %d skipped.\n"),
+		report.Count(tofu.ApplySucceeded),
+		report.Count(tofu.ApplyFailed),
+		report.Count(tofu.ApplySkipped),
+	)
+	if failed := report.Filter(tofu.ApplyFailed); len(failed) != 0 {
+		v.view.streams.Print(v.view.colorize.Color("\n[bold][red]Failed:[reset]\n"))
+		for _, change := range failed {
+			v.view.streams.Printf("  - %s\n", applyReportChangeName(change))
+		}
+	}
+	if skipped := report.Skipped(tofu.ApplySkipDependencyFailed); len(skipped) != 0 {
+		v.view.streams.Print(v.view.colorize.Color("\n[bold][yellow]Skipped because something they depend on failed:[reset]\n"))
+		for _, change := range skipped {
+			v.view.streams.Printf("  - %s\n", applyReportChangeName(change))
+		}
+	}
+	if skipped := report.Skipped(tofu.ApplySkipInterrupted); len(skipped) != 0 {
+		v.view.streams.Print(v.view.colorize.Color("\n[bold][yellow]Skipped because the apply was interrupted:[reset]\n"))
+		for _, change := range skipped {
+			v.view.streams.Printf("  - %s\n", applyReportChangeName(change))
+		}
+	}
+	if skipped := report.Skipped(tofu.ApplySkipNotAttempted); len(skipped) != 0 {
+		v.view.streams.Print(v.view.colorize.Color("\n[bold][yellow]Not attempted:[reset]\n"))
+		for _, change := range skipped {
+			v.view.streams.Printf("  - %s\n", applyReportChangeName(change))
+		}
+	}
+}
+
 func (v *ApplyHuman) Outputs(outputValues map[string]*states.OutputValue) {
 	if len(outputValues) > 0 {
 		v.view.streams.Print(v.view.colorize.Color("[reset][bold][green]\nOutputs:\n\n"))
@@ -235,6 +276,26 @@ func (v *ApplyJSON) ResourceCount(stateOutPath string) {
 	})
 }
 
+func (v *ApplyJSON) ApplyReport(report *tofu.ApplyReport) {
+	for _, change := range report.Filter(tofu.ApplySkipped) {
+		var reason string
+		switch change.SkipReason {
+		case tofu.ApplySkipInterrupted:
+			reason = json.ApplySkippedInterrupted
+		case tofu.ApplySkipNotAttempted:
+			reason = json.ApplySkippedNotAttempted
+		default:
+			reason = json.ApplySkippedDependencyFailed
+		}
+		v.view.Hook(json.NewApplySkipped(change.Addr, change.Action, reason))
+	}
+	v.view.ApplyReport(&json.ApplyReport{
+		Succeeded: report.Count(tofu.ApplySucceeded),
+		Failed:    report.Count(tofu.ApplyFailed),
+		Skipped:   report.Count(tofu.ApplySkipped),
+	})
+}
+
 func (v *ApplyJSON) Outputs(outputValues map[string]*states.OutputValue) {
 	outputs, diags := json.OutputsFromMap(outputValues)
 	if diags.HasErrors() {
@@ -267,3 +328,23 @@ func (v *ApplyJSON) Backend() Backend {
 		view: v.view,
 	}
 }
+
+// applyReportChangeName returns a description of the given change for the
+// human-readable apply report, such as "aws_instance.foo (create)".
+func applyReportChangeName(change tofu.ApplyReportChange) string {
+	var action string
+	switch change.Action {
+	case plans.Create:
+		action = "create"
+	case plans.Update:
+		action = "update"
+	case plans.Delete:
+		action = "destroy"
+	default:
+		action = "replace"
+	}
+	if change.DeposedKey != states.NotDeposed {
+		return fmt.Sprintf("%s (deposed object %s, %s)", change.Addr, change.DeposedKey, action)
+	}
+	return fmt.Sprintf("%s (%s)", change.Addr, action)
+}
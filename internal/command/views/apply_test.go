@@ -10,10 +10,13 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/command/arguments"
 	"github.com/opentofu/opentofu/internal/lang/marks"
+	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/terminal"
+	"github.com/opentofu/opentofu/internal/tofu"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -284,3 +287,114 @@ func TestApplyJSON_outputs(t *testing.T) {
 	}
 	testJSONViewOutputEquals(t, done(t).Stdout(), want)
 }
+
+func testApplyReport() *tofu.ApplyReport {
+	return &tofu.ApplyReport{
+		Changes: []tofu.ApplyReportChange{
+			{
+				Addr:    addrs.Resource{Mode: addrs.ManagedResourceMode, Type: "test_instance", Name: "foo"}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance),
+				Action:  plans.Create,
+				Outcome: tofu.ApplySucceeded,
+			},
+			{
+				Addr:    addrs.Resource{Mode: addrs.ManagedResourceMode, Type: "test_instance", Name: "bar"}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance),
+				Action:  plans.DeleteThenCreate,
+				Outcome: tofu.ApplyFailed,
+			},
+			{
+				Addr:       addrs.Resource{Mode: addrs.ManagedResourceMode, Type: "test_instance", Name: "baz"}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance),
+				Action:     plans.Update,
+				Outcome:    tofu.ApplySkipped,
+				SkipReason: tofu.ApplySkipDependencyFailed,
+			},
+			{
+				Addr:       addrs.Resource{Mode: addrs.ManagedResourceMode, Type: "test_instance", Name: "qux"}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance),
+				Action:     plans.Delete,
+				Outcome:    tofu.ApplySkipped,
+				SkipReason: tofu.ApplySkipInterrupted,
+			},
+		},
+	}
+}
+
+func TestApplyHuman_applyReport(t *testing.T) {
+	streams, done := terminal.StreamsForTesting(t)
+	v := NewApply(arguments.ViewOptions{ViewType: arguments.ViewHuman}, false, NewView(streams))
+
+	v.ApplyReport(testApplyReport())
+
+	got := done(t).Stdout()
+	for _, want := range []string{
+		"Apply report: 1 succeeded, 1 failed, 2 skipped.",
+		"Failed:\n  - test_instance.bar (replace)\n",
+		"Skipped because something they depend on failed:\n  - test_instance.baz (update)\n",
+		"Skipped because the apply was interrupted:\n  - test_instance.qux (destroy)\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("wrong result\ngot:\n%s\nwant substring: %s", got, want)
+		}
+	}
+	if strings.Contains(got, "test_instance.foo") {
+		t.Errorf("succeeded changes should not be listed\ngot:\n%s", got)
+	}
+}
+
+func TestApplyJSON_applyReport(t *testing.T) {
+	streams, done := terminal.StreamsForTesting(t)
+	v := NewApply(arguments.ViewOptions{ViewType: arguments.ViewJSON}, false, NewView(streams))
+
+	v.ApplyReport(testApplyReport())
+
+	want := []map[string]any{
+		{
+			"@level":   "info",
+			"@message": "test_instance.baz: Skipped because something it depends on failed",
+			"@module":  "tofu.ui",
+			"type":     "apply_skipped",
+			"hook": map[string]any{
+				"action": "update",
+				"reason": "dependency_failed",
+				"resource": map[string]any{
+					"addr":             "test_instance.baz",
+					"implied_provider": "test",
+					"module":           "",
+					"resource":         "test_instance.baz",
+					"resource_key":     nil,
+					"resource_name":    "baz",
+					"resource_type":    "test_instance",
+				},
+			},
+		},
+		{
+			"@level":   "info",
+			"@message": "test_instance.qux: Skipped because the apply was interrupted",
+			"@module":  "tofu.ui",
+			"type":     "apply_skipped",
+			"hook": map[string]any{
+				"action": "delete",
+				"reason": "interrupted",
+				"resource": map[string]any{
+					"addr":             "test_instance.qux",
+					"implied_provider": "test",
+					"module":           "",
+					"resource":         "test_instance.qux",
+					"resource_key":     nil,
+					"resource_name":    "qux",
+					"resource_type":    "test_instance",
+				},
+			},
+		},
+		{
+			"@level":   "info",
+			"@message": "Apply report: 1 succeeded, 1 failed, 2 skipped.",
+			"@module":  "tofu.ui",
+			"type":     "apply_report",
+			"report": map[string]any{
+				"succeeded": float64(1),
+				"failed":    float64(1),
+				"skipped":   float64(2),
+			},
+		},
+	}
+	testJSONViewOutputEquals(t, done(t).Stdout(), want)
+}
@@ -0,0 +1,200 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/jsondrift"
+	"github.com/opentofu/opentofu/internal/command/jsonentities"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// The Drift view is used for the drift command.
+type Drift interface {
+	Report(report *jsondrift.Report)
+
+	Diagnostics(diags tfdiags.Diagnostics)
+	UnsupportedLocalOp()
+	HelpPrompt()
+
+	// Backend returns the non-command view that contains methods to provide
+	// progress output for the backend operations.
+	Backend() Backend
+}
+
+// NewDrift returns an initialized Drift implementation for the given ViewType.
+func NewDrift(args arguments.ViewOptions, view *View) Drift {
+	var ret Drift
+	switch args.ViewType {
+	case arguments.ViewJSON:
+		ret = &DriftJSON{view: NewJSONView(view, nil)}
+	case arguments.ViewHuman:
+		ret = &DriftHuman{view: view}
+	default:
+		panic(fmt.Sprintf("unknown view type %v", args.ViewType))
+	}
+
+	if args.JSONInto != nil {
+		ret = DriftMulti{ret, &DriftJSON{view: NewJSONView(view, args.JSONInto)}}
+	}
+	return ret
+}
+
+type DriftMulti []Drift
+
+var _ Drift = (DriftMulti)(nil)
+
+func (m DriftMulti) Report(report *jsondrift.Report) {
+	for _, d := range m {
+		d.Report(report)
+	}
+}
+
+func (m DriftMulti) Diagnostics(diags tfdiags.Diagnostics) {
+	for _, d := range m {
+		d.Diagnostics(diags)
+	}
+}
+
+func (m DriftMulti) UnsupportedLocalOp() {
+	for _, d := range m {
+		d.UnsupportedLocalOp()
+	}
+}
+
+func (m DriftMulti) HelpPrompt() {
+	for _, d := range m {
+		d.HelpPrompt()
+	}
+}
+
+func (m DriftMulti) Backend() Backend {
+	ret := make([]Backend, len(m))
+	for i, v := range m {
+		ret[i] = v.Backend()
+	}
+	return BackendMulti(ret)
+}
+
+// The DriftHuman implementation renders a human-readable summary of the
+// drift in each workspace.
+type DriftHuman struct {
+	view *View
+}
+
+var _ Drift = (*DriftHuman)(nil)
+
+func (v *DriftHuman) Report(report *jsondrift.Report) {
+	for _, ws := range report.Workspaces {
+		switch {
+		case ws.Error != "":
+			v.view.streams.Printf(
+				v.view.colorize.Color("[reset][bold][red]Workspace %q:[reset] OpenTofu could not check for drift.\n\n"),
+				ws.Name,
+			)
+		case !ws.Drifted:
+			v.view.streams.Printf(
+				v.view.colorize.Color("[reset][bold][green]Workspace %q:[reset] No drift detected.\n\n"),
+				ws.Name,
+			)
+		default:
+			v.view.streams.Printf(
+				v.view.colorize.Color("[reset][bold][yellow]Workspace %q:[reset] %d %s changed outside of OpenTofu.\n\n"),
+				ws.Name,
+				len(ws.Resources),
+				pluralize("resource", len(ws.Resources)),
+			)
+			for _, r := range ws.Resources {
+				v.view.streams.Printf(v.view.colorize.Color("  [bold]%s[reset] %s\n"), r.Resource.Addr, driftActionDescription(r))
+				for _, attr := range r.Attributes {
+					if attr.Sensitive {
+						v.view.streams.Printf("      %s: (sensitive value)\n", attr.Path)
+						continue
+					}
+					v.view.streams.Printf("      %s: %s -> %s\n", attr.Path, driftValueString(attr.Before), driftValueString(attr.After))
+				}
+			}
+			v.view.streams.Println()
+		}
+	}
+}
+
+func (v *DriftHuman) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *DriftHuman) UnsupportedLocalOp() {
+	v.Diagnostics(tfdiags.Diagnostics{diagUnsupportedLocalOp})
+}
+
+func (v *DriftHuman) HelpPrompt() {
+	v.view.HelpPrompt("drift")
+}
+
+func (v *DriftHuman) Backend() Backend {
+	return &BackendHuman{
+		view: v.view,
+	}
+}
+
+// The DriftJSON implementation renders the whole drift report as a single
+// "drift_report" message.
+type DriftJSON struct {
+	view *JSONView
+}
+
+var _ Drift = (*DriftJSON)(nil)
+
+func (v *DriftJSON) Report(report *jsondrift.Report) {
+	v.view.DriftReport(report)
+}
+
+func (v *DriftJSON) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *DriftJSON) UnsupportedLocalOp() {
+	v.Diagnostics(tfdiags.Diagnostics{diagUnsupportedLocalOp})
+}
+
+func (v *DriftJSON) HelpPrompt() {
+}
+
+func (v *DriftJSON) Backend() Backend {
+	return &BackendJSON{
+		view: v.view,
+	}
+}
+
+func driftActionDescription(r *jsondrift.Resource) string {
+	switch r.Action {
+	case jsonentities.ActionUpdate:
+		return "has been changed"
+	case jsonentities.ActionDelete:
+		return "has been deleted"
+	case jsonentities.ActionMove:
+		if r.PreviousResource != nil {
+			return fmt.Sprintf("has moved from %s", r.PreviousResource.Addr)
+		}
+		return "has moved"
+	default:
+		return fmt.Sprintf("has drifted (%s)", r.Action)
+	}
+}
+
+func driftValueString(raw []byte) string {
+	if len(raw) == 0 {
+		return "null"
+	}
+	return string(raw)
+}
+
+func pluralize(word string, n int) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
@@ -0,0 +1,226 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/jsonentities"
+	"github.com/opentofu/opentofu/internal/command/jsonexplain"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// The Explain view is used for the explain command.
+type Explain interface {
+	// Display renders the explanation of a planned change, returning a
+	// non-zero status code if it could not be rendered.
+	Display(explanation *jsonexplain.Explanation) int
+
+	Diagnostics(diags tfdiags.Diagnostics)
+	HelpPrompt()
+}
+
+// NewExplain returns an initialized Explain implementation for the given
+// ViewType.
+func NewExplain(args arguments.ViewOptions, view *View) Explain {
+	var ret Explain
+	switch args.ViewType {
+	case arguments.ViewJSON:
+		ret = &ExplainJSON{view: view, output: view.streams.Stdout.File}
+	case arguments.ViewHuman:
+		ret = &ExplainHuman{view: view}
+	default:
+		panic(fmt.Sprintf("unknown view type %v", args.ViewType))
+	}
+
+	if args.JSONInto != nil {
+		ret = ExplainMulti{ret, &ExplainJSON{view: view, output: args.JSONInto}}
+	}
+	return ret
+}
+
+type ExplainMulti []Explain
+
+var _ Explain = (ExplainMulti)(nil)
+
+func (m ExplainMulti) Display(explanation *jsonexplain.Explanation) int {
+	code := 0
+	for _, e := range m {
+		code = max(code, e.Display(explanation))
+	}
+	return code
+}
+
+func (m ExplainMulti) Diagnostics(diags tfdiags.Diagnostics) {
+	for _, e := range m {
+		e.Diagnostics(diags)
+	}
+}
+
+func (m ExplainMulti) HelpPrompt() {
+	for _, e := range m {
+		e.HelpPrompt()
+	}
+}
+
+// The ExplainHuman implementation renders the references that contribute to
+// each changed attribute as an indented tree.
+type ExplainHuman struct {
+	view *View
+}
+
+var _ Explain = (*ExplainHuman)(nil)
+
+func (v *ExplainHuman) Display(explanation *jsonexplain.Explanation) int {
+	addr := explanation.Address
+	if explanation.Deposed != "" {
+		addr = fmt.Sprintf("%s (deposed object %s)", addr, explanation.Deposed)
+	}
+	v.view.streams.Printf(
+		v.view.colorize.Color("[reset][bold]%s %s%s.[reset]\n"),
+		addr, explainActionPhrase(explanation.Action), explainReasonPhrase(explanation.Reason),
+	)
+
+	if len(explanation.Attributes) == 0 {
+		v.view.streams.Println()
+		v.view.streams.Println("The planned change doesn't alter the value of any attribute.")
+		return 0
+	}
+
+	for _, attr := range explanation.Attributes {
+		v.view.streams.Println()
+		name := attr.Name
+		if attr.RequiresReplace {
+			name += " (forces replacement)"
+		}
+		v.view.streams.Printf(v.view.colorize.Color("[bold]%s:[reset]\n"), name)
+		if len(attr.References) == 0 {
+			v.view.streams.Println("  No references: the value is set directly in the configuration or chosen by the provider.")
+			continue
+		}
+		v.references(attr.References, "  ")
+	}
+	return 0
+}
+
+func (v *ExplainHuman) references(refs []*jsonexplain.Reference, indent string) {
+	for _, ref := range refs {
+		var b strings.Builder
+		b.WriteString(indent)
+		b.WriteString(ref.Address)
+		if rng := ref.Range; rng != nil {
+			// This is the same format as hcl.Range.String uses.
+			if rng.Start.Line == rng.End.Line {
+				fmt.Fprintf(&b, " (%s:%d,%d-%d)", rng.Filename, rng.Start.Line, rng.Start.Column, rng.End.Column)
+			} else {
+				fmt.Fprintf(&b, " (%s:%d,%d-%d,%d)", rng.Filename, rng.Start.Line, rng.Start.Column, rng.End.Line, rng.End.Column)
+			}
+		}
+		if desc := explainReferenceDescription(ref); desc != "" {
+			b.WriteString(", ")
+			b.WriteString(desc)
+		}
+		v.view.streams.Println(b.String())
+		v.references(ref.References, indent+"  ")
+	}
+}
+
+func (v *ExplainHuman) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *ExplainHuman) HelpPrompt() {
+	v.view.HelpPrompt("explain")
+}
+
+// The ExplainJSON implementation renders the explanation as a single JSON
+// document.
+type ExplainJSON struct {
+	view   *View
+	output *os.File
+}
+
+var _ Explain = (*ExplainJSON)(nil)
+
+func (v *ExplainJSON) Display(explanation *jsonexplain.Explanation) int {
+	src, err := json.Marshal(explanation)
+	if err != nil {
+		v.view.streams.Eprintf("Failed to marshal explanation to json: %s", err)
+		return 1
+	}
+	fmt.Fprintln(v.output, string(src))
+	return 0
+}
+
+// Diagnostics should only be called if the change could not be explained, in
+// which case we render human-readable diagnostics instead of a JSON document.
+func (v *ExplainJSON) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *ExplainJSON) HelpPrompt() {
+}
+
+func explainActionPhrase(action jsonentities.ChangeAction) string {
+	switch action {
+	case jsonentities.ActionCreate:
+		return "will be created"
+	case jsonentities.ActionRead:
+		return "will be read during apply"
+	case jsonentities.ActionUpdate:
+		return "will be updated in-place"
+	case jsonentities.ActionReplace:
+		return "must be replaced"
+	case jsonentities.ActionDelete:
+		return "will be destroyed"
+	case jsonentities.ActionForget:
+		return "will be removed from the state"
+	default:
+		return "has no planned changes"
+	}
+}
+
+func explainReasonPhrase(reason jsonentities.ChangeReason) string {
+	switch reason {
+	case jsonentities.ReasonTainted:
+		return ", because it is tainted"
+	case jsonentities.ReasonRequested:
+		return ", as requested using the -replace option"
+	case jsonentities.ReasonReplaceTriggeredBy:
+		return ", because of a change to an object in its replace_triggered_by argument"
+	case jsonentities.ReasonCannotUpdate:
+		return ", because some of its attributes cannot be updated in-place"
+	case jsonentities.ReasonDeleteBecauseNoResourceConfig:
+		return ", because its resource is no longer in the configuration"
+	default:
+		return ""
+	}
+}
+
+// explainReferenceDescription describes the object that a reference refers
+// to, for references that end a chain of references.
+func explainReferenceDescription(ref *jsonexplain.Reference) string {
+	switch {
+	case ref.Cycle:
+		return "which refers to itself"
+	case ref.Kind == jsonexplain.InputVariable && ref.Module == "":
+		return "a root module input variable"
+	case ref.Kind == jsonexplain.DataResource:
+		if ref.Action == jsonentities.ActionRead {
+			return "a data source that will be read during apply"
+		}
+		return "a data source"
+	case ref.Kind == jsonexplain.ManagedResource:
+		if ref.Action == "" || ref.Action == jsonentities.ActionNoOp {
+			return "a resource with no planned changes"
+		}
+		return "a resource that " + explainActionPhrase(ref.Action)
+	default:
+		return ""
+	}
+}
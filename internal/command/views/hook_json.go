@@ -139,6 +139,13 @@ func (h *jsonHook) PostApply(addr addrs.AbsResourceInstance, gen states.Generati
 	return tofu.HookActionContinue, nil
 }
 
+func (h *jsonHook) ApplyRetry(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, attempt int, delay time.Duration, err error) (tofu.HookAction, error) {
+	// The error itself is reported as a diagnostic only if the final
+	// attempt also fails.
+	h.view.Hook(json.NewApplyRetry(addr, action, attempt, delay))
+	return tofu.HookActionContinue, nil
+}
+
 func (h *jsonHook) PreProvisionInstanceStep(addr addrs.AbsResourceInstance, typeName string) (tofu.HookAction, error) {
 	h.view.Hook(json.NewProvisionStart(addr, typeName))
 	return tofu.HookActionContinue, nil
@@ -6,6 +6,7 @@
 package views
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -339,6 +340,45 @@ func TestJSONHook_refresh(t *testing.T) {
 	testJSONViewOutputEquals(t, done(t).Stdout(), want)
 }
 
+func TestJSONHook_applyRetry(t *testing.T) {
+	streams, done := terminal.StreamsForTesting(t)
+	hook := newJSONHook(NewJSONView(NewView(streams), nil))
+
+	addr := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "test_instance",
+		Name: "boop",
+	}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance)
+
+	action, err := hook.ApplyRetry(addr, states.CurrentGen, plans.Create, 2, 2*time.Second, errors.New("Throttling"))
+	testHookReturnValues(t, action, err)
+
+	want := []map[string]any{
+		{
+			"@level":   "info",
+			"@message": "test_instance.boop: Retrying after error (attempt 2) in 2s",
+			"@module":  "tofu.ui",
+			"type":     "apply_retry",
+			"hook": map[string]any{
+				"resource": map[string]any{
+					"addr":             string("test_instance.boop"),
+					"implied_provider": string("test"),
+					"module":           string(""),
+					"resource":         string("test_instance.boop"),
+					"resource_key":     nil,
+					"resource_name":    string("boop"),
+					"resource_type":    string("test_instance"),
+				},
+				"action":        "create",
+				"attempt":       float64(2),
+				"delay_seconds": float64(2),
+			},
+		},
+	}
+
+	testJSONViewOutputEquals(t, done(t).Stdout(), want)
+}
+
 func TestJSONHook_ephemeral(t *testing.T) {
 	addr := addrs.Resource{
 		Mode: addrs.EphemeralResourceMode,
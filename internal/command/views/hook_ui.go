@@ -251,6 +251,19 @@ func (h *UiHook) PostApply(addr addrs.AbsResourceInstance, gen states.Generation
 	return tofu.HookActionContinue, nil
 }
 
+func (h *UiHook) ApplyRetry(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, attempt int, delay time.Duration, err error) (tofu.HookAction, error) {
+	addrStr := addr.String()
+	if depKey, ok := gen.(states.DeposedKey); ok {
+		addrStr = fmt.Sprintf("%s (deposed object %s)", addrStr, depKey)
+	}
+
+	h.println(fmt.Sprintf(
+		h.view.colorize.Color("[reset][bold][yellow]%s: Retrying after error (attempt %d) in %s...[reset]"),
+		addrStr, attempt, delay,
+	))
+	return tofu.HookActionContinue, nil
+}
+
 func (h *UiHook) PreProvisionInstanceStep(addr addrs.AbsResourceInstance, typeName string) (tofu.HookAction, error) {
 	h.println(fmt.Sprintf(
 		h.view.colorize.Color("[reset][bold]%s: Provisioning with '%s'...[reset]"),
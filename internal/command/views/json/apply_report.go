@@ -0,0 +1,20 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package json
+
+import (
+	"fmt"
+)
+
+// ApplyReport summarizes the outcome of the planned changes in an apply
+// with -report.
+type ApplyReport struct {
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+}
+
+func (r *ApplyReport) String() string {
+	return fmt.Sprintf("Apply report: %d succeeded, %d failed, %d skipped.", r.Succeeded, r.Failed, r.Skipped)
+}
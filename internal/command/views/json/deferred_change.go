@@ -0,0 +1,28 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package json
+
+import (
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/plans"
+)
+
+// DeferredChange describes a resource or module call whose planning was
+// deferred because its instances could not be determined yet.
+type DeferredChange struct {
+	Address string `json:"address"`
+	Reason  string `json:"reason"`
+}
+
+func NewDeferredChange(dc *plans.DeferredChange) *DeferredChange {
+	return &DeferredChange{
+		Address: dc.Addr.String(),
+		Reason:  dc.Reason.JSONName(),
+	}
+}
+
+func (c *DeferredChange) String() string {
+	return fmt.Sprintf("%s: Plan deferred (%s)", c.Address, c.Reason)
+}
@@ -151,6 +151,76 @@ func NewApplyErrored(addr addrs.AbsResourceInstance, action plans.Action, elapse
 	}
 }
 
+// ApplyRetry: triggered by ApplyRetry hook when a change failed with an
+// error that its retry policy allows retrying.
+type applyRetry struct {
+	Resource jsonentities.ResourceAddr `json:"resource"`
+	Action   jsonentities.ChangeAction `json:"action"`
+	Attempt  int                       `json:"attempt"`
+	Delay    float64                   `json:"delay_seconds"`
+	delay    time.Duration
+}
+
+var _ Hook = (*applyRetry)(nil)
+
+func (h *applyRetry) HookType() MessageType {
+	return MessageApplyRetry
+}
+
+func (h *applyRetry) String() string {
+	return fmt.Sprintf("%s: Retrying after error (attempt %d) in %s", h.Resource.Addr, h.Attempt, h.delay)
+}
+
+func NewApplyRetry(addr addrs.AbsResourceInstance, action plans.Action, attempt int, delay time.Duration) Hook {
+	return &applyRetry{
+		Resource: jsonentities.NewResourceAddr(addr),
+		Action:   jsonentities.ParseChangeAction(action),
+		Attempt:  attempt,
+		Delay:    delay.Seconds(),
+		delay:    delay,
+	}
+}
+
+// ApplySkipped: emitted after an apply with -report for each
+// planned change that was never attempted.
+type applySkipped struct {
+	Resource jsonentities.ResourceAddr `json:"resource"`
+	Action   jsonentities.ChangeAction `json:"action"`
+	Reason   string                    `json:"reason"`
+}
+
+var _ Hook = (*applySkipped)(nil)
+
+func (h *applySkipped) HookType() MessageType {
+	return MessageApplySkipped
+}
+
+func (h *applySkipped) String() string {
+	switch h.Reason {
+	case ApplySkippedInterrupted:
+		return fmt.Sprintf("%s: Skipped because the apply was interrupted", h.Resource.Addr)
+	case ApplySkippedNotAttempted:
+		return fmt.Sprintf("%s: Not attempted", h.Resource.Addr)
+	default:
+		return fmt.Sprintf("%s: Skipped because something it depends on failed", h.Resource.Addr)
+	}
+}
+
+// The reasons reported in an apply_skipped message.
+const (
+	ApplySkippedDependencyFailed = "dependency_failed"
+	ApplySkippedInterrupted      = "interrupted"
+	ApplySkippedNotAttempted     = "not_attempted"
+)
+
+func NewApplySkipped(addr addrs.AbsResourceInstance, action plans.Action, reason string) Hook {
+	return &applySkipped{
+		Resource: jsonentities.NewResourceAddr(addr),
+		Action:   jsonentities.ParseChangeAction(action),
+		Reason:   reason,
+	}
+}
+
 // ProvisionStart: triggered by PreProvisionInstanceStep hook
 type provisionStart struct {
 	Resource    jsonentities.ResourceAddr `json:"resource"`
@@ -14,16 +14,21 @@ const (
 	MessageDiagnostic MessageType = "diagnostic"
 
 	// Operation results
-	MessageResourceDrift MessageType = "resource_drift"
-	MessagePlannedChange MessageType = "planned_change"
-	MessageChangeSummary MessageType = "change_summary"
-	MessageOutputs       MessageType = "outputs"
+	MessageResourceDrift  MessageType = "resource_drift"
+	MessagePlannedChange  MessageType = "planned_change"
+	MessageDeferredChange MessageType = "deferred_change"
+	MessageChangeSummary  MessageType = "change_summary"
+	MessageApplyReport    MessageType = "apply_report"
+	MessageDriftReport    MessageType = "drift_report"
+	MessageOutputs        MessageType = "outputs"
 
 	// Hook-driven messages
 	MessageApplyStart              MessageType = "apply_start"
 	MessageApplyProgress           MessageType = "apply_progress"
 	MessageApplyComplete           MessageType = "apply_complete"
 	MessageApplyErrored            MessageType = "apply_errored"
+	MessageApplySkipped            MessageType = "apply_skipped"
+	MessageApplyRetry              MessageType = "apply_retry"
 	MessageProvisionStart          MessageType = "provision_start"
 	MessageProvisionProgress       MessageType = "provision_progress"
 	MessageProvisionComplete       MessageType = "provision_complete"
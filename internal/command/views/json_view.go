@@ -12,6 +12,7 @@ import (
 
 	"github.com/hashicorp/go-hclog"
 
+	"github.com/opentofu/opentofu/internal/command/jsondrift"
 	"github.com/opentofu/opentofu/internal/command/jsonentities"
 	"github.com/opentofu/opentofu/internal/command/views/json"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -119,6 +120,14 @@ func (v *JSONView) ResourceDrift(c *jsonentities.ResourceInstanceChange) {
 	)
 }
 
+func (v *JSONView) DeferredChange(c *json.DeferredChange) {
+	v.log.Info(
+		c.String(),
+		"type", json.MessageDeferredChange,
+		"change", c,
+	)
+}
+
 func (v *JSONView) ChangeSummary(cs *json.ChangeSummary) {
 	v.log.Info(
 		cs.String(),
@@ -127,6 +136,28 @@ func (v *JSONView) ChangeSummary(cs *json.ChangeSummary) {
 	)
 }
 
+func (v *JSONView) ApplyReport(r *json.ApplyReport) {
+	v.log.Info(
+		r.String(),
+		"type", json.MessageApplyReport,
+		"report", r,
+	)
+}
+
+func (v *JSONView) DriftReport(r *jsondrift.Report) {
+	drifted := 0
+	for _, ws := range r.Workspaces {
+		if ws.Drifted {
+			drifted++
+		}
+	}
+	v.log.Info(
+		fmt.Sprintf("Drift report: drift detected in %d of %d workspaces", drifted, len(r.Workspaces)),
+		"type", json.MessageDriftReport,
+		"report", r,
+	)
+}
+
 func (v *JSONView) Hook(h json.Hook) {
 	v.log.Info(
 		h.String(),
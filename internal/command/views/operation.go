@@ -324,6 +324,10 @@ func (v *OperationJSON) Plan(plan *plans.Plan, schemas *tofu.Schemas) {
 		}
 	}
 
+	for _, dc := range plan.DeferredChanges {
+		v.view.DeferredChange(viewsjson.NewDeferredChange(dc))
+	}
+
 	v.view.ChangeSummary(cs)
 
 	var rootModuleOutputs []*plans.OutputChangeSrc
@@ -0,0 +1,43 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/plans/planfile"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// The PlanApprove view is used for the plan approve command.
+type PlanApprove interface {
+	// Approved reports that the given approval was added to the plan file.
+	Approved(path string, approval *planfile.Approval)
+
+	Diagnostics(diags tfdiags.Diagnostics)
+	HelpPrompt()
+}
+
+// NewPlanApprove returns an initialized PlanApprove implementation.
+func NewPlanApprove(view *View) PlanApprove {
+	return &PlanApproveHuman{view: view}
+}
+
+type PlanApproveHuman struct {
+	view *View
+}
+
+var _ PlanApprove = (*PlanApproveHuman)(nil)
+
+func (v *PlanApproveHuman) Approved(path string, approval *planfile.Approval) {
+	_, _ = v.view.streams.Println(fmt.Sprintf("Plan %s approved by %s.", path, approval.Approver))
+}
+
+func (v *PlanApproveHuman) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *PlanApproveHuman) HelpPrompt() {
+	v.view.HelpPrompt("plan approve")
+}
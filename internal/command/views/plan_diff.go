@@ -0,0 +1,250 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/jsonentities"
+	"github.com/opentofu/opentofu/internal/command/jsonplandiff"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// The PlanDiff view is used for the plan diff command.
+type PlanDiff interface {
+	// Display renders the differences between two saved plans, returning a
+	// non-zero status code if they could not be rendered.
+	Display(diff *jsonplandiff.Diff) int
+
+	Diagnostics(diags tfdiags.Diagnostics)
+	HelpPrompt()
+}
+
+// NewPlanDiff returns an initialized PlanDiff implementation for the given
+// ViewType.
+func NewPlanDiff(args arguments.ViewOptions, view *View) PlanDiff {
+	var ret PlanDiff
+	switch args.ViewType {
+	case arguments.ViewJSON:
+		ret = &PlanDiffJSON{view: view, output: view.streams.Stdout.File}
+	case arguments.ViewHuman:
+		ret = &PlanDiffHuman{view: view}
+	default:
+		panic(fmt.Sprintf("unknown view type %v", args.ViewType))
+	}
+
+	if args.JSONInto != nil {
+		ret = PlanDiffMulti{ret, &PlanDiffJSON{view: view, output: args.JSONInto}}
+	}
+	return ret
+}
+
+type PlanDiffMulti []PlanDiff
+
+var _ PlanDiff = (PlanDiffMulti)(nil)
+
+func (m PlanDiffMulti) Display(diff *jsonplandiff.Diff) int {
+	code := 0
+	for _, d := range m {
+		code = max(code, d.Display(diff))
+	}
+	return code
+}
+
+func (m PlanDiffMulti) Diagnostics(diags tfdiags.Diagnostics) {
+	for _, d := range m {
+		d.Diagnostics(diags)
+	}
+}
+
+func (m PlanDiffMulti) HelpPrompt() {
+	for _, d := range m {
+		d.HelpPrompt()
+	}
+}
+
+// The PlanDiffHuman implementation renders a human-readable summary of the
+// differences between the plans.
+type PlanDiffHuman struct {
+	view *View
+}
+
+var _ PlanDiff = (*PlanDiffHuman)(nil)
+
+func (v *PlanDiffHuman) Display(diff *jsonplandiff.Diff) int {
+	if diff.Equivalent {
+		v.view.streams.Printf(
+			v.view.colorize.Color("[reset][bold][green]The plans %q and %q are equivalent.[reset]\n"),
+			diff.PlanA, diff.PlanB,
+		)
+		return 0
+	}
+
+	v.view.streams.Printf(
+		v.view.colorize.Color("[reset][bold][yellow]The plans %q and %q differ.[reset]\n"),
+		diff.PlanA, diff.PlanB,
+	)
+
+	if len(diff.ResourceChanges) > 0 {
+		v.heading("Resource changes")
+		for _, rc := range diff.ResourceChanges {
+			addr := rc.Address
+			if rc.Deposed != "" {
+				addr = fmt.Sprintf("%s (deposed object %s)", addr, rc.Deposed)
+			}
+			desc := v.describeChange(diff, rc.Difference, rc.ActionA, rc.ActionB, rc.ValuesDiffer)
+			if len(rc.PropertiesDiffer) > 0 {
+				props := make([]string, len(rc.PropertiesDiffer))
+				for i, prop := range rc.PropertiesDiffer {
+					props[i] = string(prop)
+				}
+				desc = fmt.Sprintf("%s; different %s", desc, strings.Join(props, ", "))
+			}
+			v.view.streams.Printf("  %s: %s\n", addr, desc)
+		}
+	}
+
+	if len(diff.DeferredChanges) > 0 {
+		v.heading("Deferred changes")
+		for _, dc := range diff.DeferredChanges {
+			var desc string
+			switch dc.Difference {
+			case jsonplandiff.Added:
+				desc = fmt.Sprintf("only deferred in %s (%s)", diff.PlanB, dc.ReasonB)
+			case jsonplandiff.Removed:
+				desc = fmt.Sprintf("only deferred in %s (%s)", diff.PlanA, dc.ReasonA)
+			default:
+				desc = fmt.Sprintf("%s in %s, %s in %s", dc.ReasonA, diff.PlanA, dc.ReasonB, diff.PlanB)
+			}
+			v.view.streams.Printf("  %s: %s\n", dc.Address, desc)
+		}
+	}
+
+	if len(diff.OutputChanges) > 0 {
+		v.heading("Output changes")
+		for _, oc := range diff.OutputChanges {
+			v.view.streams.Printf("  %s: %s\n", oc.Address, v.describeChange(diff, oc.Difference, oc.ActionA, oc.ActionB, oc.ValuesDiffer))
+		}
+	}
+
+	if len(diff.Options) > 0 {
+		v.heading("Plan options")
+		for _, opt := range diff.Options {
+			v.view.streams.Printf("  %s: %s in %s, %s in %s\n",
+				opt.Name,
+				planDiffOrNone(strings.Join(opt.ValueA, ", ")), diff.PlanA,
+				planDiffOrNone(strings.Join(opt.ValueB, ", ")), diff.PlanB,
+			)
+		}
+	}
+
+	if len(diff.Variables) > 0 {
+		v.heading("Input variables")
+		for _, variable := range diff.Variables {
+			var desc string
+			switch variable.Difference {
+			case jsonplandiff.Added:
+				desc = fmt.Sprintf("only set in %s", diff.PlanB)
+			case jsonplandiff.Removed:
+				desc = fmt.Sprintf("only set in %s", diff.PlanA)
+			default:
+				desc = "value differs"
+			}
+			v.view.streams.Printf("  var.%s: %s\n", variable.Name, desc)
+		}
+	}
+
+	if len(diff.Providers) > 0 {
+		v.heading("Provider versions")
+		for _, p := range diff.Providers {
+			v.view.streams.Printf("  %s: %s in %s, %s in %s\n",
+				p.Address,
+				planDiffOrNone(p.VersionA), diff.PlanA,
+				planDiffOrNone(p.VersionB), diff.PlanB,
+			)
+		}
+	}
+
+	if ps := diff.PriorState; ps != nil {
+		v.heading("Prior state")
+		if ps.LineageA != ps.LineageB {
+			v.view.streams.Printf("  lineage: %s in %s, %s in %s\n",
+				planDiffOrNone(ps.LineageA), diff.PlanA,
+				planDiffOrNone(ps.LineageB), diff.PlanB,
+			)
+		}
+		if ps.SerialA != ps.SerialB {
+			v.view.streams.Printf("  serial: %d in %s, %d in %s\n", ps.SerialA, diff.PlanA, ps.SerialB, diff.PlanB)
+		}
+	}
+	return 0
+}
+
+func (v *PlanDiffHuman) heading(title string) {
+	v.view.streams.Println()
+	v.view.streams.Printf(v.view.colorize.Color("[bold]%s:[reset]\n"), title)
+}
+
+func (v *PlanDiffHuman) describeChange(diff *jsonplandiff.Diff, difference jsonplandiff.Difference, actionA, actionB jsonentities.ChangeAction, valuesDiffer bool) string {
+	switch difference {
+	case jsonplandiff.Added:
+		return fmt.Sprintf("only in %s (%s)", diff.PlanB, actionB)
+	case jsonplandiff.Removed:
+		return fmt.Sprintf("only in %s (%s)", diff.PlanA, actionA)
+	}
+	if actionA != actionB {
+		return fmt.Sprintf("%s in %s, %s in %s", actionA, diff.PlanA, actionB, diff.PlanB)
+	}
+	if valuesDiffer {
+		return fmt.Sprintf("%s with different values", actionA)
+	}
+	return string(actionA)
+}
+
+func (v *PlanDiffHuman) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *PlanDiffHuman) HelpPrompt() {
+	v.view.HelpPrompt("plan diff")
+}
+
+// The PlanDiffJSON implementation renders the differences between the plans
+// as a single JSON document.
+type PlanDiffJSON struct {
+	view   *View
+	output *os.File
+}
+
+var _ PlanDiff = (*PlanDiffJSON)(nil)
+
+func (v *PlanDiffJSON) Display(diff *jsonplandiff.Diff) int {
+	src, err := json.Marshal(diff)
+	if err != nil {
+		v.view.streams.Eprintf("Failed to marshal plan diff to json: %s", err)
+		return 1
+	}
+	fmt.Fprintln(v.output, string(src))
+	return 0
+}
+
+// Diagnostics should only be called if the plans could not be compared, in
+// which case we render human-readable diagnostics instead of a JSON document.
+func (v *PlanDiffJSON) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *PlanDiffJSON) HelpPrompt() {
+}
+
+func planDiffOrNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
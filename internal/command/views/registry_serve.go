@@ -0,0 +1,95 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+type RegistryServe interface {
+	Diagnostics(diags tfdiags.Diagnostics)
+	Serving(dir string, baseURL string)
+	Stopped()
+}
+
+// NewRegistryServe returns an initialized RegistryServe implementation for the given ViewType.
+func NewRegistryServe(args arguments.ViewOptions, view *View) RegistryServe {
+	var ret RegistryServe
+	switch args.ViewType {
+	case arguments.ViewJSON:
+		ret = &RegistryServeJSON{view: NewJSONView(view, nil)}
+	case arguments.ViewHuman:
+		ret = &RegistryServeHuman{view: view}
+	default:
+		panic(fmt.Sprintf("unknown view type %v", args.ViewType))
+	}
+
+	if args.JSONInto != nil {
+		ret = &RegistryServeMulti{ret, &RegistryServeJSON{view: NewJSONView(view, args.JSONInto)}}
+	}
+	return ret
+}
+
+type RegistryServeHuman struct {
+	view *View
+}
+
+var _ RegistryServe = (*RegistryServeHuman)(nil)
+
+func (v *RegistryServeHuman) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *RegistryServeHuman) Serving(dir string, baseURL string) {
+	_, _ = v.view.streams.Println(fmt.Sprintf("Serving modules and providers from %s at %s", dir, baseURL))
+	_, _ = v.view.streams.Println(fmt.Sprintf("  - Provider network mirror URL: %s/v1/mirror/", baseURL))
+	_, _ = v.view.streams.Println("Press Ctrl+C to stop.")
+}
+
+func (v *RegistryServeHuman) Stopped() {
+	_, _ = v.view.streams.Println("Registry server stopped.")
+}
+
+type RegistryServeMulti []RegistryServe
+
+var _ RegistryServe = (RegistryServeMulti)(nil)
+
+func (m RegistryServeMulti) Diagnostics(diags tfdiags.Diagnostics) {
+	for _, o := range m {
+		o.Diagnostics(diags)
+	}
+}
+
+func (m RegistryServeMulti) Serving(dir string, baseURL string) {
+	for _, o := range m {
+		o.Serving(dir, baseURL)
+	}
+}
+
+func (m RegistryServeMulti) Stopped() {
+	for _, o := range m {
+		o.Stopped()
+	}
+}
+
+type RegistryServeJSON struct {
+	view *JSONView
+}
+
+var _ RegistryServe = (*RegistryServeJSON)(nil)
+
+func (v *RegistryServeJSON) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *RegistryServeJSON) Serving(dir string, baseURL string) {
+	v.view.Info(fmt.Sprintf("Serving modules and providers from %s at %s", dir, baseURL))
+}
+
+func (v *RegistryServeJSON) Stopped() {
+	v.view.Info("Registry server stopped")
+}
@@ -0,0 +1,111 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/opentofu/opentofu/internal/command/arguments"
+)
+
+func TestRegistryServeView(t *testing.T) {
+	tests := map[string]struct {
+		viewCall   func(v RegistryServe)
+		wantJson   []map[string]any
+		wantStdout string
+		wantStderr string
+	}{
+		"serving": {
+			viewCall: func(v RegistryServe) {
+				v.Serving("/srv/registry", "https://localhost:8443")
+			},
+			wantStdout: withNewline("Serving modules and providers from /srv/registry at https://localhost:8443") +
+				withNewline("  - Provider network mirror URL: https://localhost:8443/v1/mirror/") +
+				withNewline("Press Ctrl+C to stop."),
+			wantJson: []map[string]any{
+				{
+					"@level":   "info",
+					"@message": "Serving modules and providers from /srv/registry at https://localhost:8443",
+					"@module":  "tofu.ui",
+				},
+			},
+		},
+		"stopped": {
+			viewCall: func(v RegistryServe) {
+				v.Stopped()
+			},
+			wantStdout: withNewline("Registry server stopped."),
+			wantJson: []map[string]any{
+				{
+					"@level":   "info",
+					"@message": "Registry server stopped",
+					"@module":  "tofu.ui",
+				},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			testRegistryServeHuman(t, tc.viewCall, tc.wantStdout, tc.wantStderr)
+			testRegistryServeJson(t, tc.viewCall, tc.wantJson)
+			testRegistryServeMulti(t, tc.viewCall, tc.wantStdout, tc.wantStderr, tc.wantJson)
+		})
+	}
+}
+
+func testRegistryServeHuman(t *testing.T, call func(v RegistryServe), wantStdout, wantStderr string) {
+	view, done := testView(t)
+	v := NewRegistryServe(arguments.ViewOptions{ViewType: arguments.ViewHuman}, view)
+	call(v)
+	output := done(t)
+	if diff := cmp.Diff(wantStderr, output.Stderr()); diff != "" {
+		t.Errorf("invalid stderr (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wantStdout, output.Stdout()); diff != "" {
+		t.Errorf("invalid stdout (-want, +got):\n%s", diff)
+	}
+}
+
+func testRegistryServeJson(t *testing.T, call func(v RegistryServe), want []map[string]any) {
+	view, done := testView(t)
+	v := NewRegistryServe(arguments.ViewOptions{ViewType: arguments.ViewJSON}, view)
+	call(v)
+	output := done(t)
+	if output.Stderr() != "" {
+		t.Errorf("expected no stderr but got:\n%s", output.Stderr())
+	}
+
+	testJSONViewOutputEquals(t, output.Stdout(), want)
+}
+
+func testRegistryServeMulti(t *testing.T, call func(v RegistryServe), wantStdout string, wantStderr string, want []map[string]any) {
+	jsonInto, err := os.CreateTemp(t.TempDir(), "json-into-*")
+	if err != nil {
+		t.Fatalf("failed to create the file to write json content into: %s", err)
+	}
+	view, done := testView(t)
+	v := NewRegistryServe(arguments.ViewOptions{ViewType: arguments.ViewHuman, JSONInto: jsonInto}, view)
+	call(v)
+	{
+		if err := jsonInto.Close(); err != nil {
+			t.Fatalf("failed to close the jsonInto file: %s", err)
+		}
+		fileContent, err := os.ReadFile(jsonInto.Name())
+		if err != nil {
+			t.Fatalf("failed to read the file content with the json output: %s", err)
+		}
+		testJSONViewOutputEquals(t, string(fileContent), want)
+	}
+	{
+		output := done(t)
+		if diff := cmp.Diff(wantStderr, output.Stderr()); diff != "" {
+			t.Errorf("invalid stderr (-want, +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(wantStdout, output.Stdout()); diff != "" {
+			t.Errorf("invalid stdout (-want, +got):\n%s", diff)
+		}
+	}
+}
@@ -7,12 +7,15 @@ package configs
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/lang"
+	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
 // CheckRule represents a configuration-defined validation rule, precondition,
@@ -39,9 +42,35 @@ type CheckRule struct {
 	// interpolation as the corresponding condition.
 	ErrorMessage hcl.Expression
 
+	// ErrorCode is an optional machine-readable identifier for the problem
+	// that a failure of this rule represents, like "QUOTA_EXCEEDED", which
+	// OpenTofu includes with the error message in its machine-readable
+	// output so that automation can react to particular failures.
+	ErrorCode string
+
+	// Severity is the severity that the module author chose for a failure of
+	// this rule, or zero if they didn't choose one. A rule with severity
+	// tfdiags.Warning reports its failures as warnings, which don't block
+	// the operation, while tfdiags.Error leaves the caller's usual severity
+	// unchanged.
+	Severity tfdiags.Severity
+
 	DeclRange hcl.Range
 }
 
+// errorCodePattern matches the valid values of the "error_code" argument.
+var errorCodePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]*$`)
+
+// FailureSeverity returns the severity to use for the diagnostic that
+// reports a failure of the rule, given the severity that the caller would
+// use for a rule without a "severity" argument.
+func (cr *CheckRule) FailureSeverity(defaultSeverity tfdiags.Severity) tfdiags.Severity {
+	if cr.Severity == tfdiags.Warning {
+		return tfdiags.Warning
+	}
+	return defaultSeverity
+}
+
 // validateSelfReferences looks for references in the check rule matching the
 // specified resource address, returning error diagnostics if such a reference
 // is found.
@@ -131,6 +160,41 @@ func decodeCheckRuleBlock(block *hcl.Block, override bool) (*CheckRule, hcl.Diag
 		cr.ErrorMessage = attr.Expr
 	}
 
+	if attr, exists := content.Attributes["error_code"]; exists {
+		// Unlike the error message, the error code must be a constant so
+		// that automation can rely on it.
+		val, valDiags := attr.Expr.Value(nil)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() {
+			if val.Type() != cty.String || val.IsNull() || !errorCodePattern.MatchString(val.AsString()) {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid error code",
+					Detail:   "The error code must be a literal string that starts with a letter and contains only letters, digits, underscores, periods, and dashes, like \"QUOTA_EXCEEDED\".",
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			} else {
+				cr.ErrorCode = val.AsString()
+			}
+		}
+	}
+
+	if attr, exists := content.Attributes["severity"]; exists {
+		switch hcl.ExprAsKeyword(attr.Expr) {
+		case "error":
+			cr.Severity = tfdiags.Error
+		case "warning":
+			cr.Severity = tfdiags.Warning
+		default:
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid \"severity\" keyword",
+				Detail:   "The \"severity\" argument requires one of the following keywords: error or warning.",
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+		}
+	}
+
 	return cr, diags
 }
 
@@ -144,6 +208,12 @@ var checkRuleBlockSchema = &hcl.BodySchema{
 			Name:     "error_message",
 			Required: true,
 		},
+		{
+			Name: "error_code",
+		},
+		{
+			Name: "severity",
+		},
 	},
 }
 
@@ -234,6 +304,15 @@ func decodeCheckBlock(block *hcl.Block, override bool) (*Check, hcl.Diagnostics)
 		case "assert":
 			assert, moreDiags := decodeCheckRuleBlock(block, override)
 			diags = append(diags, moreDiags...)
+			if assert.Severity == tfdiags.Error {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid assertion severity",
+					Detail:   "The assertions in a check block always report their failures as warnings, so their severity can't be \"error\". Use a precondition or postcondition instead to block the operation when a condition fails.",
+					Subject:  assert.DeclRange.Ptr(),
+				})
+				continue
+			}
 			if !moreDiags.HasErrors() {
 				check.Asserts = append(check.Asserts, assert)
 			}
@@ -0,0 +1,318 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/lang"
+)
+
+// Function represents a "function" block in a module, which declares a
+// user-defined function that expressions in the module can call as
+// module::<name>.
+type Function struct {
+	Name        string
+	Description string
+	Parameters  []*FunctionParameter
+	Result      hcl.Expression
+
+	// Export makes the function visible in all of the descendants of the
+	// module that declares it, unless they declare a function of the same
+	// name themselves.
+	Export bool
+
+	DeclRange hcl.Range
+}
+
+// FunctionParameter is a parameter of a [Function], which its result
+// expression refers to by name.
+type FunctionParameter struct {
+	Name      string
+	Type      cty.Type
+	DeclRange hcl.Range
+}
+
+func decodeFunctionBlock(block *hcl.Block) (*Function, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	fn := &Function{
+		Name:      block.Labels[0],
+		DeclRange: block.DefRange,
+	}
+
+	content, moreDiags := block.Body.Content(functionBlockSchema)
+	diags = append(diags, moreDiags...)
+
+	if !hclsyntax.ValidIdentifier(fn.Name) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid function name",
+			Detail:   badIdentifierDetail,
+			Subject:  &block.LabelRanges[0],
+		})
+	}
+
+	if attr, exists := content.Attributes["description"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &fn.Description)
+		diags = append(diags, valDiags...)
+	}
+
+	if attr, exists := content.Attributes["export"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &fn.Export)
+		diags = append(diags, valDiags...)
+	}
+
+	if attr, exists := content.Attributes["parameters"]; exists {
+		params, paramsDiags := decodeFunctionParameters(attr.Expr)
+		diags = append(diags, paramsDiags...)
+		fn.Parameters = params
+	}
+
+	if attr, exists := content.Attributes["result"]; exists {
+		fn.Result = attr.Expr
+		diags = append(diags, fn.validateResult()...)
+	}
+
+	return fn, diags
+}
+
+// decodeFunctionParameters decodes the object expression that declares the
+// parameters of a function and their types, in the order that callers pass
+// them.
+func decodeFunctionParameters(expr hcl.Expression) ([]*FunctionParameter, hcl.Diagnostics) {
+	pairs, diags := hcl.ExprMap(expr)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	params := make([]*FunctionParameter, 0, len(pairs))
+	seen := make(map[string]*FunctionParameter, len(pairs))
+	for _, pair := range pairs {
+		name := hcl.ExprAsKeyword(pair.Key)
+		if !hclsyntax.ValidIdentifier(name) {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid function parameter name",
+				Detail:   badIdentifierDetail,
+				Subject:  pair.Key.Range().Ptr(),
+			})
+			continue
+		}
+		if existing, exists := seen[name]; exists {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate function parameter",
+				Detail:   fmt.Sprintf("A parameter named %q was already declared at %s. Parameter names must be unique within a function.", name, existing.DeclRange),
+				Subject:  pair.Key.Range().Ptr(),
+			})
+			continue
+		}
+
+		ty, tyDiags := typeexpr.TypeConstraint(pair.Value)
+		diags = append(diags, tyDiags...)
+		param := &FunctionParameter{
+			Name:      name,
+			Type:      ty,
+			DeclRange: hcl.RangeBetween(pair.Key.Range(), pair.Value.Range()),
+		}
+		seen[name] = param
+		params = append(params, param)
+	}
+	return params, diags
+}
+
+// validateResult checks that the result expression of the function only
+// refers to the function's parameters, and only calls functions that always
+// return the same result for the same arguments.
+func (f *Function) validateResult() hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	for _, traversal := range f.Result.Variables() {
+		name := traversal.RootName()
+		if slices.ContainsFunc(f.Parameters, func(p *FunctionParameter) bool { return p.Name == name }) {
+			continue
+		}
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid reference in function",
+			Detail:   fmt.Sprintf("The result of function %q can only refer to the function's parameters, so %q is not available.", f.Name, name),
+			Subject:  traversal.SourceRange().Ptr(),
+		})
+	}
+
+	for _, traversal := range f.calls() {
+		name := traversal.RootName()
+		switch {
+		case addrs.ParseFunction(name).IsNamespace(addrs.FunctionNamespaceProvider):
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Provider function call in function",
+				Detail:   fmt.Sprintf("The result of function %q can't call provider-defined functions such as %q.", f.Name, name),
+				Subject:  traversal.SourceRange().Ptr(),
+			})
+		case addrs.ParseFunction(name).IsNamespace(addrs.FunctionNamespacePlugin):
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Plugin function call in function",
+				Detail:   fmt.Sprintf("The result of function %q can't call functions provided by function plugins such as %q.", f.Name, name),
+				Subject:  traversal.SourceRange().Ptr(),
+			})
+		case lang.IsImpureFunction(name):
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Impure function call in function",
+				Detail:   fmt.Sprintf("A function declared by a \"function\" block must always return the same result for the same arguments, so the result of function %q can't call %q.", f.Name, name),
+				Subject:  traversal.SourceRange().Ptr(),
+			})
+		}
+	}
+
+	return diags
+}
+
+// calls returns the functions that the result expression of the function
+// calls, as traversals whose root name is the name of the called function.
+func (f *Function) calls() []hcl.Traversal {
+	if f.Result == nil {
+		return nil
+	}
+	if fexpr, ok := f.Result.(hcl.ExpressionWithFunctions); ok {
+		return fexpr.Functions()
+	}
+	return nil
+}
+
+// checkFunctionRecursion returns an error for each cycle of calls between the
+// given functions, which are the functions declared in a module.
+func checkFunctionRecursion(funcs map[string]*Function) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(funcs))
+	var stack []string
+
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, traversal := range funcs[name].calls() {
+			called := addrs.ParseFunction(traversal.RootName())
+			if !called.IsNamespace(addrs.FunctionNamespaceModule) || len(called.Namespaces) != 1 {
+				continue
+			}
+			if _, exists := funcs[called.Name]; !exists {
+				continue
+			}
+			switch state[called.Name] {
+			case unvisited:
+				visit(called.Name)
+			case visiting:
+				cycle := stack[slices.Index(stack, called.Name):]
+				chain := make([]string, 0, len(cycle)+1)
+				for _, n := range cycle {
+					chain = append(chain, lang.UserFunctionName(n))
+				}
+				chain = append(chain, lang.UserFunctionName(called.Name))
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Recursive function call",
+					Detail:   fmt.Sprintf("Functions declared by \"function\" blocks can't call themselves, directly or indirectly: %s.", strings.Join(chain, " → ")),
+					Subject:  traversal.SourceRange().Ptr(),
+				})
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = visited
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(funcs)) {
+		if state[name] == unvisited {
+			visit(name)
+		}
+	}
+	return diags
+}
+
+// UserFunctions returns the user-defined functions that expressions in the
+// receiving module can call, keyed by name: those declared by the module's own
+// "function" blocks, and those exported by the "function" blocks of its
+// ancestors.
+//
+// Each call builds new UserFunction values, so callers that create many
+// scopes for the same module should keep the result rather than calling this
+// again for each scope.
+func (c *Config) UserFunctions() map[string]*lang.UserFunction {
+	visible, _ := c.userFunctions()
+	return visible
+}
+
+// userFunctions returns the user-defined functions that are visible in the
+// receiving module and those that it exports to its children.
+func (c *Config) userFunctions() (visible, exported map[string]*lang.UserFunction) {
+	if c == nil || c.Module == nil {
+		return nil, nil
+	}
+	var inherited map[string]*lang.UserFunction
+	if c.Parent != nil {
+		_, inherited = c.Parent.userFunctions()
+	}
+	return c.Module.userFunctions(inherited)
+}
+
+// userFunctions returns the user-defined functions that are visible in the
+// module, given those that its parent module exports to it, and also the
+// functions that the module exports to its own children.
+func (m *Module) userFunctions(inherited map[string]*lang.UserFunction) (visible, exported map[string]*lang.UserFunction) {
+	if m == nil || len(m.Functions) == 0 {
+		return inherited, inherited
+	}
+
+	visible = make(map[string]*lang.UserFunction, len(inherited)+len(m.Functions))
+	exported = make(map[string]*lang.UserFunction, len(inherited))
+	maps.Copy(visible, inherited)
+	maps.Copy(exported, inherited)
+
+	for name, fn := range m.Functions {
+		uf := &lang.UserFunction{
+			Name:        fn.Name,
+			Description: fn.Description,
+			Params:      make([]lang.UserFunctionParam, len(fn.Parameters)),
+			Result:      fn.Result,
+			// The function's result can call the other functions visible
+			// in this module, including those that are inherited.
+			Functions: visible,
+		}
+		for i, p := range fn.Parameters {
+			uf.Params[i] = lang.UserFunctionParam{Name: p.Name, Type: p.Type}
+		}
+		visible[name] = uf
+		if fn.Export {
+			exported[name] = uf
+		}
+	}
+	return visible, exported
+}
+
+var functionBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "description"},
+		{Name: "parameters"},
+		{Name: "result", Required: true},
+		{Name: "export"},
+	},
+}
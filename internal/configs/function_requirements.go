@@ -0,0 +1,55 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// RequiredFunction represents a function plugin that a module requires,
+// declared in the "required_functions" argument of a "terraform" block.
+//
+// The module doesn't say how to run the plugin: each function plugin is
+// defined by a "function_plugin" block in the CLI configuration, and
+// expressions in the module call its functions as
+// plugin::<name>::<function>.
+type RequiredFunction struct {
+	Name      string
+	DeclRange hcl.Range
+}
+
+func decodeRequiredFunctionsAttr(attr *hcl.Attribute) ([]*RequiredFunction, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	exprs, listDiags := hcl.ExprList(attr.Expr)
+	diags = append(diags, listDiags...)
+	if listDiags.HasErrors() {
+		return nil, diags
+	}
+
+	ret := make([]*RequiredFunction, 0, len(exprs))
+	for _, expr := range exprs {
+		val, valDiags := expr.Value(nil)
+		diags = append(diags, valDiags...)
+		if valDiags.HasErrors() {
+			continue
+		}
+		if val.IsNull() || !val.IsKnown() || val.Type() != cty.String || !hclsyntax.ValidIdentifier(val.AsString()) {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid function plugin name",
+				Detail:   "Each element of required_functions must be a string containing the name of a function plugin. A name must start with a letter or underscore and may contain only letters, digits, underscores, and dashes.",
+				Subject:  expr.Range().Ptr(),
+			})
+			continue
+		}
+		ret = append(ret, &RequiredFunction{
+			Name:      val.AsString(),
+			DeclRange: expr.Range(),
+		})
+	}
+	return ret, diags
+}
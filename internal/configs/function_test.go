@@ -0,0 +1,104 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+func TestDecodeFunctionBlock(t *testing.T) {
+	file, diags := NewParser(nil).LoadConfigFile("testdata/valid-files/functions.tf")
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	mod, diags := NewModule([]*File{file}, nil, RootModuleCallForTesting(), "testdata/valid-files", SelectiveLoadAll)
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+
+	if got, want := slices.Sorted(maps.Keys(mod.Functions)), []string{"answer", "normalize_tags", "resource_name", "sanitize"}; !slices.Equal(got, want) {
+		t.Fatalf("wrong functions %q; want %q", got, want)
+	}
+
+	fn := mod.Functions["resource_name"]
+	var params []string
+	for _, p := range fn.Parameters {
+		params = append(params, p.Name+" "+p.Type.FriendlyName())
+	}
+	// Parameters keep the order in which they are declared, because callers
+	// pass arguments positionally.
+	if diff := cmp.Diff([]string{"env string", "name string", "index number"}, params); diff != "" {
+		t.Errorf("wrong parameters\n%s", diff)
+	}
+	if fn.Export {
+		t.Errorf("resource_name is exported")
+	}
+
+	fn = mod.Functions["normalize_tags"]
+	if !fn.Export {
+		t.Errorf("normalize_tags is not exported")
+	}
+	if got, want := fn.Parameters[0].Type, cty.Map(cty.String); !got.Equals(want) {
+		t.Errorf("wrong parameter type %#v; want %#v", got, want)
+	}
+	if fn.Description == "" {
+		t.Errorf("normalize_tags has no description")
+	}
+}
+
+func TestFunctionRecursion(t *testing.T) {
+	_, diags := testModuleFromDir("testdata/invalid-modules/function-recursion")
+	var found bool
+	for _, diag := range diags {
+		if diag.Summary == "Recursive function call" {
+			found = true
+			if want := `Functions declared by "function" blocks can't call themselves, directly or indirectly: module::even → module::odd → module::even.`; diag.Detail != want {
+				t.Errorf("wrong detail\ngot:  %s\nwant: %s", diag.Detail, want)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("no recursion error; got: %s", diags.Error())
+	}
+	if got := len(diags); got != 1 {
+		t.Errorf("got %d diagnostics; want 1 for the single cycle\n%s", got, hcl.Diagnostics(diags).Error())
+	}
+}
+
+func TestConfigUserFunctions(t *testing.T) {
+	cfg, diags := testNestedModuleConfigFromDir(t, "testdata/functions-nested")
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+
+	root := cfg.UserFunctions()
+	if got, want := slices.Sorted(maps.Keys(root)), []string{"exported", "private", "shadowed"}; !slices.Equal(got, want) {
+		t.Errorf("wrong root functions %q; want %q", got, want)
+	}
+
+	child := cfg.Descendent(addrs.RootModule.Child("child")).UserFunctions()
+	if got, want := slices.Sorted(maps.Keys(child)), []string{"exported", "own", "shadowed"}; !slices.Equal(got, want) {
+		t.Fatalf("wrong child functions %q; want %q", got, want)
+	}
+	if child["exported"].Result != root["exported"].Result {
+		t.Errorf("child doesn't inherit the exported function from its parent")
+	}
+	if child["shadowed"].Result == root["shadowed"].Result {
+		t.Errorf("child's own function doesn't shadow the one exported by its parent")
+	}
+	// The child's functions can call the other functions visible in the
+	// child module, including the inherited ones.
+	if got := child["own"].Functions["exported"]; got == nil || got.Result != root["exported"].Result {
+		t.Errorf("child function can't call the inherited function")
+	}
+
+}
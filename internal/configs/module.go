@@ -37,6 +37,7 @@ type Module struct {
 	ProviderRequirements *RequiredProviders
 	ProviderLocalNames   map[addrs.Provider]string
 	ProviderMetas        map[addrs.Provider]*ProviderMeta
+	RequiredFunctions    map[string]*RequiredFunction
 	Encryption           *config.EncryptionConfig
 
 	Variables map[string]*Variable
@@ -55,6 +56,8 @@ type Module struct {
 
 	Checks map[string]*Check
 
+	Functions map[string]*Function
+
 	Tests map[string]*TestFile
 
 	// IsOverridden indicates if the module is being overridden. It's used in
@@ -94,6 +97,7 @@ type File struct {
 	ProviderConfigs   []*Provider
 	ProviderMetas     []*ProviderMeta
 	RequiredProviders []*RequiredProviders
+	RequiredFunctions []*RequiredFunction
 	Encryptions       []*config.EncryptionConfig
 
 	Variables []*Variable
@@ -111,6 +115,8 @@ type File struct {
 	Removed []*Removed
 
 	Checks []*Check
+
+	Functions []*Function
 }
 
 // SelectiveLoader allows the consumer to only load and validate the portions of files needed for the given operations/contexts
@@ -178,7 +184,9 @@ func NewModuleUneval(primaryFiles, overrideFiles []*File, sourceDir string, load
 		DataResources:      map[string]*Resource{},
 		EphemeralResources: map[string]*Resource{},
 		Checks:             map[string]*Check{},
+		Functions:          map[string]*Function{},
 		ProviderMetas:      map[addrs.Provider]*ProviderMeta{},
+		RequiredFunctions:  map[string]*RequiredFunction{},
 		Tests:              map[string]*TestFile{},
 		SourceDir:          sourceDir,
 	}
@@ -232,6 +240,8 @@ func NewModuleUneval(primaryFiles, overrideFiles []*File, sourceDir string, load
 		diags = append(diags, fileDiags...)
 	}
 
+	diags = append(diags, checkFunctionRecursion(mod.Functions)...)
+
 	return mod, diags
 }
 
@@ -599,6 +609,20 @@ func (m *Module) appendFile(file *File) hcl.Diagnostics {
 
 	m.Removed = append(m.Removed, file.Removed...)
 
+	m.appendRequiredFunctions(file.RequiredFunctions)
+
+	for _, f := range file.Functions {
+		if existing, exists := m.Functions[f.Name]; exists {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate function declaration",
+				Detail:   fmt.Sprintf("A function named %q was already declared at %s. Function names must be unique within a module.", existing.Name, existing.DeclRange),
+				Subject:  &f.DeclRange,
+			})
+		}
+		m.Functions[f.Name] = f
+	}
+
 	return diags
 }
 
@@ -822,9 +846,33 @@ func (m *Module) mergeFile(file *File) hcl.Diagnostics {
 		})
 	}
 
+	for _, f := range file.Functions {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Cannot override function blocks",
+			Detail:   "Function blocks can appear only in normal files, not in override files.",
+			Subject:  f.DeclRange.Ptr(),
+		})
+	}
+
+	// Override files can require additional function plugins, but can't
+	// remove a requirement from a primary file.
+	m.appendRequiredFunctions(file.RequiredFunctions)
+
 	return diags
 }
 
+// appendRequiredFunctions adds the given function plugin requirements to the
+// module. A module can list the same plugin in more than one file, in which
+// case we keep the first declaration.
+func (m *Module) appendRequiredFunctions(reqs []*RequiredFunction) {
+	for _, r := range reqs {
+		if _, exists := m.RequiredFunctions[r.Name]; !exists {
+			m.RequiredFunctions[r.Name] = r
+		}
+	}
+}
+
 // gatherProviderLocalNames is a helper function that populatesA a map of
 // provider FQNs -> provider local names. This information is useful for
 // user-facing output, which should include both the FQN and LocalName. It must
@@ -200,7 +200,7 @@ func (mc *ModuleCall) decodeStaticSource(ctx context.Context, eval *StaticEvalua
 		// NOTE: This code was originally executed as part of decodeModuleBlock and is now deferred until we have the config merged and static context built
 		var err error
 		if mc.VersionAttr != nil {
-			mc.SourceAddr, err = addrs.ParseModuleSourceRegistry(mc.SourceAddrRaw)
+			mc.SourceAddr, err = addrs.ParseModuleSourceVersioned(mc.SourceAddrRaw)
 		} else {
 			mc.SourceAddr, err = addrs.ParseModuleSource(mc.SourceAddrRaw)
 		}
@@ -230,14 +230,14 @@ func (mc *ModuleCall) decodeStaticSource(ctx context.Context, eval *StaticEvalua
 					Subject: mc.Source.Range().Ptr(),
 				})
 			} else {
-				if mc.VersionAttr != nil {
+				if mc.VersionAttr != nil && !getmodules.IsGitPackageAddress(mc.SourceAddrRaw) {
 					// In this case we'll include some extra context that
 					// we assumed a registry source address due to the
 					// version argument.
 					diags = append(diags, &hcl.Diagnostic{
 						Severity: hcl.DiagError,
 						Summary:  "Invalid registry module source address",
-						Detail:   fmt.Sprintf("Failed to parse module registry address: %s.\n\nOpenTofu assumed that you intended a module registry source address because you also set the argument \"version\", which applies only to registry modules.", err),
+						Detail:   fmt.Sprintf("Failed to parse module registry address: %s.\n\nOpenTofu assumed that you intended a module registry source address because you also set the argument \"version\", which applies only to registry modules and to git repositories selected with the \"git::\" prefix.", err),
 						Subject:  mc.Source.Range().Ptr(),
 					})
 				} else {
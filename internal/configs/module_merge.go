@@ -35,6 +35,10 @@ func (p *Provider) merge(op *Provider) hcl.Diagnostics {
 		p.Version = op.Version
 	}
 
+	if op.MaxConcurrency != 0 {
+		p.MaxConcurrency = op.MaxConcurrency
+	}
+
 	p.Config = MergeBodies(p.Config, op.Config)
 
 	return diags
@@ -64,6 +68,8 @@ func (v *Variable) merge(ov *Variable) hcl.Diagnostics {
 	if ov.Type != cty.NilType {
 		v.Type = ov.Type
 		v.ConstraintType = ov.ConstraintType
+		v.TypeDefaults = ov.TypeDefaults
+		v.DeprecatedAttributes = ov.DeprecatedAttributes
 	}
 	if ov.ParsingMode != 0 {
 		v.ParsingMode = ov.ParsingMode
@@ -88,7 +94,11 @@ func (v *Variable) merge(ov *Variable) hcl.Diagnostics {
 	// constraint but the converted value cannot. In practice, this situation
 	// should be rare since most of our conversions are interchangeable.
 	if v.Default != cty.NilVal {
-		val, err := convert.Convert(v.Default, v.ConstraintType)
+		val := v.Default
+		if v.TypeDefaults != nil && !val.IsNull() {
+			val = v.TypeDefaults.Apply(val)
+		}
+		val, err := convert.Convert(val, v.ConstraintType)
 		if err != nil {
 			// What exactly we'll say in the error message here depends on whether
 			// it was Default or Type that was overridden here.
@@ -273,6 +283,9 @@ func (r *Resource) merge(or *Resource, rps map[string]*RequiredProvider) hcl.Dia
 		if or.Managed.Destroy != nil {
 			r.Managed.Destroy = or.Managed.Destroy
 		}
+		if or.Managed.Retry != nil {
+			r.Managed.Retry = or.Managed.Retry
+		}
 
 		if len(or.Managed.Provisioners) != 0 {
 			r.Managed.Provisioners = or.Managed.Provisioners
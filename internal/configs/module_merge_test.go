@@ -528,3 +528,39 @@ func TestModuleOverrideIgnoreAllChanges(t *testing.T) {
 		t.Fatalf("wrong result: expected r.Managed.IgnoreAllChanges to be true")
 	}
 }
+
+func TestModuleOverrideVariableTypeDefaults(t *testing.T) {
+	mod, diags := testModuleFromDir("testdata/valid-modules/override-variable-type-defaults")
+	assertNoDiagnostics(t, diags)
+	if mod == nil {
+		t.Fatalf("module is nil")
+	}
+
+	t.Run("default overridden", func(t *testing.T) {
+		got := mod.Variables["default_overridden"].Default
+		want := cty.ObjectVal(map[string]cty.Value{
+			"name": cty.StringVal("override"),
+			"tags": cty.MapValEmpty(cty.String),
+			"server": cty.ObjectVal(map[string]cty.Value{
+				"port": cty.NumberIntVal(22),
+			}),
+		})
+		if !got.RawEquals(want) {
+			t.Errorf("wrong default\ngot:  %#v\nwant: %#v", got, want)
+		}
+	})
+
+	t.Run("type overridden", func(t *testing.T) {
+		v := mod.Variables["type_overridden"]
+		want := cty.ObjectVal(map[string]cty.Value{
+			"name":     cty.StringVal("base"),
+			"old_name": cty.StringVal("unused"),
+		})
+		if !v.Default.RawEquals(want) {
+			t.Errorf("wrong default\ngot:  %#v\nwant: %#v", v.Default, want)
+		}
+		if len(v.DeprecatedAttributes) != 1 || v.DeprecatedAttributes[0].Message != "Use name instead." {
+			t.Errorf("wrong deprecated attributes: %#v", v.DeprecatedAttributes)
+		}
+	})
+}
@@ -6,6 +6,7 @@
 package configs
 
 import (
+	"slices"
 	"strings"
 	"testing"
 
@@ -294,6 +295,26 @@ func TestModule_required_providers_multiple_one_with_syntax_error(t *testing.T)
 // requirements map for a local name matching the resource type, and fall back
 // to a default provider if none is found. This applies to both managed and
 // data resources.
+func TestModule_required_functions(t *testing.T) {
+	mod, diags := testModuleFromDir("testdata/valid-modules/required-functions")
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+
+	var got []string
+	for name := range mod.RequiredFunctions {
+		got = append(got, name)
+	}
+	slices.Sort(got)
+	want := []string{"jsonutil", "naming", "testing"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong required functions\n%s", diff)
+	}
+	if got, want := mod.RequiredFunctions["jsonutil"].DeclRange.Filename, "testdata/valid-modules/required-functions/main.tf"; got != want {
+		t.Errorf("wrong declaration for jsonutil in %s; want %s", got, want)
+	}
+}
+
 func TestModule_implied_provider(t *testing.T) {
 	mod, diags := testModuleFromDir("testdata/valid-modules/implied-providers")
 	if diags.HasErrors() {
@@ -45,6 +45,10 @@ type Variable struct {
 	ConstraintType cty.Type
 	TypeDefaults   *typeexpr.Defaults
 
+	// DeprecatedAttributes are the nested attributes of ConstraintType that
+	// the module author has marked as deprecated.
+	DeprecatedAttributes []*DeprecatedAttribute
+
 	ParsingMode VariableParsingMode
 	Validations []*CheckRule
 	Sensitive   bool
@@ -123,10 +127,13 @@ func decodeVariableBlock(block *hcl.Block, override bool) (*Variable, hcl.Diagno
 	}
 
 	if attr, exists := content.Attributes["type"]; exists {
-		ty, tyDefaults, parseMode, tyDiags := decodeVariableType(attr.Expr)
+		tyExpr, deprecatedAttrs, depDiags := extractDeprecatedAttributes(attr.Expr)
+		diags = append(diags, depDiags...)
+		ty, tyDefaults, parseMode, tyDiags := decodeVariableType(tyExpr)
 		diags = append(diags, tyDiags...)
 		v.ConstraintType = ty
 		v.TypeDefaults = tyDefaults
+		v.DeprecatedAttributes = deprecatedAttrs
 		v.Type = ty.WithoutOptionalAttributesDeep()
 		v.ParsingMode = parseMode
 	}
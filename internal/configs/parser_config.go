@@ -101,6 +101,12 @@ func loadConfigFileBody(body hcl.Body, _ string, override bool) (*File, hcl.Diag
 			// with "required_version" and the other two are not relevant
 			// to OpenTofu. ("language" blocks contain OpenTofu's equivalents.)
 
+			if attr, exists := content.Attributes["required_functions"]; exists {
+				reqs, reqsDiags := decodeRequiredFunctionsAttr(attr)
+				diags = append(diags, reqsDiags...)
+				file.RequiredFunctions = append(file.RequiredFunctions, reqs...)
+			}
+
 			for _, innerBlock := range content.Blocks {
 				switch innerBlock.Type {
 
@@ -236,6 +242,13 @@ func loadConfigFileBody(body hcl.Body, _ string, override bool) (*File, hcl.Diag
 				file.Removed = append(file.Removed, cfg)
 			}
 
+		case "function":
+			cfg, cfgDiags := decodeFunctionBlock(block)
+			diags = append(diags, cfgDiags...)
+			if cfg != nil {
+				file.Functions = append(file.Functions, cfg)
+			}
+
 		default:
 			// Should never happen because the above cases should be exhaustive
 			// for all block type names in our schema.
@@ -305,6 +318,10 @@ var configFileSchema = &hcl.BodySchema{
 		{
 			Type: "removed",
 		},
+		{
+			Type:       "function",
+			LabelNames: []string{"name"},
+		},
 		{
 			Type: "terraform",
 		},
@@ -329,6 +346,8 @@ var terraformBlockSchema = &hcl.BodySchema{
 		// "language" blocks, which are handled elsewhere in this package.
 		{Name: "experiments"},
 		{Name: "language"},
+
+		{Name: "required_functions"},
 	},
 	Blocks: []hcl.BlockHeaderSchema{
 		{
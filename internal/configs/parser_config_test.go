@@ -106,6 +106,42 @@ func TestParserLoadConfigFileFailureMessages(t *testing.T) {
 			"Invalid data resource lifecycle argument",
 			`The lifecycle argument "ignore_changes" is defined only for managed resources ("resource" blocks), and is not valid for data resources.`,
 		},
+		{
+			"invalid-files/provider-max-concurrency-bad.tf",
+			hcl.DiagError,
+			"Invalid max_concurrency value",
+			"The max_concurrency argument must be a whole number greater than zero.",
+		},
+		{
+			"invalid-files/resource-lifecycle-retry-bad.tf",
+			hcl.DiagError,
+			"Invalid retry block",
+			"The retry policy is invalid: invalid on_error_matching pattern \"(\": error parsing regexp: missing closing ): `(`.",
+		},
+		{
+			"invalid-files/required-functions-bad-name.tf",
+			hcl.DiagError,
+			"Invalid function plugin name",
+			"Each element of required_functions must be a string containing the name of a function plugin. A name must start with a letter or underscore and may contain only letters, digits, underscores, and dashes.",
+		},
+		{
+			"invalid-files/function-reference.tf",
+			hcl.DiagError,
+			"Invalid reference in function",
+			`The result of function "name" can only refer to the function's parameters, so "var" is not available.`,
+		},
+		{
+			"invalid-files/function-impure.tf",
+			hcl.DiagError,
+			"Impure function call in function",
+			`A function declared by a "function" block must always return the same result for the same arguments, so the result of function "stamp" can't call "timestamp".`,
+		},
+		{
+			"invalid-files/function-plugin.tf",
+			hcl.DiagError,
+			"Plugin function call in function",
+			`The result of function "shout" can't call functions provided by function plugins such as "plugin::strings::upper".`,
+		},
 		{
 			"invalid-files/variable-type-unknown.tf",
 			hcl.DiagError,
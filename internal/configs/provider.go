@@ -31,6 +31,11 @@ type Provider struct {
 
 	Version VersionConstraint
 
+	// MaxConcurrency, if greater than zero, limits how many operations on
+	// resource instances that belong to this provider configuration
+	// OpenTofu will run at once.
+	MaxConcurrency int
+
 	Config hcl.Body
 
 	DeclRange hcl.Range
@@ -103,6 +108,19 @@ func decodeProviderBlock(block *hcl.Block) (*Provider, hcl.Diagnostics) {
 		diags = append(diags, versionDiags...)
 	}
 
+	if attr, exists := content.Attributes["max_concurrency"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &provider.MaxConcurrency)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() && provider.MaxConcurrency < 1 {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid max_concurrency value",
+				Detail:   "The max_concurrency argument must be a whole number greater than zero.",
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+		}
+	}
+
 	if attr, exists := content.Attributes["for_each"]; exists {
 		provider.ForEach = attr.Expr
 	}
@@ -303,6 +321,9 @@ var providerBlockSchema = &hcl.BodySchema{
 		{
 			Name: "for_each",
 		},
+		{
+			Name: "max_concurrency",
+		},
 
 		// Attribute names reserved for future expansion.
 		{Name: "count"},
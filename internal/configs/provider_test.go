@@ -37,6 +37,34 @@ func TestProviderReservedNames(t *testing.T) {
 	})
 }
 
+func TestProviderMaxConcurrency(t *testing.T) {
+	parser := testParser(map[string]string{
+		"config.tf": `
+provider "foo" {
+  max_concurrency = 2
+  other           = "value"
+}
+`,
+	})
+	file, diags := parser.LoadConfigFile("config.tf")
+	assertNoDiagnostics(t, diags)
+
+	if got, want := len(file.ProviderConfigs), 1; got != want {
+		t.Fatalf("wrong number of provider configs %d; want %d", got, want)
+	}
+	provider := file.ProviderConfigs[0]
+	if got, want := provider.MaxConcurrency, 2; got != want {
+		t.Errorf("wrong max_concurrency %d; want %d", got, want)
+	}
+
+	// max_concurrency is a meta-argument, so it must not be passed on to
+	// the provider as part of its configuration.
+	attrs, _ := provider.Config.JustAttributes()
+	if _, exists := attrs["max_concurrency"]; exists {
+		t.Errorf("max_concurrency was included in the provider configuration")
+	}
+}
+
 func TestParseProviderConfigCompact(t *testing.T) {
 	tests := []struct {
 		Input    string
@@ -79,6 +79,10 @@ type ManagedResource struct {
 	IgnoreChanges    []hcl.Traversal
 	IgnoreAllChanges bool
 
+	// Retry is the policy for retrying failed changes, from the "retry"
+	// block in the lifecycle block, or nil if there isn't one.
+	Retry *RetryPolicy
+
 	CreateBeforeDestroySet bool
 }
 
@@ -304,6 +308,19 @@ func decodeResourceBlock(block *hcl.Block, override bool) (*Resource, hcl.Diagno
 					case "postcondition":
 						r.Postconditions = append(r.Postconditions, cr)
 					}
+				case "retry":
+					if r.Managed.Retry != nil {
+						diags = append(diags, &hcl.Diagnostic{
+							Severity: hcl.DiagError,
+							Summary:  "Duplicate retry block",
+							Detail:   fmt.Sprintf("This resource already has a retry block at %s.", r.Managed.Retry.DeclRange),
+							Subject:  &block.DefRange,
+						})
+						continue
+					}
+					policy, moreDiags := decodeRetryBlock(block)
+					diags = append(diags, moreDiags...)
+					r.Managed.Retry = policy
 				default:
 					// The cases above should be exhaustive for all block types
 					// defined in the lifecycle schema, so this shouldn't happen.
@@ -564,6 +581,13 @@ func decodeDataBlock(block *hcl.Block, override, nested bool) (*Resource, hcl.Di
 					case "postcondition":
 						r.Postconditions = append(r.Postconditions, cr)
 					}
+				case "retry":
+					diags = append(diags, &hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Invalid data resource lifecycle block",
+						Detail:   `The lifecycle block "retry" is defined only for managed resources ("resource" blocks), and is not valid for data resources.`,
+						Subject:  block.DefRange.Ptr(),
+					})
 				default:
 					// The cases above should be exhaustive for all block types
 					// defined in the lifecycle schema, so this shouldn't happen.
@@ -725,6 +749,8 @@ func decodeEphemeralBlock(block *hcl.Block, override bool) (*Resource, hcl.Diagn
 					case "postcondition":
 						r.Postconditions = append(r.Postconditions, cr)
 					}
+				case "retry":
+					diags = append(diags, invalidEphemeralBlockDiag("retry", block.DefRange))
 				default:
 					// The cases above should be exhaustive for all block types
 					// defined in the lifecycle schema, so this shouldn't happen.
@@ -1155,5 +1181,6 @@ var resourceLifecycleBlockSchema = &hcl.BodySchema{
 	Blocks: []hcl.BlockHeaderSchema{
 		{Type: "precondition"},
 		{Type: "postcondition"},
+		{Type: "retry"},
 	},
 }
@@ -0,0 +1,142 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+)
+
+// DefaultRetryBackoff is the delay before the first retry of a failed change
+// when a retry policy doesn't specify its own.
+const DefaultRetryBackoff = time.Second
+
+// MaxRetryDelay is the longest that OpenTofu waits before any retry, however
+// many times the delay has doubled.
+const MaxRetryDelay = 5 * time.Minute
+
+// RetryPolicy describes how OpenTofu should retry applying a change to a
+// managed resource instance when the provider returns an error, as set by a
+// "retry" block inside a resource's "lifecycle" block or by the CLI
+// configuration.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times to try applying a change,
+	// including the first attempt.
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry. The delay doubles for
+	// each subsequent retry, up to MaxRetryDelay.
+	Backoff time.Duration
+
+	// OnErrorMatching, if not empty, restricts retries to errors whose
+	// summary or detail matches at least one of these patterns.
+	OnErrorMatching []*regexp.Regexp
+
+	DeclRange hcl.Range
+}
+
+// NewRetryPolicy builds a RetryPolicy from its raw settings, as written in
+// the configuration. backoff is a duration string as accepted by
+// [time.ParseDuration], or empty to use [DefaultRetryBackoff].
+func NewRetryPolicy(maxAttempts int, backoff string, onErrorMatching []string) (*RetryPolicy, error) {
+	if maxAttempts < 1 {
+		return nil, fmt.Errorf("max_attempts must be at least 1")
+	}
+	ret := &RetryPolicy{
+		MaxAttempts: maxAttempts,
+		Backoff:     DefaultRetryBackoff,
+	}
+	if backoff != "" {
+		d, err := time.ParseDuration(backoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid backoff duration %q: %w", backoff, err)
+		}
+		if d < 0 {
+			return nil, fmt.Errorf("backoff duration must not be negative")
+		}
+		ret.Backoff = d
+	}
+	for _, pattern := range onErrorMatching {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid on_error_matching pattern %q: %w", pattern, err)
+		}
+		ret.OnErrorMatching = append(ret.OnErrorMatching, re)
+	}
+	return ret, nil
+}
+
+// Delay returns how long to wait before the given retry, where 1 is the
+// first retry.
+func (p *RetryPolicy) Delay(retry int) time.Duration {
+	// We stop doubling once we reach the maximum, so the delay can't
+	// overflow however many retries are allowed.
+	d := min(p.Backoff, MaxRetryDelay)
+	for i := 1; i < retry && d > 0 && d < MaxRetryDelay; i++ {
+		d *= 2
+	}
+	return min(d, MaxRetryDelay)
+}
+
+// MatchesError returns true if the policy allows retrying after an error
+// with the given summary and detail.
+func (p *RetryPolicy) MatchesError(summary, detail string) bool {
+	if len(p.OnErrorMatching) == 0 {
+		return true
+	}
+	for _, re := range p.OnErrorMatching {
+		if re.MatchString(summary) || re.MatchString(detail) {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeRetryBlock(block *hcl.Block) (*RetryPolicy, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	content, moreDiags := block.Body.Content(retryBlockSchema)
+	diags = append(diags, moreDiags...)
+
+	var maxAttempts int
+	var backoff string
+	var patterns []string
+	if attr, exists := content.Attributes["max_attempts"]; exists {
+		diags = append(diags, gohcl.DecodeExpression(attr.Expr, nil, &maxAttempts)...)
+	}
+	if attr, exists := content.Attributes["backoff"]; exists {
+		diags = append(diags, gohcl.DecodeExpression(attr.Expr, nil, &backoff)...)
+	}
+	if attr, exists := content.Attributes["on_error_matching"]; exists {
+		diags = append(diags, gohcl.DecodeExpression(attr.Expr, nil, &patterns)...)
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	ret, err := NewRetryPolicy(maxAttempts, backoff, patterns)
+	if err != nil {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid retry block",
+			Detail:   fmt.Sprintf("The retry policy is invalid: %s.", err),
+			Subject:  block.DefRange.Ptr(),
+		})
+		return nil, diags
+	}
+	ret.DeclRange = block.DefRange
+	return ret, diags
+}
+
+var retryBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "max_attempts", Required: true},
+		{Name: "backoff"},
+		{Name: "on_error_matching"},
+	},
+}
@@ -0,0 +1,75 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	policy, err := NewRetryPolicy(4, "2s", []string{"Throttling", "(?i)rate exceeded"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for retry, want := range map[int]time.Duration{1: 2 * time.Second, 2: 4 * time.Second, 3: 8 * time.Second} {
+		if got := policy.Delay(retry); got != want {
+			t.Errorf("wrong delay for retry %d: got %s, want %s", retry, got, want)
+		}
+	}
+
+	tests := []struct {
+		summary, detail string
+		want            bool
+	}{
+		{"ThrottlingException", "", true},
+		{"API error", "Rate Exceeded for CreateRole", true},
+		{"AccessDenied", "not authorized to perform iam:CreateRole", false},
+	}
+	for _, test := range tests {
+		if got := policy.MatchesError(test.summary, test.detail); got != test.want {
+			t.Errorf("wrong result for %q/%q: got %t, want %t", test.summary, test.detail, got, test.want)
+		}
+	}
+
+	// The delay stops doubling at the maximum, rather than growing without
+	// limit or overflowing.
+	policy, err = NewRetryPolicy(100, "5s", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for retry, want := range map[int]time.Duration{6: 160 * time.Second, 7: MaxRetryDelay, 20: MaxRetryDelay, 40: MaxRetryDelay, 99: MaxRetryDelay} {
+		if got := policy.Delay(retry); got != want {
+			t.Errorf("wrong delay for retry %d: got %s, want %s", retry, got, want)
+		}
+	}
+	policy, err = NewRetryPolicy(2, "1h", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := policy.Delay(1); got != MaxRetryDelay {
+		t.Errorf("wrong delay for backoff longer than the maximum: got %s, want %s", got, MaxRetryDelay)
+	}
+
+	policy, err = NewRetryPolicy(2, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policy.Backoff != DefaultRetryBackoff {
+		t.Errorf("wrong default backoff %s", policy.Backoff)
+	}
+	if !policy.MatchesError("anything", "") {
+		t.Error("policy with no patterns should match any error")
+	}
+
+	for _, bad := range []struct {
+		maxAttempts int
+		backoff     string
+	}{{0, ""}, {3, "soon"}, {3, "-1s"}} {
+		if _, err := NewRetryPolicy(bad.maxAttempts, bad.backoff, nil); err == nil {
+			t.Errorf("expected error for max_attempts=%d backoff=%q", bad.maxAttempts, bad.backoff)
+		}
+	}
+}
@@ -81,13 +81,21 @@ func RootModuleCallForTesting() StaticModuleCall {
 type StaticEvaluator struct {
 	call StaticModuleCall
 	cfg  *Module
+
+	// userFunctions are the user-defined functions of the module, built
+	// once so that every static scope shares them. Only the module's own
+	// functions are available, because functions exported by parent modules
+	// aren't known during static evaluation.
+	userFunctions map[string]*lang.UserFunction
 }
 
 // Creates a static evaluator based from the given module and module call
 func NewStaticEvaluator(mod *Module, call StaticModuleCall) *StaticEvaluator {
+	userFunctions, _ := mod.userFunctions(nil)
 	return &StaticEvaluator{
-		call: call,
-		cfg:  mod,
+		call:          call,
+		cfg:           mod,
+		userFunctions: userFunctions,
 	}
 }
 
@@ -674,7 +674,7 @@ func decodeTestRunModuleBlock(block *hcl.Block) (*TestRunModuleCall, hcl.Diagnos
 		if !rawDiags.HasErrors() {
 			var err error
 			if haveVersionArg {
-				module.Source, err = addrs.ParseModuleSourceRegistry(raw)
+				module.Source, err = addrs.ParseModuleSourceVersioned(raw)
 			} else {
 				module.Source, err = addrs.ParseModuleSource(raw)
 			}
@@ -704,14 +704,14 @@ func decodeTestRunModuleBlock(block *hcl.Block) (*TestRunModuleCall, hcl.Diagnos
 						Subject: module.SourceDeclRange.Ptr(),
 					})
 				default:
-					if haveVersionArg {
+					if haveVersionArg && !getmodules.IsGitPackageAddress(raw) {
 						// In this case we'll include some extra context that
 						// we assumed a registry source address due to the
 						// version argument.
 						diags = append(diags, &hcl.Diagnostic{
 							Severity: hcl.DiagError,
 							Summary:  "Invalid registry module source address",
-							Detail:   fmt.Sprintf("Failed to parse module registry address: %s.\n\nOpenTofu assumed that you intended a module registry source address because you also set the argument \"version\", which applies only to registry modules.", err),
+							Detail:   fmt.Sprintf("Failed to parse module registry address: %s.\n\nOpenTofu assumed that you intended a module registry source address because you also set the argument \"version\", which applies only to registry modules and to git repositories selected with the \"git::\" prefix.", err),
 							Subject:  module.SourceDeclRange.Ptr(),
 						})
 					} else {
@@ -0,0 +1,33 @@
+variable "region" {
+  validation {
+    condition     = var.region != ""
+    error_message = "The region must not be empty."
+    error_code    = "1BAD" # ERROR: Invalid error code
+  }
+
+  validation {
+    condition     = var.region != "mars"
+    error_message = "The region must be on Earth."
+    error_code    = "REGION_${var.region}" # ERROR: Variables not allowed
+  }
+
+  validation {
+    condition     = var.region != "moon"
+    error_message = "The region must not be the moon."
+    severity      = fatal # ERROR: Invalid "severity" keyword
+  }
+}
+
+check "health" {
+  assert { # ERROR: Invalid assertion severity
+    condition     = var.region != "venus"
+    error_message = "Venus is too hot."
+    severity      = error
+  }
+
+  assert {
+    condition     = var.region != "mercury"
+    error_message = "Mercury is too close to the sun."
+    severity      = warning
+  }
+}
@@ -0,0 +1,10 @@
+function "shadowed" {
+  result = "child"
+}
+
+function "own" {
+  parameters = {
+    s = string
+  }
+  result = "${module::exported()}-${s}"
+}
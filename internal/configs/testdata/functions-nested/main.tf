@@ -0,0 +1,17 @@
+function "exported" {
+  result = "root"
+  export = true
+}
+
+function "private" {
+  result = "root"
+}
+
+function "shadowed" {
+  result = "root"
+  export = true
+}
+
+module "child" {
+  source = "./child"
+}
@@ -0,0 +1,7 @@
+function "name" {
+  parameters = {
+    name = string
+    name = number
+  }
+  result = name
+}
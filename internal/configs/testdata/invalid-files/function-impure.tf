@@ -0,0 +1,6 @@
+function "stamp" {
+  parameters = {
+    name = string
+  }
+  result = "${name}-${timestamp()}"
+}
@@ -0,0 +1,10 @@
+terraform {
+  required_functions = ["strings"]
+}
+
+function "shout" {
+  parameters = {
+    name = string
+  }
+  result = plugin::strings::upper(name)
+}
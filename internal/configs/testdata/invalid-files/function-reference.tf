@@ -0,0 +1,10 @@
+variable "prefix" {
+  type = string
+}
+
+function "name" {
+  parameters = {
+    name = string
+  }
+  result = "${var.prefix}-${name}"
+}
@@ -0,0 +1,3 @@
+provider "foo" {
+  max_concurrency = 0
+}
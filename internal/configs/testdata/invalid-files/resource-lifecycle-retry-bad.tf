@@ -0,0 +1,8 @@
+resource "aws_iam_role" "example" {
+  lifecycle {
+    retry {
+      max_attempts      = 3
+      on_error_matching = ["("]
+    }
+  }
+}
@@ -0,0 +1,13 @@
+function "even" {
+  parameters = {
+    n = number
+  }
+  result = n == 0 ? true : module::odd(n - 1)
+}
+
+function "odd" {
+  parameters = {
+    n = number
+  }
+  result = n == 0 ? false : module::even(n - 1)
+}
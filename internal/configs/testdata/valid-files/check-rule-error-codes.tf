@@ -0,0 +1,51 @@
+variable "quota" {
+  type = number
+
+  validation {
+    condition     = var.quota <= 100
+    error_message = "The quota must not exceed 100."
+    error_code    = "QUOTA_EXCEEDED"
+  }
+
+  validation {
+    condition     = var.quota >= 10
+    error_message = "A quota below 10 is unlikely to be enough."
+    error_code    = "quota.low"
+    severity      = warning
+  }
+}
+
+resource "test" "example" {
+  lifecycle {
+    precondition {
+      condition     = var.quota > 0
+      error_message = "The quota must be positive."
+      severity      = error
+    }
+    postcondition {
+      condition     = self.id != ""
+      error_message = "The resource must have an ID."
+      error_code    = "MISSING-ID"
+      severity      = warning
+    }
+  }
+}
+
+output "quota" {
+  value = var.quota
+
+  precondition {
+    condition     = var.quota != 42
+    error_message = "The quota is suspicious."
+    error_code    = "QUOTA_SUSPICIOUS"
+  }
+}
+
+check "quota" {
+  assert {
+    condition     = var.quota < 90
+    error_message = "The quota is nearly exhausted."
+    error_code    = "QUOTA_NEARLY_EXHAUSTED"
+    severity      = warning
+  }
+}
@@ -0,0 +1,28 @@
+function "normalize_tags" {
+  description = "Lowercases tag keys and trims whitespace from tag values."
+  parameters = {
+    tags = map(string)
+  }
+  result = { for k, v in tags : lower(k) => trimspace(v) }
+  export = true
+}
+
+function "resource_name" {
+  parameters = {
+    env   = string
+    name  = string
+    index = number
+  }
+  result = format("%s-%s-%02d", module::sanitize(env), module::sanitize(name), index)
+}
+
+function "sanitize" {
+  parameters = {
+    s = string
+  }
+  result = replace(lower(s), "/[^a-z0-9]+/", "-")
+}
+
+function "answer" {
+  result = 42
+}
@@ -16,3 +16,8 @@ provider "baz" {
   alias = "foo"
   for_each = {"a": "first", "b": "second"}
 }
+
+provider "baz" {
+  alias           = "limited"
+  max_concurrency = 2
+}
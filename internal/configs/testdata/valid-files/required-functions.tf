@@ -0,0 +1,7 @@
+terraform {
+  required_functions = ["jsonutil", "naming"]
+}
+
+locals {
+  name = plugin::naming::resource_name("web", 1)
+}
@@ -0,0 +1,19 @@
+resource "aws_iam_role" "example" {
+  name = "example"
+
+  lifecycle {
+    retry {
+      max_attempts      = 5
+      backoff           = "2s"
+      on_error_matching = ["Throttling", "(?i)rate exceeded"]
+    }
+  }
+}
+
+resource "aws_instance" "minimal" {
+  lifecycle {
+    retry {
+      max_attempts = 3
+    }
+  }
+}
@@ -0,0 +1,16 @@
+variable "default_overridden" {
+  default = {
+    name   = "override"
+    server = {}
+  }
+}
+
+variable "type_overridden" {
+  type = object({
+    name = string
+    old_name = {
+      type       = optional(string, "unused")
+      deprecated = "Use name instead."
+    }
+  })
+}
@@ -0,0 +1,21 @@
+variable "default_overridden" {
+  type = object({
+    name = string
+    tags = optional(map(string), {})
+    server = optional(object({
+      port = optional(number, 22)
+    }), {})
+  })
+  default = {
+    name = "base"
+  }
+}
+
+variable "type_overridden" {
+  type = object({
+    name = string
+  })
+  default = {
+    name = "base"
+  }
+}
@@ -0,0 +1,3 @@
+terraform {
+  required_functions = ["jsonutil"]
+}
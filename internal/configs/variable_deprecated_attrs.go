@@ -0,0 +1,204 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// DeprecatedAttribute describes a nested attribute of an input variable's
+// type constraint that the module author has marked as deprecated.
+//
+// A module author declares a deprecated attribute by writing an object with
+// "type" and "deprecated" arguments in place of the attribute's type:
+//
+//	type = object({
+//	  name     = string
+//	  old_name = {
+//	    type       = optional(string)
+//	    deprecated = "Use name instead."
+//	  }
+//	})
+type DeprecatedAttribute struct {
+	// Path is the path to the attribute from the variable's value. An index
+	// step whose key is unknown stands for every element of a collection.
+	Path cty.Path
+
+	// Message is the message given in the "deprecated" argument.
+	Message string
+
+	DeclRange hcl.Range
+}
+
+// extractDeprecatedAttributes finds the deprecated attributes declared in the
+// given type constraint expression, and returns an expression without those
+// declarations that typeexpr can decode.
+//
+// Only the native syntax supports deprecated attributes, so any other
+// expression is returned unchanged.
+func extractDeprecatedAttributes(expr hcl.Expression) (hcl.Expression, []*DeprecatedAttribute, hcl.Diagnostics) {
+	syntaxExpr, ok := expr.(hclsyntax.Expression)
+	if !ok {
+		return expr, nil, nil
+	}
+	var attrs []*DeprecatedAttribute
+	newExpr, diags := extractDeprecatedAttributesFrom(syntaxExpr, nil, &attrs)
+	return newExpr, attrs, diags
+}
+
+func extractDeprecatedAttributesFrom(expr hclsyntax.Expression, path cty.Path, attrs *[]*DeprecatedAttribute) (hclsyntax.Expression, hcl.Diagnostics) {
+	call, ok := expr.(*hclsyntax.FunctionCallExpr)
+	if !ok || len(call.Args) == 0 {
+		// Only calls like object(...) and list(...) can have attributes
+		// nested inside them. Anything else is either a primitive type or
+		// something typeexpr will reject with a suitable error.
+		return expr, nil
+	}
+
+	var diags hcl.Diagnostics
+	newCall := *call
+	newCall.Args = slices.Clone(call.Args)
+
+	switch call.Name {
+	case "object":
+		cons, ok := call.Args[0].(*hclsyntax.ObjectConsExpr)
+		if !ok {
+			return expr, nil
+		}
+		newCons := *cons
+		newCons.Items = slices.Clone(cons.Items)
+		for i, item := range cons.Items {
+			name := hcl.ExprAsKeyword(item.KeyExpr)
+			if name == "" {
+				continue
+			}
+			attrPath := append(path.Copy(), cty.GetAttrStep{Name: name})
+
+			valueExpr := item.ValueExpr
+			if spec, ok := valueExpr.(*hclsyntax.ObjectConsExpr); ok {
+				var moreDiags hcl.Diagnostics
+				valueExpr, moreDiags = decodeDeprecatedAttribute(name, spec, attrPath, attrs)
+				diags = append(diags, moreDiags...)
+			}
+			valueExpr, moreDiags := extractDeprecatedAttributesFrom(valueExpr, attrPath, attrs)
+			diags = append(diags, moreDiags...)
+			newCons.Items[i].ValueExpr = valueExpr
+		}
+		newCall.Args[0] = &newCons
+
+	case "optional":
+		// An optional attribute has the same path as its type.
+		argExpr, moreDiags := extractDeprecatedAttributesFrom(call.Args[0], path, attrs)
+		diags = append(diags, moreDiags...)
+		newCall.Args[0] = argExpr
+
+	case "list", "set", "map":
+		elemPath := append(path.Copy(), cty.IndexStep{Key: cty.DynamicVal})
+		argExpr, moreDiags := extractDeprecatedAttributesFrom(call.Args[0], elemPath, attrs)
+		diags = append(diags, moreDiags...)
+		newCall.Args[0] = argExpr
+
+	case "tuple":
+		cons, ok := call.Args[0].(*hclsyntax.TupleConsExpr)
+		if !ok {
+			return expr, nil
+		}
+		newCons := *cons
+		newCons.Exprs = slices.Clone(cons.Exprs)
+		for i, elemExpr := range cons.Exprs {
+			elemPath := append(path.Copy(), cty.IndexStep{Key: cty.NumberIntVal(int64(i))})
+			elemExpr, moreDiags := extractDeprecatedAttributesFrom(elemExpr, elemPath, attrs)
+			diags = append(diags, moreDiags...)
+			newCons.Exprs[i] = elemExpr
+		}
+		newCall.Args[0] = &newCons
+
+	default:
+		return expr, nil
+	}
+
+	return &newCall, diags
+}
+
+// decodeDeprecatedAttribute decodes an object with "type" and "deprecated"
+// arguments given as the type of the named attribute, and returns the
+// expression for the attribute's type.
+func decodeDeprecatedAttribute(name string, spec *hclsyntax.ObjectConsExpr, path cty.Path, attrs *[]*DeprecatedAttribute) (hclsyntax.Expression, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	var typeExpr, deprecatedExpr hclsyntax.Expression
+
+	for _, item := range spec.Items {
+		switch hcl.ExprAsKeyword(item.KeyExpr) {
+		case "type":
+			typeExpr = item.ValueExpr
+		case "deprecated":
+			deprecatedExpr = item.ValueExpr
+		default:
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid attribute specification",
+				Detail:   "An object given as the type of an attribute can only have the arguments \"type\" and \"deprecated\".",
+				Subject:  item.KeyExpr.Range().Ptr(),
+			})
+		}
+	}
+	if typeExpr == nil || deprecatedExpr == nil {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid attribute specification",
+			Detail:   fmt.Sprintf("The specification of attribute %q must have both a \"type\" argument and a \"deprecated\" argument.", name),
+			Subject:  spec.Range().Ptr(),
+		})
+		if typeExpr == nil {
+			// We use "any" in place of the missing type so that typeexpr
+			// doesn't report a second error about the same problem.
+			rng := spec.Range()
+			return &hclsyntax.ScopeTraversalExpr{
+				Traversal: hcl.Traversal{hcl.TraverseRoot{Name: "any", SrcRange: rng}},
+				SrcRange:  rng,
+			}, diags
+		}
+		return typeExpr, diags
+	}
+
+	if call, ok := typeExpr.(*hclsyntax.FunctionCallExpr); !ok || call.Name != "optional" {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid deprecated attribute",
+			Detail:   fmt.Sprintf("Attribute %q is deprecated, so it must be optional, because otherwise callers couldn't stop setting it. Use optional(...) for its type.", name),
+			Subject:  typeExpr.Range().Ptr(),
+		})
+	}
+
+	var msg string
+	val, valDiags := deprecatedExpr.Value(nil)
+	diags = append(diags, valDiags...)
+	if !valDiags.HasErrors() {
+		if val.Type() != cty.String || val.IsNull() || strings.TrimSpace(val.AsString()) == "" {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid `deprecated` value",
+				Detail:   `The "deprecated" argument must be a string that isn't empty, and should provide instructions on how to migrate away from usage of this deprecated attribute.`,
+				Subject:  deprecatedExpr.Range().Ptr(),
+			})
+		} else {
+			msg = val.AsString()
+		}
+	}
+
+	if !diags.HasErrors() {
+		*attrs = append(*attrs, &DeprecatedAttribute{
+			Path:      path,
+			Message:   msg,
+			DeclRange: spec.Range(),
+		})
+	}
+	return typeExpr, diags
+}
@@ -0,0 +1,125 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestVariableDeprecatedAttributes(t *testing.T) {
+	parser := testParser(map[string]string{
+		"main.tf": `
+variable "settings" {
+  type = object({
+    name     = string
+    old_name = {
+      type       = optional(string)
+      deprecated = "Use name instead."
+    }
+    servers = optional(list(object({
+      host = string
+      port = {
+        type       = optional(number, 22)
+        deprecated = "Ports are now chosen automatically."
+      }
+    })), [])
+    pair = optional(tuple([string, object({
+      legacy = {
+        type       = optional(bool)
+        deprecated = "Not used anymore."
+      }
+    })]))
+  })
+}
+`,
+	})
+
+	file, diags := parser.LoadConfigFile("main.tf")
+	assertNoDiagnostics(t, diags)
+
+	v := file.Variables[0]
+	wantType := cty.Object(map[string]cty.Type{
+		"name":     cty.String,
+		"old_name": cty.String,
+		"servers": cty.List(cty.Object(map[string]cty.Type{
+			"host": cty.String,
+			"port": cty.Number,
+		})),
+		"pair": cty.Tuple([]cty.Type{cty.String, cty.Object(map[string]cty.Type{
+			"legacy": cty.Bool,
+		})}),
+	})
+	if !v.Type.Equals(wantType) {
+		t.Errorf("wrong type\ngot:  %#v\nwant: %#v", v.Type, wantType)
+	}
+
+	got := map[string]string{}
+	for _, attr := range v.DeprecatedAttributes {
+		got[fmt.Sprintf("%#v", attr.Path)] = attr.Message
+	}
+	want := map[string]string{
+		fmt.Sprintf("%#v", cty.GetAttrPath("old_name")):                                          "Use name instead.",
+		fmt.Sprintf("%#v", cty.GetAttrPath("servers").Index(cty.DynamicVal).GetAttr("port")):     "Ports are now chosen automatically.",
+		fmt.Sprintf("%#v", cty.GetAttrPath("pair").Index(cty.NumberIntVal(1)).GetAttr("legacy")): "Not used anymore.",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong deprecated attributes\n%s", diff)
+	}
+
+	// The defaults for the optional attributes must still be there,
+	// including the one declared inside a deprecated attribute.
+	if v.TypeDefaults == nil {
+		t.Fatal("missing type defaults")
+	}
+	gotVal := v.TypeDefaults.Apply(cty.ObjectVal(map[string]cty.Value{
+		"name":     cty.StringVal("a"),
+		"old_name": cty.NullVal(cty.String),
+		"servers": cty.TupleVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"host": cty.StringVal("h"),
+			"port": cty.NullVal(cty.Number),
+		})}),
+		"pair": cty.NullVal(cty.DynamicPseudoType),
+	}))
+	if port := gotVal.GetAttr("servers").Index(cty.NumberIntVal(0)).GetAttr("port"); !port.RawEquals(cty.NumberIntVal(22)) {
+		t.Errorf("wrong default for port: %#v", port)
+	}
+}
+
+func TestVariableDeprecatedAttributesInvalid(t *testing.T) {
+	src := `
+variable "settings" {
+  type = object({
+    required = {
+      type       = string
+      deprecated = "Callers can't avoid this."
+    }
+    empty = {
+      type       = optional(string)
+      deprecated = ""
+    }
+    missing = {
+      deprecated = "No type."
+    }
+    extra = {
+      type       = optional(string)
+      deprecated = "Too many arguments."
+      default    = "x"
+    }
+  })
+}
+`
+	parser := testParser(map[string]string{"main.tf": src})
+
+	_, diags := parser.LoadConfigFile("main.tf")
+	assertExactDiagnostics(t, diags, []string{
+		`main.tf:5,20-26: Invalid deprecated attribute; Attribute "required" is deprecated, so it must be optional, because otherwise callers couldn't stop setting it. Use optional(...) for its type.`,
+		"main.tf:10,20-22: Invalid `deprecated` value; The \"deprecated\" argument must be a string that isn't empty, and should provide instructions on how to migrate away from usage of this deprecated attribute.",
+		`main.tf:12,15-14,6: Invalid attribute specification; The specification of attribute "missing" must have both a "type" argument and a "deprecated" argument.`,
+		`main.tf:18,7-14: Invalid attribute specification; An object given as the type of an attribute can only have the arguments "type" and "deprecated".`,
+	})
+}
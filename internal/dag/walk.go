@@ -43,6 +43,10 @@ type Walker struct {
 	// Callback is what is called for each vertex
 	Callback WalkFunc
 
+	// SkipCallback, if set, is called instead of Callback for each vertex
+	// that is skipped because one of its dependencies failed.
+	SkipCallback func(Vertex)
+
 	// Reverse, if true, causes the source of an edge to depend on a target.
 	// When false (default), the target depends on the source.
 	Reverse bool
@@ -386,6 +390,9 @@ func (w *Walker) walkVertex(v Vertex, info *walkerVertex) {
 		diags = w.Callback(v)
 	} else {
 		log.Printf("[TRACE] dag/walk: upstream of %q errored, so skipping", VertexName(v))
+		if w.SkipCallback != nil {
+			w.SkipCallback(v)
+		}
 		// This won't be displayed to the user because we'll set upstreamFailed,
 		// but we need to ensure there's at least one error in here so that
 		// the failures will cascade downstream.
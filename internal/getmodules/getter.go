@@ -91,22 +91,27 @@ var goGetterDecompressorMediaTypes = map[string]string{
 	"archive/zip": "zip",
 }
 
-// goGetterGetters is an initial table of getters that we use as a starting
-// point when building a _real_ table of getters to pass into a
-// [reusingGetter] instance.
+// goGetterGetters is an initial table of constructors for the getters that
+// we use as a starting point when building a _real_ table of getters to pass
+// into a [reusingGetter] instance.
+//
+// [getter.Client.Get] modifies internal state inside each of the getters it
+// is given before calling into them, so it is not safe to use the same getter
+// instances for concurrent requests. We therefore instantiate a fresh set of
+// getters for each request using these constructors.
 //
 // The elements mapped to nil here are those which are populated dynamically
 // based on arguments to [NewPackageFetcher], included here only so it's
 // easier to refer to the entire list of supported getter keys in one place.
-var goGetterGetters = map[string]getter.Getter{
-	"file":  new(getter.FileGetter),
-	"gcs":   new(getter.GCSGetter),
-	"git":   new(getter.GitGetter),
-	"hg":    new(getter.HgGetter),
+var goGetterGetters = map[string]func() getter.Getter{
+	"file":  func() getter.Getter { return new(getter.FileGetter) },
+	"gcs":   func() getter.Getter { return new(getter.GCSGetter) },
+	"git":   func() getter.Getter { return new(getter.GitGetter) },
+	"hg":    func() getter.Getter { return new(getter.HgGetter) },
 	"http":  nil, // configured dynamically in NewPackageFetcher
 	"https": nil, // configured dynamically in NewPackageFetcher
 	"oci":   nil, // configured dynamically using [PackageFetcherEnvironment.OCIRepositoryStore]
-	"s3":    new(getter.S3Getter),
+	"s3":    func() getter.Getter { return new(getter.S3Getter) },
 }
 
 // A reusingGetter is a helper for the module installer that remembers
@@ -114,25 +119,44 @@ var goGetterGetters = map[string]getter.Getter{
 // asked to install, and will copy from a prior installation directory if
 // it has the same resolved source address.
 //
-// The keys in a reusingGetter are the normalized (post-detection) package
-// addresses, and the values are the paths where each source was previously
-// installed. (Users of this map should treat the keys as addrs.ModulePackage
-// values, but we can't type them that way because the addrs package
-// imports getmodules in order to indirectly access our go-getter
-// configuration.)
+// A reusingGetter is safe for concurrent use. Concurrent requests for
+// different packages proceed independently, while a request for a package
+// that is already being fetched waits for that fetch to complete and then
+// copies its result.
 type reusingGetter struct {
-	// getters are the go-getter getters that this particular instance of
-	// reusingGetter should use.
-	getters map[string]getter.Getter
+	// getters are the constructors for the go-getter getters that this
+	// particular instance of reusingGetter should use.
+	getters map[string]func() getter.Getter
+
+	// The keys in installs are the normalized (post-detection) package
+	// addresses. (Users of this map should treat the keys as
+	// addrs.ModulePackage values, but we can't type them that way because
+	// the addrs package imports getmodules in order to indirectly access our
+	// go-getter configuration.)
+	installs   map[string]*reusableInstall // initialized on first install request
+	installsMu sync.Mutex                  // must hold while interacting with installs
+}
 
-	previousInstalls   map[string]string // initialized on first install request
-	previousInstallsMu sync.Mutex        // must hold while interacting with previousInstalls
+// reusableInstall tracks a single package fetch that later requests for
+// the same package might reuse.
+type reusableInstall struct {
+	// done is closed once the fetch has completed, after which dir and err
+	// must not be modified.
+	done chan struct{}
+
+	// dir is the directory where the package was installed.
+	dir string
+
+	// err is the error from fetching the package, if any. Later requests
+	// for the same package will retry the fetch rather than reusing a
+	// failed result.
+	err error
 }
 
-func newReusingGetter(getters map[string]getter.Getter) *reusingGetter {
+func newReusingGetter(getters map[string]func() getter.Getter) *reusingGetter {
 	return &reusingGetter{
 		getters: getters,
-		// previousInstalls initialized only on request
+		// installs initialized only on request
 	}
 }
 
@@ -157,64 +181,106 @@ func newReusingGetter(getters map[string]getter.Getter) *reusingGetter {
 // reasonable way to improve these error messages at this layer because
 // the underlying errors are not separately recognizable.
 func (g *reusingGetter) getWithGoGetter(ctx context.Context, instPath, packageAddr string) error {
-	var err error
-
-	// For now we hold the "previousInstalls" mutex throughout our entire work here
-	// since we don't currently try to use a single getter concurrently anyway.
-	// If we _do_ want to enable more concurrency in future then we'll need a
-	// more interesting strategy to make sure that only concurrent attempts to
-	// install the _same_ package get serialized, but we'll wait until we have
-	// that need before we introduce that complexity.
-	//
-	// NOTE WELL: [getter.Client.Get] modifies internal state inside each of the
-	// getters passed in [getter.Client.Getters] before calling into them, so
-	// it is _not_ safe to reuse the same getter instances across multiple
-	// concurrent calls. If we want to make this work concurrently in future
-	// then we'll need to instead instantiate the getters on demand for each
-	// request.
-	g.previousInstallsMu.Lock()
-	defer g.previousInstallsMu.Unlock()
-	if g.previousInstalls == nil {
-		g.previousInstalls = make(map[string]string)
-	}
+	for {
+		g.installsMu.Lock()
+		if g.installs == nil {
+			g.installs = make(map[string]*reusableInstall)
+		}
+		prev, exists := g.installs[packageAddr]
+		if !exists {
+			// We'll be the one to fetch this package, and any concurrent
+			// requests for the same package will wait for us to finish.
+			inst := &reusableInstall{
+				done: make(chan struct{}),
+				dir:  instPath,
+			}
+			g.installs[packageAddr] = inst
+			g.installsMu.Unlock()
 
-	if prevDir, exists := g.previousInstalls[packageAddr]; exists {
-		log.Printf("[TRACE] getmodules: copying previous install of %q from %s to %s", packageAddr, prevDir, instPath)
+			inst.err = g.fetch(ctx, instPath, packageAddr)
+			if inst.err != nil {
+				// A failed fetch is not reusable, so we'll forget it to
+				// allow a subsequent request to try again.
+				g.installsMu.Lock()
+				if g.installs[packageAddr] == inst {
+					delete(g.installs, packageAddr)
+				}
+				g.installsMu.Unlock()
+			}
+			close(inst.done)
+			return inst.err
+		}
+		g.installsMu.Unlock()
+
+		select {
+		case <-prev.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if prev.err != nil {
+			// The fetch we were waiting for failed, so we'll try again
+			// ourselves in case the problem was specific to that request.
+			continue
+		}
+		if _, err := os.Stat(prev.dir); err != nil {
+			// The caller has since moved or removed the previous
+			// installation, so we'll need to fetch the package again.
+			g.installsMu.Lock()
+			if g.installs[packageAddr] == prev {
+				delete(g.installs, packageAddr)
+			}
+			g.installsMu.Unlock()
+			continue
+		}
+
+		log.Printf("[TRACE] getmodules: copying previous install of %q from %s to %s", packageAddr, prev.dir, instPath)
 		err := os.Mkdir(instPath, os.ModePerm)
 		if err != nil {
 			return fmt.Errorf("failed to create directory %s: %w", instPath, err)
 		}
-		err = copy.CopyDir(instPath, prevDir)
+		err = copy.CopyDir(instPath, prev.dir)
 		if err != nil {
-			return fmt.Errorf("failed to copy from %s to %s: %w", prevDir, instPath, err)
+			if _, statErr := os.Stat(prev.dir); statErr != nil {
+				// The previous installation was moved or removed while we
+				// were copying it, so we'll discard our partial copy and
+				// try again.
+				if err := os.RemoveAll(instPath); err != nil {
+					return fmt.Errorf("failed to remove %s: %w", instPath, err)
+				}
+				continue
+			}
+			return fmt.Errorf("failed to copy from %s to %s: %w", prev.dir, instPath, err)
 		}
-	} else {
-		log.Printf("[TRACE] getmodules: fetching %q to %q", packageAddr, instPath)
-		client := getter.Client{
-			Src: packageAddr,
-			Dst: instPath,
-			Pwd: instPath,
-
-			Mode: getter.ClientModeDir,
-
-			Detectors:     goGetterNoDetectors, // our caller should've already done detection
-			Decompressors: goGetterDecompressors,
-			Getters:       g.getters,
-			Ctx:           ctx,
-		}
-		err = client.Get()
-		if err != nil {
-			return err
+		// If we get down here then we've copied a previous tree we
+		// downloaded, and so we should have got the full module package
+		// structure written into instPath.
+		return nil
+	}
+}
+
+// fetch uses go-getter to fetch the package at the given address into the
+// given target directory, without any reuse of earlier fetches.
+func (g *reusingGetter) fetch(ctx context.Context, instPath, packageAddr string) error {
+	log.Printf("[TRACE] getmodules: fetching %q to %q", packageAddr, instPath)
+	getters := make(map[string]getter.Getter, len(g.getters))
+	for name, makeGetter := range g.getters {
+		if makeGetter != nil {
+			getters[name] = makeGetter()
 		}
-		// Remember where we installed this so we might reuse this directory
-		// on subsequent calls to avoid re-downloading.
-		g.previousInstalls[packageAddr] = instPath
 	}
+	client := getter.Client{
+		Src: packageAddr,
+		Dst: instPath,
+		Pwd: instPath,
 
-	// If we get down here then we've either downloaded the package or
-	// copied a previous tree we downloaded, and so either way we should
-	// have got the full module package structure written into instPath.
-	return nil
+		Mode: getter.ClientModeDir,
+
+		Detectors:     goGetterNoDetectors, // our caller should've already done detection
+		Decompressors: goGetterDecompressors,
+		Getters:       getters,
+		Ctx:           ctx,
+	}
+	return client.Get()
 }
 
 // withoutQueryParams implements getter.Detector and can be used to wrap another detector.
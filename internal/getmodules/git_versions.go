@@ -0,0 +1,133 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package getmodules
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+
+	version "github.com/hashicorp/go-version"
+)
+
+// gitPackagePrefix is the go-getter "forced getter" prefix that selects the
+// git getter. Version constraints are supported only for package addresses
+// that use it explicitly.
+const gitPackagePrefix = "git::"
+
+// GitTagVersion is a git tag that has been interpreted as a module version.
+type GitTagVersion struct {
+	Version *version.Version
+	Tag     string
+}
+
+// IsGitPackageAddress returns true if the given package address explicitly
+// selects the git getter, either as written in the configuration or after
+// normalization with [NormalizePackageAddress].
+func IsGitPackageAddress(packageAddr string) bool {
+	return strings.HasPrefix(packageAddr, gitPackagePrefix)
+}
+
+// GitPackageHasRef returns true if the given git package address already
+// selects a specific ref using the "ref" query string argument.
+func GitPackageHasRef(packageAddr string) bool {
+	u, err := url.Parse(strings.TrimPrefix(packageAddr, gitPackagePrefix))
+	if err != nil {
+		return false
+	}
+	return u.Query().Has("ref")
+}
+
+// GitPackageAddressWithRef returns a copy of the given git package address
+// with its "ref" query string argument set to the given ref.
+func GitPackageAddressWithRef(packageAddr string, ref string) (string, error) {
+	u, err := url.Parse(strings.TrimPrefix(packageAddr, gitPackagePrefix))
+	if err != nil {
+		return "", fmt.Errorf("invalid git repository URL: %w", err)
+	}
+	q := u.Query()
+	q.Set("ref", ref)
+	u.RawQuery = q.Encode()
+	return gitPackagePrefix + u.String(), nil
+}
+
+// GitTagVersions lists the tags in the git repository at the given package
+// address that name versions of the module in the given subdirectory of
+// the repository, ordered by increasing version.
+//
+// Monorepos typically tag each module separately by prefixing the version
+// number with the module's path, so when subDir is non-empty only tags of
+// the form "SUBDIR/vX.Y.Z" or "SUBDIR/X.Y.Z" are considered. Otherwise only
+// tags of the form "vX.Y.Z" or "X.Y.Z" are considered.
+//
+// The tags are listed using "git ls-remote", and so the git executable must
+// be available and able to authenticate to the repository using its own
+// ambient configuration, as for installing the package itself.
+func GitTagVersions(ctx context.Context, packageAddr string, subDir string) ([]GitTagVersion, error) {
+	if !IsGitPackageAddress(packageAddr) {
+		return nil, fmt.Errorf("%q is not a git repository address", packageAddr)
+	}
+	u, err := url.Parse(strings.TrimPrefix(packageAddr, gitPackagePrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid git repository URL: %w", err)
+	}
+	// The query string contains arguments for go-getter, not for git.
+	u.RawQuery = ""
+
+	cmd := exec.CommandContext(ctx, "git", "ls-remote", "--tags", "--refs", u.String())
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("failed to list tags: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	return parseGitTagVersions(&stdout, subDir), nil
+}
+
+// parseGitTagVersions interprets the output of "git ls-remote --tags --refs"
+// as described for [GitTagVersions].
+func parseGitTagVersions(lsRemoteOutput io.Reader, subDir string) []GitTagVersion {
+	prefix := ""
+	if subDir != "" {
+		prefix = path.Clean(subDir) + "/"
+	}
+
+	var ret []GitTagVersion
+	sc := bufio.NewScanner(lsRemoteOutput)
+	for sc.Scan() {
+		// Each line is a commit id and a ref name, separated by a tab.
+		_, ref, ok := strings.Cut(sc.Text(), "\t")
+		if !ok {
+			continue
+		}
+		tag, ok := strings.CutPrefix(ref, "refs/tags/")
+		if !ok {
+			continue
+		}
+		versionStr, ok := strings.CutPrefix(tag, prefix)
+		if !ok || strings.Contains(versionStr, "/") {
+			continue
+		}
+		v, err := version.NewSemver(strings.TrimPrefix(versionStr, "v"))
+		if err != nil {
+			continue
+		}
+		ret = append(ret, GitTagVersion{Version: v, Tag: tag})
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Version.LessThan(ret[j].Version)
+	})
+	return ret
+}
@@ -0,0 +1,86 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package getmodules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseGitTagVersions(t *testing.T) {
+	const lsRemoteOutput = "" +
+		"1111111111111111111111111111111111111111\trefs/tags/modules/vpc/v1.4.0\n" +
+		"2222222222222222222222222222222222222222\trefs/tags/modules/vpc/v1.10.0\n" +
+		"3333333333333333333333333333333333333333\trefs/tags/modules/vpc/1.2.0\n" +
+		"4444444444444444444444444444444444444444\trefs/tags/modules/vpc/v2.0.0-beta.1\n" +
+		"5555555555555555555555555555555555555555\trefs/tags/modules/vpc/latest\n" +
+		"6666666666666666666666666666666666666666\trefs/tags/modules/vpc/nested/v9.0.0\n" +
+		"7777777777777777777777777777777777777777\trefs/tags/modules/vpc-peering/v5.0.0\n" +
+		"8888888888888888888888888888888888888888\trefs/tags/v3.0.0\n" +
+		"9999999999999999999999999999999999999999\trefs/tags/3.1.0\n" +
+		"malformed line\n"
+
+	tests := map[string]struct {
+		subDir string
+		want   []string
+	}{
+		"monorepo subdirectory": {
+			subDir: "modules/vpc",
+			want: []string{
+				"modules/vpc/1.2.0",
+				"modules/vpc/v1.4.0",
+				"modules/vpc/v1.10.0",
+				"modules/vpc/v2.0.0-beta.1",
+			},
+		},
+		"non-normalized subdirectory": {
+			subDir: "modules/./vpc/",
+			want: []string{
+				"modules/vpc/1.2.0",
+				"modules/vpc/v1.4.0",
+				"modules/vpc/v1.10.0",
+				"modules/vpc/v2.0.0-beta.1",
+			},
+		},
+		"repository root": {
+			subDir: "",
+			want: []string{
+				"v3.0.0",
+				"3.1.0",
+			},
+		},
+		"no matching tags": {
+			subDir: "modules/other",
+			want:   nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			for _, tv := range parseGitTagVersions(strings.NewReader(lsRemoteOutput), test.subDir) {
+				got = append(got, tv.Tag)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("wrong tags\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGitPackageAddressWithRef(t *testing.T) {
+	got, err := GitPackageAddressWithRef("git::https://example.com/monorepo.git?depth=1", "modules/vpc/v1.4.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "git::https://example.com/monorepo.git?depth=1&ref=modules%2Fvpc%2Fv1.4.0"
+	if got != want {
+		t.Errorf("wrong result\ngot:  %s\nwant: %s", got, want)
+	}
+	if !GitPackageHasRef(got) {
+		t.Errorf("GitPackageHasRef returned false for %s", got)
+	}
+}
@@ -30,6 +30,8 @@ import (
 // rather than fetching the package from its origin repeatedly. There is
 // no way to reset this cache, so a particular PackageFetcher instance should
 // live only for the duration of a single initialization process.
+//
+// A PackageFetcher is safe for concurrent use by multiple goroutines.
 type PackageFetcher struct {
 	getter *reusingGetter
 }
@@ -54,21 +56,25 @@ func NewPackageFetcher(ctx context.Context, env PackageFetcherEnvironment) *Pack
 
 	// The OCI Distribution getter needs to acquire credentials based on
 	// centrally-configured policy, encapsulated in env.OCIRepositoryStore.
-	getters["oci"] = &ociDistributionGetter{
-		getOCIRepositoryStore: env.OCIRepositoryStore,
+	getters["oci"] = func() getter.Getter {
+		return &ociDistributionGetter{
+			getOCIRepositoryStore: env.OCIRepositoryStore,
+		}
 	}
 
 	// The HTTP getter (used for both "http" and "https" schemes) uses
 	// the HTTP client we instantiated above, whose behavior can be
 	// incluenced by the ctx argument we passed to it, such as by
 	// enabling OpenTelemetry tracing when appropriate.
-	var httpGetter = &getter.HttpGetter{
-		Client:             httpClient,
-		Netrc:              true,
-		XTerraformGetLimit: 10,
+	newHTTPGetter := func() getter.Getter {
+		return &getter.HttpGetter{
+			Client:             httpClient,
+			Netrc:              true,
+			XTerraformGetLimit: 10,
+		}
 	}
-	getters["http"] = httpGetter
-	getters["https"] = httpGetter
+	getters["http"] = newHTTPGetter
+	getters["https"] = newHTTPGetter
 
 	return &PackageFetcher{
 		getter: newReusingGetter(getters),
@@ -0,0 +1,45 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package getmodules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestPackageFetcher_concurrent(t *testing.T) {
+	srcDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(srcDir, "main.tf"), []byte("# hello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	packageAddr := "file://" + filepath.ToSlash(srcDir)
+
+	fetcher := NewPackageFetcher(t.Context(), nil)
+	targetsDir := t.TempDir()
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Go(func() {
+			instDir := filepath.Join(targetsDir, fmt.Sprintf("inst%d", i))
+			errs[i] = fetcher.FetchPackage(t.Context(), instDir, packageAddr)
+		})
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("fetch %d failed: %s", i, err)
+		}
+		got, err := os.ReadFile(filepath.Join(targetsDir, fmt.Sprintf("inst%d", i), "main.tf"))
+		if err != nil {
+			t.Fatalf("fetch %d: %s", i, err)
+		}
+		if string(got) != "# hello\n" {
+			t.Errorf("fetch %d: wrong content %q", i, got)
+		}
+	}
+}
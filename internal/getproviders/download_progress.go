@@ -0,0 +1,64 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package getproviders
+
+import (
+	"context"
+	"io"
+)
+
+// DownloadProgressFunc is the signature of a callback that can be attached
+// to a context using [ContextWithDownloadProgress] to be notified about the
+// progress of a package download.
+//
+// downloaded is the total number of bytes of the package retrieved so far,
+// including any bytes that were retrieved by an earlier interrupted attempt
+// that is now being resumed. total is the expected final size of the
+// package, or -1 if the server did not announce the size in advance.
+//
+// Package locations that retrieve packages from a remote location may call
+// the function many times during a single download, and the function may
+// be called concurrently from multiple goroutines when multiple packages
+// are being installed at once.
+type DownloadProgressFunc func(downloaded, total int64)
+
+// ContextWithDownloadProgress returns a context derived from the given
+// context that carries the given download progress callback.
+//
+// Passing the result to [PackageLocation.InstallProviderPackage] causes
+// package locations that support progress reporting to call fn as they
+// retrieve the package. Locations that are already on the local filesystem
+// do not report progress.
+func ContextWithDownloadProgress(ctx context.Context, fn DownloadProgressFunc) context.Context {
+	return context.WithValue(ctx, ctxDownloadProgress, fn)
+}
+
+// downloadProgressForContext returns the download progress callback
+// registered in the given context, or nil if there is none.
+func downloadProgressForContext(ctx context.Context) DownloadProgressFunc {
+	fn, _ := ctx.Value(ctxDownloadProgress).(DownloadProgressFunc)
+	return fn
+}
+
+type ctxDownloadProgressType int
+
+const ctxDownloadProgress = ctxDownloadProgressType(0)
+
+// progressWriter is an [io.Writer] that passes its writes through to another
+// writer while reporting the running total to a [DownloadProgressFunc].
+type progressWriter struct {
+	w        io.Writer
+	progress DownloadProgressFunc
+	written  int64
+	total    int64
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	n, err := pw.w.Write(p)
+	pw.written += int64(n)
+	if n > 0 {
+		pw.progress(pw.written, pw.total)
+	}
+	return n, err
+}
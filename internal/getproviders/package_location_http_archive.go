@@ -8,9 +8,13 @@ package getproviders
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/go-getter"
 	"github.com/hashicorp/go-retryablehttp"
@@ -58,56 +62,32 @@ func (p PackageHTTPURL) InstallProviderPackage(ctx context.Context, meta Package
 	defer span.End()
 
 	// When we're installing from an HTTP URL we expect the URL to refer to
-	// a zip file. We'll fetch that into a temporary file here and then
+	// a zip file. We'll fetch that into a local file here and then
 	// delegate to installFromLocalArchive below to actually extract it.
 	// (We're not using go-getter here because its HTTP getter has a bunch
 	// of extraneous functionality we don't need or want, like indirection
-	// through X-Terraform-Get header, attempting partial fetches for
-	// files that already exist, etc.)
-
-	retryableClient := p.ClientBuilder(ctx)
-
-	req, err := retryablehttp.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("invalid provider download request: %w", err)
-	}
-	resp, err := retryableClient.Do(req)
-	if err != nil {
-		if ctx.Err() == context.Canceled {
-			// "context canceled" is not a user-friendly error message,
-			// so we'll return a more appropriate one here.
-			return nil, fmt.Errorf("provider download was interrupted")
-		}
-		return nil, fmt.Errorf("%s: %w", HostFromRequest(req.Request), err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unsuccessful request to %s: %s", url, resp.Status)
-	}
-
-	f, err := os.CreateTemp("", "terraform-provider")
-	if err != nil {
-		return nil, fmt.Errorf("failed to open temporary file to download from %s: %w", url, err)
-	}
-	defer os.Remove(f.Name())
-	defer f.Close()
-
-	// We'll borrow go-getter's "cancelable copy" implementation here so that
-	// the download can potentially be interrupted partway through.
-	n, err := getter.Copy(ctx, f, resp.Body)
-	if err == nil && n < resp.ContentLength {
-		err = fmt.Errorf("incorrect response size: expected %d bytes, but got %d bytes", resp.ContentLength, n)
-	}
-	if err != nil {
+	// through X-Terraform-Get header.)
+	//
+	// The archive is downloaded to a file alongside the target directory,
+	// rather than to a temporary file, so that if the download is
+	// interrupted then a later attempt to install the same package can
+	// resume from where this one left off. The caller is responsible for
+	// making sure that only one installation into targetDir is running at
+	// a time, as providercache.Dir does using its per-package file lock.
+	archiveFilename := targetDir + partialDownloadSuffix
+	if err := p.download(ctx, url, archiveFilename); err != nil {
 		return nil, err
 	}
+	// Once the download is complete the partial file has served its
+	// purpose: if the package turns out to be invalid then we want the next
+	// attempt to start from scratch rather than resuming a bad download.
+	defer os.Remove(archiveFilename)
 
-	archiveFilename := f.Name()
 	localLocation := PackageLocalArchive(archiveFilename)
 
 	var authResult *PackageAuthenticationResult
 	if meta.Authentication != nil {
+		var err error
 		if authResult, err = meta.Authentication.AuthenticatePackage(localLocation); err != nil {
 			return authResult, err
 		}
@@ -133,6 +113,134 @@ func (p PackageHTTPURL) InstallProviderPackage(ctx context.Context, meta Package
 	return authResult, nil
 }
 
+// partialDownloadSuffix is the suffix added to the target directory path of
+// a package to produce the path where its archive is downloaded.
+//
+// The resulting file is a sibling of the package's unpacked directory, which
+// provider searches in the unpacked layout ignore because it isn't a
+// directory.
+const partialDownloadSuffix = ".zip.partial"
+
+// download retrieves the archive at the given URL into the file at filename.
+//
+// If the file already exists and is non-empty then it's assumed to be the
+// leftovers of an earlier interrupted download of the same URL, and so
+// download asks the server for only the remaining bytes. If the server
+// doesn't support range requests then the file is truncated and the whole
+// archive is retrieved again.
+//
+// If the download fails partway through then the file is left in place so
+// that a future call can resume it.
+func (p PackageHTTPURL) download(ctx context.Context, url string, filename string) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil { //nolint:mnd // directory permissions
+		return fmt.Errorf("failed to create directory to download from %s: %w", url, err)
+	}
+	f, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0644) //nolint:mnd // file permissions
+	if err != nil {
+		return fmt.Errorf("failed to open file to download from %s: %w", url, err)
+	}
+	defer f.Close()
+
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return fmt.Errorf("failed to prepare file to download from %s: %w", url, err)
+	}
+
+	retryableClient := p.ClientBuilder(ctx)
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("invalid provider download request: %w", err)
+	}
+	if offset > 0 {
+		log.Printf("[TRACE] getproviders.PackageHTTPURL: resuming download of %s from byte %d", url, offset)
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := retryableClient.Do(req)
+	if err != nil {
+		if ctx.Err() == context.Canceled {
+			// "context canceled" is not a user-friendly error message,
+			// so we'll return a more appropriate one here.
+			return fmt.Errorf("provider download was interrupted")
+		}
+		return fmt.Errorf("%s: %w", HostFromRequest(req.Request), err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case offset > 0 && resp.StatusCode == http.StatusPartialContent:
+		// The server is sending only the remainder of the archive, so we'll
+		// append to what we already have, as long as it's actually sending
+		// the range we asked for.
+		if start, ok := contentRangeStart(resp.Header.Get("Content-Range")); !ok || start != offset {
+			log.Printf("[TRACE] getproviders.PackageHTTPURL: server sent unexpected range %q for %s; starting over", resp.Header.Get("Content-Range"), url)
+			if err := f.Truncate(0); err != nil {
+				return fmt.Errorf("failed to prepare file to download from %s: %w", url, err)
+			}
+			// With the file now empty, this won't make a range request and
+			// so can't end up back here.
+			return p.download(ctx, url, filename)
+		}
+	case offset > 0 && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// The most likely explanation is that the file is already complete
+		// from an earlier attempt that failed after downloading, such as
+		// during extraction. If the file is actually corrupt then we'll
+		// catch that when verifying it and start over next time.
+		return nil
+	case resp.StatusCode == http.StatusOK:
+		// Either we didn't ask for a range or the server ignored our
+		// request for one, so we'll start over from the beginning.
+		if offset > 0 {
+			log.Printf("[TRACE] getproviders.PackageHTTPURL: server does not support resuming download of %s; starting over", url)
+		}
+		offset = 0
+		if err := f.Truncate(0); err != nil {
+			return fmt.Errorf("failed to prepare file to download from %s: %w", url, err)
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to prepare file to download from %s: %w", url, err)
+		}
+	default:
+		return fmt.Errorf("unsuccessful request to %s: %s", url, resp.Status)
+	}
+
+	var w io.Writer = f
+	if progress := downloadProgressForContext(ctx); progress != nil {
+		total := int64(-1)
+		if resp.ContentLength >= 0 {
+			total = offset + resp.ContentLength
+		}
+		w = &progressWriter{w: f, progress: progress, written: offset, total: total}
+		progress(offset, total)
+	}
+
+	// We'll borrow go-getter's "cancelable copy" implementation here so that
+	// the download can potentially be interrupted partway through.
+	n, err := getter.Copy(ctx, w, resp.Body)
+	if err == nil && n < resp.ContentLength {
+		err = fmt.Errorf("incorrect response size: expected %d bytes, but got %d bytes", resp.ContentLength, n)
+	}
+	return err
+}
+
+// contentRangeStart returns the first byte position from the value of a
+// Content-Range header, like "bytes 4000-9999/10000".
+func contentRangeStart(header string) (int64, bool) {
+	spec, ok := strings.CutPrefix(header, "bytes ")
+	if !ok {
+		return 0, false
+	}
+	first, _, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return start, true
+}
+
 // packageHTTPUrlClientWithRetry is the extracted logic from the [PackageHTTPURL.InstallProviderPackage] to be
 // able to reuse the same logic with a custom retry.
 // This is kept as it was previously, before being moved here, to avoid introducing unwanted behaviors
@@ -0,0 +1,167 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package getproviders
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+func TestPackageHTTPURLDownload(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+
+	tests := map[string]struct {
+		// existing is the content of the partial file before the download
+		// begins, or nil if there should be no partial file at all.
+		existing []byte
+		// supportsRanges is true if the server should honor range requests.
+		supportsRanges bool
+		// wrongRange is true if the server should answer range requests with
+		// partial content that doesn't start at the requested offset.
+		wrongRange bool
+
+		wantRangeHeader   string
+		wantFirstProgress int64
+	}{
+		"fresh download": {
+			existing:        nil,
+			supportsRanges:  true,
+			wantRangeHeader: "",
+		},
+		"resumed download": {
+			existing:          content[:4000],
+			supportsRanges:    true,
+			wantRangeHeader:   "bytes=4000-",
+			wantFirstProgress: 4000,
+		},
+		"resume not supported by server": {
+			existing:        []byte("garbage that must be discarded"),
+			supportsRanges:  false,
+			wantRangeHeader: "bytes=30-",
+		},
+		"server sends the wrong range": {
+			existing:        content[:4000],
+			wrongRange:      true,
+			wantRangeHeader: "",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotRangeHeader string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotRangeHeader = r.Header.Get("Range")
+				if test.wrongRange && gotRangeHeader != "" {
+					w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", len(content)-1, len(content)))
+					w.WriteHeader(http.StatusPartialContent)
+					_, _ = w.Write(content)
+					return
+				}
+				if test.supportsRanges {
+					http.ServeContent(w, r, "package.zip", time.Time{}, bytes.NewReader(content))
+					return
+				}
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+				_, _ = w.Write(content)
+			}))
+			defer server.Close()
+
+			filename := filepath.Join(t.TempDir(), "linux_amd64"+partialDownloadSuffix)
+			if test.existing != nil {
+				if err := os.WriteFile(filename, test.existing, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var progress [][2]int64
+			ctx := ContextWithDownloadProgress(context.Background(), func(downloaded, total int64) {
+				progress = append(progress, [2]int64{downloaded, total})
+			})
+
+			location := PackageHTTPURL{
+				URL: server.URL + "/package.zip",
+				ClientBuilder: func(ctx context.Context) *retryablehttp.Client {
+					return packageHTTPUrlClientWithRetry(ctx, 0)
+				},
+			}
+			if err := location.download(ctx, location.URL, filename); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if gotRangeHeader != test.wantRangeHeader {
+				t.Errorf("wrong Range header\ngot:  %q\nwant: %q", gotRangeHeader, test.wantRangeHeader)
+			}
+			got, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, content) {
+				t.Errorf("wrong file content after download\ngot:  %s...\nwant: %s...", truncateForTest(got), truncateForTest(content))
+			}
+
+			if len(progress) == 0 {
+				t.Fatalf("no progress events reported")
+			}
+			if first := progress[0]; first[0] != test.wantFirstProgress {
+				t.Errorf("wrong first progress event %v; want downloaded=%d", first, test.wantFirstProgress)
+			}
+			wantLast := [2]int64{int64(len(content)), int64(len(content))}
+			if last := progress[len(progress)-1]; last != wantLast {
+				t.Errorf("wrong final progress event\ngot:  %v\nwant: %v", last, wantLast)
+			}
+		})
+	}
+}
+
+func TestPackageHTTPURLDownload_failureKeepsPartialFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	filename := filepath.Join(t.TempDir(), "linux_amd64"+partialDownloadSuffix)
+	if err := os.WriteFile(filename, []byte("partial"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	location := PackageHTTPURL{
+		URL: server.URL + "/package.zip",
+		ClientBuilder: func(ctx context.Context) *retryablehttp.Client {
+			return packageHTTPUrlClientWithRetry(ctx, 0)
+		},
+	}
+	err := location.download(context.Background(), location.URL, filename)
+	if err == nil {
+		t.Fatalf("unexpected success")
+	}
+	if !strings.Contains(err.Error(), "404 Not Found") {
+		t.Errorf("wrong error: %s", err)
+	}
+	got, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("partial file was not preserved: %s", err)
+	}
+	if string(got) != "partial" {
+		t.Errorf("partial file was modified: %q", got)
+	}
+}
+
+func truncateForTest(b []byte) string {
+	const limit = 20
+	if len(b) > limit {
+		b = b[:limit]
+	}
+	return string(b)
+}
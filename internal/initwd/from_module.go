@@ -178,6 +178,7 @@ func DirFromModule(ctx context.Context, loader configload.Loader, rootDir, modul
 	}
 
 	walker := inst.moduleInstallWalker(ctx, instManifest, true, wrapHooks, remoteFetcher)
+	inst.prefetchModuleCalls(ctx, addrs.RootModule, fakeRootModule, instManifest, true, remoteFetcher)
 	_, cDiags := inst.installDescendentModules(ctx, fakeRootModule, instManifest, walker, true)
 	if cDiags.HasErrors() {
 		return diags.Append(cDiags)
@@ -0,0 +1,402 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package initwd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/opentofu/opentofu/internal/flock"
+)
+
+// moduleCache is a content-addressed cache of module packages shared between
+// working directories, activated using [ModuleInstaller.SetGlobalCacheDir].
+//
+// The cache directory contains three subdirectories:
+//
+//   - "packages" contains the module packages themselves, each in a directory
+//     named after a hash of the package's contents, so that identical packages
+//     are stored only once.
+//   - "sources" contains a small JSON index file for each cached source
+//     address and version, named after a hash of the two, which records the
+//     hash of the corresponding package.
+//   - "locks" contains a lock file for each cached source address and
+//     version, named in the same way as its index file, and a lock file for
+//     each package, named after its hash with a "package-" prefix.
+//
+// New entries are always prepared in a temporary directory inside the cache
+// directory and then renamed into place, so that other processes never
+// observe a partially-written package or index file. Installations of the
+// same key are serialized using its lock file, so that only one of several
+// processes sharing the cache downloads the package. Because a package can be
+// shared by several keys, anything that reads, replaces or removes a package
+// directory must also hold the package's own lock.
+type moduleCache struct {
+	dir string
+
+	// locks serializes concurrent uses of the same lock file within this
+	// process, because file locks are not guaranteed to exclude other
+	// goroutines in the same process on all platforms.
+	locks   map[string]*sync.Mutex
+	locksMu sync.Mutex
+}
+
+// moduleCacheIndex is the content of an index file in the "sources"
+// subdirectory of a module cache directory.
+type moduleCacheIndex struct {
+	// Key is the source address and version that the package was selected
+	// by, as passed to [moduleCache.install].
+	Key string `json:"key"`
+
+	// Package is the hash of the package's contents, which is also the
+	// name of its directory in the "packages" subdirectory.
+	Package string `json:"package"`
+}
+
+// install populates targetDir, which must not already exist, with the
+// package identified by the given key.
+//
+// If the cache doesn't already contain the package then install uses the
+// given function to download it into a new directory inside the cache,
+// which the function must create, and then adds it to the cache before
+// copying it into targetDir. Failing to add a successfully-downloaded
+// package to the cache is not an error, because the package can still be
+// installed from where it was downloaded.
+func (c *moduleCache) install(ctx context.Context, key string, targetDir string, download func(ctx context.Context, dir string) error) error {
+	unlock, err := c.lock(ctx, c.keyHash(key))
+	if err != nil {
+		return err
+	}
+	defer c.unlock(unlock, key)
+
+	if hash, ok := c.lookup(key); ok {
+		installed, err := c.installPackage(ctx, hash, targetDir)
+		if err != nil {
+			return err
+		}
+		if installed {
+			log.Printf("[TRACE] ModuleInstaller: installed %s from the global module cache package %s", key, hash)
+			return nil
+		}
+	}
+
+	tmpDir, err := os.MkdirTemp(c.dir, ".tmp-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory in module cache: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	downloadDir := filepath.Join(tmpDir, "package")
+	err = download(ctx, downloadDir)
+	if err != nil {
+		return err
+	}
+
+	hash, err := hashModulePackage(downloadDir)
+	if err != nil {
+		log.Printf("[WARN] ModuleInstaller: failed to add %s to the global module cache: failed to hash package: %s", key, err)
+		return copyModulePackage(targetDir, downloadDir)
+	}
+	unlockPkg, err := c.lock(ctx, c.packageLockName(hash))
+	if err != nil {
+		return err
+	}
+	defer c.unlock(unlockPkg, key)
+
+	pkgDir, err := c.add(key, hash, tmpDir, downloadDir)
+	if err != nil {
+		log.Printf("[WARN] ModuleInstaller: failed to add %s to the global module cache: %s", key, err)
+		pkgDir = downloadDir
+	} else {
+		log.Printf("[TRACE] ModuleInstaller: added %s to the global module cache at %s", key, pkgDir)
+	}
+	return copyModulePackage(targetDir, pkgDir)
+}
+
+// lookup returns the hash of the cached package with the given key, if the
+// cache has an index entry for it. The caller must hold the lock for the key.
+func (c *moduleCache) lookup(key string) (string, bool) {
+	src, err := os.ReadFile(c.indexPath(key))
+	if err != nil {
+		return "", false
+	}
+	var index moduleCacheIndex
+	if err := json.Unmarshal(src, &index); err != nil || index.Key != key || index.Package == "" {
+		log.Printf("[WARN] ModuleInstaller: ignoring invalid global module cache index for %s", key)
+		return "", false
+	}
+	return index.Package, true
+}
+
+// installPackage copies the cached package with the given hash into
+// targetDir, returning false if the cache doesn't contain the package.
+//
+// The cache directory might be shared with other processes and other users,
+// so installPackage checks that the package still matches its hash. If it
+// doesn't then installPackage removes it so that it can be replaced.
+func (c *moduleCache) installPackage(ctx context.Context, hash string, targetDir string) (bool, error) {
+	unlock, err := c.lock(ctx, c.packageLockName(hash))
+	if err != nil {
+		return false, err
+	}
+	defer c.unlock(unlock, hash)
+
+	pkgDir := c.packagePath(hash)
+	if !c.verifyPackage(hash) {
+		return false, nil
+	}
+	return true, copyModulePackage(targetDir, pkgDir)
+}
+
+// add moves the package in downloadDir, whose contents have the given hash,
+// into the cache under the given key, and returns its new location. If the
+// cache already contains an identical package, possibly for another key,
+// then add uses that one instead.
+//
+// tmpDir must be a temporary directory inside the cache directory that add
+// can use for preparing the index file. The caller must hold the locks for
+// both the key and the package.
+func (c *moduleCache) add(key string, hash string, tmpDir string, downloadDir string) (string, error) {
+	pkgDir := c.packagePath(hash)
+	if !c.verifyPackage(hash) {
+		if err := os.MkdirAll(filepath.Dir(pkgDir), os.ModePerm); err != nil {
+			return "", err
+		}
+		if err := os.Rename(downloadDir, pkgDir); err != nil {
+			return "", err
+		}
+	}
+
+	// The package is in the cache now, so failing to index it only means
+	// that the next installation of this key will download it again.
+	src, err := json.Marshal(moduleCacheIndex{Key: key, Package: hash})
+	if err != nil {
+		log.Printf("[WARN] ModuleInstaller: failed to index %s in the global module cache: %s", key, err)
+		return pkgDir, nil
+	}
+	if err := c.writeIndex(key, tmpDir, src); err != nil {
+		log.Printf("[WARN] ModuleInstaller: failed to index %s in the global module cache: %s", key, err)
+	}
+	return pkgDir, nil
+}
+
+func (c *moduleCache) writeIndex(key string, tmpDir string, src []byte) error {
+	tmpIndex := filepath.Join(tmpDir, "index.json")
+	if err := os.WriteFile(tmpIndex, src, 0644); err != nil {
+		return err
+	}
+	indexPath := c.indexPath(key)
+	if err := os.MkdirAll(filepath.Dir(indexPath), os.ModePerm); err != nil {
+		return err
+	}
+	return os.Rename(tmpIndex, indexPath)
+}
+
+// verifyPackage returns true if the cache contains a package with the given
+// hash whose contents still match it. It removes any package directory that
+// doesn't match, so that it can be replaced. The caller must hold the lock
+// for the package.
+func (c *moduleCache) verifyPackage(hash string) bool {
+	pkgDir := c.packagePath(hash)
+	if info, err := os.Stat(pkgDir); err != nil || !info.IsDir() {
+		return false
+	}
+	if got, err := hashModulePackage(pkgDir); err != nil || got != hash {
+		log.Printf("[WARN] ModuleInstaller: global module cache package at %s doesn't match its hash; removing it", pkgDir)
+		if err := os.RemoveAll(pkgDir); err != nil {
+			log.Printf("[WARN] ModuleInstaller: failed to remove %s: %s", pkgDir, err)
+		}
+		return false
+	}
+	return true
+}
+
+// lock blocks until no other user of the lock file with the given name is
+// in progress in this or any other process using the same cache directory,
+// and then returns a function that the caller must call once it's done.
+//
+// Keys are locked using their hash, while packages are locked using the
+// name returned by [moduleCache.packageLockName]. A caller that needs both
+// must lock the key first.
+func (c *moduleCache) lock(ctx context.Context, name string) (unlock func() error, err error) {
+	c.locksMu.Lock()
+	if c.locks == nil {
+		c.locks = make(map[string]*sync.Mutex)
+	}
+	mu, exists := c.locks[name]
+	if !exists {
+		mu = new(sync.Mutex)
+		c.locks[name] = mu
+	}
+	c.locksMu.Unlock()
+
+	mu.Lock()
+
+	lockFile := filepath.Join(c.dir, "locks", name+".lock")
+	log.Printf("[TRACE] ModuleInstaller: acquiring global module cache lock %s", lockFile)
+	if err := os.MkdirAll(filepath.Dir(lockFile), os.ModePerm); err != nil {
+		mu.Unlock()
+		return nil, fmt.Errorf("failed to create module cache lock directory: %w", err)
+	}
+	f, err := os.OpenFile(lockFile, os.O_RDWR|os.O_CREATE, 0644) //nolint:mnd // file permissions
+	if err != nil {
+		mu.Unlock()
+		return nil, fmt.Errorf("failed to open module cache lock file: %w", err)
+	}
+	if err := flock.LockBlocking(ctx, f); err != nil {
+		f.Close()
+		mu.Unlock()
+		return nil, fmt.Errorf("unable to acquire file lock on %q: %w", lockFile, err)
+	}
+
+	return func() error {
+		defer mu.Unlock()
+		log.Printf("[TRACE] ModuleInstaller: releasing global module cache lock %s", lockFile)
+		unlockErr := flock.Unlock(f)
+		return errors.Join(unlockErr, f.Close())
+	}, nil
+}
+
+// unlock calls the given function returned by [moduleCache.lock], logging
+// any error. what describes the locked entry for the log.
+func (c *moduleCache) unlock(unlock func() error, what string) {
+	if err := unlock(); err != nil {
+		log.Printf("[WARN] ModuleInstaller: failed to unlock global module cache entry for %s: %s", what, err)
+	}
+}
+
+func (c *moduleCache) packageLockName(hash string) string {
+	return "package-" + hash
+}
+
+func (c *moduleCache) keyHash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *moduleCache) indexPath(key string) string {
+	return filepath.Join(c.dir, "sources", c.keyHash(key)+".json")
+}
+
+func (c *moduleCache) packagePath(hash string) string {
+	return filepath.Join(c.dir, "packages", hash)
+}
+
+// hashModulePackage returns a hash of the names, types, and contents of all
+// of the files and symlinks in the given directory.
+func hashModulePackage(dir string) (string, error) {
+	h := sha256.New()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		switch {
+		case d.Type()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(h, "symlink %q %q\n", rel, filepath.ToSlash(target))
+		case d.IsDir():
+			fmt.Fprintf(h, "dir %q\n", rel)
+		case d.Type().IsRegular():
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			fileHash, err := hashFile(path)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(h, "file %q %t %s\n", rel, info.Mode()&0111 != 0, fileHash)
+		default:
+			return fmt.Errorf("unsupported file type for %s", path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// copyModulePackage copies the module package in src into the new directory
+// dst, preserving symlinks and file modes.
+func copyModulePackage(dst string, src string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case d.IsDir():
+			return os.MkdirAll(target, os.ModePerm)
+		case d.Type().IsRegular():
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			return copyFile(target, path, info.Mode().Perm())
+		default:
+			return fmt.Errorf("unsupported file type for %s", path)
+		}
+	})
+}
+
+func copyFile(dst string, src string, mode fs.FileMode) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, out.Close())
+	}()
+	_, err = io.Copy(out, in)
+	return err
+}
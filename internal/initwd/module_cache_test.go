@@ -0,0 +1,110 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package initwd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestModuleCache_corruptPackage(t *testing.T) {
+	cache := &moduleCache{dir: t.TempDir()}
+	const key = "example.com/foo/bar/baz 1.0.0"
+
+	downloads := 0
+	download := func(ctx context.Context, dir string) error {
+		downloads++
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dir, "main.tf"), []byte("# original\n"), 0644)
+	}
+	install := func(t *testing.T) string {
+		t.Helper()
+		target := filepath.Join(t.TempDir(), "module")
+		if err := cache.install(t.Context(), key, target, download); err != nil {
+			t.Fatalf("install failed: %s", err)
+		}
+		src, err := os.ReadFile(filepath.Join(target, "main.tf"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(src)
+	}
+
+	if got := install(t); got != "# original\n" {
+		t.Fatalf("wrong content after first install: %q", got)
+	}
+	if got := install(t); got != "# original\n" || downloads != 1 {
+		t.Fatalf("second install didn't use the cache: content %q after %d downloads", got, downloads)
+	}
+
+	// Something else sharing the cache directory modifies the package.
+	hash, ok := cache.lookup(key)
+	if !ok {
+		t.Fatal("package is not in the cache")
+	}
+	pkgDir := cache.packagePath(hash)
+	if err := os.WriteFile(filepath.Join(pkgDir, "main.tf"), []byte("# tampered\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := install(t); got != "# original\n" {
+		t.Errorf("installed tampered package: %q", got)
+	}
+	if downloads != 2 {
+		t.Errorf("tampered package wasn't downloaded again; got %d downloads", downloads)
+	}
+	if _, ok := cache.lookup(key); !ok {
+		t.Errorf("package wasn't added back to the cache")
+	}
+}
+
+func TestModuleCache_sharedPackage(t *testing.T) {
+	cache := &moduleCache{dir: t.TempDir()}
+	download := func(ctx context.Context, dir string) error {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dir, "main.tf"), []byte("# original\n"), 0644)
+	}
+	install := func(t *testing.T, key string) string {
+		t.Helper()
+		target := filepath.Join(t.TempDir(), "module")
+		if err := cache.install(t.Context(), key, target, download); err != nil {
+			t.Fatalf("install failed: %s", err)
+		}
+		src, err := os.ReadFile(filepath.Join(target, "main.tf"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(src)
+	}
+
+	const keyA = "example.com/foo/bar/baz 1.0.0"
+	const keyB = "git::https://example.com/baz.git?ref=v1.0.0"
+	install(t, keyA)
+	hash, ok := cache.lookup(keyA)
+	if !ok {
+		t.Fatal("package is not in the cache")
+	}
+
+	// Something else sharing the cache directory modifies the package before
+	// another key with identical content is installed, so the existing
+	// package directory must not be reused as it is.
+	if err := os.WriteFile(filepath.Join(cache.packagePath(hash), "main.tf"), []byte("# tampered\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := install(t, keyB); got != "# original\n" {
+		t.Errorf("installed tampered package: %q", got)
+	}
+	if got, ok := cache.lookup(keyB); !ok || got != hash {
+		t.Errorf("wrong package for second key %q; want %q", got, hash)
+	}
+	if got := install(t, keyA); got != "# original\n" {
+		t.Errorf("first key doesn't use the replaced package: %q", got)
+	}
+}
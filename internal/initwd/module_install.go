@@ -15,6 +15,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/apparentlymart/go-versions/versions"
 	version "github.com/hashicorp/go-version"
@@ -48,6 +49,33 @@ type ModuleInstaller struct {
 	// the values are package locations returned by the registry client.
 	registryPackageSources map[moduleVersion]registry.PackageLocation
 
+	// The keys in gitTagVersions are git package addresses and subdirectories,
+	// and the values are the versions found in the tags of that repository.
+	gitTagVersions map[gitModule][]getmodules.GitTagVersion
+
+	// The keys in fetches are module keys as used in the manifest, and the
+	// values track the fetching of the package for each remote module that
+	// has started but not yet been consumed by the configuration walk.
+	fetches map[string]*moduleFetch
+
+	// mu must be held while accessing the maps above, because packages
+	// may be fetched concurrently with the configuration walk.
+	mu sync.Mutex
+
+	// fetchesWG tracks the background goroutines that are fetching packages
+	// ahead of the configuration walk.
+	fetchesWG sync.WaitGroup
+
+	// workerSlots, if non-nil, is a semaphore limiting how many packages
+	// the installer will fetch ahead of the configuration walk at the same
+	// time. If nil, packages are fetched only when the walk reaches them.
+	workerSlots chan struct{}
+
+	// globalCache, if non-nil, is a shared cache of module packages that
+	// were selected by version, used as a read-through cache when fetching
+	// those packages.
+	globalCache *moduleCache
+
 	ConfigInstance func(ctx context.Context, root *configs.Module, modules eval.ExternalModules) (*eval.ConfigInstance, tfdiags.Diagnostics)
 }
 
@@ -57,6 +85,11 @@ type moduleVersion struct {
 	subdir  string
 }
 
+type gitModule struct {
+	packageAddr addrs.ModulePackage
+	subdir      string
+}
+
 // NewModuleInstaller constructs a new [ModuleInstaller] object whose methods
 // will make use of the given dependencies.
 //
@@ -83,6 +116,9 @@ func NewModuleInstaller(modsDir string, loader configload.Loader, registryClient
 		fetcher:                 remotePackageFetcher,
 		registryPackageVersions: make(map[addrs.ModuleRegistryPackage]*response.ModuleVersions),
 		registryPackageSources:  make(map[moduleVersion]registry.PackageLocation),
+		gitTagVersions:          make(map[gitModule][]getmodules.GitTagVersion),
+		fetches:                 make(map[string]*moduleFetch),
+		workerSlots:             make(chan struct{}, DefaultMaxConcurrency),
 	}
 }
 
@@ -197,6 +233,9 @@ func (i *ModuleInstaller) InstallModules(ctx context.Context, rootDir, testsDir
 	} else {
 		rootMod, mDiags := i.loader.LoadConfigDirWithTests(rootDir, testsDir, call)
 		diags = diags.Append(mDiags)
+		if rootMod != nil {
+			i.prefetchModuleCalls(ctx, addrs.RootModule, rootMod, manifest, upgrade, fetcher)
+		}
 
 		cfg, instDiags := i.installDescendentModules(ctx, rootMod, manifest, walker, installErrsOnly)
 		diags = append(diags, instDiags...)
@@ -206,6 +245,23 @@ func (i *ModuleInstaller) InstallModules(ctx context.Context, rootDir, testsDir
 }
 
 func (i *ModuleInstaller) moduleInstallWalker(_ context.Context, manifest modsdir.Manifest, upgrade bool, hooks ModuleInstallHooks, fetcher *getmodules.PackageFetcher) configs.ModuleWalker {
+	installModule := i.moduleInstallFunc(manifest, upgrade, hooks, fetcher)
+	return configs.ModuleWalkerFunc(
+		func(ctx context.Context, req *configs.ModuleRequest) (*configs.Module, *version.Version, hcl.Diagnostics) {
+			mod, v, diags := installModule(ctx, req)
+			if mod != nil {
+				// Now that we know what this module calls, we can start
+				// fetching its children while the walk continues elsewhere.
+				i.prefetchModuleCalls(ctx, req.Path, mod, manifest, upgrade, fetcher)
+			}
+			return mod, v, diags
+		},
+	)
+}
+
+// moduleInstallFunc returns the function that installs a single module on
+// behalf of the walker returned by [ModuleInstaller.moduleInstallWalker].
+func (i *ModuleInstaller) moduleInstallFunc(manifest modsdir.Manifest, upgrade bool, hooks ModuleInstallHooks, fetcher *getmodules.PackageFetcher) configs.ModuleWalkerFunc {
 	return configs.ModuleWalkerFunc(
 		func(ctx context.Context, req *configs.ModuleRequest) (*configs.Module, *version.Version, hcl.Diagnostics) {
 			var diags hcl.Diagnostics
@@ -293,22 +349,17 @@ func (i *ModuleInstaller) moduleInstallWalker(_ context.Context, manifest modsdi
 
 			record, recorded := manifest[key]
 			if !recorded {
-				// Clean up any stale cache directory that might be present.
+				// Clean up any stale cache directory that might be present,
+				// unless we already started fetching this module ahead of
+				// the walk, which then took care of that itself.
 				// If this is a local (relative) source then the dir will
 				// not exist, but we'll ignore that.
-				log.Printf("[TRACE] ModuleInstaller: cleaning directory %s prior to install of %s", instPath, key)
-				err := os.RemoveAll(instPath)
-				if err != nil && !os.IsNotExist(err) {
-					log.Printf("[TRACE] ModuleInstaller: failed to remove %s: %s", key, err)
-					diags = diags.Append(&hcl.Diagnostic{
-						Severity: hcl.DiagError,
-						Summary:  "Failed to remove local module cache",
-						Detail: fmt.Sprintf(
-							"OpenTofu tried to remove %s in order to reinstall this module, but encountered an error: %s",
-							instPath, err,
-						),
-					})
-					return nil, nil, diags
+				if !i.moduleFetchStarted(key) {
+					cleanDiags := cleanModuleDir(key, instPath)
+					diags = append(diags, cleanDiags...)
+					if cleanDiags.HasErrors() {
+						return nil, nil, diags
+					}
 				}
 			} else {
 				// If this module is already recorded and its root directory
@@ -347,15 +398,15 @@ func (i *ModuleInstaller) moduleInstallWalker(_ context.Context, manifest modsdi
 			case addrs.ModuleSourceRegistry:
 				log.Printf("[TRACE] ModuleInstaller: %s is a registry module at %s", key, addr.String())
 				span.SetAttributes(traceattrs.String("opentofu.module.source_type", "registry"))
-				mod, v, mDiags := i.installRegistryModule(ctx, req, key, instPath, addr, manifest, hooks, fetcher)
+				mod, v, mDiags := i.installRegistryModule(ctx, req, key, instPath, manifest, hooks, fetcher)
 				diags = append(diags, mDiags...)
 				return mod, v, diags
 
 			case addrs.ModuleSourceRemote:
 				log.Printf("[TRACE] ModuleInstaller: %s address %q will be handled by go-getter", key, addr.String())
-				mod, mDiags := i.installGoGetterModule(ctx, req, key, instPath, manifest, hooks, fetcher)
+				mod, v, mDiags := i.installGoGetterModule(ctx, req, key, instPath, manifest, hooks, fetcher)
 				diags = append(diags, mDiags...)
-				return mod, nil, diags
+				return mod, v, diags
 
 			default:
 				// Shouldn't get here, because there are no other implementations
@@ -388,6 +439,12 @@ func (i *ModuleInstaller) installDescendentModules(ctx context.Context, rootMod
 
 	cfg, cDiags := configs.BuildConfig(ctx, rootMod, walker)
 	diags = diags.Append(cDiags)
+
+	// The walk consumes every fetch that it started ahead of time, but we'll
+	// make sure none are still running before we return in case the walk
+	// ended early.
+	i.fetchesWG.Wait()
+
 	if installErrsOnly {
 		// We can't continue if there was an error during installation, but
 		// return all diagnostics in case there happens to be anything else
@@ -495,7 +552,7 @@ func (i *ModuleInstaller) installLocalModule(ctx context.Context, req *configs.M
 // public hashicorp/go-version API.
 var versionRegexp = regexp.MustCompile(version.VersionRegexpRaw)
 
-func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *configs.ModuleRequest, key string, instPath string, addr addrs.ModuleSourceRegistry, manifest modsdir.Manifest, hooks ModuleInstallHooks, fetcher *getmodules.PackageFetcher) (*configs.Module, *version.Version, hcl.Diagnostics) {
+func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *configs.ModuleRequest, key string, instPath string, manifest modsdir.Manifest, hooks ModuleInstallHooks, fetcher *getmodules.PackageFetcher) (*configs.Module, *version.Version, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
 
 	ctx, span := tracing.Tracer().Start(ctx, "Install Registry Module", tracing.SpanAttributes(
@@ -505,6 +562,78 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 	))
 	defer span.End()
 
+	fetch := i.fetchModule(ctx, req, key, instPath, fetcher)
+	diags = append(diags, fetch.diags...)
+	if fetch.version != nil {
+		// Report up to the caller that we're downloading, or have already
+		// downloaded, the selected version.
+		hooks.Download(key, fetch.packageAddr, fetch.version)
+	}
+	if fetch.location != "" {
+		span.SetAttributes(traceattrs.OpenTofuModuleSource(fetch.location))
+	}
+	if fetch.diags.HasErrors() {
+		tracing.SetSpanError(span, diags)
+		return nil, nil, diags
+	}
+
+	modDir := fetch.modDir
+	log.Printf("[TRACE] ModuleInstaller: %s %q was downloaded to %s", key, fetch.location, modDir)
+
+	// Finally we are ready to try actually loading the module.
+	mod, mDiags := i.loader.LoadConfigDir(modDir, req.Call)
+	if mod == nil {
+
+		subDir := fetch.subdir
+		isMissingSubDir, missingDir := isSubDirNonExistent(modDir)
+		// nil indicates missing or unreadable directory, so we'll
+		// discard the returned diags and return a more specific
+		// error message here.
+		if subDir != "" && isMissingSubDir {
+			// This may be a user error, or a submodule may have been removed between unpinned versions of the module (ie a module update)
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Module subdirectory not found",
+				Detail:   fmt.Sprintf("Cannot find directory %q in module %q. The requested subdirectory was %q.", missingDir, instPath, subDir),
+				Subject:  req.CallRange.Ptr(),
+			})
+		} else {
+			// This is genuinely unexpected - the module was just downloaded
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unreadable module directory",
+				Detail:   fmt.Sprintf("The directory %s could not be read. This is a bug in OpenTofu and should be reported.", modDir),
+			})
+		}
+	} else {
+		diags = diags.Extend(mDiags)
+	}
+
+	// Note the local location in our manifest.
+	manifest[key] = modsdir.Record{
+		Key:        key,
+		Version:    fetch.version,
+		Dir:        modDir,
+		SourceAddr: req.SourceAddr.String(),
+	}
+	log.Printf("[DEBUG] Module installer: %s installed at %s", key, modDir)
+	hooks.Install(key, fetch.version, modDir)
+
+	return mod, fetch.version, diags
+}
+
+// fetchRegistryModule selects the newest version of the given registry module
+// that matches the request's version constraint, and then fetches the
+// package containing that version into instPath, recording the outcome in
+// the given fetch.
+//
+// This is the part of installing a registry module that does not interact
+// with the configuration loader, the hooks, or the manifest, and so it may
+// run concurrently with the configuration walk. Refer to
+// [ModuleInstaller.fetchModule] for more information.
+func (i *ModuleInstaller) fetchRegistryModule(ctx context.Context, req *configs.ModuleRequest, key string, instPath string, addr addrs.ModuleSourceRegistry, fetcher *getmodules.PackageFetcher, fetch *moduleFetch) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
 	if i.reg == nil || fetcher == nil {
 		// Only local package sources are available when we have no registry
 		// client or no fetcher, since both would be needed for successful install.
@@ -515,14 +644,11 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 			Detail:   "Only local module sources are supported in this context.",
 			Subject:  req.CallRange.Ptr(),
 		})
-		tracing.SetSpanError(span, diags)
-		return nil, nil, diags
+		return diags
 	}
 
 	hostname := addr.Package.Host
 	reg := i.reg
-	var resp *response.ModuleVersions
-	var exists bool
 
 	// A registry entry isn't _really_ a module package, but we'll pretend it's
 	// one for the sake of this reporting by just trimming off any source
@@ -530,7 +656,10 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 	packageAddr := addr.Package
 
 	// check if we've already looked up this module from the registry
-	if resp, exists = i.registryPackageVersions[packageAddr]; exists {
+	i.mu.Lock()
+	resp, exists := i.registryPackageVersions[packageAddr]
+	i.mu.Unlock()
+	if exists {
 		log.Printf("[TRACE] %s using already found available versions of %s at %s", key, addr, hostname)
 	} else {
 		var err error
@@ -563,12 +692,12 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 					Subject:  req.CallRange.Ptr(),
 				})
 			}
-			tracing.SetSpanError(span, diags)
-			return nil, nil, diags
+			return diags
 		}
+		i.mu.Lock()
 		i.registryPackageVersions[packageAddr] = resp
+		i.mu.Unlock()
 	}
-
 	// The response might contain information about dependencies to allow us
 	// to potentially optimize future requests, but we don't currently do that
 	// and so for now we'll just take the first item which is guaranteed to
@@ -582,7 +711,7 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 			Detail:   fmt.Sprintf("The registry at %s returned an invalid response when OpenTofu requested available versions for module %q (%s:%d).", hostname, req.Name, req.CallRange.Filename, req.CallRange.Start.Line),
 			Subject:  req.CallRange.Ptr(),
 		})
-		return nil, nil, diags
+		return diags
 	}
 
 	modMeta := resp.Modules[0]
@@ -713,8 +842,7 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 			Detail:   fmt.Sprintf("Module %q (%s:%d) has no versions available on %s.", addr, req.CallRange.Filename, req.CallRange.Start.Line, hostname),
 			Subject:  req.CallRange.Ptr(),
 		})
-		tracing.SetSpanError(span, diags)
-		return nil, nil, diags
+		return diags
 	}
 
 	if latestMatch == nil {
@@ -724,12 +852,13 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 			Detail:   fmt.Sprintf("There is no available version of module %q (%s:%d) which matches the given version constraint. The newest available version is %s.", addr, req.CallRange.Filename, req.CallRange.Start.Line, latestVersion),
 			Subject:  req.CallRange.Ptr(),
 		})
-		tracing.SetSpanError(span, diags)
-		return nil, nil, diags
+		return diags
 	}
 
-	// Report up to the caller that we're about to start downloading.
-	hooks.Download(key, packageAddr.String(), latestMatch)
+	// Report the selected version up to the caller, even if we fail to
+	// download it below.
+	fetch.packageAddr = packageAddr.String()
+	fetch.version = latestMatch
 
 	// If we manage to get down here then we've found a suitable version to
 	// install, so we need to ask the registry where we should download it from.
@@ -737,8 +866,12 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 
 	// first check the cache for the download URL
 	moduleAddr := moduleVersion{module: packageAddr, version: latestMatch.String(), subdir: addr.Subdir}
-	if _, exists := i.registryPackageSources[moduleAddr]; !exists {
-		packageLocation, err := reg.ModulePackageLocation(ctx, packageAddr, latestMatch.String(), addr.Subdir)
+	i.mu.Lock()
+	packageLocation, exists := i.registryPackageSources[moduleAddr]
+	i.mu.Unlock()
+	if !exists {
+		var err error
+		packageLocation, err = reg.ModulePackageLocation(ctx, packageAddr, latestMatch.String(), addr.Subdir)
 		if err != nil {
 			log.Printf("[ERROR] %s from %s %s: %s", key, addr, latestMatch, err)
 			diags = diags.Append(&hcl.Diagnostic{
@@ -746,34 +879,32 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 				Summary:  "Error accessing remote module registry",
 				Detail:   fmt.Sprintf("Failed to retrieve a download URL for %s %s from %s: %s", addr, latestMatch, hostname, err),
 			})
-			tracing.SetSpanError(span, diags)
-			return nil, nil, diags
+			return diags
 		}
-		span.SetAttributes(traceattrs.OpenTofuModuleSource(packageLocation.UILabel()))
+		i.mu.Lock()
 		i.registryPackageSources[moduleAddr] = packageLocation
+		i.mu.Unlock()
 	}
-
-	packageLocation := i.registryPackageSources[moduleAddr]
+	fetch.location = packageLocation.UILabel()
+	fetch.subdir = packageLocation.Subdir()
 
 	log.Printf("[TRACE] ModuleInstaller: %s %s %s is available at %q", key, packageAddr, latestMatch, packageLocation.UILabel())
-	var err error      // populated in the cases below
-	modDir := instPath // possibly overwritten below if the module is in a subdirectory of the package
+	var download func(ctx context.Context, dir string) error
 	switch packageLocation := packageLocation.(type) {
 	case registry.PackageLocationDirect:
 		// Direct locations are handled by the same registry client that
 		// returned them, since the download might require using equivalent
-		// credentials as were used to decide the location. modDir is the
-		// directory where the requested module was installed, which might
-		// be a subdirectory of instPath.
-		modDir, err = reg.InstallModulePackage(ctx, packageLocation, instPath)
+		// credentials as were used to decide the location.
+		download = func(ctx context.Context, dir string) error {
+			_, err := reg.InstallModulePackage(ctx, packageLocation, dir)
+			return err
+		}
 	case registry.PackageLocationIndirect:
 		// Indirect locations are handled by the package fetcher, similar to
 		// if the same address had been specified directly in the "source"
 		// argument of the module call.
-		err = fetcher.FetchPackage(ctx, instPath, packageLocation.SourceAddr.Package.String())
-		if packageLocation.SourceAddr.Subdir != "" {
-			subDir := filepath.FromSlash(packageLocation.SourceAddr.Subdir)
-			modDir = filepath.Join(modDir, subDir)
+		download = func(ctx context.Context, dir string) error {
+			return fetcher.FetchPackage(ctx, dir, packageLocation.SourceAddr.Package.String())
 		}
 	default:
 		// The above cases should be exhaustive for all of the implementations
@@ -783,15 +914,21 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 			Summary:  "Unsupported package location",
 			Detail:   fmt.Sprintf("Registry client returned a package location of type %T, which the module installer doesn't support. This is a bug in OpenTofu.", packageLocation),
 		})
-		return nil, nil, diags
+		return diags
 	}
+
+	// A registry module version is immutable, so the package is eligible for
+	// the global module cache regardless of where the registry told us to
+	// download it from.
+	cacheKey := fmt.Sprintf("registry %s %s", packageAddr.ForRegistryProtocol(), latestMatch)
+	err := i.downloadModulePackage(ctx, cacheKey, instPath, download)
 	if errors.Is(err, context.Canceled) {
 		diags = diags.Append(&hcl.Diagnostic{
 			Severity: hcl.DiagError,
 			Summary:  "Module download was interrupted",
 			Detail:   fmt.Sprintf("Interrupt signal received when downloading module %s.", addr),
 		})
-		return nil, nil, diags
+		return diags
 	}
 	if err != nil {
 		// Errors returned by go-getter have very inconsistent quality as
@@ -805,26 +942,50 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 			Detail:   fmt.Sprintf("Could not download module %q (%s:%d) source code from %q: %s.", req.Name, req.CallRange.Filename, req.CallRange.Start.Line, packageLocation, err),
 			Subject:  req.CallRange.Ptr(),
 		})
+		return diags
+	}
+
+	// modDir is the directory where the requested module was installed,
+	// which might be a subdirectory of instPath.
+	fetch.modDir = instPath
+	if fetch.subdir != "" {
+		fetch.modDir = filepath.Join(instPath, filepath.FromSlash(fetch.subdir))
+	}
+	return diags
+}
+
+func (i *ModuleInstaller) installGoGetterModule(ctx context.Context, req *configs.ModuleRequest, key string, instPath string, manifest modsdir.Manifest, hooks ModuleInstallHooks, fetcher *getmodules.PackageFetcher) (*configs.Module, *version.Version, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	addr := req.SourceAddr.(addrs.ModuleSourceRemote)
+
+	fetch := i.fetchModule(ctx, req, key, instPath, fetcher)
+	diags = append(diags, fetch.diags...)
+	if fetch.packageAddr != "" {
+		// Report up to the caller that we're downloading, or have already
+		// downloaded, the package.
+		hooks.Download(key, fetch.packageAddr, fetch.version)
+	}
+	if fetch.diags.HasErrors() {
 		return nil, nil, diags
 	}
 
-	log.Printf("[TRACE] ModuleInstaller: %s %q was downloaded to %s", key, packageLocation.UILabel(), modDir)
+	modDir := fetch.modDir
+	log.Printf("[TRACE] ModuleInstaller: %s %q was downloaded to %s", key, addr, modDir)
 
 	// Finally we are ready to try actually loading the module.
 	mod, mDiags := i.loader.LoadConfigDir(modDir, req.Call)
 	if mod == nil {
-
-		subDir := packageLocation.Subdir()
-		isMissingSubDir, missingDir := isSubDirNonExistent(modDir)
 		// nil indicates missing or unreadable directory, so we'll
 		// discard the returned diags and return a more specific
 		// error message here.
-		if subDir != "" && isMissingSubDir {
-			// This may be a user error, or a submodule may have been removed between unpinned versions of the module (ie a module update)
+		isNonExistent, missingDir := isSubDirNonExistent(modDir)
+		if addr.Subdir != "" && isNonExistent {
+			// This is a user configuration error - they referenced a submodule that doesn't exist
 			diags = diags.Append(&hcl.Diagnostic{
 				Severity: hcl.DiagError,
 				Summary:  "Module subdirectory not found",
-				Detail:   fmt.Sprintf("Cannot find directory %q in module %q. The requested subdirectory was %q.", missingDir, instPath, subDir),
+				Detail:   fmt.Sprintf("Cannot find directory %q in the module path %q. The requested subdirectory was %q.", missingDir, instPath, addr.Subdir),
 				Subject:  req.CallRange.Ptr(),
 			})
 		} else {
@@ -842,17 +1003,27 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 	// Note the local location in our manifest.
 	manifest[key] = modsdir.Record{
 		Key:        key,
-		Version:    latestMatch,
+		Version:    fetch.version,
+		Ref:        fetch.ref,
 		Dir:        modDir,
 		SourceAddr: req.SourceAddr.String(),
 	}
 	log.Printf("[DEBUG] Module installer: %s installed at %s", key, modDir)
-	hooks.Install(key, latestMatch, modDir)
+	hooks.Install(key, fetch.version, modDir)
 
-	return mod, latestMatch, diags
+	return mod, fetch.version, diags
 }
 
-func (i *ModuleInstaller) installGoGetterModule(ctx context.Context, req *configs.ModuleRequest, key string, instPath string, manifest modsdir.Manifest, hooks ModuleInstallHooks, fetcher *getmodules.PackageFetcher) (*configs.Module, hcl.Diagnostics) {
+// fetchGoGetterModule fetches the package for the given remote module
+// source address into instPath, first selecting a tag to fetch if the
+// request has a version constraint, and records the outcome in the given
+// fetch.
+//
+// This is the part of installing a remote module that does not interact
+// with the configuration loader, the hooks, or the manifest, and so it may
+// run concurrently with the configuration walk. Refer to
+// [ModuleInstaller.fetchModule] for more information.
+func (i *ModuleInstaller) fetchGoGetterModule(ctx context.Context, req *configs.ModuleRequest, key string, instPath string, addr addrs.ModuleSourceRemote, fetcher *getmodules.PackageFetcher, fetch *moduleFetch) hcl.Diagnostics {
 	var diags hcl.Diagnostics
 
 	if fetcher == nil {
@@ -864,25 +1035,60 @@ func (i *ModuleInstaller) installGoGetterModule(ctx context.Context, req *config
 			Detail:   "Only local module sources are supported in this context.",
 			Subject:  req.CallRange.Ptr(),
 		})
-		return nil, diags
+		return diags
 	}
 
-	// Report up to the caller that we're about to start downloading.
-	addr := req.SourceAddr.(addrs.ModuleSourceRemote)
 	packageAddr := addr.Package
-	hooks.Download(key, packageAddr.String(), nil)
+	fetchAddr := packageAddr.String()
 
+	// A version constraint is allowed only for git repositories, where we
+	// select the newest matching tag and then fetch that ref.
+	var cacheKey string
 	if req.VersionConstraint.HasRequirements() {
-		diags = diags.Append(&hcl.Diagnostic{
-			Severity: hcl.DiagError,
-			Summary:  "Invalid version constraint",
-			Detail:   fmt.Sprintf("Cannot apply a version constraint to module %q (at %s:%d) because it doesn't come from a module registry.", req.Name, req.CallRange.Filename, req.CallRange.Start.Line),
-			Subject:  req.CallRange.Ptr(),
-		})
-		return nil, diags
+		if !getmodules.IsGitPackageAddress(fetchAddr) {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid version constraint",
+				Detail:   fmt.Sprintf("Cannot apply a version constraint to module %q (at %s:%d) because it doesn't come from a module registry or a git repository.", req.Name, req.CallRange.Filename, req.CallRange.Start.Line),
+				Subject:  req.CallRange.Ptr(),
+			})
+			return diags
+		}
+
+		selected, selDiags := i.selectGitTagVersion(ctx, req, key, addr)
+		diags = append(diags, selDiags...)
+		if selDiags.HasErrors() {
+			return diags
+		}
+		var err error
+		fetchAddr, err = getmodules.GitPackageAddressWithRef(fetchAddr, selected.Tag)
+		if err != nil {
+			// Should not get here, because we already parsed this address
+			// successfully in order to list its tags.
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid module source address",
+				Detail:   fmt.Sprintf("Cannot select tag %q for module %q (at %s:%d): %s.", selected.Tag, req.Name, req.CallRange.Filename, req.CallRange.Start.Line, err),
+				Subject:  req.CallRange.Ptr(),
+			})
+			return diags
+		}
+		fetch.version = selected.Version
+		fetch.ref = selected.Tag
+
+		// Only a package selected by version is eligible for the global
+		// module cache, because the content at any other address might
+		// change over time.
+		cacheKey = fmt.Sprintf("git %s %s", fetchAddr, selected.Version)
 	}
 
-	err := fetcher.FetchPackage(ctx, instPath, packageAddr.String())
+	// Report the package up to the caller, even if we fail to download it
+	// below.
+	fetch.packageAddr = packageAddr.String()
+
+	err := i.downloadModulePackage(ctx, cacheKey, instPath, func(ctx context.Context, dir string) error {
+		return fetcher.FetchPackage(ctx, dir, fetchAddr)
+	})
 	if err != nil {
 		// go-getter generates a poor error for an invalid relative path, so
 		// we'll detect that case and generate a better one.
@@ -915,7 +1121,7 @@ func (i *ModuleInstaller) installGoGetterModule(ctx context.Context, req *config
 				Subject:  req.CallRange.Ptr(),
 			})
 		}
-		return nil, diags
+		return diags
 	}
 
 	modDir, err := getmodules.ExpandSubdirGlobs(instPath, addr.Subdir)
@@ -925,48 +1131,79 @@ func (i *ModuleInstaller) installGoGetterModule(ctx context.Context, req *config
 			Summary:  "Failed to expand subdir globs",
 			Detail:   err.Error(),
 		})
-		return nil, diags
+		return diags
 	}
+	fetch.modDir = modDir
+	fetch.subdir = addr.Subdir
+	return diags
+}
 
-	log.Printf("[TRACE] ModuleInstaller: %s %q was downloaded to %s", key, addr, modDir)
+// selectGitTagVersion finds the newest version of a module in a git
+// repository that matches the version constraint in the given request, using
+// the repository's tags as described in [getmodules.GitTagVersions].
+func (i *ModuleInstaller) selectGitTagVersion(ctx context.Context, req *configs.ModuleRequest, key string, addr addrs.ModuleSourceRemote) (*getmodules.GitTagVersion, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
 
-	// Finally we are ready to try actually loading the module.
-	mod, mDiags := i.loader.LoadConfigDir(modDir, req.Call)
-	if mod == nil {
-		// nil indicates missing or unreadable directory, so we'll
-		// discard the returned diags and return a more specific
-		// error message here.
-		isNonExistent, missingDir := isSubDirNonExistent(modDir)
-		if addr.Subdir != "" && isNonExistent {
-			// This is a user configuration error - they referenced a submodule that doesn't exist
+	cacheKey := gitModule{packageAddr: addr.Package, subdir: addr.Subdir}
+	i.mu.Lock()
+	available, exists := i.gitTagVersions[cacheKey]
+	i.mu.Unlock()
+	if exists {
+		log.Printf("[TRACE] %s using already found available versions of %s", key, addr)
+	} else {
+		var err error
+		log.Printf("[DEBUG] %s listing available versions of %s from git tags", key, addr)
+		available, err = getmodules.GitTagVersions(ctx, addr.Package.String(), addr.Subdir)
+		if errors.Is(err, context.Canceled) {
 			diags = diags.Append(&hcl.Diagnostic{
 				Severity: hcl.DiagError,
-				Summary:  "Module subdirectory not found",
-				Detail:   fmt.Sprintf("Cannot find directory %q in the module path %q. The requested subdirectory was %q.", missingDir, instPath, addr.Subdir),
-				Subject:  req.CallRange.Ptr(),
+				Summary:  "Module installation was interrupted",
+				Detail:   fmt.Sprintf("Received interrupt signal while retrieving available versions for module %q.", req.Name),
 			})
-		} else {
-			// This is genuinely unexpected - the module was just downloaded
+			return nil, diags
+		} else if err != nil {
 			diags = diags.Append(&hcl.Diagnostic{
 				Severity: hcl.DiagError,
-				Summary:  "Unreadable module directory",
-				Detail:   fmt.Sprintf("The directory %s could not be read. This is a bug in OpenTofu and should be reported.", modDir),
+				Summary:  "Error accessing git repository",
+				Detail:   fmt.Sprintf("Failed to retrieve available versions for module %q (%s:%d) from %s: %s.", req.Name, req.CallRange.Filename, req.CallRange.Start.Line, addr.Package, err),
+				Subject:  req.CallRange.Ptr(),
 			})
+			return nil, diags
 		}
-	} else {
-		diags = diags.Extend(mDiags)
+		i.mu.Lock()
+		i.gitTagVersions[cacheKey] = available
+		i.mu.Unlock()
 	}
 
-	// Note the local location in our manifest.
-	manifest[key] = modsdir.Record{
-		Key:        key,
-		Dir:        modDir,
-		SourceAddr: req.SourceAddr.String(),
+	if len(available) == 0 {
+		tagForm := "vX.Y.Z"
+		if addr.Subdir != "" {
+			tagForm = addr.Subdir + "/vX.Y.Z"
+		}
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Module has no versions",
+			Detail:   fmt.Sprintf("Module %q (%s:%d) has no versions available in %s. OpenTofu looks for tags of the form %q.", req.Name, req.CallRange.Filename, req.CallRange.Start.Line, addr.Package, tagForm),
+			Subject:  req.CallRange.Ptr(),
+		})
+		return nil, diags
 	}
-	log.Printf("[DEBUG] Module installer: %s installed at %s", key, modDir)
-	hooks.Install(key, nil, modDir)
 
-	return mod, diags
+	// available is sorted by increasing version, so the first match
+	// from the end is the newest.
+	for idx := len(available) - 1; idx >= 0; idx-- {
+		if req.VersionConstraint.Check(available[idx].Version) {
+			log.Printf("[TRACE] %s selected tag %q for version constraint %s", key, available[idx].Tag, req.VersionConstraint.String())
+			return &available[idx], diags
+		}
+	}
+	diags = diags.Append(&hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Unresolvable module version constraint",
+		Detail:   fmt.Sprintf("There is no available version of module %q (%s:%d) which matches the given version constraint. The newest available version is %s.", req.Name, req.CallRange.Filename, req.CallRange.Start.Line, available[len(available)-1].Version),
+		Subject:  req.CallRange.Ptr(),
+	})
+	return nil, diags
 }
 
 func (i *ModuleInstaller) packageInstallPath(modulePath addrs.Module) string {
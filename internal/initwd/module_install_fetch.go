@@ -0,0 +1,287 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package initwd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/getmodules"
+	"github.com/opentofu/opentofu/internal/modsdir"
+)
+
+// DefaultMaxConcurrency is the number of module packages that a
+// [ModuleInstaller] will fetch concurrently unless configured otherwise
+// using [ModuleInstaller.SetMaxConcurrency].
+const DefaultMaxConcurrency = 8
+
+// SetMaxConcurrency limits how many module packages the receiving installer
+// will fetch at the same time during InstallModules.
+//
+// The configuration walk that discovers and loads modules is always
+// sequential, so that hook calls and diagnostics appear in a predictable
+// order, but as soon as a module has been loaded the installer starts
+// fetching the packages for its remote module calls in the background.
+// A limit of 1 disables that, so that each package is fetched only once
+// the walk reaches its module call.
+//
+// A limit of zero or less selects [DefaultMaxConcurrency].
+func (i *ModuleInstaller) SetMaxConcurrency(limit int) {
+	switch {
+	case limit <= 0:
+		i.workerSlots = make(chan struct{}, DefaultMaxConcurrency)
+	case limit == 1:
+		i.workerSlots = nil
+	default:
+		i.workerSlots = make(chan struct{}, limit)
+	}
+}
+
+// SetGlobalCacheDir activates a shared cache of module packages in the given
+// directory, which must already exist.
+//
+// Only packages selected by version are cached: versions of registry modules
+// and versions of modules in git repositories selected by tag. Each package
+// is stored only once under a hash of its contents, and is found using the
+// source address and version it was selected by. The cache is populated
+// atomically, so it can be shared by concurrent OpenTofu processes.
+//
+// Each installed module still gets its own copy of the package in the
+// installer's modules directory, so the cache only avoids the download.
+func (i *ModuleInstaller) SetGlobalCacheDir(dir string) {
+	if dir == "" {
+		i.globalCache = nil
+		return
+	}
+	i.globalCache = &moduleCache{dir: dir}
+}
+
+// moduleFetch tracks the fetching of the package for a single remote module
+// call, which might either happen inline during the configuration walk or
+// ahead of time in the background.
+type moduleFetch struct {
+	// done is closed once the fetch is complete, after which the other
+	// fields must not be modified.
+	done chan struct{}
+
+	// sourceAddr and versionConstraint are from the module request that the
+	// fetch was started for. The configuration walk uses a result only if
+	// its own request matches.
+	sourceAddr        string
+	versionConstraint string
+
+	// packageAddr is the address of the package that the module's source
+	// address resolved to, for reporting to the Download hook. It's empty
+	// if the source address could not be resolved.
+	packageAddr string
+
+	// version and ref are the version and git tag selected for a module with
+	// a version constraint.
+	version *version.Version
+	ref     string
+
+	// location is a description of where a registry module's package was
+	// downloaded from, for logging and tracing.
+	location string
+
+	// modDir is the directory where the requested module was installed,
+	// which might be a subdirectory of the installation path if subdir
+	// is non-empty.
+	modDir string
+	subdir string
+
+	diags hcl.Diagnostics
+}
+
+func (f *moduleFetch) matches(req *configs.ModuleRequest) bool {
+	return f.sourceAddr == req.SourceAddr.String() && f.versionConstraint == req.VersionConstraint.String()
+}
+
+// fetchModule fetches the package for the given remote module request into
+// instPath, or returns the result of a fetch for the same request that was
+// already started ahead of the configuration walk by prefetchModuleCalls.
+//
+// The returned fetch is always complete.
+func (i *ModuleInstaller) fetchModule(ctx context.Context, req *configs.ModuleRequest, key string, instPath string, fetcher *getmodules.PackageFetcher) *moduleFetch {
+	i.mu.Lock()
+	fetch, exists := i.fetches[key]
+	delete(i.fetches, key)
+	i.mu.Unlock()
+
+	if exists {
+		<-fetch.done
+		if fetch.matches(req) {
+			log.Printf("[TRACE] ModuleInstaller: %s was fetched ahead of time", key)
+			return fetch
+		}
+		// Should not get here, because the walk makes the same requests
+		// that we predicted, but we'll fetch again just in case.
+		log.Printf("[TRACE] ModuleInstaller: discarding fetch of %s for a different request", key)
+		if diags := cleanModuleDir(key, instPath); diags.HasErrors() {
+			return &moduleFetch{diags: diags}
+		}
+	}
+
+	fetch = newModuleFetch(req)
+	i.runModuleFetch(ctx, req, key, instPath, fetcher, fetch)
+	return fetch
+}
+
+// moduleFetchStarted returns true if a fetch for the module with the given
+// key was started ahead of the configuration walk and has not yet been
+// consumed by it.
+func (i *ModuleInstaller) moduleFetchStarted(key string) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	_, exists := i.fetches[key]
+	return exists
+}
+
+// prefetchModuleCalls starts fetching, in the background, the packages for
+// the remote module calls in the given module that the configuration walk
+// will need to install, so that they are hopefully ready by the time the
+// walk reaches them.
+//
+// This is only an optimization: the walk itself must still visit each of
+// the module calls, at which point it waits for any fetch that was started
+// here or otherwise fetches the package inline.
+func (i *ModuleInstaller) prefetchModuleCalls(ctx context.Context, path addrs.Module, mod *configs.Module, manifest modsdir.Manifest, upgrade bool, fetcher *getmodules.PackageFetcher) {
+	if i.workerSlots == nil {
+		return // fetching ahead is disabled
+	}
+
+	for _, call := range mod.ModuleCalls {
+		switch call.SourceAddr.(type) {
+		case addrs.ModuleSourceRegistry, addrs.ModuleSourceRemote:
+			// These are the module sources that need fetching.
+		default:
+			continue
+		}
+		if !hclsyntax.ValidIdentifier(call.Name) {
+			// The walk won't install a module with an invalid name.
+			continue
+		}
+
+		// This request must match the one that configs.BuildConfig will
+		// construct for this call, at least in the fields that fetching
+		// relies on.
+		req := &configs.ModuleRequest{
+			Name:              call.Name,
+			Path:              path.Child(call.Name),
+			SourceAddr:        call.SourceAddr,
+			VersionConstraint: call.Version,
+			CallRange:         call.DeclRange,
+		}
+		if call.Source != nil {
+			req.SourceAddrRange = call.Source.Range()
+		}
+		key := manifest.ModuleKey(req.Path)
+		if !upgrade && moduleAlreadyInstalled(manifest, key, req) {
+			continue
+		}
+
+		i.mu.Lock()
+		if _, exists := i.fetches[key]; exists {
+			i.mu.Unlock()
+			continue
+		}
+		fetch := newModuleFetch(req)
+		i.fetches[key] = fetch
+		i.mu.Unlock()
+
+		instPath := i.packageInstallPath(req.Path)
+		workerSlots := i.workerSlots
+		i.fetchesWG.Go(func() {
+			workerSlots <- struct{}{}
+			defer func() { <-workerSlots }()
+
+			if diags := cleanModuleDir(key, instPath); diags.HasErrors() {
+				fetch.diags = diags
+				close(fetch.done)
+				return
+			}
+			i.runModuleFetch(ctx, req, key, instPath, fetcher, fetch)
+		})
+	}
+}
+
+func newModuleFetch(req *configs.ModuleRequest) *moduleFetch {
+	return &moduleFetch{
+		done:              make(chan struct{}),
+		sourceAddr:        req.SourceAddr.String(),
+		versionConstraint: req.VersionConstraint.String(),
+	}
+}
+
+// runModuleFetch performs the given fetch, which must not yet have been run,
+// and then marks it as complete.
+func (i *ModuleInstaller) runModuleFetch(ctx context.Context, req *configs.ModuleRequest, key string, instPath string, fetcher *getmodules.PackageFetcher, fetch *moduleFetch) {
+	defer close(fetch.done)
+
+	switch addr := req.SourceAddr.(type) {
+	case addrs.ModuleSourceRegistry:
+		fetch.diags = i.fetchRegistryModule(ctx, req, key, instPath, addr, fetcher, fetch)
+	case addrs.ModuleSourceRemote:
+		fetch.diags = i.fetchGoGetterModule(ctx, req, key, instPath, addr, fetcher, fetch)
+	default:
+		// Shouldn't get here, because only remote modules are fetched.
+		panic(fmt.Sprintf("can't fetch module source address %#v", addr))
+	}
+}
+
+// downloadModulePackage uses the given function to download a module package
+// into instPath, unless it's available in the global module cache.
+//
+// cacheKey identifies the package in the global module cache, and must
+// include both its source address and its version. If it's empty then the
+// package is not eligible for caching.
+func (i *ModuleInstaller) downloadModulePackage(ctx context.Context, cacheKey string, instPath string, download func(ctx context.Context, dir string) error) error {
+	if i.globalCache == nil || cacheKey == "" {
+		return download(ctx, instPath)
+	}
+	return i.globalCache.install(ctx, cacheKey, instPath, download)
+}
+
+// moduleAlreadyInstalled returns true if the configuration walk would reuse
+// the existing installation of the module with the given key, rather than
+// installing it again, when not upgrading.
+func moduleAlreadyInstalled(manifest modsdir.Manifest, key string, req *configs.ModuleRequest) bool {
+	record, recorded := manifest[key]
+	if !recorded || record.SourceAddr != req.SourceAddr.String() {
+		return false
+	}
+	if record.Version != nil && !req.VersionConstraint.Check(record.Version) {
+		return false
+	}
+	info, err := os.Stat(record.Dir)
+	return err == nil && info.IsDir()
+}
+
+// cleanModuleDir removes any stale installation of the module with the given
+// key from instPath, prior to installing it again.
+func cleanModuleDir(key string, instPath string) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	log.Printf("[TRACE] ModuleInstaller: cleaning directory %s prior to install of %s", instPath, key)
+	err := os.RemoveAll(instPath)
+	if err != nil && !os.IsNotExist(err) {
+		log.Printf("[TRACE] ModuleInstaller: failed to remove %s: %s", key, err)
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to remove local module cache",
+			Detail: fmt.Sprintf(
+				"OpenTofu tried to remove %s in order to reinstall this module, but encountered an error: %s",
+				instPath, err,
+			),
+		})
+	}
+	return diags
+}
@@ -17,8 +17,11 @@ import (
 // methods.
 type ModuleInstallHooks interface {
 	// Download is called for modules that are retrieved from a remote source
-	// before that download begins, to allow a caller to give feedback
-	// on progress through a possibly-long sequence of downloads.
+	// before that module is installed, to allow a caller to give feedback
+	// on progress through a possibly-long sequence of downloads. The
+	// download itself might already have begun in the background, or
+	// might be skipped entirely if the package is in the global module
+	// cache.
 	Download(moduleAddr, packageAddr string, version *version.Version)
 
 	// Install is called for each module that is installed, even if it did
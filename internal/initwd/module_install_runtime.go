@@ -43,6 +43,10 @@ func (i *ModuleInstaller) installDescendentModulesNewRuntime(ctx context.Context
 		})
 	}
 
+	// The walker starts fetching packages for child modules ahead of time,
+	// so we'll make sure none of those are still running once we return.
+	defer i.fetchesWG.Wait()
+
 	root, hclDiags := i.loader.LoadConfigDirUneval(rootDir, configs.SelectiveLoadAll)
 	diags = diags.Append(hclDiags)
 	if diags.HasErrors() {
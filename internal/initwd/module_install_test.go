@@ -9,7 +9,9 @@ import (
 	"bytes"
 	"context"
 	"flag"
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -24,6 +26,7 @@ import (
 	"github.com/opentofu/opentofu/internal/configs/configload"
 	"github.com/opentofu/opentofu/internal/copy"
 	"github.com/opentofu/opentofu/internal/getmodules"
+	"github.com/opentofu/opentofu/internal/modsdir"
 	"github.com/opentofu/opentofu/internal/registry"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 
@@ -753,6 +756,187 @@ func TestLoaderInstallModules_goGetter(t *testing.T) {
 	assertResultDeepEqual(t, gotTraces, wantTraces)
 }
 
+func TestModuleInstaller_gitVersionConstraint(t *testing.T) {
+	repoDir := testGitMonorepo(t)
+
+	// The installer reports paths with symlinks resolved, so we must do
+	// the same in case the temporary directory is behind a symlink.
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	source := "git::file://" + filepath.ToSlash(repoDir) + "//modules/vpc"
+	rootSrc := fmt.Sprintf("module \"vpc\" {\n  source  = %q\n  version = \"~> 1.0\"\n}\n", source)
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(rootSrc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	hooks := &testInstallHooks{}
+	modulesDir := filepath.Join(dir, ".terraform/modules")
+	loader := configload.NewLoaderForTests(t, false)
+	fetcher := getmodules.NewPackageFetcher(t.Context(), nil)
+	inst := NewModuleInstaller(modulesDir, loader, nil, fetcher)
+	_, diags := inst.InstallModules(context.Background(), ".", "tests", false, false, hooks, configs.RootModuleCallForTesting())
+	assertNoDiagnostics(t, diags)
+
+	v110 := version.Must(version.NewVersion("1.1.0"))
+	wantCalls := []testInstallHookCall{
+		{
+			Name:        "Download",
+			ModuleAddr:  "vpc",
+			PackageAddr: "git::file://" + filepath.ToSlash(repoDir),
+			Version:     v110,
+		},
+		{
+			Name:       "Install",
+			ModuleAddr: "vpc",
+			Version:    v110,
+			LocalPath:  filepath.Join(dir, ".terraform/modules/vpc/modules/vpc"),
+		},
+	}
+	assertResultDeepEqual(t, hooks.Calls, wantCalls)
+
+	manifest, err := modsdir.ReadManifestSnapshotForDir(modulesDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	record := manifest["vpc"]
+	if got, want := record.Ref, "modules/vpc/v1.1.0"; got != want {
+		t.Errorf("wrong ref in manifest %q; want %q", got, want)
+	}
+	if !record.Version.Equal(v110) {
+		t.Errorf("wrong version in manifest %s; want %s", record.Version, v110)
+	}
+
+	loader, err = configload.NewLoader(&configload.Config{
+		ModulesDir: modulesDir,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, loadDiags := loader.LoadConfig(t.Context(), ".", configs.RootModuleCallForTesting())
+	assertNoDiagnostics(t, tfdiags.Diagnostics{}.Append(loadDiags))
+	if got, want := config.Children["vpc"].Module.Variables["v"].Description, "vpc 1.1.0"; got != want {
+		t.Errorf("wrong module version installed: got %q, want %q", got, want)
+	}
+}
+
+func TestModuleInstaller_concurrentWithGlobalCache(t *testing.T) {
+	repoDir := testGitMonorepo(t)
+	cacheDir := t.TempDir()
+	source := "git::file://" + filepath.ToSlash(repoDir) + "//modules/vpc"
+	rootSrc := fmt.Sprintf(`
+module "a" {
+  source  = %q
+  version = "~> 1.0"
+}
+module "b" {
+  source  = %q
+  version = "~> 1.0"
+}
+module "c" {
+  source  = %q
+  version = ">= 2.0"
+}
+`, source, source, source)
+
+	v110 := version.Must(version.NewVersion("1.1.0"))
+	v200 := version.Must(version.NewVersion("2.0.0"))
+	wantDescs := map[string]string{
+		"a": "vpc 1.1.0",
+		"b": "vpc 1.1.0",
+		"c": "vpc 2.0.0",
+	}
+
+	install := func(t *testing.T) string {
+		t.Helper()
+		dir, err := filepath.EvalSymlinks(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Chdir(dir)
+		if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(rootSrc), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		hooks := &testInstallHooks{}
+		modulesDir := filepath.Join(dir, ".terraform/modules")
+		loader := configload.NewLoaderForTests(t, false)
+		fetcher := getmodules.NewPackageFetcher(t.Context(), nil)
+		inst := NewModuleInstaller(modulesDir, loader, nil, fetcher)
+		inst.SetMaxConcurrency(3)
+		inst.SetGlobalCacheDir(cacheDir)
+		_, diags := inst.InstallModules(context.Background(), ".", "tests", false, false, hooks, configs.RootModuleCallForTesting())
+		assertNoDiagnostics(t, diags)
+
+		// The walk is still sequential, so the hooks must be called in
+		// a predictable order even though the packages were fetched
+		// concurrently.
+		packageAddr := "git::file://" + filepath.ToSlash(repoDir)
+		wantCalls := []testInstallHookCall{
+			{Name: "Download", ModuleAddr: "a", PackageAddr: packageAddr, Version: v110},
+			{Name: "Install", ModuleAddr: "a", Version: v110, LocalPath: filepath.Join(dir, ".terraform/modules/a/modules/vpc")},
+			{Name: "Download", ModuleAddr: "b", PackageAddr: packageAddr, Version: v110},
+			{Name: "Install", ModuleAddr: "b", Version: v110, LocalPath: filepath.Join(dir, ".terraform/modules/b/modules/vpc")},
+			{Name: "Download", ModuleAddr: "c", PackageAddr: packageAddr, Version: v200},
+			{Name: "Install", ModuleAddr: "c", Version: v200, LocalPath: filepath.Join(dir, ".terraform/modules/c/modules/vpc")},
+		}
+		assertResultDeepEqual(t, hooks.Calls, wantCalls)
+
+		loader, err = configload.NewLoader(&configload.Config{
+			ModulesDir: modulesDir,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		config, loadDiags := loader.LoadConfig(t.Context(), ".", configs.RootModuleCallForTesting())
+		assertNoDiagnostics(t, tfdiags.Diagnostics{}.Append(loadDiags))
+		for name, want := range wantDescs {
+			if got := config.Children[name].Module.Variables["v"].Description; got != want {
+				t.Errorf("wrong version of module %q installed: got %q, want %q", name, got, want)
+			}
+		}
+		return dir
+	}
+
+	t.Run("populate cache", func(t *testing.T) {
+		install(t)
+
+		// Each of the two selected versions must be cached exactly once.
+		for _, subdir := range []string{"sources", "packages"} {
+			entries, err := os.ReadDir(filepath.Join(cacheDir, subdir))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := len(entries), 2; got != want {
+				t.Errorf("wrong number of entries in %s: got %d, want %d", subdir, got, want)
+			}
+		}
+	})
+	t.Run("install from cache", func(t *testing.T) {
+		// We'll move the tags to a commit with different content, which
+		// should not be visible when installing again because the cache
+		// already contains packages for these versions.
+		if err := os.WriteFile(filepath.Join(repoDir, "modules", "vpc", "main.tf"), []byte("variable \"v\" {\n  description = \"retagged\"\n}\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		for _, args := range [][]string{
+			{"add", "-A"},
+			{"commit", "-q", "-m", "retagged"},
+			{"tag", "-f", "modules/vpc/v1.1.0"},
+			{"tag", "-f", "modules/vpc/v2.0.0"},
+		} {
+			cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false", "-c", "tag.gpgsign=false"}, args...)...)
+			cmd.Dir = repoDir
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("git %s failed: %s\n%s", strings.Join(args, " "), err, out)
+			}
+		}
+		install(t)
+	})
+}
+
 func TestModuleInstaller_fromTests(t *testing.T) {
 	fixtureDir := filepath.Clean("testdata/local-module-from-test")
 	dir := tempChdir(t, fixtureDir)
@@ -1125,3 +1309,47 @@ func assertResultDeepEqual(t *testing.T, got, want any) bool {
 	}
 	return false
 }
+
+// testGitMonorepo creates a local git repository containing a module in
+// the subdirectory "modules/vpc", with tags for versions 1.0.0, 1.1.0, and
+// 2.0.0 using the monorepo path prefix convention, and returns its path.
+//
+// The description of the module's variable "v" reveals which version of the
+// module was installed.
+func testGitMonorepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("this test requires the git executable")
+	}
+
+	repoDir := t.TempDir()
+	runGit := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false", "-c", "tag.gpgsign=false"}, args...)...)
+		cmd.Dir = repoDir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %s\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+	writeVPC := func(desc string) {
+		t.Helper()
+		dir := filepath.Join(repoDir, "modules", "vpc")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		src := fmt.Sprintf("variable \"v\" {\n  description = %q\n  default     = \"\"\n}\n", desc)
+		if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGit("add", "-A")
+		runGit("commit", "-q", "-m", desc)
+	}
+	runGit("init", "-q")
+	for _, v := range []string{"1.0.0", "1.1.0", "2.0.0"} {
+		writeVPC("vpc " + v)
+		runGit("tag", "modules/vpc/v"+v)
+	}
+	// This tag doesn't have the module's prefix, so must be ignored.
+	runGit("tag", "v1.5.0")
+	return repoDir
+}
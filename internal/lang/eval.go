@@ -7,6 +7,7 @@ package lang
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"maps"
@@ -218,17 +219,46 @@ func (s *Scope) EvalExpr(ctx context.Context, expr hcl.Expression, wantType cty.
 
 // Identify and enhance any function related dialogs produced by a hcl.EvalContext
 func enhanceFunctionDiags(diags hcl.Diagnostics) hcl.Diagnostics {
-	out := make(hcl.Diagnostics, len(diags))
-	for i, diag := range diags {
-		out[i] = diag
+	out := make(hcl.Diagnostics, 0, len(diags))
+	for _, diag := range diags {
+		if callExtra, ok := diag.Extra.(hclsyntax.FunctionCallDiagExtra); ok {
+			var ufErr userFunctionError
+			if errors.As(callExtra.FunctionCallError(), &ufErr) {
+				out = append(out, userFunctionDiags(diag, ufErr)...)
+				continue
+			}
+		}
 
 		if funcExtra, ok := diag.Extra.(hclsyntax.FunctionCallUnknownDiagExtra); ok {
-			out[i] = enhanceFunctionDiag(diag, funcExtra)
+			diag = enhanceFunctionDiag(diag, funcExtra)
 		}
+		out = append(out, diag)
 	}
 	return out
 }
 
+// userFunctionDiags returns the diagnostics from evaluating the result of a
+// user-defined function in place of the given diagnostic about the failed
+// call, so that they point at the expression in the function block that
+// failed. Each one also mentions the call, so that it's still clear where
+// the function was called from.
+func userFunctionDiags(callDiag *hcl.Diagnostic, ufErr userFunctionError) hcl.Diagnostics {
+	diags := enhanceFunctionDiags(ufErr.Diags)
+	for i, diag := range diags {
+		// Shallow copy of the diagnostic so that we can modify it without
+		// affecting anyone else that might be holding a pointer to it.
+		enhanced := *diag
+		if callDiag.Subject != nil {
+			enhanced.Detail = strings.TrimSpace(fmt.Sprintf(
+				"%s\n\nThis happened while calling function %q at %s.",
+				enhanced.Detail, UserFunctionName(ufErr.Name), callDiag.Subject,
+			))
+		}
+		diags[i] = &enhanced
+	}
+	return diags
+}
+
 // enhanceFunctionDiag returns a potentially-improved version of the given diagnostic
 // based on the information in funcExtra.
 func enhanceFunctionDiag(diag *hcl.Diagnostic, funcExtra hclsyntax.FunctionCallUnknownDiagExtra) *hcl.Diagnostic {
@@ -253,6 +283,16 @@ func enhanceFunctionDiag(diag *hcl.Diagnostic, funcExtra hclsyntax.FunctionCallU
 		// Error is in core namespace, mirror non-core equivalent
 		enhanced.Summary = "Call to unknown function"
 		enhanced.Detail = fmt.Sprintf("There is no builtin (%s::) function named %q.", addrs.FunctionNamespaceCore, funcName)
+	} else if fn.IsNamespace(addrs.FunctionNamespaceModule) {
+		enhanced.Summary = "Call to unknown function"
+		enhanced.Detail = fmt.Sprintf("There is no function named %q declared by a \"function\" block in this module, or exported by a \"function\" block in one of its parent modules.", funcName)
+	} else if fn.IsNamespace(addrs.FunctionNamespacePlugin) {
+		enhanced.Summary = "Call to unknown function"
+		if len(fn.Namespaces) != 2 {
+			enhanced.Detail = fmt.Sprintf("Invalid function %q: expected plugin::<plugin>::<function>.", fn)
+		} else {
+			enhanced.Detail = fmt.Sprintf("There is no function named %q provided by function plugin %q. A module can only call functions from the function plugins listed in the \"required_functions\" argument of its \"terraform\" block.", funcName, fn.Namespaces[1])
+		}
 	} else if fn.IsNamespace(addrs.FunctionNamespaceProvider) {
 		if _, err := fn.AsProviderFunction(); err != nil {
 			// complete mismatch or invalid prefix
@@ -984,11 +984,29 @@ func Test_enhanceFunctionDiags(t *testing.T) {
 			"Call to unknown function",
 			"There is no builtin (core::) function named \"missing_function\".",
 		},
+		{
+			"Missing module function",
+			"attr = module::missing_function(54)",
+			"Call to unknown function",
+			"There is no function named \"missing_function\" declared by a \"function\" block in this module, or exported by a \"function\" block in one of its parent modules.",
+		},
 		{
 			"Invalid prefix",
 			"attr = magic::missing_function(54)",
 			"Unknown function namespace",
-			"Function \"magic::missing_function\" does not exist within a valid namespace (provider,core)",
+			"Function \"magic::missing_function\" does not exist within a valid namespace (provider,core,module,plugin)",
+		},
+		{
+			"Missing plugin function",
+			"attr = plugin::example::missing_function(54)",
+			"Call to unknown function",
+			"There is no function named \"missing_function\" provided by function plugin \"example\". A module can only call functions from the function plugins listed in the \"required_functions\" argument of its \"terraform\" block.",
+		},
+		{
+			"Plugin function without plugin name",
+			"attr = plugin::missing_function(54)",
+			"Call to unknown function",
+			"Invalid function \"plugin::missing_function\": expected plugin::<plugin>::<function>.",
 		},
 		{
 			"Too many namespaces",
@@ -7,6 +7,8 @@ package evalchecks
 
 import (
 	"github.com/apparentlymart/go-shquot/shquot"
+
+	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
@@ -30,6 +32,53 @@ func (e DiagnosticCausedByUnknown) DiagnosticCausedByUnknown() bool {
 	return bool(e)
 }
 
+// DiagnosticUnknownExpansion is an implementation of
+// tfdiags.DiagnosticExtraBecauseUnknown which we use in the "Extra" field of
+// the errors about an unknown "count" or "for_each" value, when we know the
+// address of the object whose instances could not be determined.
+//
+// Callers that are able to defer the planning of that object to a later
+// plan/apply round can use [UnknownExpansion] to recognize those errors.
+type DiagnosticUnknownExpansion struct {
+	// Addr is the address of the resource or module call whose expansion
+	// is unknown.
+	Addr addrs.Targetable
+
+	// Argument is the name of the argument whose value is unknown: either
+	// "count" or "for_each".
+	Argument string
+}
+
+var _ tfdiags.DiagnosticExtraBecauseUnknown = DiagnosticUnknownExpansion{}
+
+func (e DiagnosticUnknownExpansion) DiagnosticCausedByUnknown() bool {
+	return true
+}
+
+// UnknownExpansion returns the extra information from the given diagnostic
+// if it's an error about an unknown "count" or "for_each" value for an
+// object with a known address.
+func UnknownExpansion(diag tfdiags.Diagnostic) (DiagnosticUnknownExpansion, bool) {
+	if diag.Severity() != tfdiags.Error {
+		return DiagnosticUnknownExpansion{}, false
+	}
+	extra := tfdiags.ExtraInfo[DiagnosticUnknownExpansion](diag)
+	return extra, extra.Addr != nil
+}
+
+// unknownExpansionExtra returns the value to use in the "Extra" field of
+// an error about the given argument of the object at excludableAddr having
+// an unknown value.
+func unknownExpansionExtra(excludableAddr addrs.Targetable, argument string) any {
+	if excludableAddr == nil {
+		return DiagnosticCausedByUnknown(true)
+	}
+	return DiagnosticUnknownExpansion{
+		Addr:     excludableAddr,
+		Argument: argument,
+	}
+}
+
 // DiagnosticCausedByConfidentialValues is an implementation of
 // tfdiags.DiagnosticExtraBecauseConfidentialValues which we can use in the "Extra" field
 // of a diagnostic to indicate that the problem was caused by confidential values
@@ -54,7 +54,7 @@ func EvaluateCountExpression(expr hcl.Expression, ctx EvaluateFunc, excludableAd
 			// we can't easily do that right now because the hcl.EvalContext
 			// (which is not the same as the ctx we have in scope here) is
 			// hidden away inside evaluateCountExpressionValue.
-			Extra: DiagnosticCausedByUnknown(true),
+			Extra: unknownExpansionExtra(excludableAddr, "count"),
 		})
 	}
 
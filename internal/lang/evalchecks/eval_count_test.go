@@ -155,6 +155,19 @@ func TestEvaluateCountExpression_errors(t *testing.T) {
 			if got, want := tfdiags.DiagnosticCausedByUnknown(diags[0]), test.CausedByUnknown; got != want {
 				t.Errorf("wrong result from tfdiags.DiagnosticCausedByUnknown\ngot:  %#v\nwant: %#v", got, want)
 			}
+
+			expansion, deferrable := UnknownExpansion(diags[0])
+			if want := test.CausedByUnknown && test.excludableAddr != nil; deferrable != want {
+				t.Errorf("wrong result from UnknownExpansion\ngot:  %#v\nwant: %#v", deferrable, want)
+			}
+			if deferrable {
+				if got, want := expansion.Addr.String(), test.excludableAddr.String(); got != want {
+					t.Errorf("wrong unknown expansion address\ngot:  %s\nwant: %s", got, want)
+				}
+				if got, want := expansion.Argument, "count"; got != want {
+					t.Errorf("wrong unknown expansion argument\ngot:  %s\nwant: %s", got, want)
+				}
+			}
 		})
 	}
 }
@@ -150,7 +150,7 @@ func performValueChecks(expr hcl.Expression, hclCtx *hcl.EvalContext, allowUnkno
 				Subject:     expr.Range().Ptr(),
 				Expression:  expr,
 				EvalContext: hclCtx,
-				Extra:       DiagnosticCausedByUnknown(true),
+				Extra:       unknownExpansionExtra(excludableAddr, "for_each"),
 			})
 		}
 		resultVal = cty.UnknownVal(ty)
@@ -256,6 +256,47 @@ func TestEvaluateForEachExpressionValueTuple(t *testing.T) {
 	}
 }
 
+func TestEvaluateForEachExpression_unknownExpansion(t *testing.T) {
+	addr := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "happycloud_virtual_machine",
+		Name: "boop",
+	}.Absolute(addrs.RootModuleInstance)
+	expr := hcltest.MockExprLiteral(cty.UnknownVal(cty.Map(cty.String)))
+
+	t.Run("with excludable address", func(t *testing.T) {
+		_, diags := EvaluateForEachExpression(expr, mockRefsFunc(), addr)
+		if len(diags) != 1 {
+			t.Fatalf("got %d diagnostics; want 1", len(diags))
+		}
+		expansion, ok := UnknownExpansion(diags[0])
+		if !ok {
+			t.Fatal("UnknownExpansion returned false; want true")
+		}
+		if expansion.Addr.String() != addr.String() {
+			t.Errorf("wrong address %s; want %s", expansion.Addr, addr)
+		}
+		if got, want := expansion.Argument, "for_each"; got != want {
+			t.Errorf("wrong argument %q; want %q", got, want)
+		}
+		if !tfdiags.DiagnosticCausedByUnknown(diags[0]) {
+			t.Error("tfdiags.DiagnosticCausedByUnknown returned false; want true")
+		}
+	})
+	t.Run("without excludable address", func(t *testing.T) {
+		_, diags := EvaluateForEachExpression(expr, mockRefsFunc(), nil)
+		if len(diags) != 1 {
+			t.Fatalf("got %d diagnostics; want 1", len(diags))
+		}
+		if _, ok := UnknownExpansion(diags[0]); ok {
+			t.Error("UnknownExpansion returned true; want false")
+		}
+		if !tfdiags.DiagnosticCausedByUnknown(diags[0]) {
+			t.Error("tfdiags.DiagnosticCausedByUnknown returned false; want true")
+		}
+	})
+}
+
 func TestForEachCommandLineExcludeSuggestion(t *testing.T) {
 	noKeyResourceAddr := addrs.Resource{
 		Mode: addrs.ManagedResourceMode,
@@ -0,0 +1,26 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package funcplugin implements function plugins, which are local programs
+// that provide additional functions to the OpenTofu language.
+//
+// A function plugin is much simpler to write than a provider: OpenTofu runs
+// the program once to find out which functions it provides, and then once
+// for each distinct call, exchanging a single JSON document in each
+// direction over the program's standard input and output. The protocol
+// follows the same conventions as the external key provider:
+//
+//  1. On start, the program must write a header line containing
+//     {"magic":"OpenTofu-Function-Plugin","version":1} to its standard
+//     output.
+//  2. OpenTofu writes a request object to the program's standard input and
+//     then closes it. The request's "method" property is either "functions"
+//     or "call".
+//  3. The program writes a response object to its standard output and exits
+//     with status zero. Anything written to standard error is included in
+//     the error message if the program fails.
+//
+// Types are written in the JSON type encoding used by cty, and values in the
+// JSON value encoding for their declared type. See [RequestV1],
+// [FunctionsOutputV1] and [CallOutputV1] for the details.
+package funcplugin
@@ -0,0 +1,348 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcplugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// defaultTimeout is how long we wait for each run of a plugin's command
+// before giving up on it.
+const defaultTimeout = time.Minute
+
+// Plugin is a function plugin, which provides functions by running an
+// external command.
+//
+// A Plugin remembers the functions its command provides and the result of
+// each distinct call, so that the command runs only once for each of them,
+// and so callers should create a new Plugin for each OpenTofu run. Results
+// of runs that were interrupted by canceling the caller's context are not
+// remembered, so that a later caller can try again. It is safe for
+// concurrent use.
+type Plugin struct {
+	name    string
+	command []string
+	timeout time.Duration
+
+	loadLock sync.Mutex
+	loaded   bool
+	funcs    map[string]*functionSpec
+	loadErr  error
+
+	callsLock sync.Mutex
+	calls     map[string]*callResult
+}
+
+// functionSpec is the signature of a function that the plugin provides, from
+// which Functions builds a function.Function for each caller's context.
+type functionSpec struct {
+	name       string
+	spec       function.Spec
+	paramTypes []cty.Type
+}
+
+type callResult struct {
+	sync.Mutex
+	done bool
+	val  cty.Value
+	err  error
+}
+
+// New returns a plugin with the given name that runs the given command,
+// whose first element is the program to run and whose other elements are
+// arguments to pass to it.
+func New(name string, command []string) (*Plugin, error) {
+	if !hclsyntax.ValidIdentifier(name) {
+		return nil, fmt.Errorf("%q is not a valid plugin name", name)
+	}
+	if len(command) < 1 || command[0] == "" {
+		return nil, fmt.Errorf("the command option is required")
+	}
+	return &Plugin{
+		name:    name,
+		command: command,
+		timeout: defaultTimeout,
+		calls:   make(map[string]*callResult),
+	}, nil
+}
+
+// Name returns the name of the plugin, which expressions use in the
+// plugin::<name>::<function> syntax to call its functions.
+func (p *Plugin) Name() string {
+	return p.name
+}
+
+// Functions returns the functions that the plugin provides, keyed by their
+// names without a namespace. The plugin's command runs under the given
+// context, so canceling it stops a command that hasn't responded yet.
+//
+// The plugin's command runs to find the functions the first time this method
+// is called, and later calls return functions with the same signatures.
+func (p *Plugin) Functions(ctx context.Context) (map[string]function.Function, error) {
+	p.loadLock.Lock()
+	if !p.loaded {
+		funcs, err := p.loadFunctions(ctx)
+		if err != nil && ctx.Err() != nil {
+			p.loadLock.Unlock()
+			return nil, err
+		}
+		p.funcs, p.loadErr, p.loaded = funcs, err, true
+	}
+	funcs, err := p.funcs, p.loadErr
+	p.loadLock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]function.Function, len(funcs))
+	for name, fs := range funcs {
+		ret[name] = p.function(ctx, fs)
+	}
+	return ret, nil
+}
+
+func (p *Plugin) loadFunctions(ctx context.Context) (map[string]*functionSpec, error) {
+	var out FunctionsOutputV1
+	if err := p.run(ctx, &RequestV1{Method: MethodFunctions}, &out); err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]*functionSpec, len(out.Functions))
+	for name, sig := range out.Functions {
+		if !hclsyntax.ValidIdentifier(name) {
+			return nil, fmt.Errorf("the plugin returned the invalid function name %q", name)
+		}
+		fn, err := p.newFunction(name, sig)
+		if err != nil {
+			return nil, fmt.Errorf("the plugin returned an invalid signature for function %q: %w", name, err)
+		}
+		ret[name] = fn
+	}
+	return ret, nil
+}
+
+func (p *Plugin) newFunction(name string, sig *FunctionV1) (*functionSpec, error) {
+	if sig == nil {
+		return nil, fmt.Errorf("the signature is null")
+	}
+	if len(sig.ReturnType) == 0 {
+		return nil, fmt.Errorf("return_type is required")
+	}
+	retType, err := ctyjson.UnmarshalType(sig.ReturnType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid return_type: %w", err)
+	}
+
+	fs := &functionSpec{
+		name: name,
+		spec: function.Spec{
+			Description: sig.Description,
+			Type:        function.StaticReturnType(retType),
+		},
+		paramTypes: make([]cty.Type, 0, len(sig.Params)),
+	}
+	for i, ps := range sig.Params {
+		param, err := newParameter(ps)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameter %d: %w", i, err)
+		}
+		fs.spec.Params = append(fs.spec.Params, param)
+		fs.paramTypes = append(fs.paramTypes, param.Type)
+	}
+	if sig.VariadicParam != nil {
+		param, err := newParameter(sig.VariadicParam)
+		if err != nil {
+			return nil, fmt.Errorf("invalid variadic_param: %w", err)
+		}
+		fs.spec.VarParam = &param
+	}
+	return fs, nil
+}
+
+// function returns the implementation of the given function, which runs the
+// plugin's command under the given context.
+func (p *Plugin) function(ctx context.Context, fs *functionSpec) function.Function {
+	spec := fs.spec
+	spec.Impl = func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		req := &RequestV1{
+			Method:    MethodCall,
+			Function:  fs.name,
+			Arguments: make([]json.RawMessage, len(args)),
+		}
+		for i, arg := range args {
+			ty := cty.DynamicPseudoType
+			if i < len(fs.paramTypes) {
+				ty = fs.paramTypes[i]
+			} else if spec.VarParam != nil {
+				ty = spec.VarParam.Type
+			}
+			raw, err := ctyjson.Marshal(arg, ty)
+			if err != nil {
+				return cty.UnknownVal(retType), function.NewArgErrorf(i, "can't send this value to the function plugin: %s", err)
+			}
+			req.Arguments[i] = raw
+		}
+		return p.call(ctx, req, retType)
+	}
+	return function.New(&spec)
+}
+
+func newParameter(ps *ParameterV1) (function.Parameter, error) {
+	if ps == nil {
+		return function.Parameter{}, fmt.Errorf("the parameter is null")
+	}
+	if ps.Name == "" {
+		return function.Parameter{}, fmt.Errorf("name is required")
+	}
+	if len(ps.Type) == 0 {
+		return function.Parameter{}, fmt.Errorf("type is required")
+	}
+	ty, err := ctyjson.UnmarshalType(ps.Type)
+	if err != nil {
+		return function.Parameter{}, fmt.Errorf("invalid type: %w", err)
+	}
+	return function.Parameter{
+		Name:        ps.Name,
+		Description: ps.Description,
+		Type:        ty,
+		AllowNull:   ps.AllowNullValue,
+	}, nil
+}
+
+// call returns the result of the given call request, running the plugin's
+// command only if the same request hasn't been made before, or if every
+// earlier run of it was interrupted.
+func (p *Plugin) call(ctx context.Context, req *RequestV1, retType cty.Type) (cty.Value, error) {
+	key, err := json.Marshal(req)
+	if err != nil {
+		return cty.UnknownVal(retType), err
+	}
+
+	p.callsLock.Lock()
+	result, ok := p.calls[string(key)]
+	if !ok {
+		result = &callResult{}
+		p.calls[string(key)] = result
+	}
+	p.callsLock.Unlock()
+
+	result.Lock()
+	defer result.Unlock()
+	if !result.done {
+		val, err := p.callUncached(ctx, req, retType)
+		if err != nil && ctx.Err() != nil {
+			return val, err
+		}
+		result.val, result.err, result.done = val, err, true
+	}
+	return result.val, result.err
+}
+
+func (p *Plugin) callUncached(ctx context.Context, req *RequestV1, retType cty.Type) (cty.Value, error) {
+	var out CallOutputV1
+	if err := p.run(ctx, req, &out); err != nil {
+		return cty.UnknownVal(retType), err
+	}
+
+	if out.Error != "" {
+		if idx := out.ErrorArgument; idx != nil && *idx >= 0 && *idx < len(req.Arguments) {
+			return cty.UnknownVal(retType), function.NewArgErrorf(*idx, "%s", out.Error)
+		}
+		return cty.UnknownVal(retType), errors.New(out.Error)
+	}
+	if len(out.Result) == 0 {
+		return cty.UnknownVal(retType), fmt.Errorf("the function plugin returned no result")
+	}
+	val, err := ctyjson.Unmarshal(out.Result, retType)
+	if err != nil {
+		return cty.UnknownVal(retType), fmt.Errorf("the function plugin returned an invalid result (%w)", err)
+	}
+	return val, nil
+}
+
+// run runs the plugin's command with the given request on its standard input,
+// and decodes the response from its standard output into out. The command is
+// killed if it doesn't respond within the plugin's timeout, or if the given
+// context is canceled first.
+func (p *Plugin) run(stopCtx context.Context, req *RequestV1, out any) error {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("bug: cannot JSON-marshal the request (%w)", err)
+	}
+
+	ctx, cancel := context.WithTimeout(stopCtx, p.timeout)
+	defer cancel()
+
+	log.Printf("[TRACE] funcplugin: running %q for plugin %q to handle %q request", p.command[0], p.name, req.Method)
+	cmd := exec.CommandContext(ctx, p.command[0], p.command[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// Don't wait for the output of any child processes that outlive the
+	// command after it is killed.
+	cmd.WaitDelay = time.Second
+
+	err = cmd.Run()
+	if stopCtx.Err() != nil {
+		return fmt.Errorf("the function plugin command was interrupted")
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("the function plugin command did not respond within %s", p.timeout)
+	}
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("the function plugin command exited with a non-zero exit code (%v)%s", err, stderrSuffix(stderr))
+		}
+		return fmt.Errorf("the function plugin command could not be run (%w)", err)
+	}
+
+	headerLine, body, ok := bytes.Cut(stdout.Bytes(), []byte("\n"))
+	if !ok {
+		return fmt.Errorf("the function plugin command did not write a header line%s", stderrSuffix(stderr))
+	}
+	var header Header
+	// Note: this is intentionally not using strict decoding. Later protocol
+	// versions may introduce additional header fields.
+	if err := json.Unmarshal(headerLine, &header); err != nil {
+		return fmt.Errorf("failed to unmarshal header from the function plugin command (%w)", err)
+	}
+	if header.Magic != HeaderMagic {
+		return fmt.Errorf("invalid magic received from the function plugin command: %s", header.Magic)
+	}
+	if header.Version != 1 {
+		return fmt.Errorf("invalid version number received from the function plugin command: %d", header.Version)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(out); err != nil {
+		return fmt.Errorf("the function plugin command returned an invalid JSON response (%w)%s", err, stderrSuffix(stderr))
+	}
+	return nil
+}
+
+func stderrSuffix(stderr *bytes.Buffer) string {
+	msg := strings.TrimSpace(stderr.String())
+	if msg == "" {
+		return ""
+	}
+	return "\n\nStderr:\n-------\n" + msg
+}
@@ -0,0 +1,215 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcplugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+func TestPlugin(t *testing.T) {
+	plugin := testPlugin(t)
+	callsLog := filepath.Join(t.TempDir(), "calls.log")
+	t.Setenv("CALLS_LOG", callsLog)
+
+	funcs, err := plugin.Functions(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for name := range funcs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	if got, want := strings.Join(names, ","), "count,fail,greet"; got != want {
+		t.Fatalf("wrong functions %s; want %s", got, want)
+	}
+	if got, want := funcs["greet"].Description(), "Returns a greeting for the given name."; got != want {
+		t.Errorf("wrong description %q; want %q", got, want)
+	}
+
+	for range 2 {
+		got, err := funcs["greet"].Call([]cty.Value{cty.StringVal("world")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := cty.StringVal("Hello, world!"); !got.RawEquals(want) {
+			t.Errorf("wrong result %#v; want %#v", got, want)
+		}
+	}
+
+	got, err := funcs["count"].Call([]cty.Value{cty.True, cty.StringVal("a"), cty.NullVal(cty.Number)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := cty.NumberIntVal(3); !got.RawEquals(want) {
+		t.Errorf("wrong result %#v; want %#v", got, want)
+	}
+
+	_, err = funcs["fail"].Call([]cty.Value{cty.NumberIntVal(-1)})
+	var argErr function.ArgError
+	if !errors.As(err, &argErr) || argErr.Index != 0 || argErr.Error() != "the value must be positive" {
+		t.Errorf("wrong error %#v", err)
+	}
+
+	// The command runs only once for each distinct request, because the
+	// plugin remembers the signatures and the result of the first call to
+	// greet.
+	if _, err := plugin.Functions(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	calls, err := os.ReadFile(callsLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"method":"functions"}
+{"method":"call","function":"greet","arguments":["world"]}
+{"method":"call","function":"count","arguments":[{"value":true,"type":"bool"},{"value":"a","type":"string"},{"value":null,"type":"number"}]}
+{"method":"call","function":"fail","arguments":[-1]}
+`
+	if got := string(calls); got != want {
+		t.Errorf("wrong requests\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPlugin_errors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("this test uses a POSIX shell")
+	}
+
+	tests := map[string]struct {
+		script  string
+		wantErr string
+	}{
+		"no header": {
+			`cat >/dev/null`,
+			"the function plugin command did not write a header line",
+		},
+		"wrong magic": {
+			`cat >/dev/null; echo '{"magic":"OpenTofu-External-Key-Provider","version":1}'`,
+			"invalid magic received from the function plugin command: OpenTofu-External-Key-Provider",
+		},
+		"wrong version": {
+			`cat >/dev/null; echo '{"magic":"OpenTofu-Function-Plugin","version":2}'`,
+			"invalid version number received from the function plugin command: 2",
+		},
+		"non-zero exit": {
+			`cat >/dev/null; echo 'something went wrong' >&2; exit 3`,
+			"the function plugin command exited with a non-zero exit code (exit status 3)\n\nStderr:\n-------\nsomething went wrong",
+		},
+		"invalid response": {
+			`cat >/dev/null; echo '{"magic":"OpenTofu-Function-Plugin","version":1}'; echo '{"funcs":{}}'`,
+			`the function plugin command returned an invalid JSON response (json: unknown field "funcs")`,
+		},
+		"invalid function name": {
+			`cat >/dev/null; echo '{"magic":"OpenTofu-Function-Plugin","version":1}'; echo '{"functions":{"a-b:c":{"params":[],"return_type":"string"}}}'`,
+			`the plugin returned the invalid function name "a-b:c"`,
+		},
+		"invalid type": {
+			`cat >/dev/null; echo '{"magic":"OpenTofu-Function-Plugin","version":1}'; echo '{"functions":{"f":{"params":[{"name":"a","type":"strin"}],"return_type":"string"}}}'`,
+			`the plugin returned an invalid signature for function "f": invalid parameter 0: invalid type: invalid primitive type name "strin"`,
+		},
+		"missing return type": {
+			`cat >/dev/null; echo '{"magic":"OpenTofu-Function-Plugin","version":1}'; echo '{"functions":{"f":{"params":[]}}}'`,
+			`the plugin returned an invalid signature for function "f": return_type is required`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			plugin, err := New("test", []string{"sh", "-c", test.script})
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = plugin.Functions(t.Context())
+			if err == nil {
+				t.Fatal("succeeded; want error")
+			}
+			if got := err.Error(); got != test.wantErr {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestPlugin_interrupted(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("this test uses a POSIX shell")
+	}
+	// The command hangs the first time it runs.
+	marker := filepath.Join(t.TempDir(), "started")
+	script := fmt.Sprintf(`if [ ! -e %q ]; then touch %q; exec sleep 60; fi; exec sh %q`, marker, marker, filepath.Join("testdata", "plugin.sh"))
+	plugin, err := New("test", []string{"sh", "-c", script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CALLS_LOG", filepath.Join(t.TempDir(), "calls.log"))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	start := time.Now()
+	_, err = plugin.Functions(ctx)
+	if err == nil || err.Error() != "the function plugin command was interrupted" {
+		t.Errorf("wrong error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("the command wasn't stopped until %s", elapsed)
+	}
+
+	// The interruption isn't remembered, so a later caller can load the
+	// functions and call them, even after an interrupted call.
+	funcs, err := plugin.Functions(t.Context())
+	if err != nil {
+		t.Fatalf("functions are not loaded after an interrupted load: %s", err)
+	}
+	canceled, cancel := context.WithCancel(t.Context())
+	cancel()
+	canceledFuncs, err := plugin.Functions(canceled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := []cty.Value{cty.StringVal("world")}
+	if _, err := canceledFuncs["greet"].Call(args); err == nil {
+		t.Fatal("call with a canceled context succeeded")
+	}
+	got, err := funcs["greet"].Call(args)
+	if err != nil {
+		t.Fatalf("call failed after an interrupted call: %s", err)
+	}
+	if want := cty.StringVal("Hello, world!"); !got.RawEquals(want) {
+		t.Errorf("wrong result %#v; want %#v", got, want)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("test", nil); err == nil || err.Error() != "the command option is required" {
+		t.Errorf("wrong error for missing command: %v", err)
+	}
+	if _, err := New("not valid", []string{"true"}); err == nil || err.Error() != `"not valid" is not a valid plugin name` {
+		t.Errorf("wrong error for invalid name: %v", err)
+	}
+}
+
+func testPlugin(t *testing.T) *Plugin {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("this test uses a POSIX shell")
+	}
+	plugin, err := New("test", []string{"sh", filepath.Join("testdata", "plugin.sh")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return plugin
+}
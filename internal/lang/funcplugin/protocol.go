@@ -0,0 +1,84 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcplugin
+
+import (
+	"encoding/json"
+)
+
+// HeaderMagic is the magic string that needs to be present in the header to
+// identify the external program as an OpenTofu function plugin.
+const HeaderMagic = "OpenTofu-Function-Plugin"
+
+// Header describes the initial header the external program must output as a
+// single line, followed by a single newline.
+type Header struct {
+	// Magic must always be "OpenTofu-Function-Plugin".
+	Magic string `json:"magic"`
+	// Version is the protocol version number. This currently must be 1.
+	Version int `json:"version"`
+}
+
+const (
+	// MethodFunctions requests the signatures of all of the functions that
+	// the plugin provides, as a [FunctionsOutputV1] object.
+	MethodFunctions = "functions"
+
+	// MethodCall requests the result of calling one of the plugin's
+	// functions, as a [CallOutputV1] object.
+	MethodCall = "call"
+)
+
+// RequestV1 describes the input datastructure passed in over stdin.
+// This structure is valid for protocol version 1.
+type RequestV1 struct {
+	Method string `json:"method"`
+
+	// Function and Arguments are set only for [MethodCall]. Each argument is
+	// encoded as JSON for the type of its parameter, so arguments for
+	// parameters of type "dynamic" are objects with "value" and "type"
+	// properties.
+	Function  string            `json:"function,omitempty"`
+	Arguments []json.RawMessage `json:"arguments,omitempty"`
+}
+
+// FunctionsOutputV1 describes the output datastructure for [MethodFunctions].
+// This structure is valid for protocol version 1.
+type FunctionsOutputV1 struct {
+	Functions map[string]*FunctionV1 `json:"functions"`
+}
+
+// FunctionV1 is the signature of a function provided by a plugin.
+type FunctionV1 struct {
+	Description   string          `json:"description,omitempty"`
+	Params        []*ParameterV1  `json:"params"`
+	VariadicParam *ParameterV1    `json:"variadic_param,omitempty"`
+	ReturnType    json.RawMessage `json:"return_type"`
+}
+
+// ParameterV1 is a parameter of a function provided by a plugin.
+type ParameterV1 struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Type        json.RawMessage `json:"type"`
+
+	// AllowNullValue must be set for OpenTofu to pass null values to the
+	// function for this parameter. Otherwise OpenTofu returns an error
+	// without calling the plugin.
+	AllowNullValue bool `json:"allow_null_value,omitempty"`
+}
+
+// CallOutputV1 describes the output datastructure for [MethodCall].
+// This structure is valid for protocol version 1.
+type CallOutputV1 struct {
+	// Result is the result of the call, encoded as JSON for the function's
+	// return type. It's ignored if Error is set.
+	Result json.RawMessage `json:"result,omitempty"`
+
+	// Error, if set, is a message explaining why the function could not
+	// produce a result for the given arguments, and ErrorArgument is the
+	// zero-based index of the argument that caused the error, if any.
+	Error         string `json:"error,omitempty"`
+	ErrorArgument *int   `json:"error_argument,omitempty"`
+}
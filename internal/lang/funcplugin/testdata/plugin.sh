@@ -0,0 +1,55 @@
+#!/bin/sh
+# Copyright (c) The OpenTofu Authors
+# SPDX-License-Identifier: MPL-2.0
+
+# This is a function plugin for tests. If the CALLS_LOG environment variable
+# is set, it appends the request it receives to the named file.
+
+set -e
+
+echo '{"magic":"OpenTofu-Function-Plugin","version":1}'
+
+INPUT=$(cat)
+if [ -n "${CALLS_LOG}" ]; then
+  printf '%s\n' "${INPUT}" >> "${CALLS_LOG}"
+fi
+
+case "${INPUT}" in
+  *'"method":"functions"'*)
+    cat << EOF2
+{
+  "functions": {
+    "greet": {
+      "description": "Returns a greeting for the given name.",
+      "params": [{"name": "name", "type": "string"}],
+      "return_type": "string"
+    },
+    "count": {
+      "params": [],
+      "variadic_param": {"name": "values", "type": "dynamic", "allow_null_value": true},
+      "return_type": "number"
+    },
+    "fail": {
+      "params": [{"name": "value", "type": "number"}],
+      "return_type": "number"
+    }
+  }
+}
+EOF2
+    ;;
+  *'"function":"greet"'*)
+    NAME=$(printf '%s' "${INPUT}" | sed 's/.*"arguments":\["\([^"]*\)"\].*/\1/')
+    printf '{"result":"Hello, %s!"}\n' "${NAME}"
+    ;;
+  *'"function":"count"'*)
+    COUNT=$(printf '%s' "${INPUT}" | grep -o '"type"' | wc -l)
+    printf '{"result":%d}\n' "${COUNT}"
+    ;;
+  *'"function":"fail"'*)
+    echo '{"error":"the value must be positive","error_argument":0}'
+    ;;
+  *)
+    echo "unexpected request: ${INPUT}" >&2
+    exit 1
+    ;;
+esac
@@ -8,6 +8,9 @@ package funcs
 import (
 	"fmt"
 	"math/big"
+	"net"
+	"net/netip"
+	"slices"
 
 	"github.com/apparentlymart/go-cidr/cidr"
 	"github.com/opentofu/opentofu/internal/ipaddr"
@@ -263,6 +266,401 @@ var CidrContainsFunc = function.New(&function.Spec{
 	},
 })
 
+// CidrMergeFunc constructs a function that aggregates a list of IP network
+// address prefixes into the shortest list of prefixes that covers exactly the
+// same addresses.
+var CidrMergeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "prefixes",
+			Type: cty.List(cty.String),
+		},
+	},
+	Type:         function.StaticReturnType(cty.List(cty.String)),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		if !args[0].IsWhollyKnown() {
+			return cty.UnknownVal(retType), nil
+		}
+		prefixes, err := parsePrefixList(0, args[0])
+		if err != nil {
+			return cty.UnknownVal(retType), err
+		}
+		return prefixListVal(mergePrefixes(prefixes)), nil
+	},
+})
+
+// CidrExcludeFunc constructs a function that calculates the shortest list of
+// IP network address prefixes that covers all of the addresses in a given
+// prefix except those in any of a list of excluded prefixes.
+var CidrExcludeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "prefix",
+			Type: cty.String,
+		},
+		{
+			Name: "excluded_prefixes",
+			Type: cty.List(cty.String),
+		},
+	},
+	Type:         function.StaticReturnType(cty.List(cty.String)),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		if !args[1].IsWhollyKnown() {
+			return cty.UnknownVal(retType), nil
+		}
+		base, err := parsePrefix(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgError(0, err)
+		}
+		excluded, err := parsePrefixList(1, args[1])
+		if err != nil {
+			return cty.UnknownVal(retType), err
+		}
+
+		remaining := []netip.Prefix{base}
+		for _, ex := range excluded {
+			// As with cidrcontains, excluding a prefix of the other address
+			// family is more likely to be a mistake than intentional, so
+			// we return an error rather than silently ignoring it.
+			if ex.Addr().Is4() != base.Addr().Is4() {
+				return cty.UnknownVal(retType), function.NewArgErrorf(1, "address family mismatch: %s vs. %s", base, ex)
+			}
+			var next []netip.Prefix
+			for _, p := range remaining {
+				next = append(next, excludePrefix(p, ex)...)
+			}
+			remaining = next
+		}
+		return prefixListVal(mergePrefixes(remaining)), nil
+	},
+})
+
+// cidrHostsType is the type of the objects returned by the cidrhosts
+// function.
+var cidrHostsType = cty.Object(map[string]cty.Type{
+	"network":   cty.String,
+	"first":     cty.String,
+	"last":      cty.String,
+	"broadcast": cty.String,
+	"count":     cty.Number,
+})
+
+// CidrHostsFunc constructs a function that describes the range of host
+// addresses within a given IP network address prefix.
+var CidrHostsFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "prefix",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cidrHostsType),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		p, err := parsePrefix(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgError(0, err)
+		}
+
+		network, last := p.Addr(), prefixLastAddr(p)
+		first := network
+		broadcast := cty.NullVal(cty.String)
+		count := new(big.Int).Lsh(big.NewInt(1), uint(network.BitLen()-p.Bits()))
+
+		// IPv4 networks reserve their first address as the network address
+		// and their last as the broadcast address, except for the
+		// point-to-point /31 networks described in RFC 3021 and single
+		// host /32 networks. IPv6 has no broadcast addresses.
+		if network.Is4() && p.Bits() <= 30 {
+			broadcast = cty.StringVal(last.String())
+			first, last = first.Next(), last.Prev()
+			count.Sub(count, big.NewInt(2))
+		}
+
+		return cty.ObjectVal(map[string]cty.Value{
+			"network":   cty.StringVal(network.String()),
+			"first":     cty.StringVal(first.String()),
+			"last":      cty.StringVal(last.String()),
+			"broadcast": broadcast,
+			"count":     cty.NumberVal(new(big.Float).SetInt(count)),
+		}), nil
+	},
+})
+
+// CidrOverlapFunc constructs a function that checks whether two IP network
+// address prefixes have any addresses in common.
+var CidrOverlapFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "a",
+			Type: cty.String,
+		},
+		{
+			Name: "b",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.Bool),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		a, err := parsePrefix(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgError(0, err)
+		}
+		b, err := parsePrefix(args[1].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgError(1, err)
+		}
+
+		// We return an error for prefixes of different address families
+		// for the same reason as cidrcontains does.
+		if a.Addr().Is4() != b.Addr().Is4() {
+			return cty.UnknownVal(retType), fmt.Errorf("address family mismatch: %s vs. %s", args[0].AsString(), args[1].AsString())
+		}
+
+		return cty.BoolVal(a.Overlaps(b)), nil
+	},
+})
+
+// IPv6EUI64Func constructs a function that calculates the IPv6 address that
+// a host with a given MAC address assigns itself within a /64 prefix using a
+// modified EUI-64 interface identifier, as described in RFC 4291.
+var IPv6EUI64Func = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "prefix",
+			Type: cty.String,
+		},
+		{
+			Name: "mac_address",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		p, err := parseIPv6Prefix(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgError(0, err)
+		}
+		if p.Bits() != 64 {
+			return cty.UnknownVal(retType), function.NewArgErrorf(0, "EUI-64 interface identifiers require a /64 prefix, but %s has a prefix length of %d bits", args[0].AsString(), p.Bits())
+		}
+
+		hw, err := net.ParseMAC(args[1].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgErrorf(1, "invalid MAC address: %w", err)
+		}
+		var iid []byte
+		switch len(hw) {
+		case 6:
+			// A 48-bit MAC address is extended to 64 bits by inserting
+			// FF:FE between its third and fourth bytes.
+			iid = []byte{hw[0], hw[1], hw[2], 0xff, 0xfe, hw[3], hw[4], hw[5]}
+		case 8:
+			iid = hw
+		default:
+			return cty.UnknownVal(retType), function.NewArgErrorf(1, "invalid MAC address: must be either 48 or 64 bits long")
+		}
+
+		addr := p.Addr().As16()
+		copy(addr[8:], iid)
+		// The "modified" in modified EUI-64 is that the universal/local bit
+		// is inverted.
+		addr[8] ^= 0x02
+		return cty.StringVal(netip.AddrFrom16(addr).String()), nil
+	},
+})
+
+// IPv6DelegateFunc constructs a function that calculates a delegated IPv6
+// prefix of a given length within a given prefix, such as a /56 delegated to
+// a customer site from a provider's /48.
+var IPv6DelegateFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "prefix",
+			Type: cty.String,
+		},
+		{
+			Name: "prefix_length",
+			Type: cty.Number,
+		},
+		{
+			Name: "index",
+			Type: cty.Number,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		var length int
+		if err := gocty.FromCtyValue(args[1], &length); err != nil {
+			return cty.UnknownVal(retType), function.NewArgError(1, err)
+		}
+		var index *big.Int
+		if err := gocty.FromCtyValue(args[2], &index); err != nil {
+			return cty.UnknownVal(retType), function.NewArgError(2, err)
+		}
+
+		p, err := parseIPv6Prefix(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgError(0, err)
+		}
+		if length < p.Bits() || length > 128 {
+			return cty.UnknownVal(retType), function.NewArgErrorf(1, "delegated prefix length must be between %d and 128", p.Bits())
+		}
+		count := new(big.Int).Lsh(big.NewInt(1), uint(length-p.Bits()))
+		if index.Sign() < 0 || index.Cmp(count) >= 0 {
+			return cty.UnknownVal(retType), function.NewArgErrorf(2, "%s has only %s delegated prefixes of length %d", p, count, length)
+		}
+
+		_, network, err := ipaddr.ParseCIDR(p.String())
+		if err != nil {
+			// Should never happen, because p is a valid prefix.
+			return cty.UnknownVal(retType), err
+		}
+		delegated, err := cidr.SubnetBig(network, length-p.Bits(), index)
+		if err != nil {
+			return cty.UnknownVal(retType), err
+		}
+		return cty.StringVal(delegated.String()), nil
+	},
+})
+
+// parsePrefix parses an IP network address prefix given in CIDR notation,
+// with the same leniency as the other CIDR functions, and returns the
+// network it describes.
+func parsePrefix(s string) (netip.Prefix, error) {
+	_, network, err := ipaddr.ParseCIDR(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid CIDR expression: %w", err)
+	}
+	addr, _ := netip.AddrFromSlice(network.IP)
+	bits, _ := network.Mask.Size()
+	return netip.PrefixFrom(addr, bits), nil
+}
+
+// parseIPv6Prefix is like parsePrefix but returns an error for IPv4
+// prefixes.
+func parseIPv6Prefix(s string) (netip.Prefix, error) {
+	p, err := parsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	if p.Addr().Is4() {
+		return netip.Prefix{}, fmt.Errorf("%s is not an IPv6 prefix", s)
+	}
+	return p, nil
+}
+
+// parsePrefixList parses a wholly-known list of prefixes given as the
+// argument with the given index.
+func parsePrefixList(argIdx int, list cty.Value) ([]netip.Prefix, error) {
+	ret := make([]netip.Prefix, 0, list.LengthInt())
+	for it := list.ElementIterator(); it.Next(); {
+		idx, elem := it.Element()
+		if elem.IsNull() {
+			return nil, function.NewArgErrorf(argIdx, "element %s is null", idx.AsBigFloat().String())
+		}
+		p, err := parsePrefix(elem.AsString())
+		if err != nil {
+			return nil, function.NewArgErrorf(argIdx, "element %s: %w", idx.AsBigFloat().String(), err)
+		}
+		ret = append(ret, p)
+	}
+	return ret, nil
+}
+
+func prefixListVal(prefixes []netip.Prefix) cty.Value {
+	if len(prefixes) == 0 {
+		return cty.ListValEmpty(cty.String)
+	}
+	vals := make([]cty.Value, len(prefixes))
+	for i, p := range prefixes {
+		vals[i] = cty.StringVal(p.String())
+	}
+	return cty.ListVal(vals)
+}
+
+// mergePrefixes returns the shortest sorted list of prefixes that covers
+// exactly the same addresses as the given prefixes, with IPv4 prefixes
+// before IPv6 prefixes.
+func mergePrefixes(prefixes []netip.Prefix) []netip.Prefix {
+	sorted := slices.Clone(prefixes)
+	slices.SortFunc(sorted, func(a, b netip.Prefix) int {
+		if c := a.Addr().Compare(b.Addr()); c != 0 {
+			return c
+		}
+		return a.Bits() - b.Bits()
+	})
+
+	var ret []netip.Prefix
+	for _, p := range sorted {
+		// Because the prefixes are sorted by address and then by length,
+		// any prefix that is covered by an earlier one is covered by the
+		// last one we kept.
+		if n := len(ret); n > 0 && ret[n-1].Bits() <= p.Bits() && ret[n-1].Contains(p.Addr()) {
+			continue
+		}
+		ret = append(ret, p)
+
+		// Whenever the last two prefixes are the two halves of a larger
+		// prefix we replace them with it, which might then in turn be
+		// half of an even larger prefix.
+		for n := len(ret); n >= 2; n = len(ret) {
+			a, b := ret[n-2], ret[n-1]
+			if a.Bits() != b.Bits() || a.Bits() == 0 {
+				break
+			}
+			parent, _ := a.Addr().Prefix(a.Bits() - 1)
+			if !parent.Contains(b.Addr()) {
+				break
+			}
+			ret = append(ret[:n-2], parent)
+		}
+	}
+	return ret
+}
+
+// excludePrefix returns the prefixes that cover all of the addresses in p
+// that are not in ex.
+func excludePrefix(p, ex netip.Prefix) []netip.Prefix {
+	if !p.Overlaps(ex) {
+		return []netip.Prefix{p}
+	}
+	if ex.Bits() <= p.Bits() {
+		return nil // ex covers all of p
+	}
+
+	// Otherwise we repeatedly split p in half, keeping the half that
+	// doesn't contain ex and splitting the other until it is ex.
+	var ret []netip.Prefix
+	for p.Bits() < ex.Bits() {
+		lo := netip.PrefixFrom(p.Addr(), p.Bits()+1)
+		hi := netip.PrefixFrom(prefixLastAddr(lo).Next(), p.Bits()+1)
+		if lo.Contains(ex.Addr()) {
+			ret = append(ret, hi)
+			p = lo
+		} else {
+			ret = append(ret, lo)
+			p = hi
+		}
+	}
+	return ret
+}
+
+// prefixLastAddr returns the last address in the given prefix.
+func prefixLastAddr(p netip.Prefix) netip.Addr {
+	addr := p.Addr().AsSlice()
+	for i := p.Bits(); i < len(addr)*8; i++ {
+		addr[i/8] |= 0x80 >> (i % 8)
+	}
+	ret, _ := netip.AddrFromSlice(addr)
+	return ret
+}
+
 // CidrHost calculates a full host IP address within a given IP network address prefix.
 func CidrHost(prefix, hostnum cty.Value) (cty.Value, error) {
 	return CidrHostFunc.Call([]cty.Value{prefix, hostnum})
@@ -291,3 +689,39 @@ func CidrSubnets(prefix cty.Value, newbits ...cty.Value) (cty.Value, error) {
 func CidrContains(prefix, address cty.Value) (cty.Value, error) {
 	return CidrContainsFunc.Call([]cty.Value{prefix, address})
 }
+
+// CidrMerge aggregates a list of IP network address prefixes into the shortest
+// list of prefixes that covers exactly the same addresses.
+func CidrMerge(prefixes cty.Value) (cty.Value, error) {
+	return CidrMergeFunc.Call([]cty.Value{prefixes})
+}
+
+// CidrExclude calculates the prefixes that cover all of the addresses in a
+// given IP network address prefix except those in a list of excluded prefixes.
+func CidrExclude(prefix, excluded cty.Value) (cty.Value, error) {
+	return CidrExcludeFunc.Call([]cty.Value{prefix, excluded})
+}
+
+// CidrHosts describes the range of host addresses within a given IP network
+// address prefix.
+func CidrHosts(prefix cty.Value) (cty.Value, error) {
+	return CidrHostsFunc.Call([]cty.Value{prefix})
+}
+
+// CidrOverlap checks whether two IP network address prefixes have any
+// addresses in common.
+func CidrOverlap(a, b cty.Value) (cty.Value, error) {
+	return CidrOverlapFunc.Call([]cty.Value{a, b})
+}
+
+// IPv6EUI64 calculates an IPv6 address within a /64 prefix using the modified
+// EUI-64 interface identifier for a given MAC address.
+func IPv6EUI64(prefix, mac cty.Value) (cty.Value, error) {
+	return IPv6EUI64Func.Call([]cty.Value{prefix, mac})
+}
+
+// IPv6Delegate calculates a delegated IPv6 prefix of a given length within a
+// given prefix.
+func IPv6Delegate(prefix, length, index cty.Value) (cty.Value, error) {
+	return IPv6DelegateFunc.Call([]cty.Value{prefix, length, index})
+}
@@ -571,3 +571,497 @@ func TestCidrContains(t *testing.T) {
 		})
 	}
 }
+
+func TestCidrMerge(t *testing.T) {
+	tests := []struct {
+		Prefixes cty.Value
+		Want     cty.Value
+		Err      string
+	}{
+		{
+			// Adjacent halves are merged, repeatedly
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.1.0/24"),
+				cty.StringVal("10.0.0.0/24"),
+				cty.StringVal("10.0.2.0/23"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/22"),
+			}),
+			``,
+		},
+		{
+			// Covered prefixes and duplicates are removed, and adjacent
+			// prefixes that aren't halves of a larger one are kept
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.1.0/24"),
+				cty.StringVal("10.0.2.0/24"),
+				cty.StringVal("10.0.2.128/25"),
+				cty.StringVal("10.0.2.0/24"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.1.0/24"),
+				cty.StringVal("10.0.2.0/24"),
+			}),
+			``,
+		},
+		{
+			// IPv4 prefixes sort before IPv6 prefixes, and host bits are
+			// ignored as in the other CIDR functions
+			cty.ListVal([]cty.Value{
+				cty.StringVal("2001:db8:0:1::/64"),
+				cty.StringVal("192.168.0.1/24"),
+				cty.StringVal("2001:db8::/64"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("192.168.0.0/24"),
+				cty.StringVal("2001:db8::/63"),
+			}),
+			``,
+		},
+		{
+			cty.ListValEmpty(cty.String),
+			cty.ListValEmpty(cty.String),
+			``,
+		},
+		{
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/24"),
+				cty.UnknownVal(cty.String),
+			}),
+			cty.UnknownVal(cty.List(cty.String)).RefineNotNull(),
+			``,
+		},
+		{
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/24"),
+				cty.StringVal("10.0.0.0"),
+			}),
+			cty.NilVal,
+			`element 1: invalid CIDR expression: invalid CIDR address: 10.0.0.0`,
+		},
+		{
+			cty.ListVal([]cty.Value{
+				cty.NullVal(cty.String),
+			}),
+			cty.NilVal,
+			`element 0 is null`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("cidrmerge(%#v)", test.Prefixes), func(t *testing.T) {
+			got, err := CidrMerge(test.Prefixes)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); got != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestCidrExclude(t *testing.T) {
+	tests := []struct {
+		Prefix   cty.Value
+		Excluded cty.Value
+		Want     cty.Value
+		Err      string
+	}{
+		{
+			cty.StringVal("10.0.0.0/16"),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/24"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.1.0/24"),
+				cty.StringVal("10.0.2.0/23"),
+				cty.StringVal("10.0.4.0/22"),
+				cty.StringVal("10.0.8.0/21"),
+				cty.StringVal("10.0.16.0/20"),
+				cty.StringVal("10.0.32.0/19"),
+				cty.StringVal("10.0.64.0/18"),
+				cty.StringVal("10.0.128.0/17"),
+			}),
+			``,
+		},
+		{
+			// Excluded prefixes outside of the base prefix are ignored,
+			// and the remaining space is merged back together
+			cty.StringVal("10.0.0.0/22"),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.1.0/24"),
+				cty.StringVal("10.0.2.0/24"),
+				cty.StringVal("192.168.0.0/16"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/24"),
+				cty.StringVal("10.0.3.0/24"),
+			}),
+			``,
+		},
+		{
+			cty.StringVal("2001:db8::/62"),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("2001:db8:0:2::/64"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("2001:db8::/63"),
+				cty.StringVal("2001:db8:0:3::/64"),
+			}),
+			``,
+		},
+		{
+			cty.StringVal("10.0.1.0/24"),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/16"),
+			}),
+			cty.ListValEmpty(cty.String),
+			``,
+		},
+		{
+			cty.StringVal("10.0.0.0/16"),
+			cty.ListValEmpty(cty.String),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/16"),
+			}),
+			``,
+		},
+		{
+			cty.StringVal("10.0.0.0/16"),
+			cty.UnknownVal(cty.List(cty.String)),
+			cty.UnknownVal(cty.List(cty.String)).RefineNotNull(),
+			``,
+		},
+		{
+			cty.StringVal("10.0.0.0/16"),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("fd00::/8"),
+			}),
+			cty.NilVal,
+			`address family mismatch: 10.0.0.0/16 vs. fd00::/8`,
+		},
+		{
+			cty.StringVal("not-a-cidr"),
+			cty.ListValEmpty(cty.String),
+			cty.NilVal,
+			`invalid CIDR expression: invalid CIDR address: not-a-cidr`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("cidrexclude(%#v, %#v)", test.Prefix, test.Excluded), func(t *testing.T) {
+			got, err := CidrExclude(test.Prefix, test.Excluded)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); got != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestCidrHosts(t *testing.T) {
+	tests := []struct {
+		Prefix cty.Value
+		Want   cty.Value
+		Err    string
+	}{
+		{
+			cty.StringVal("10.0.1.0/24"),
+			cty.ObjectVal(map[string]cty.Value{
+				"network":   cty.StringVal("10.0.1.0"),
+				"first":     cty.StringVal("10.0.1.1"),
+				"last":      cty.StringVal("10.0.1.254"),
+				"broadcast": cty.StringVal("10.0.1.255"),
+				"count":     cty.NumberIntVal(254),
+			}),
+			``,
+		},
+		{
+			// Point-to-point links have no network or broadcast address
+			cty.StringVal("10.0.1.6/31"),
+			cty.ObjectVal(map[string]cty.Value{
+				"network":   cty.StringVal("10.0.1.6"),
+				"first":     cty.StringVal("10.0.1.6"),
+				"last":      cty.StringVal("10.0.1.7"),
+				"broadcast": cty.NullVal(cty.String),
+				"count":     cty.NumberIntVal(2),
+			}),
+			``,
+		},
+		{
+			cty.StringVal("2001:db8::/64"),
+			cty.ObjectVal(map[string]cty.Value{
+				"network":   cty.StringVal("2001:db8::"),
+				"first":     cty.StringVal("2001:db8::"),
+				"last":      cty.StringVal("2001:db8::ffff:ffff:ffff:ffff"),
+				"broadcast": cty.NullVal(cty.String),
+				"count":     cty.MustParseNumberVal("18446744073709551616"),
+			}),
+			``,
+		},
+		{
+			cty.UnknownVal(cty.String),
+			cty.UnknownVal(cidrHostsType).RefineNotNull(),
+			``,
+		},
+		{
+			cty.StringVal("10.0.1.0"),
+			cty.NilVal,
+			`invalid CIDR expression: invalid CIDR address: 10.0.1.0`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("cidrhosts(%#v)", test.Prefix), func(t *testing.T) {
+			got, err := CidrHosts(test.Prefix)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); got != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestCidrOverlap(t *testing.T) {
+	tests := []struct {
+		A, B cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.StringVal("10.0.0.0/16"),
+			cty.StringVal("10.0.128.0/24"),
+			cty.True,
+			``,
+		},
+		{
+			cty.StringVal("10.0.128.0/24"),
+			cty.StringVal("10.0.0.0/16"),
+			cty.True,
+			``,
+		},
+		{
+			cty.StringVal("10.0.0.0/24"),
+			cty.StringVal("10.0.1.0/24"),
+			cty.False,
+			``,
+		},
+		{
+			cty.StringVal("2001:db8::/32"),
+			cty.StringVal("2001:db8:ff::/48"),
+			cty.True,
+			``,
+		},
+		{
+			cty.StringVal("10.0.0.0/8"),
+			cty.StringVal("fd00::/8"),
+			cty.NilVal,
+			`address family mismatch: 10.0.0.0/8 vs. fd00::/8`,
+		},
+		{
+			cty.StringVal("10.0.0.0/8"),
+			cty.StringVal("10.0.0.1"),
+			cty.NilVal,
+			`invalid CIDR expression: invalid CIDR address: 10.0.0.1`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("cidroverlap(%#v, %#v)", test.A, test.B), func(t *testing.T) {
+			got, err := CidrOverlap(test.A, test.B)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); got != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestIPv6EUI64(t *testing.T) {
+	tests := []struct {
+		Prefix, MAC cty.Value
+		Want        cty.Value
+		Err         string
+	}{
+		{
+			cty.StringVal("2001:db8:1:2::/64"),
+			cty.StringVal("00:1a:2b:3c:4d:5e"),
+			cty.StringVal("2001:db8:1:2:21a:2bff:fe3c:4d5e"),
+			``,
+		},
+		{
+			// The universal/local bit is inverted in both directions
+			cty.StringVal("fe80::/64"),
+			cty.StringVal("02-00-5e-10-00-01"),
+			cty.StringVal("fe80::5eff:fe10:1"),
+			``,
+		},
+		{
+			cty.StringVal("2001:db8::/64"),
+			cty.StringVal("0200.5eff.fe10.0001"),
+			cty.StringVal("2001:db8::5eff:fe10:1"),
+			``,
+		},
+		{
+			cty.StringVal("2001:db8::/48"),
+			cty.StringVal("00:1a:2b:3c:4d:5e"),
+			cty.NilVal,
+			`EUI-64 interface identifiers require a /64 prefix, but 2001:db8::/48 has a prefix length of 48 bits`,
+		},
+		{
+			cty.StringVal("10.0.0.0/8"),
+			cty.StringVal("00:1a:2b:3c:4d:5e"),
+			cty.NilVal,
+			`10.0.0.0/8 is not an IPv6 prefix`,
+		},
+		{
+			cty.StringVal("2001:db8::/64"),
+			cty.StringVal("00:1a:2b"),
+			cty.NilVal,
+			`invalid MAC address: address 00:1a:2b: invalid MAC address`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("ipv6eui64(%#v, %#v)", test.Prefix, test.MAC), func(t *testing.T) {
+			got, err := IPv6EUI64(test.Prefix, test.MAC)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); got != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestIPv6Delegate(t *testing.T) {
+	tests := []struct {
+		Prefix, Length, Index cty.Value
+		Want                  cty.Value
+		Err                   string
+	}{
+		{
+			cty.StringVal("2001:db8:ab00::/40"),
+			cty.NumberIntVal(56),
+			cty.NumberIntVal(0),
+			cty.StringVal("2001:db8:ab00::/56"),
+			``,
+		},
+		{
+			cty.StringVal("2001:db8:ab00::/40"),
+			cty.NumberIntVal(56),
+			cty.NumberIntVal(258),
+			cty.StringVal("2001:db8:ab01:200::/56"),
+			``,
+		},
+		{
+			cty.StringVal("2001:db8::/48"),
+			cty.NumberIntVal(48),
+			cty.NumberIntVal(0),
+			cty.StringVal("2001:db8::/48"),
+			``,
+		},
+		{
+			cty.StringVal("2001:db8::/48"),
+			cty.NumberIntVal(56),
+			cty.NumberIntVal(256),
+			cty.NilVal,
+			`2001:db8::/48 has only 256 delegated prefixes of length 56`,
+		},
+		{
+			cty.StringVal("2001:db8::/48"),
+			cty.NumberIntVal(32),
+			cty.NumberIntVal(0),
+			cty.NilVal,
+			`delegated prefix length must be between 48 and 128`,
+		},
+		{
+			cty.StringVal("10.0.0.0/8"),
+			cty.NumberIntVal(16),
+			cty.NumberIntVal(0),
+			cty.NilVal,
+			`10.0.0.0/8 is not an IPv6 prefix`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("ipv6delegate(%#v, %#v, %#v)", test.Prefix, test.Length, test.Index), func(t *testing.T) {
+			got, err := IPv6Delegate(test.Prefix, test.Length, test.Index)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); got != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
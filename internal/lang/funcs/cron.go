@@ -0,0 +1,292 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// CronNextFunc constructs a function that returns the first time after a
+// timestamp that matches a cron schedule expression.
+var CronNextFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "expression",
+			Type: cty.String,
+		},
+		{
+			Name: "timestamp",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		sched, err := parseCronSchedule(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(0, err)
+		}
+		from, err := parseTimestamp(args[1].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(1, err)
+		}
+		next, ok := sched.next(from)
+		if !ok {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(0, "the schedule has no matching time within %d years of the given timestamp", cronSearchYears)
+		}
+		return cty.StringVal(next.Format(time.RFC3339)), nil
+	},
+})
+
+// cronSearchYears is how many years after the given timestamp cronnext
+// searches for a matching time, so that a schedule which can never match,
+// such as one for the 30th of February, doesn't search forever.
+const cronSearchYears = 5
+
+// cronSchedule is a parsed cron schedule expression. Each field is a bit
+// set of the values that the field matches.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domStar and dowStar record whether the day of month and day of week
+	// fields started with "*", in which case a day must match both fields instead of
+	// either of them.
+	domStar, dowStar bool
+
+	// loc is the time zone given by a CRON_TZ prefix, or nil to use the UTC
+	// offset of the timestamp.
+	loc *time.Location
+}
+
+// cronField describes the range of one field of a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// The day of week field accepts 7 as well as 0 for Sunday.
+	cronDow = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// cronMacros are the shorthand schedules that can be used instead of the
+// five fields.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// parseCronSchedule parses a standard five-field cron schedule expression,
+// optionally preceded by a time zone as "CRON_TZ=Europe/Berlin".
+func parseCronSchedule(expr string) (*cronSchedule, error) {
+	sched := &cronSchedule{}
+
+	expr = strings.TrimSpace(expr)
+	if after, ok := strings.CutPrefix(expr, "CRON_TZ="); ok {
+		name, rest, _ := strings.Cut(after, " ")
+		loc, err := loadTimeZone(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CRON_TZ: %w", err)
+		}
+		sched.loc = loc
+		expr = strings.TrimSpace(rest)
+	}
+	if macro, ok := cronMacros[expr]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("a cron expression must have five fields, for the minute, hour, day of month, month and day of week, but this one has %d", len(fields))
+	}
+
+	var err error
+	if sched.minute, _, err = parseCronField(fields[0], cronMinute); err != nil {
+		return nil, err
+	}
+	if sched.hour, _, err = parseCronField(fields[1], cronHour); err != nil {
+		return nil, err
+	}
+	if sched.dom, sched.domStar, err = parseCronField(fields[2], cronDom); err != nil {
+		return nil, err
+	}
+	if sched.month, _, err = parseCronField(fields[3], cronMonth); err != nil {
+		return nil, err
+	}
+	if sched.dow, sched.dowStar, err = parseCronField(fields[4], cronDow); err != nil {
+		return nil, err
+	}
+	if sched.dow&(1<<7) != 0 {
+		sched.dow |= 1 << 0
+	}
+	return sched, nil
+}
+
+// parseCronField parses one field of a cron expression: a comma-separated
+// list of "*", single values and ranges like "1-5", each optionally with a
+// step like "*/15". It also reports whether the field starts with "*",
+// which is how traditional cron implementations decide whether the day of
+// month and day of week fields are restricted.
+func parseCronField(field string, spec cronField) (uint64, bool, error) {
+	var bits uint64
+	for part := range strings.SplitSeq(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepStr)
+			if err != nil || step < 1 {
+				return 0, false, fmt.Errorf("invalid step %q in the %s field: must be a positive whole number", stepStr, spec.name)
+			}
+		}
+
+		var lo, hi int
+		switch {
+		case rng == "*" || rng == "?":
+			lo, hi = spec.min, spec.max
+			if spec.name == cronDow.name {
+				// We don't want "*" to include Sunday twice.
+				hi = 6
+			}
+		default:
+			loStr, hiStr, isRange := strings.Cut(rng, "-")
+			var err error
+			lo, err = parseCronValue(loStr, spec)
+			if err != nil {
+				return 0, false, err
+			}
+			switch {
+			case isRange:
+				hi, err = parseCronValue(hiStr, spec)
+				if err != nil {
+					return 0, false, err
+				}
+				if hi < lo {
+					return 0, false, fmt.Errorf("invalid range %q in the %s field: the start must not be after the end", rng, spec.name)
+				}
+			case hasStep:
+				// A single value with a step, like "5/15", means from that
+				// value to the end of the range.
+				hi = spec.max
+			default:
+				hi = lo
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, strings.HasPrefix(field, "*") || strings.HasPrefix(field, "?"), nil
+}
+
+func parseCronValue(s string, spec cronField) (int, error) {
+	if v, ok := spec.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < spec.min || v > spec.max {
+		return 0, fmt.Errorf("invalid value %q in the %s field: must be a whole number between %d and %d", s, spec.name, spec.min, spec.max)
+	}
+	return v, nil
+}
+
+// next returns the first time strictly after from that matches the
+// schedule, or false if there is no such time within cronSearchYears.
+//
+// The result is in the time zone of the schedule, if it has one, or else
+// at the same UTC offset as from.
+//
+// A time that the clocks skip over when daylight saving time starts in the
+// schedule's time zone never matches.
+func (s *cronSchedule) next(from time.Time) (time.Time, bool) {
+	if s.loc != nil {
+		from = from.In(s.loc)
+	}
+	loc := from.Location()
+
+	// We start at the beginning of the next minute, and then step forward
+	// through the fields from the largest to the smallest, starting again
+	// whenever a field wraps around to its first value.
+	t := from.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + cronSearchYears
+
+retry:
+	if t.Year() > limit {
+		return time.Time{}, false
+	}
+
+	for s.month&(1<<uint(t.Month())) == 0 {
+		t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		if t.Month() == time.January {
+			goto retry
+		}
+	}
+
+	for !s.dayMatches(t) {
+		t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		if t.Day() == 1 {
+			goto retry
+		}
+	}
+
+	for s.hour&(1<<uint(t.Hour())) == 0 {
+		// We move by elapsed time rather than by constructing a new time
+		// from its parts so that we can't step backward into a repeated
+		// hour at the end of daylight saving time.
+		day := t.Day()
+		t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+		if t.Day() != day {
+			goto retry
+		}
+	}
+
+	for s.minute&(1<<uint(t.Minute())) == 0 {
+		hour := t.Hour()
+		t = t.Add(time.Minute)
+		if t.Hour() != hour {
+			goto retry
+		}
+	}
+
+	return t, true
+}
+
+// dayMatches returns true if the date of t matches the day of month and day
+// of week fields of the schedule.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// CronNext returns the first time after timestamp that matches a cron
+// schedule expression.
+func CronNext(expression, timestamp cty.Value) (cty.Value, error) {
+	return CronNextFunc.Call([]cty.Value{expression, timestamp})
+}
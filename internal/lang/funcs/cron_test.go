@@ -0,0 +1,156 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestCronNext(t *testing.T) {
+	tests := []struct {
+		Expression, Timestamp cty.Value
+		Want                  cty.Value
+		Err                   string
+	}{
+		{
+			cty.StringVal("*/15 * * * *"),
+			cty.StringVal("2024-01-15T09:07:30Z"),
+			cty.StringVal("2024-01-15T09:15:00Z"),
+			``,
+		},
+		{
+			// The result is strictly after the given timestamp.
+			cty.StringVal("0 9 * * *"),
+			cty.StringVal("2024-01-15T09:00:00Z"),
+			cty.StringVal("2024-01-16T09:00:00Z"),
+			``,
+		},
+		{
+			cty.StringVal("30 2 * * SUN"),
+			cty.StringVal("2024-01-15T09:00:00Z"),
+			cty.StringVal("2024-01-21T02:30:00Z"),
+			``,
+		},
+		{
+			// 7 also means Sunday.
+			cty.StringVal("30 2 * * 7"),
+			cty.StringVal("2024-01-15T09:00:00Z"),
+			cty.StringVal("2024-01-21T02:30:00Z"),
+			``,
+		},
+		{
+			cty.StringVal("0 22 * * mon-fri"),
+			cty.StringVal("2024-01-19T23:00:00Z"),
+			cty.StringVal("2024-01-22T22:00:00Z"),
+			``,
+		},
+		{
+			// When both day fields are restricted, either can match.
+			cty.StringVal("0 0 1 * MON"),
+			cty.StringVal("2024-01-23T00:00:00Z"),
+			cty.StringVal("2024-01-29T00:00:00Z"),
+			``,
+		},
+		{
+			cty.StringVal("0 0 29 FEB *"),
+			cty.StringVal("2024-03-01T00:00:00Z"),
+			cty.StringVal("2028-02-29T00:00:00Z"),
+			``,
+		},
+		{
+			cty.StringVal("@monthly"),
+			cty.StringVal("2024-12-15T00:00:00Z"),
+			cty.StringVal("2025-01-01T00:00:00Z"),
+			``,
+		},
+		{
+			// Without CRON_TZ, the schedule uses the timestamp's UTC offset.
+			cty.StringVal("0 2 * * *"),
+			cty.StringVal("2024-01-15T09:00:00+05:30"),
+			cty.StringVal("2024-01-16T02:00:00+05:30"),
+			``,
+		},
+		{
+			cty.StringVal("CRON_TZ=Europe/Berlin 0 2 * * SUN"),
+			cty.StringVal("2024-07-01T00:00:00Z"),
+			cty.StringVal("2024-07-07T02:00:00+02:00"),
+			``,
+		},
+		{
+			// 02:30 doesn't exist on the day that daylight saving time
+			// starts, so the schedule skips that day.
+			cty.StringVal("CRON_TZ=Europe/Berlin 30 2 * * *"),
+			cty.StringVal("2024-03-30T12:00:00Z"),
+			cty.StringVal("2024-04-01T02:30:00+02:00"),
+			``,
+		},
+		{
+			cty.UnknownVal(cty.String),
+			cty.StringVal("2024-01-15T09:00:00Z"),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			``,
+		},
+		{
+			cty.StringVal("0 0 30 2 *"),
+			cty.StringVal("2024-01-15T09:00:00Z"),
+			cty.NilVal,
+			`the schedule has no matching time within 5 years of the given timestamp`,
+		},
+		{
+			cty.StringVal("0 0 * *"),
+			cty.StringVal("2024-01-15T09:00:00Z"),
+			cty.NilVal,
+			`a cron expression must have five fields, for the minute, hour, day of month, month and day of week, but this one has 4`,
+		},
+		{
+			cty.StringVal("0 24 * * *"),
+			cty.StringVal("2024-01-15T09:00:00Z"),
+			cty.NilVal,
+			`invalid value "24" in the hour field: must be a whole number between 0 and 23`,
+		},
+		{
+			cty.StringVal("0 0 * * FRI-MON"),
+			cty.StringVal("2024-01-15T09:00:00Z"),
+			cty.NilVal,
+			`invalid range "FRI-MON" in the day of week field: the start must not be after the end`,
+		},
+		{
+			cty.StringVal("*/0 * * * *"),
+			cty.StringVal("2024-01-15T09:00:00Z"),
+			cty.NilVal,
+			`invalid step "0" in the minute field: must be a positive whole number`,
+		},
+		{
+			cty.StringVal("CRON_TZ=Nowhere 0 0 * * *"),
+			cty.StringVal("2024-01-15T09:00:00Z"),
+			cty.NilVal,
+			`invalid CRON_TZ: unknown time zone "Nowhere": must be the name of a time zone from the IANA time zone database, such as "UTC" or "Europe/Berlin"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("cronnext(%#v, %#v)", test.Expression, test.Timestamp), func(t *testing.T) {
+			got, err := CronNext(test.Expression, test.Timestamp)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); got != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
@@ -0,0 +1,478 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"gopkg.in/ini.v1"
+)
+
+// TOMLDecodeFunc constructs a function that parses a string as a TOML
+// document and returns an object representing its top-level table.
+var TOMLDecodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "src",
+			Type: cty.String,
+		},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		if !args[0].IsKnown() {
+			return cty.DynamicPseudoType, nil
+		}
+		val, err := tomlDecode(args[0].AsString())
+		if err != nil {
+			return cty.NilType, function.NewArgError(0, err)
+		}
+		return val.Type(), nil
+	},
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return tomlDecode(args[0].AsString())
+	},
+})
+
+// TOMLEncodeFunc constructs a function that encodes an object or map as a
+// TOML document.
+var TOMLEncodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowDynamicType: true,
+			AllowUnknown:     true,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		val := args[0]
+		if !val.IsWhollyKnown() {
+			return cty.UnknownVal(retType), nil
+		}
+		if ty := val.Type(); !ty.IsObjectType() && !ty.IsMapType() {
+			return cty.UnknownVal(retType), function.NewArgErrorf(0, "a TOML document must be an object or a map, not %s", ty.FriendlyName())
+		}
+
+		raw, err := tomlEncodeValue(val, nil)
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgError(0, err)
+		}
+		var buf bytes.Buffer
+		enc := toml.NewEncoder(&buf)
+		enc.Indent = ""
+		if err := enc.Encode(raw); err != nil {
+			return cty.UnknownVal(retType), fmt.Errorf("failed to encode TOML: %w", err)
+		}
+		return cty.StringVal(buf.String()), nil
+	},
+})
+
+// INIDecodeFunc constructs a function that parses a string as an INI file
+// and returns a map of its sections, each a map of the section's keys to
+// their values.
+var INIDecodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "src",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.Map(cty.Map(cty.String))),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		f, err := ini.Load([]byte(args[0].AsString()))
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgErrorf(0, "invalid INI: %w", err)
+		}
+
+		sections := make(map[string]cty.Value)
+		for _, section := range f.Sections() {
+			keys := section.Keys()
+			if len(keys) == 0 && section.Name() == ini.DefaultSection {
+				continue
+			}
+			vals := make(map[string]cty.Value, len(keys))
+			for _, key := range keys {
+				vals[key.Name()] = cty.StringVal(key.Value())
+			}
+			if len(vals) == 0 {
+				sections[section.Name()] = cty.MapValEmpty(cty.String)
+				continue
+			}
+			sections[section.Name()] = cty.MapVal(vals)
+		}
+		if len(sections) == 0 {
+			return cty.MapValEmpty(cty.Map(cty.String)), nil
+		}
+		return cty.MapVal(sections), nil
+	},
+})
+
+// XMLDecodeFunc constructs a function that parses a string as an XML
+// document and returns an object representing its root element.
+//
+// Each element is represented as an object with an attribute for each of
+// the element's XML attributes, named with an "@" prefix, an attribute for
+// each distinct name of its child elements, whose value is a tuple of all of
+// the child elements with that name in document order, and a "#text"
+// attribute with the element's text content, if it has any that isn't
+// whitespace. Namespaces are not represented, so elements and attributes
+// are named by their local names.
+var XMLDecodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "src",
+			Type: cty.String,
+		},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		if !args[0].IsKnown() {
+			return cty.DynamicPseudoType, nil
+		}
+		val, err := xmlDecode(args[0].AsString())
+		if err != nil {
+			return cty.NilType, function.NewArgError(0, err)
+		}
+		return val.Type(), nil
+	},
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return xmlDecode(args[0].AsString())
+	},
+})
+
+// HCLDecodeFunc constructs a function that parses a string as an HCL file
+// containing only attributes whose values are constant expressions, and
+// returns an object with the value of each attribute.
+var HCLDecodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "src",
+			Type: cty.String,
+		},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		if !args[0].IsKnown() {
+			return cty.DynamicPseudoType, nil
+		}
+		val, err := hclDecode(args[0].AsString())
+		if err != nil {
+			return cty.NilType, function.NewArgError(0, err)
+		}
+		return val.Type(), nil
+	},
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return hclDecode(args[0].AsString())
+	},
+})
+
+func tomlDecode(src string) (cty.Value, error) {
+	var raw map[string]any
+	if _, err := toml.Decode(src, &raw); err != nil {
+		return cty.NilVal, fmt.Errorf("invalid TOML: %w", err)
+	}
+	return tomlDecodeValue(raw)
+}
+
+// tomlDecodeValue converts a value produced by the TOML decoder into a cty
+// value. Tables become objects and arrays become tuples. Dates and times
+// become strings in the same format as in TOML, because they may lack a
+// date, a time or a time zone.
+func tomlDecodeValue(raw any) (cty.Value, error) {
+	switch raw := raw.(type) {
+	case map[string]any:
+		if len(raw) == 0 {
+			return cty.EmptyObjectVal, nil
+		}
+		attrs := make(map[string]cty.Value, len(raw))
+		for k, v := range raw {
+			val, err := tomlDecodeValue(v)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			attrs[k] = val
+		}
+		return cty.ObjectVal(attrs), nil
+	case []map[string]any:
+		elems := make([]any, len(raw))
+		for i, v := range raw {
+			elems[i] = v
+		}
+		return tomlDecodeValue(elems)
+	case []any:
+		if len(raw) == 0 {
+			return cty.EmptyTupleVal, nil
+		}
+		elems := make([]cty.Value, len(raw))
+		for i, v := range raw {
+			val, err := tomlDecodeValue(v)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			elems[i] = val
+		}
+		return cty.TupleVal(elems), nil
+	case string:
+		return cty.StringVal(raw), nil
+	case bool:
+		return cty.BoolVal(raw), nil
+	case int64:
+		return cty.NumberIntVal(raw), nil
+	case float64:
+		if math.IsNaN(raw) {
+			return cty.NilVal, errors.New("TOML value nan cannot be represented as a number")
+		}
+		return cty.NumberFloatVal(raw), nil
+	case time.Time:
+		switch raw.Location().String() {
+		case "datetime-local":
+			return cty.StringVal(raw.Format("2006-01-02T15:04:05.999999999")), nil
+		case "date-local":
+			return cty.StringVal(raw.Format("2006-01-02")), nil
+		case "time-local":
+			return cty.StringVal(raw.Format("15:04:05.999999999")), nil
+		default:
+			return cty.StringVal(raw.Format(time.RFC3339Nano)), nil
+		}
+	default:
+		// Should never happen, because the above covers all of the types
+		// that the TOML decoder produces.
+		return cty.NilVal, fmt.Errorf("unsupported TOML value of type %T", raw)
+	}
+}
+
+// tomlEncodeValue converts a cty value into a value that the TOML encoder
+// accepts. Attributes and elements of objects and maps that are null are
+// omitted, because TOML cannot represent null.
+func tomlEncodeValue(val cty.Value, path cty.Path) (any, error) {
+	if val.IsNull() {
+		return nil, path.NewErrorf("TOML cannot represent null values")
+	}
+
+	ty := val.Type()
+	switch {
+	case ty.IsObjectType() || ty.IsMapType():
+		ret := make(map[string]any, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			if v.IsNull() {
+				continue
+			}
+			raw, err := tomlEncodeValue(v, path.Index(k))
+			if err != nil {
+				return nil, err
+			}
+			ret[k.AsString()] = raw
+		}
+		return ret, nil
+	case ty.IsListType() || ty.IsSetType() || ty.IsTupleType():
+		ret := make([]any, 0, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			raw, err := tomlEncodeValue(v, path.Index(k))
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, raw)
+		}
+		return ret, nil
+	case ty == cty.String:
+		return val.AsString(), nil
+	case ty == cty.Bool:
+		return val.True(), nil
+	case ty == cty.Number:
+		bf := val.AsBigFloat()
+		if i, acc := bf.Int64(); acc == 0 && bf.IsInt() {
+			return i, nil
+		}
+		f, _ := bf.Float64()
+		return f, nil
+	default:
+		return nil, path.NewErrorf("TOML cannot represent %s values", ty.FriendlyName())
+	}
+}
+
+// xmlElement is an element of an XML document being decoded by xmlDecode.
+type xmlElement struct {
+	attrs map[string]cty.Value
+
+	// children are the values of the child elements, grouped by name, and
+	// childNames are the names in the order they first appear.
+	children   map[string][]cty.Value
+	childNames []string
+
+	text strings.Builder
+}
+
+func (e *xmlElement) value() cty.Value {
+	attrs := make(map[string]cty.Value, len(e.attrs)+len(e.children)+1)
+	for name, val := range e.attrs {
+		attrs[name] = val
+	}
+	for _, name := range e.childNames {
+		attrs[name] = cty.TupleVal(e.children[name])
+	}
+	if text := strings.TrimSpace(e.text.String()); text != "" {
+		attrs["#text"] = cty.StringVal(text)
+	}
+	if len(attrs) == 0 {
+		return cty.EmptyObjectVal
+	}
+	return cty.ObjectVal(attrs)
+}
+
+func (e *xmlElement) addChild(name string, val cty.Value) {
+	if _, exists := e.children[name]; !exists {
+		e.childNames = append(e.childNames, name)
+	}
+	e.children[name] = append(e.children[name], val)
+}
+
+func xmlDecode(src string) (cty.Value, error) {
+	dec := xml.NewDecoder(strings.NewReader(src))
+
+	var root cty.Value
+	var rootName string
+	var stack []*xmlElement
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return cty.NilVal, fmt.Errorf("invalid XML: %w", err)
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if len(stack) == 0 && root != cty.NilVal {
+				return cty.NilVal, errors.New("invalid XML: a document must have exactly one root element")
+			}
+			elem := &xmlElement{
+				attrs:    make(map[string]cty.Value, len(tok.Attr)),
+				children: make(map[string][]cty.Value),
+			}
+			for _, attr := range tok.Attr {
+				if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+					continue
+				}
+				elem.attrs["@"+attr.Name.Local] = cty.StringVal(attr.Value)
+			}
+			stack = append(stack, elem)
+		case xml.EndElement:
+			elem := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				root, rootName = elem.value(), tok.Name.Local
+				continue
+			}
+			stack[len(stack)-1].addChild(tok.Name.Local, elem.value())
+		case xml.CharData:
+			if len(stack) == 0 {
+				if len(bytes.TrimSpace(tok)) != 0 {
+					return cty.NilVal, errors.New("invalid XML: text is not allowed outside of the root element")
+				}
+				continue
+			}
+			stack[len(stack)-1].text.Write(tok)
+		}
+	}
+
+	if root == cty.NilVal {
+		return cty.NilVal, errors.New("invalid XML: a document must have exactly one root element")
+	}
+	return cty.ObjectVal(map[string]cty.Value{
+		rootName: root,
+	}), nil
+}
+
+func hclDecode(src string) (cty.Value, error) {
+	f, diags := hclsyntax.ParseConfig([]byte(src), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return cty.NilVal, hclDecodeError(diags)
+	}
+	attrs, diags := f.Body.JustAttributes()
+	if diags.HasErrors() {
+		return cty.NilVal, hclDecodeError(diags)
+	}
+	if len(attrs) == 0 {
+		return cty.EmptyObjectVal, nil
+	}
+
+	vals := make(map[string]cty.Value, len(attrs))
+	for name, attr := range attrs {
+		// A nil evaluation context means that the expressions can't refer
+		// to any variables or call any functions.
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return cty.NilVal, hclDecodeError(diags)
+		}
+		vals[name] = val
+	}
+	return cty.ObjectVal(vals), nil
+}
+
+// hclDecodeError returns an error describing the first error in the given
+// diagnostics, which came from parsing or evaluating the source given to
+// hcldecode.
+func hclDecodeError(diags hcl.Diagnostics) error {
+	for _, diag := range diags {
+		if diag.Severity != hcl.DiagError {
+			continue
+		}
+		msg := diag.Summary
+		if diag.Detail != "" {
+			msg += "; " + diag.Detail
+		}
+		if diag.Subject != nil {
+			return fmt.Errorf("invalid HCL on line %d: %s", diag.Subject.Start.Line, msg)
+		}
+		return fmt.Errorf("invalid HCL: %s", msg)
+	}
+	return errors.New("invalid HCL")
+}
+
+// TOMLDecode parses the given string as a TOML document and returns an
+// object representing its top-level table.
+func TOMLDecode(src cty.Value) (cty.Value, error) {
+	return TOMLDecodeFunc.Call([]cty.Value{src})
+}
+
+// TOMLEncode encodes the given object or map as a TOML document.
+func TOMLEncode(val cty.Value) (cty.Value, error) {
+	return TOMLEncodeFunc.Call([]cty.Value{val})
+}
+
+// INIDecode parses the given string as an INI file and returns a map of its
+// sections, each a map of the section's keys to their values.
+func INIDecode(src cty.Value) (cty.Value, error) {
+	return INIDecodeFunc.Call([]cty.Value{src})
+}
+
+// XMLDecode parses the given string as an XML document and returns an object
+// representing its root element.
+func XMLDecode(src cty.Value) (cty.Value, error) {
+	return XMLDecodeFunc.Call([]cty.Value{src})
+}
+
+// HCLDecode parses the given string as an HCL file containing only
+// attributes and returns an object with the value of each attribute.
+func HCLDecode(src cty.Value) (cty.Value, error) {
+	return HCLDecodeFunc.Call([]cty.Value{src})
+}
@@ -0,0 +1,451 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/lang/marks"
+)
+
+func TestTOMLDecode(t *testing.T) {
+	tests := []struct {
+		Src  cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.StringVal(`
+title = "example"
+enabled = true
+ratio = 0.5
+ports = [8000, 8001]
+mixed = [1, "two"]
+empty = []
+created = 1979-05-27T07:32:00Z
+day = 1979-05-27
+at = 07:32:00
+
+[owner]
+name = "Tom"
+
+[[servers]]
+name = "alpha"
+
+[[servers]]
+name = "beta"
+port = 22
+`),
+			cty.ObjectVal(map[string]cty.Value{
+				"title":   cty.StringVal("example"),
+				"enabled": cty.True,
+				"ratio":   cty.NumberFloatVal(0.5),
+				"ports":   cty.TupleVal([]cty.Value{cty.NumberIntVal(8000), cty.NumberIntVal(8001)}),
+				"mixed":   cty.TupleVal([]cty.Value{cty.NumberIntVal(1), cty.StringVal("two")}),
+				"empty":   cty.EmptyTupleVal,
+				"created": cty.StringVal("1979-05-27T07:32:00Z"),
+				"day":     cty.StringVal("1979-05-27"),
+				"at":      cty.StringVal("07:32:00"),
+				"owner": cty.ObjectVal(map[string]cty.Value{
+					"name": cty.StringVal("Tom"),
+				}),
+				"servers": cty.TupleVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						"name": cty.StringVal("alpha"),
+					}),
+					cty.ObjectVal(map[string]cty.Value{
+						"name": cty.StringVal("beta"),
+						"port": cty.NumberIntVal(22),
+					}),
+				}),
+			}),
+			``,
+		},
+		{
+			cty.StringVal(""),
+			cty.EmptyObjectVal,
+			``,
+		},
+		{
+			cty.StringVal(`a = "b"`).Mark(marks.Sensitive),
+			cty.ObjectVal(map[string]cty.Value{
+				"a": cty.StringVal("b"),
+			}).Mark(marks.Sensitive),
+			``,
+		},
+		{
+			cty.UnknownVal(cty.String),
+			cty.DynamicVal,
+			``,
+		},
+		{
+			cty.StringVal(`a = nan`),
+			cty.NilVal,
+			`TOML value nan cannot be represented as a number`,
+		},
+		{
+			cty.StringVal(`a = `),
+			cty.NilVal,
+			`invalid TOML`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("tomldecode(%#v)", test.Src), func(t *testing.T) {
+			got, err := TOMLDecode(test.Src)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if !strings.Contains(err.Error(), test.Err) {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestTOMLEncode(t *testing.T) {
+	tests := []struct {
+		Val  cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"title":   cty.StringVal("example"),
+				"enabled": cty.True,
+				"ratio":   cty.NumberFloatVal(0.5),
+				"ports":   cty.ListVal([]cty.Value{cty.NumberIntVal(8000), cty.NumberIntVal(8001)}),
+				"unset":   cty.NullVal(cty.String),
+				"owner": cty.MapVal(map[string]cty.Value{
+					"name": cty.StringVal("Tom"),
+				}),
+				"servers": cty.TupleVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						"name": cty.StringVal("alpha"),
+					}),
+				}),
+			}),
+			cty.StringVal(`enabled = true
+ports = [8000, 8001]
+ratio = 0.5
+title = "example"
+
+[owner]
+name = "Tom"
+
+[[servers]]
+name = "alpha"
+`),
+			``,
+		},
+		{
+			cty.MapValEmpty(cty.String),
+			cty.StringVal(""),
+			``,
+		},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"a": cty.StringVal("b").Mark(marks.Sensitive),
+			}),
+			cty.StringVal("a = \"b\"\n").Mark(marks.Sensitive),
+			``,
+		},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"a": cty.UnknownVal(cty.String),
+			}),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			``,
+		},
+		{
+			cty.ListVal([]cty.Value{cty.StringVal("a")}),
+			cty.NilVal,
+			`a TOML document must be an object or a map, not list of string`,
+		},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"a": cty.ListVal([]cty.Value{cty.NullVal(cty.String)}),
+			}),
+			cty.NilVal,
+			`TOML cannot represent null values`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("tomlencode(%#v)", test.Val), func(t *testing.T) {
+			got, err := TOMLEncode(test.Val)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if !strings.Contains(err.Error(), test.Err) {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestINIDecode(t *testing.T) {
+	tests := []struct {
+		Src  cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.StringVal(`
+; global settings
+log_level = debug
+
+[database]
+host = db.example.com
+port = 5432 ; inline comment
+
+[empty]
+`),
+			cty.MapVal(map[string]cty.Value{
+				"DEFAULT": cty.MapVal(map[string]cty.Value{
+					"log_level": cty.StringVal("debug"),
+				}),
+				"database": cty.MapVal(map[string]cty.Value{
+					"host": cty.StringVal("db.example.com"),
+					"port": cty.StringVal("5432"),
+				}),
+				"empty": cty.MapValEmpty(cty.String),
+			}),
+			``,
+		},
+		{
+			cty.StringVal(""),
+			cty.MapValEmpty(cty.Map(cty.String)),
+			``,
+		},
+		{
+			cty.UnknownVal(cty.String),
+			cty.UnknownVal(cty.Map(cty.Map(cty.String))).RefineNotNull(),
+			``,
+		},
+		{
+			cty.StringVal("[unterminated"),
+			cty.NilVal,
+			`invalid INI`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("inidecode(%#v)", test.Src), func(t *testing.T) {
+			got, err := INIDecode(test.Src)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if !strings.Contains(err.Error(), test.Err) {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestXMLDecode(t *testing.T) {
+	tests := []struct {
+		Src  cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.StringVal(`<?xml version="1.0" encoding="UTF-8"?>
+<!-- services -->
+<services xmlns="urn:example" xmlns:x="urn:x" version="2">
+  <service name="web" x:tier="front">
+    <port>80</port>
+    <port>443</port>
+  </service>
+  <service name="db"><![CDATA[primary & replica]]></service>
+  <empty/>
+</services>
+`),
+			cty.ObjectVal(map[string]cty.Value{
+				"services": cty.ObjectVal(map[string]cty.Value{
+					"@version": cty.StringVal("2"),
+					"service": cty.TupleVal([]cty.Value{
+						cty.ObjectVal(map[string]cty.Value{
+							"@name": cty.StringVal("web"),
+							"@tier": cty.StringVal("front"),
+							"port": cty.TupleVal([]cty.Value{
+								cty.ObjectVal(map[string]cty.Value{"#text": cty.StringVal("80")}),
+								cty.ObjectVal(map[string]cty.Value{"#text": cty.StringVal("443")}),
+							}),
+						}),
+						cty.ObjectVal(map[string]cty.Value{
+							"@name": cty.StringVal("db"),
+							"#text": cty.StringVal("primary & replica"),
+						}),
+					}),
+					"empty": cty.TupleVal([]cty.Value{cty.EmptyObjectVal}),
+				}),
+			}),
+			``,
+		},
+		{
+			cty.UnknownVal(cty.String),
+			cty.DynamicVal,
+			``,
+		},
+		{
+			cty.StringVal(""),
+			cty.NilVal,
+			`a document must have exactly one root element`,
+		},
+		{
+			cty.StringVal("<a/><b/>"),
+			cty.NilVal,
+			`a document must have exactly one root element`,
+		},
+		{
+			cty.StringVal("<a/>text"),
+			cty.NilVal,
+			`text is not allowed outside of the root element`,
+		},
+		{
+			cty.StringVal("<a>"),
+			cty.NilVal,
+			`invalid XML`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("xmldecode(%#v)", test.Src), func(t *testing.T) {
+			got, err := XMLDecode(test.Src)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if !strings.Contains(err.Error(), test.Err) {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestHCLDecode(t *testing.T) {
+	tests := []struct {
+		Src  cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.StringVal(`
+name    = "web"
+count   = 2
+enabled = true
+tags    = { env = "prod" }
+zones   = ["a", "b"]
+label   = "${"web"}-1"
+`),
+			cty.ObjectVal(map[string]cty.Value{
+				"name":    cty.StringVal("web"),
+				"count":   cty.NumberIntVal(2),
+				"enabled": cty.True,
+				"tags": cty.ObjectVal(map[string]cty.Value{
+					"env": cty.StringVal("prod"),
+				}),
+				"zones": cty.TupleVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+				"label": cty.StringVal("web-1"),
+			}),
+			``,
+		},
+		{
+			cty.StringVal(""),
+			cty.EmptyObjectVal,
+			``,
+		},
+		{
+			cty.UnknownVal(cty.String),
+			cty.DynamicVal,
+			``,
+		},
+		{
+			cty.StringVal("a = var.b"),
+			cty.NilVal,
+			`invalid HCL on line 1: Variables not allowed`,
+		},
+		{
+			cty.StringVal("a = upper(\"b\")"),
+			cty.NilVal,
+			`invalid HCL on line 1: Function calls not allowed`,
+		},
+		{
+			cty.StringVal("a = 1\n\nb {}\n"),
+			cty.NilVal,
+			`invalid HCL on line 3: Unexpected "b" block`,
+		},
+		{
+			cty.StringVal("a = "),
+			cty.NilVal,
+			`invalid HCL on line 1`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("hcldecode(%#v)", test.Src), func(t *testing.T) {
+			got, err := HCLDecode(test.Src)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if !strings.Contains(err.Error(), test.Err) {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
@@ -104,6 +104,13 @@ var DescriptionList = map[string]descriptionEntry{
 			"`contained_ip_or_prefix` is either an IP address or an address prefix given in CIDR notation.",
 		},
 	},
+	"cidrexclude": {
+		Description: "`cidrexclude` calculates the shortest list of IP network address prefixes that covers all of the addresses in a given prefix except those in any of a list of excluded prefixes.",
+		ParamDescription: []string{
+			"`prefix` must be given in CIDR notation, as defined in [RFC 4632 section 3.1](https://tools.ietf.org/html/rfc4632#section-3.1).",
+			"`excluded_prefixes` is a list of address prefixes given in CIDR notation, of the same address family as `prefix`.",
+		},
+	},
 	"cidrhost": {
 		Description: "`cidrhost` calculates a full host IP address for a given host number within a given IP network address prefix.",
 		ParamDescription: []string{
@@ -111,12 +118,31 @@ var DescriptionList = map[string]descriptionEntry{
 			"`hostnum` is a whole number that can be represented as a binary integer with no more than the number of digits remaining in the address after the given prefix.",
 		},
 	},
+	"cidrhosts": {
+		Description: "`cidrhosts` describes the range of host addresses within a given IP network address prefix.",
+		ParamDescription: []string{
+			"`prefix` must be given in CIDR notation, as defined in [RFC 4632 section 3.1](https://tools.ietf.org/html/rfc4632#section-3.1).",
+		},
+	},
+	"cidrmerge": {
+		Description: "`cidrmerge` aggregates a list of IP network address prefixes into the shortest list of prefixes that covers exactly the same addresses.",
+		ParamDescription: []string{
+			"`prefixes` is a list of address prefixes given in CIDR notation.",
+		},
+	},
 	"cidrnetmask": {
 		Description: "`cidrnetmask` converts an IPv4 address prefix given in CIDR notation into a subnet mask address.",
 		ParamDescription: []string{
 			"`prefix` must be given in CIDR notation, as defined in [RFC 4632 section 3.1](https://tools.ietf.org/html/rfc4632#section-3.1).",
 		},
 	},
+	"cidroverlap": {
+		Description: "`cidroverlap` determines whether two IP network address prefixes have any addresses in common.",
+		ParamDescription: []string{
+			"`a` must be given in CIDR notation, as defined in [RFC 4632 section 3.1](https://tools.ietf.org/html/rfc4632#section-3.1).",
+			"`b` must be given in CIDR notation, and be of the same address family as `a`.",
+		},
+	},
 	"cidrsubnet": {
 		Description: "`cidrsubnet` calculates a subnet address within given IP network address prefix.",
 		ParamDescription: []string{
@@ -153,6 +179,13 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`contains` determines whether a given list or set contains a given single value as one of its elements.",
 		ParamDescription: []string{"", ""},
 	},
+	"cronnext": {
+		Description: "`cronnext` returns the first time after a timestamp that matches a cron schedule expression, as an [RFC 3339](https://tools.ietf.org/html/rfc3339) timestamp.",
+		ParamDescription: []string{
+			"A standard five-field cron expression, such as `\"30 2 * * SUN\"`, optionally preceded by a time zone such as `\"CRON_TZ=Europe/Berlin \"`.",
+			"",
+		},
+	},
 	"csvdecode": {
 		Description:      "`csvdecode` decodes a string containing CSV-formatted data and produces a list of maps representing that data.",
 		ParamDescription: []string{""},
@@ -237,6 +270,18 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`formatlist` produces a list of strings by formatting a number of other values according to a specification string.",
 		ParamDescription: []string{"", ""},
 	},
+	"formattime": {
+		Description: "`formattime` converts a timestamp into a different time format, as it appears in a given time zone.",
+		ParamDescription: []string{
+			"",
+			"",
+			"The name of a time zone from the IANA time zone database, such as `\"Europe/Berlin\"`.",
+		},
+	},
+	"hcldecode": {
+		Description:      "`hcldecode` parses a string as an HCL file containing only attributes with constant values, and produces an object with the value of each attribute.",
+		ParamDescription: []string{""},
+	},
 	"indent": {
 		Description: "`indent` adds a given number of spaces to the beginnings of all but the first line in a given multi-line string.",
 		ParamDescription: []string{
@@ -248,6 +293,25 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`index` finds the element index for a given value in a list.",
 		ParamDescription: []string{"", ""},
 	},
+	"inidecode": {
+		Description:      "`inidecode` parses a string as an INI file, and produces a map of its sections, each a map of the section's keys to their string values.",
+		ParamDescription: []string{""},
+	},
+	"ipv6delegate": {
+		Description: "`ipv6delegate` calculates a delegated IPv6 prefix of a given length within a given IPv6 prefix.",
+		ParamDescription: []string{
+			"`prefix` is an IPv6 address prefix given in CIDR notation.",
+			"`prefix_length` is the length of the delegated prefixes, which must be at least the length of `prefix`.",
+			"`index` is the zero-based number of the delegated prefix to calculate.",
+		},
+	},
+	"ipv6eui64": {
+		Description: "`ipv6eui64` calculates the IPv6 address that a host with a given MAC address assigns itself within a /64 prefix using a modified EUI-64 interface identifier.",
+		ParamDescription: []string{
+			"`prefix` is an IPv6 address prefix given in CIDR notation, with a prefix length of 64 bits.",
+			"`mac_address` is a 48-bit or 64-bit MAC address.",
+		},
+	},
 	"issensitive": {
 		Description:      "`issensitive` takes any value and returns `true` if the value is marked as sensitive, and `false` otherwise.",
 		ParamDescription: []string{""},
@@ -329,6 +393,10 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`parseint` parses the given string as a representation of an integer in the specified base and returns the resulting number. The base must be between 2 and 62 inclusive.",
 		ParamDescription: []string{"", ""},
 	},
+	"parsetime": {
+		Description:      "`parsetime` parses a string in a given time format, returning an [RFC 3339](https://tools.ietf.org/html/rfc3339) timestamp. An optional IANA time zone, like `\"Europe/Berlin\"`, applies to strings without a UTC offset.",
+		ParamDescription: []string{"", "", ""},
+	},
 	"pathexpand": {
 		Description:      "`pathexpand` takes a filesystem path that might begin with a `~` segment, and if so it replaces that segment with the current user's home directory path.",
 		ParamDescription: []string{""},
@@ -337,6 +405,13 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`pow` calculates an exponent, by raising its first argument to the power of the second argument.",
 		ParamDescription: []string{"", ""},
 	},
+	"query": {
+		Description: "`query` evaluates a [JMESPath](https://jmespath.org/) query against a value, and returns the result.",
+		ParamDescription: []string{
+			"",
+			"A JMESPath query, such as `\"spec.containers[0].image\"` or `\"items[?status == 'ready'].name\"`.",
+		},
+	},
 	"range": {
 		Description:      "`range` generates a list of numbers using a start value, a limit value, and a step value.",
 		ParamDescription: []string{""},
@@ -361,6 +436,28 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`rsadecrypt` decrypts an RSA-encrypted ciphertext, returning the corresponding cleartext.",
 		ParamDescription: []string{"", ""},
 	},
+	"semvercompare": {
+		Description: "`semvercompare` compares two [semantic versions](https://semver.org/), returning -1 if the first is lower than the second, 1 if it is higher, and 0 if they have the same precedence.",
+		ParamDescription: []string{
+			"",
+			"",
+		},
+	},
+	"semvermatch": {
+		Description: "`semvermatch` returns `true` if a [semantic version](https://semver.org/) meets a version constraint string, using the same syntax as the `version` argument of provider requirements.",
+		ParamDescription: []string{
+			"",
+			"A version constraint string, such as `\">= 1.2.0, < 2.0.0\"` or `\"~> 1.2\"`.",
+		},
+	},
+	"semvermax": {
+		Description:      "`semvermax` returns the element of a list of [semantic versions](https://semver.org/) that has the highest precedence.",
+		ParamDescription: []string{""},
+	},
+	"semverparse": {
+		Description:      "`semverparse` parses a [semantic version](https://semver.org/) string, and returns an object with its `major`, `minor` and `patch` numbers, and its `prerelease` and `build` labels.",
+		ParamDescription: []string{""},
+	},
 	"sensitive": {
 		Description:      "`sensitive` takes any value and returns a copy of it marked so that OpenTofu will treat it as sensitive, with the same meaning and behavior as for [sensitive input variables](/language/values/variables#suppressing-values-in-cli-output).",
 		ParamDescription: []string{""},
@@ -455,6 +552,10 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`timecmp` compares two timestamps and returns a number that represents the ordering of the instants those timestamps represent.",
 		ParamDescription: []string{"", ""},
 	},
+	"timediff": {
+		Description:      "`timediff` returns the duration between two timestamps, in the same syntax as the duration argument of `timeadd`.",
+		ParamDescription: []string{"", ""},
+	},
 	"timestamp": {
 		Description:      "`timestamp` returns a UTC timestamp string in [RFC 3339](https://tools.ietf.org/html/rfc3339) format.",
 		ParamDescription: []string{},
@@ -479,6 +580,14 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`tomap` converts its argument to a map value.",
 		ParamDescription: []string{""},
 	},
+	"tomldecode": {
+		Description:      "`tomldecode` parses a string as a [TOML](https://toml.io/) document, and produces an object representing its top-level table.",
+		ParamDescription: []string{""},
+	},
+	"tomlencode": {
+		Description:      "`tomlencode` encodes a given object or map to a string using [TOML](https://toml.io/) syntax.",
+		ParamDescription: []string{""},
+	},
 	"tonumber": {
 		Description:      "`tonumber` converts its argument to a number value.",
 		ParamDescription: []string{""},
@@ -546,6 +655,14 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`values` takes a map and returns a list containing the values of the elements in that map.",
 		ParamDescription: []string{""},
 	},
+	"weekday": {
+		Description:      "`weekday` returns the name of the day of the week of a timestamp, such as `\"Monday\"`.",
+		ParamDescription: []string{""},
+	},
+	"xmldecode": {
+		Description:      "`xmldecode` parses a string as an XML document, and produces an object representing its root element.",
+		ParamDescription: []string{""},
+	},
 	"yamldecode": {
 		Description:      "`yamldecode` parses a string as a subset of YAML, and produces a representation of its value.",
 		ParamDescription: []string{""},
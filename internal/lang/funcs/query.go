@@ -0,0 +1,347 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/jmespath/go-jmespath"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// QueryFunc constructs a function that evaluates a JMESPath query against a
+// value.
+//
+// Queries that are just a path of attribute names and indexes, such as
+// "spec.containers[0].image", are evaluated directly against the given
+// value, so the result keeps only the marks of the parts of the value that
+// it came from, and querying an unknown value produces an unknown value of
+// the type that the path leads to, if that can be determined.
+//
+// Other queries are evaluated by go-jmespath against a copy of the value
+// with no marks, so the result has all of the marks of the whole value, and
+// if any part of the value is unknown then so is the whole result.
+var QueryFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowNull:        true,
+			AllowUnknown:     true,
+			AllowDynamicType: true,
+			AllowMarked:      true,
+		},
+		{
+			Name: "query",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.DynamicPseudoType),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		val, query := args[0], args[1].AsString()
+
+		jp, err := jmespath.Compile(query)
+		if err != nil {
+			return cty.NilVal, function.NewArgErrorf(1, "invalid query: %w", err)
+		}
+
+		if steps, ok := parseQueryPath(query); ok {
+			return queryPath(val, steps), nil
+		}
+
+		unmarked, pvm := val.UnmarkDeepWithPaths()
+		var valMarks []cty.ValueMarks
+		for _, pm := range pvm {
+			valMarks = append(valMarks, pm.Marks)
+		}
+		if !unmarked.IsWhollyKnown() {
+			return cty.DynamicVal.WithMarks(valMarks...), nil
+		}
+
+		input, err := queryInputValue(unmarked)
+		if err != nil {
+			return cty.NilVal, function.NewArgError(0, err)
+		}
+		raw, err := jp.Search(input)
+		if err != nil {
+			return cty.NilVal, function.NewArgErrorf(1, "failed to evaluate query: %w", err)
+		}
+		ret, err := queryResultValue(raw)
+		if err != nil {
+			return cty.NilVal, function.NewArgErrorf(1, "failed to evaluate query: %w", err)
+		}
+		return ret.WithMarks(valMarks...), nil
+	},
+})
+
+// queryStep is a step of a query that is just a path: either an attribute
+// name or an index, which is negative to count from the end.
+type queryStep struct {
+	name    string
+	index   int
+	isIndex bool
+}
+
+// parseQueryPath parses a JMESPath query that is just a path of attribute
+// names and indexes, such as `a.b[0]."c-d"[-1]`, returning false if the
+// query uses any other syntax.
+func parseQueryPath(query string) ([]queryStep, bool) {
+	var steps []queryStep
+	rest := strings.TrimSpace(query)
+	for rest != "" {
+		switch {
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, false
+			}
+			idx, err := strconv.Atoi(strings.TrimSpace(rest[1:end]))
+			if err != nil {
+				return nil, false
+			}
+			steps = append(steps, queryStep{index: idx, isIndex: true})
+			rest = rest[end+1:]
+		case len(steps) == 0 || rest[0] == '.':
+			if len(steps) > 0 {
+				rest = strings.TrimLeft(rest[1:], " \t\r\n")
+			}
+			name, n, ok := parseQueryIdentifier(rest)
+			if !ok {
+				return nil, false
+			}
+			steps = append(steps, queryStep{name: name})
+			rest = rest[n:]
+		default:
+			return nil, false
+		}
+		rest = strings.TrimLeft(rest, " \t\r\n")
+	}
+	return steps, len(steps) > 0
+}
+
+// parseQueryIdentifier parses the JMESPath identifier at the start of the
+// given string, returning the name it represents and its length.
+func parseQueryIdentifier(s string) (string, int, bool) {
+	if s == "" {
+		return "", 0, false
+	}
+	if s[0] == '"' {
+		for i := 1; i < len(s); i++ {
+			switch s[i] {
+			case '\\':
+				i++
+			case '"':
+				var name string
+				if err := json.Unmarshal([]byte(s[:i+1]), &name); err != nil {
+					return "", 0, false
+				}
+				return name, i + 1, true
+			}
+		}
+		return "", 0, false
+	}
+
+	n := 0
+	for n < len(s) {
+		c := s[n]
+		if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (n > 0 && c >= '0' && c <= '9') {
+			n++
+			continue
+		}
+		break
+	}
+	if n == 0 {
+		return "", 0, false
+	}
+	return s[:n], n, true
+}
+
+// queryPath evaluates a query that is just a path against the given value,
+// following the JMESPath rule that any step that doesn't exist produces null.
+func queryPath(val cty.Value, steps []queryStep) cty.Value {
+	var pathMarks []cty.ValueMarks
+	for _, step := range steps {
+		var valMarks cty.ValueMarks
+		val, valMarks = val.Unmark()
+		pathMarks = append(pathMarks, valMarks)
+		if val.IsNull() {
+			val = cty.NullVal(cty.DynamicPseudoType)
+			break
+		}
+		val = queryStepValue(val, step)
+	}
+	return val.WithMarks(pathMarks...)
+}
+
+func queryStepValue(val cty.Value, step queryStep) cty.Value {
+	ty := val.Type()
+	if ty == cty.DynamicPseudoType {
+		return cty.DynamicVal
+	}
+	null := cty.NullVal(cty.DynamicPseudoType)
+
+	if step.isIndex {
+		switch {
+		case ty.IsTupleType():
+			etys := ty.TupleElementTypes()
+			idx, ok := queryIndex(step.index, len(etys))
+			if !ok {
+				return null
+			}
+			if !val.IsKnown() {
+				return cty.UnknownVal(etys[idx])
+			}
+			return val.Index(cty.NumberIntVal(int64(idx)))
+		case ty.IsListType() || ty.IsSetType():
+			if !val.IsKnown() {
+				return cty.UnknownVal(ty.ElementType())
+			}
+			idx, ok := queryIndex(step.index, val.LengthInt())
+			if !ok {
+				return null
+			}
+			i := 0
+			for it := val.ElementIterator(); it.Next(); i++ {
+				if i == idx {
+					_, elem := it.Element()
+					return elem
+				}
+			}
+		}
+		return null
+	}
+
+	switch {
+	case ty.IsObjectType():
+		if !ty.HasAttribute(step.name) {
+			return null
+		}
+		if !val.IsKnown() {
+			return cty.UnknownVal(ty.AttributeType(step.name))
+		}
+		return val.GetAttr(step.name)
+	case ty.IsMapType():
+		if !val.IsKnown() {
+			return cty.UnknownVal(ty.ElementType())
+		}
+		key := cty.StringVal(step.name)
+		if !val.HasIndex(key).True() {
+			return null
+		}
+		return val.Index(key)
+	}
+	return null
+}
+
+// queryIndex returns the index of the element that the given JMESPath index
+// selects in a sequence of the given length, if there is one.
+func queryIndex(idx, length int) (int, bool) {
+	if idx < 0 {
+		idx += length
+	}
+	return idx, idx >= 0 && idx < length
+}
+
+// queryInputValue converts a known value with no marks to the representation
+// that go-jmespath expects, which is the same as that of encoding/json.
+//
+// go-jmespath represents all numbers as float64, so it returns an error for
+// a number that float64 can't represent exactly, such as an integer above
+// 2^53, rather than silently changing it.
+func queryInputValue(val cty.Value) (any, error) {
+	if val.IsNull() {
+		return nil, nil
+	}
+	ty := val.Type()
+	switch {
+	case ty == cty.String:
+		return val.AsString(), nil
+	case ty == cty.Number:
+		f, acc := val.AsBigFloat().Float64()
+		if acc != big.Exact {
+			return nil, fmt.Errorf("the number %s can't be queried without losing precision; use a query that is just a path, or convert the number to a string first", val.AsBigFloat().Text('f', -1))
+		}
+		return f, nil
+	case ty == cty.Bool:
+		return val.True(), nil
+	case ty.IsObjectType() || ty.IsMapType():
+		ret := make(map[string]any, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			raw, err := queryInputValue(v)
+			if err != nil {
+				return nil, err
+			}
+			ret[k.AsString()] = raw
+		}
+		return ret, nil
+	default:
+		// Lists, sets and tuples
+		ret := make([]any, 0, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			raw, err := queryInputValue(v)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, raw)
+		}
+		return ret, nil
+	}
+}
+
+// queryResultValue converts a result from go-jmespath to a value, with
+// objects for JSON objects and tuples for JSON arrays.
+func queryResultValue(raw any) (cty.Value, error) {
+	switch raw := raw.(type) {
+	case nil:
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	case string:
+		return cty.StringVal(raw), nil
+	case float64:
+		return cty.NumberFloatVal(raw), nil
+	case bool:
+		return cty.BoolVal(raw), nil
+	case map[string]any:
+		if len(raw) == 0 {
+			return cty.EmptyObjectVal, nil
+		}
+		attrs := make(map[string]cty.Value, len(raw))
+		for k, v := range raw {
+			val, err := queryResultValue(v)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			attrs[k] = val
+		}
+		return cty.ObjectVal(attrs), nil
+	case []any:
+		if len(raw) == 0 {
+			return cty.EmptyTupleVal, nil
+		}
+		elems := make([]cty.Value, len(raw))
+		for i, v := range raw {
+			val, err := queryResultValue(v)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			elems[i] = val
+		}
+		return cty.TupleVal(elems), nil
+	default:
+		// Should never happen, because the above covers all of the types
+		// that go-jmespath produces for JSON-like input.
+		return cty.NilVal, fmt.Errorf("unsupported result of type %T", raw)
+	}
+}
+
+// Query evaluates a JMESPath query against the given value.
+func Query(val, query cty.Value) (cty.Value, error) {
+	return QueryFunc.Call([]cty.Value{val, query})
+}
@@ -0,0 +1,282 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/lang/marks"
+)
+
+func TestQuery(t *testing.T) {
+	pod := cty.ObjectVal(map[string]cty.Value{
+		"metadata": cty.ObjectVal(map[string]cty.Value{
+			"name": cty.StringVal("web"),
+			"labels": cty.MapVal(map[string]cty.Value{
+				"app":                    cty.StringVal("web"),
+				"app.kubernetes.io/tier": cty.StringVal("frontend"),
+			}),
+		}),
+		"spec": cty.ObjectVal(map[string]cty.Value{
+			"containers": cty.ListVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{
+					"name":  cty.StringVal("app"),
+					"image": cty.StringVal("nginx:1.27"),
+					"ports": cty.ListVal([]cty.Value{cty.NumberIntVal(80), cty.NumberIntVal(443)}),
+				}),
+				cty.ObjectVal(map[string]cty.Value{
+					"name":  cty.StringVal("sidecar"),
+					"image": cty.StringVal("envoy:1.31"),
+					"ports": cty.ListVal([]cty.Value{cty.NumberIntVal(9901)}),
+				}),
+			}),
+		}),
+	})
+	secret := cty.ObjectVal(map[string]cty.Value{
+		"name":     cty.StringVal("db"),
+		"password": cty.StringVal("hunter2").Mark(marks.Sensitive),
+	})
+	null := cty.NullVal(cty.DynamicPseudoType)
+
+	tests := []struct {
+		Value cty.Value
+		Query string
+		Want  cty.Value
+		Err   string
+	}{
+		{
+			pod,
+			`spec.containers[0].image`,
+			cty.StringVal("nginx:1.27"),
+			``,
+		},
+		{
+			pod,
+			`spec.containers[-1].ports`,
+			cty.ListVal([]cty.Value{cty.NumberIntVal(9901)}),
+			``,
+		},
+		{
+			pod,
+			` metadata . labels."app.kubernetes.io/tier" `,
+			cty.StringVal("frontend"),
+			``,
+		},
+		{
+			pod,
+			`spec.containers[2].image`,
+			null,
+			``,
+		},
+		{
+			pod,
+			`metadata.missing.name`,
+			null,
+			``,
+		},
+		{
+			pod,
+			`metadata[0]`,
+			null,
+			``,
+		},
+		{
+			pod,
+			`spec.containers[*].name`,
+			cty.TupleVal([]cty.Value{cty.StringVal("app"), cty.StringVal("sidecar")}),
+			``,
+		},
+		{
+			pod,
+			`spec.containers[?contains(ports, ` + "`443`" + `)].name | [0]`,
+			cty.StringVal("app"),
+			``,
+		},
+		{
+			pod,
+			`length(spec.containers)`,
+			cty.NumberIntVal(2),
+			``,
+		},
+		{
+			pod,
+			`{name: metadata.name, images: spec.containers[].image}`,
+			cty.ObjectVal(map[string]cty.Value{
+				"name":   cty.StringVal("web"),
+				"images": cty.TupleVal([]cty.Value{cty.StringVal("nginx:1.27"), cty.StringVal("envoy:1.31")}),
+			}),
+			``,
+		},
+		{
+			cty.SetVal([]cty.Value{cty.StringVal("a")}),
+			`[0]`,
+			cty.StringVal("a"),
+			``,
+		},
+		{
+			cty.NullVal(cty.String),
+			`a`,
+			null,
+			``,
+		},
+
+		// Paths keep only the marks of the parts of the value they refer to
+		{
+			secret,
+			`name`,
+			cty.StringVal("db"),
+			``,
+		},
+		{
+			secret,
+			`password`,
+			cty.StringVal("hunter2").Mark(marks.Sensitive),
+			``,
+		},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"a": cty.ObjectVal(map[string]cty.Value{
+					"b": cty.StringVal("c").Mark(marks.Ephemeral),
+				}),
+			}).Mark(marks.Sensitive),
+			`a.b`,
+			cty.StringVal("c").WithMarks(cty.NewValueMarks(marks.Sensitive, marks.Ephemeral)),
+			``,
+		},
+		// Other queries have all of the marks of the value
+		{
+			secret,
+			`keys(@)`,
+			cty.TupleVal([]cty.Value{cty.StringVal("name"), cty.StringVal("password")}).Mark(marks.Sensitive),
+			``,
+		},
+
+		// Paths into unknown values produce unknown values of the type the
+		// path leads to
+		{
+			cty.UnknownVal(cty.Object(map[string]cty.Type{
+				"a": cty.List(cty.Object(map[string]cty.Type{
+					"b": cty.String,
+				})),
+			})),
+			`a[0].b`,
+			cty.UnknownVal(cty.String),
+			``,
+		},
+		{
+			cty.UnknownVal(cty.Object(map[string]cty.Type{
+				"a": cty.String,
+			})),
+			`b`,
+			null,
+			``,
+		},
+		{
+			cty.UnknownVal(cty.Tuple([]cty.Type{cty.String, cty.Number})),
+			`[-1]`,
+			cty.UnknownVal(cty.Number),
+			``,
+		},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"id":   cty.UnknownVal(cty.String).RefineNotNull(),
+				"name": cty.StringVal("web"),
+			}),
+			`id`,
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			``,
+		},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"id":   cty.UnknownVal(cty.String),
+				"name": cty.StringVal("web"),
+			}),
+			`name`,
+			cty.StringVal("web"),
+			``,
+		},
+		{
+			cty.DynamicVal,
+			`a.b`,
+			cty.DynamicVal,
+			``,
+		},
+		// Other queries of values that aren't wholly known are unknown
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"id":   cty.UnknownVal(cty.String),
+				"name": cty.StringVal("web"),
+			}),
+			`keys(@)`,
+			cty.DynamicVal,
+			``,
+		},
+
+		{
+			pod,
+			`spec.containers[`,
+			cty.NilVal,
+			`invalid query: `,
+		},
+		{
+			pod,
+			`length(metadata.name.missing)`,
+			cty.NilVal,
+			`failed to evaluate query: `,
+		},
+
+		// go-jmespath only has float64 numbers, so other queries can't use
+		// larger integers, but paths can.
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"account": cty.MustParseNumberVal("123456789012345678"),
+			}),
+			`account`,
+			cty.MustParseNumberVal("123456789012345678"),
+			``,
+		},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"account": cty.MustParseNumberVal("123456789012345678"),
+			}),
+			`[account]`,
+			cty.NilVal,
+			`the number 123456789012345678 can't be queried without losing precision`,
+		},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"account": cty.MustParseNumberVal("9007199254740992"),
+			}),
+			`[account]`,
+			cty.TupleVal([]cty.Value{cty.MustParseNumberVal("9007199254740992")}),
+			``,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("query(%#v, %q)", test.Value, test.Query), func(t *testing.T) {
+			got, err := Query(test.Value, cty.StringVal(test.Query))
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); !strings.HasPrefix(got, test.Err) {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s...", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
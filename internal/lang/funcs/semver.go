@@ -0,0 +1,192 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apparentlymart/go-versions/versions"
+	"github.com/apparentlymart/go-versions/versions/constraints"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// semverType is the type of the objects returned by the semverparse
+// function.
+var semverType = cty.Object(map[string]cty.Type{
+	"major":      cty.Number,
+	"minor":      cty.Number,
+	"patch":      cty.Number,
+	"prerelease": cty.String,
+	"build":      cty.String,
+})
+
+// SemverParseFunc constructs a function that parses a semantic version
+// string and returns an object describing its parts.
+var SemverParseFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "version",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(semverType),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		v, err := parseSemver(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgError(0, err)
+		}
+		return cty.ObjectVal(map[string]cty.Value{
+			"major":      cty.NumberUIntVal(v.Major),
+			"minor":      cty.NumberUIntVal(v.Minor),
+			"patch":      cty.NumberUIntVal(v.Patch),
+			"prerelease": cty.StringVal(string(v.Prerelease)),
+			"build":      cty.StringVal(string(v.Metadata)),
+		}), nil
+	},
+})
+
+// SemverCompareFunc constructs a function that compares two semantic
+// versions, returning -1, 0 or 1.
+var SemverCompareFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "a",
+			Type: cty.String,
+		},
+		{
+			Name: "b",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.Number),
+	RefineResult: refineSemverCompare,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		a, err := parseSemver(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgError(0, err)
+		}
+		b, err := parseSemver(args[1].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgError(1, err)
+		}
+		switch {
+		case a.LessThan(b):
+			return cty.NumberIntVal(-1), nil
+		case a.GreaterThan(b):
+			return cty.NumberIntVal(1), nil
+		default:
+			return cty.NumberIntVal(0), nil
+		}
+	},
+})
+
+// SemverMatchFunc constructs a function that checks whether a semantic
+// version meets a version constraint string.
+var SemverMatchFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "version",
+			Type: cty.String,
+		},
+		{
+			Name: "constraint",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.Bool),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		v, err := parseSemver(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgError(0, err)
+		}
+		spec, err := constraints.ParseRubyStyleMulti(args[1].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgErrorf(1, "invalid version constraint: %w", err)
+		}
+		return cty.BoolVal(versions.MeetingConstraints(spec).Has(v)), nil
+	},
+})
+
+// SemverMaxFunc constructs a function that returns the highest of a list of
+// semantic versions.
+var SemverMaxFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "versions",
+			Type: cty.List(cty.String),
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		list := args[0]
+		if !list.IsWhollyKnown() {
+			return cty.UnknownVal(retType), nil
+		}
+		if list.LengthInt() == 0 {
+			return cty.UnknownVal(retType), function.NewArgErrorf(0, "at least one version is required")
+		}
+
+		var highest cty.Value
+		var highestVersion versions.Version
+		for it := list.ElementIterator(); it.Next(); {
+			idx, elem := it.Element()
+			if elem.IsNull() {
+				return cty.UnknownVal(retType), function.NewArgErrorf(0, "element %s is null", idx.AsBigFloat().String())
+			}
+			v, err := parseSemver(elem.AsString())
+			if err != nil {
+				return cty.UnknownVal(retType), function.NewArgErrorf(0, "element %s: %w", idx.AsBigFloat().String(), err)
+			}
+			if highest == cty.NilVal || v.GreaterThan(highestVersion) {
+				highest, highestVersion = elem, v
+			}
+		}
+		return highest, nil
+	},
+})
+
+// parseSemver parses a semantic version string, which may have a "v" prefix
+// as is conventional in the version tags of many projects.
+func parseSemver(s string) (versions.Version, error) {
+	v, err := versions.ParseVersion(strings.TrimPrefix(s, "v"))
+	if err != nil {
+		return versions.Unspecified, fmt.Errorf("invalid version %q: %w", s, err)
+	}
+	return v, nil
+}
+
+func refineSemverCompare(b *cty.RefinementBuilder) *cty.RefinementBuilder {
+	return b.NotNull().
+		NumberRangeLowerBound(cty.NumberIntVal(-1), true).
+		NumberRangeUpperBound(cty.NumberIntVal(1), true)
+}
+
+// SemverParse parses a semantic version string and returns an object
+// describing its parts.
+func SemverParse(version cty.Value) (cty.Value, error) {
+	return SemverParseFunc.Call([]cty.Value{version})
+}
+
+// SemverCompare compares two semantic versions, returning -1 if a is lower
+// than b, 1 if a is higher than b, and 0 if they have the same precedence.
+func SemverCompare(a, b cty.Value) (cty.Value, error) {
+	return SemverCompareFunc.Call([]cty.Value{a, b})
+}
+
+// SemverMatch returns true if the given semantic version meets the given
+// version constraint string.
+func SemverMatch(version, constraint cty.Value) (cty.Value, error) {
+	return SemverMatchFunc.Call([]cty.Value{version, constraint})
+}
+
+// SemverMax returns the element of the given list of semantic versions that
+// has the highest precedence.
+func SemverMax(list cty.Value) (cty.Value, error) {
+	return SemverMaxFunc.Call([]cty.Value{list})
+}
@@ -0,0 +1,330 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/lang/marks"
+)
+
+func TestSemverParse(t *testing.T) {
+	tests := []struct {
+		Version cty.Value
+		Want    cty.Value
+		Err     string
+	}{
+		{
+			cty.StringVal("1.2.3"),
+			cty.ObjectVal(map[string]cty.Value{
+				"major":      cty.NumberIntVal(1),
+				"minor":      cty.NumberIntVal(2),
+				"patch":      cty.NumberIntVal(3),
+				"prerelease": cty.StringVal(""),
+				"build":      cty.StringVal(""),
+			}),
+			``,
+		},
+		{
+			cty.StringVal("v1.29"),
+			cty.ObjectVal(map[string]cty.Value{
+				"major":      cty.NumberIntVal(1),
+				"minor":      cty.NumberIntVal(29),
+				"patch":      cty.NumberIntVal(0),
+				"prerelease": cty.StringVal(""),
+				"build":      cty.StringVal(""),
+			}),
+			``,
+		},
+		{
+			cty.StringVal("2.0.0-beta.1+20240101").Mark(marks.Sensitive),
+			cty.ObjectVal(map[string]cty.Value{
+				"major":      cty.NumberIntVal(2),
+				"minor":      cty.NumberIntVal(0),
+				"patch":      cty.NumberIntVal(0),
+				"prerelease": cty.StringVal("beta.1"),
+				"build":      cty.StringVal("20240101"),
+			}).Mark(marks.Sensitive),
+			``,
+		},
+		{
+			cty.UnknownVal(cty.String),
+			cty.UnknownVal(semverType).RefineNotNull(),
+			``,
+		},
+		{
+			cty.StringVal("1.x"),
+			cty.NilVal,
+			`invalid version "1.x": can't use wildcard for minor number; an exact version is required`,
+		},
+		{
+			cty.StringVal("1.2.3.4"),
+			cty.NilVal,
+			`invalid version "1.2.3.4": too many numbered portions; only three are allowed (major, minor, patch)`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("semverparse(%#v)", test.Version), func(t *testing.T) {
+			got, err := SemverParse(test.Version)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); got != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestSemverCompare(t *testing.T) {
+	tests := []struct {
+		A, B cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.StringVal("1.2.3"),
+			cty.StringVal("1.2.3"),
+			cty.NumberIntVal(0),
+			``,
+		},
+		{
+			cty.StringVal("1.9.0"),
+			cty.StringVal("1.10.0"),
+			cty.NumberIntVal(-1),
+			``,
+		},
+		{
+			cty.StringVal("v2"),
+			cty.StringVal("1.99.99"),
+			cty.NumberIntVal(1),
+			``,
+		},
+		{
+			cty.StringVal("1.0.0-rc.1"),
+			cty.StringVal("1.0.0"),
+			cty.NumberIntVal(-1),
+			``,
+		},
+		{
+			// Build metadata doesn't affect precedence
+			cty.StringVal("1.0.0+a"),
+			cty.StringVal("1.0.0+b"),
+			cty.NumberIntVal(0),
+			``,
+		},
+		{
+			cty.UnknownVal(cty.String),
+			cty.StringVal("1.0.0"),
+			cty.UnknownVal(cty.Number).Refine().
+				NotNull().
+				NumberRangeLowerBound(cty.NumberIntVal(-1), true).
+				NumberRangeUpperBound(cty.NumberIntVal(1), true).
+				NewValue(),
+			``,
+		},
+		{
+			cty.StringVal("1.0.0"),
+			cty.StringVal("latest"),
+			cty.NilVal,
+			`invalid version "latest"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("semvercompare(%#v, %#v)", test.A, test.B), func(t *testing.T) {
+			got, err := SemverCompare(test.A, test.B)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); !strings.HasPrefix(got, test.Err) {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s...", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestSemverMatch(t *testing.T) {
+	tests := []struct {
+		Version, Constraint cty.Value
+		Want                cty.Value
+		Err                 string
+	}{
+		{
+			cty.StringVal("1.29.2"),
+			cty.StringVal(">= 1.28, < 2.0"),
+			cty.True,
+			``,
+		},
+		{
+			cty.StringVal("1.27.9"),
+			cty.StringVal(">= 1.28, < 2.0"),
+			cty.False,
+			``,
+		},
+		{
+			cty.StringVal("1.29.2"),
+			cty.StringVal("~> 1.29.0"),
+			cty.True,
+			``,
+		},
+		{
+			cty.StringVal("1.30.0"),
+			cty.StringVal("~> 1.29.0"),
+			cty.False,
+			``,
+		},
+		{
+			// Pre-releases only match constraints that mention them exactly
+			cty.StringVal("2.0.0-beta.1"),
+			cty.StringVal(">= 1.0"),
+			cty.False,
+			``,
+		},
+		{
+			cty.StringVal("2.0.0-beta.1"),
+			cty.StringVal("2.0.0-beta.1"),
+			cty.True,
+			``,
+		},
+		{
+			cty.StringVal("1.0.0"),
+			cty.UnknownVal(cty.String),
+			cty.UnknownVal(cty.Bool).RefineNotNull(),
+			``,
+		},
+		{
+			cty.StringVal("1.0.0"),
+			cty.StringVal(">=1.0 <2.0"),
+			cty.NilVal,
+			`invalid version constraint: missing comma after ">=1.0"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("semvermatch(%#v, %#v)", test.Version, test.Constraint), func(t *testing.T) {
+			got, err := SemverMatch(test.Version, test.Constraint)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); got != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestSemverMax(t *testing.T) {
+	tests := []struct {
+		List cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.ListVal([]cty.Value{
+				cty.StringVal("1.9.2"),
+				cty.StringVal("1.29.0"),
+				cty.StringVal("1.28.4"),
+			}),
+			cty.StringVal("1.29.0"),
+			``,
+		},
+		{
+			// The highest version is returned as written
+			cty.ListVal([]cty.Value{
+				cty.StringVal("v1.2"),
+				cty.StringVal("1.1.9"),
+				cty.StringVal("1.2.0-rc.1"),
+			}),
+			cty.StringVal("v1.2"),
+			``,
+		},
+		{
+			cty.ListVal([]cty.Value{
+				cty.StringVal("1.0.0"),
+				cty.UnknownVal(cty.String),
+			}),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			``,
+		},
+		{
+			cty.ListValEmpty(cty.String),
+			cty.NilVal,
+			`at least one version is required`,
+		},
+		{
+			cty.ListVal([]cty.Value{
+				cty.StringVal("1.0.0"),
+				cty.StringVal("next"),
+			}),
+			cty.NilVal,
+			`element 1: invalid version "next": `,
+		},
+		{
+			cty.ListVal([]cty.Value{
+				cty.StringVal("1.0.0"),
+				cty.NullVal(cty.String),
+			}),
+			cty.NilVal,
+			`element 1 is null`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("semvermax(%#v)", test.List), func(t *testing.T) {
+			got, err := SemverMax(test.List)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); !strings.HasPrefix(got, test.Err) {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s...", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
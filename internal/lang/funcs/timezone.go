@@ -0,0 +1,639 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// We embed the IANA time zone database so that the functions in this
+	// file return the same results regardless of which time zone data, if
+	// any, is installed on the system running OpenTofu.
+	_ "time/tzdata"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// FormatTimeFunc constructs a function that formats a timestamp as it
+// appears in a given time zone, using the same format syntax as formatdate.
+var FormatTimeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "format",
+			Type: cty.String,
+		},
+		{
+			Name: "timestamp",
+			Type: cty.String,
+		},
+		{
+			Name: "timezone",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		ts, err := parseTimestamp(args[1].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(1, err)
+		}
+		loc, err := loadTimeZone(args[2].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(2, err)
+		}
+		ret, err := formatTime(args[0].AsString(), ts.In(loc))
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(0, err)
+		}
+		return cty.StringVal(ret), nil
+	},
+})
+
+// ParseTimeFunc constructs a function that parses a string using the same
+// format syntax as formatdate, returning an RFC 3339 timestamp. An optional
+// third argument gives the IANA time zone of times without a UTC offset.
+var ParseTimeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "format",
+			Type: cty.String,
+		},
+		{
+			Name: "time",
+			Type: cty.String,
+		},
+	},
+	VarParam: &function.Parameter{
+		Name: "timezone",
+		Type: cty.String,
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		if len(args) > 3 {
+			return cty.UnknownVal(cty.String), fmt.Errorf("parsetime() takes no more than three arguments")
+		}
+		tokens, err := splitTimeFormat(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(0, err)
+		}
+		var loc *time.Location
+		if len(args) > 2 {
+			loc, err = loadTimeZone(args[2].AsString())
+			if err != nil {
+				return cty.UnknownVal(cty.String), function.NewArgError(2, err)
+			}
+		}
+		ts, err := parseTime(tokens, args[1].AsString(), loc)
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(1, err)
+		}
+		return cty.StringVal(ts.Format(time.RFC3339)), nil
+	},
+})
+
+// TimeDiffFunc constructs a function that returns the duration between two
+// timestamps, in the duration syntax accepted by timeadd.
+var TimeDiffFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "timestamp_a",
+			Type: cty.String,
+		},
+		{
+			Name: "timestamp_b",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		tsA, err := parseTimestamp(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(0, err)
+		}
+		tsB, err := parseTimestamp(args[1].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(1, err)
+		}
+		return cty.StringVal(tsA.Sub(tsB).String()), nil
+	},
+})
+
+// WeekdayFunc constructs a function that returns the name of the day of the
+// week of a timestamp, in the timestamp's own UTC offset.
+var WeekdayFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "timestamp",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		ts, err := parseTimestamp(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(0, err)
+		}
+		return cty.StringVal(ts.Weekday().String()), nil
+	},
+})
+
+// loadTimeZone returns the location for the given IANA time zone name, such
+// as "Europe/Berlin".
+//
+// Unlike [time.LoadLocation], this doesn't accept "Local" or the empty
+// string, because their meaning depends on the system running OpenTofu.
+func loadTimeZone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("must be the name of a time zone from the IANA time zone database, such as \"UTC\" or \"Europe/Berlin\"")
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q: must be the name of a time zone from the IANA time zone database, such as \"UTC\" or \"Europe/Berlin\"", name)
+	}
+	return loc, nil
+}
+
+// splitTimeFormat splits a format string in the syntax of formatdate into
+// its verbs, like "YYYY", and its literal text, with any quoting removed.
+//
+// Literal text is returned with a leading single quote so that callers can
+// tell it apart from verbs.
+func splitTimeFormat(format string) ([]string, error) {
+	const esc = '\''
+	var ret []string
+	for i := 0; i < len(format); {
+		c := format[i]
+		switch {
+		case c == esc:
+			// A quoted literal, in which a doubled quote stands for a single
+			// quote. Two quotes on their own also stand for a single quote.
+			if i+1 < len(format) && format[i+1] == esc {
+				ret = append(ret, "''")
+				i += 2
+				continue
+			}
+			var lit strings.Builder
+			lit.WriteByte(esc)
+			j := i + 1
+			for {
+				if j >= len(format) {
+					return nil, fmt.Errorf("unterminated literal '")
+				}
+				if format[j] == esc {
+					if j+1 < len(format) && format[j+1] == esc {
+						lit.WriteByte(esc)
+						j += 2
+						continue
+					}
+					break
+				}
+				lit.WriteByte(format[j])
+				j++
+			}
+			ret = append(ret, lit.String())
+			i = j + 1
+		case isTimeFormatVerbChar(c):
+			j := i + 1
+			for j < len(format) && format[j] == c {
+				j++
+			}
+			verb := format[i:j]
+			if !validTimeFormatVerbs[verb] {
+				return nil, fmt.Errorf("invalid date format verb %q", verb)
+			}
+			ret = append(ret, verb)
+			i = j
+		default:
+			j := i + 1
+			for j < len(format) && format[j] != esc && !isTimeFormatVerbChar(format[j]) {
+				j++
+			}
+			ret = append(ret, "'"+format[i:j])
+			i = j
+		}
+	}
+	return ret, nil
+}
+
+func isTimeFormatVerbChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// validTimeFormatVerbs are the verbs of the format syntax of formatdate.
+var validTimeFormatVerbs = map[string]bool{
+	"YY": true, "YYYY": true,
+	"M": true, "MM": true, "MMM": true, "MMMM": true,
+	"D": true, "DD": true,
+	"EEE": true, "EEEE": true,
+	"h": true, "hh": true,
+	"H": true, "HH": true,
+	"AA": true, "aa": true,
+	"m": true, "mm": true,
+	"s": true, "ss": true,
+	"Z": true, "ZZZ": true, "ZZZZ": true, "ZZZZZ": true,
+}
+
+// formatTime formats t using a format string in the syntax of formatdate.
+//
+// Unlike formatdate, the "ZZZ" verb writes the abbreviation of the time
+// zone, like "CET", because t can be in any time zone rather than only at
+// a fixed UTC offset.
+func formatTime(format string, t time.Time) (string, error) {
+	tokens, err := splitTimeFormat(format)
+	if err != nil {
+		return "", err
+	}
+
+	var buf strings.Builder
+	for _, tok := range tokens {
+		switch tok {
+		case "YY":
+			fmt.Fprintf(&buf, "%02d", t.Year()%100)
+		case "YYYY":
+			fmt.Fprintf(&buf, "%04d", t.Year())
+		case "M":
+			fmt.Fprintf(&buf, "%d", t.Month())
+		case "MM":
+			fmt.Fprintf(&buf, "%02d", t.Month())
+		case "MMM":
+			buf.WriteString(t.Month().String()[:3])
+		case "MMMM":
+			buf.WriteString(t.Month().String())
+		case "D":
+			fmt.Fprintf(&buf, "%d", t.Day())
+		case "DD":
+			fmt.Fprintf(&buf, "%02d", t.Day())
+		case "EEE":
+			buf.WriteString(t.Weekday().String()[:3])
+		case "EEEE":
+			buf.WriteString(t.Weekday().String())
+		case "h":
+			fmt.Fprintf(&buf, "%d", t.Hour())
+		case "hh":
+			fmt.Fprintf(&buf, "%02d", t.Hour())
+		case "H":
+			buf.WriteString(t.Format("3"))
+		case "HH":
+			buf.WriteString(t.Format("03"))
+		case "AA":
+			buf.WriteString(t.Format("PM"))
+		case "aa":
+			buf.WriteString(t.Format("pm"))
+		case "m":
+			fmt.Fprintf(&buf, "%d", t.Minute())
+		case "mm":
+			fmt.Fprintf(&buf, "%02d", t.Minute())
+		case "s":
+			fmt.Fprintf(&buf, "%d", t.Second())
+		case "ss":
+			fmt.Fprintf(&buf, "%02d", t.Second())
+		case "Z":
+			buf.WriteString(t.Format("Z07:00"))
+		case "ZZZ":
+			buf.WriteString(t.Format("MST"))
+		case "ZZZZ":
+			buf.WriteString(t.Format("-0700"))
+		case "ZZZZZ":
+			buf.WriteString(t.Format("-07:00"))
+		default:
+			// Anything else is literal text, marked by a leading quote.
+			buf.WriteString(tok[1:])
+		}
+	}
+	return buf.String(), nil
+}
+
+// parseTime parses s using the tokens of a format string returned by
+// [splitTimeFormat].
+//
+// Any part of the date that the format doesn't include defaults to the
+// same value as in Go's [time.Parse]. If s has no UTC offset then it is a
+// wall-clock time in loc, or in UTC if loc is nil. The "ZZZ" verb also
+// accepts the abbreviations that loc uses, like "CET", which is what
+// formatTime writes for it.
+func parseTime(tokens []string, s string, loc *time.Location) (time.Time, error) {
+	year, month, day := 0, 1, 1
+	hour, minute, second := 0, 0, 0
+	hour12, pm := -1, false
+	weekday := -1
+	offset, hasOffset := 0, false
+	abbrev := ""
+
+	rest := s
+	for _, tok := range tokens {
+		var err error
+		switch tok {
+		case "YY":
+			year, rest, err = takeTimeNumber(rest, 2, 2, "a two-digit year")
+			// This follows the same convention as Go's time.Parse.
+			if year >= 69 {
+				year += 1900
+			} else {
+				year += 2000
+			}
+		case "YYYY":
+			year, rest, err = takeTimeNumber(rest, 4, 4, "a four-digit year")
+		case "M":
+			month, rest, err = takeTimeNumber(rest, 1, 2, "a month")
+		case "MM":
+			month, rest, err = takeTimeNumber(rest, 2, 2, "a two-digit month")
+		case "MMM", "MMMM":
+			var m int
+			m, rest, err = takeTimeName(rest, len(tok) == 3, "a month name", func(i int) string {
+				return time.Month(i + 1).String()
+			}, 12)
+			month = m + 1
+		case "D":
+			day, rest, err = takeTimeNumber(rest, 1, 2, "a day of month")
+		case "DD":
+			day, rest, err = takeTimeNumber(rest, 2, 2, "a two-digit day of month")
+		case "EEE", "EEEE":
+			weekday, rest, err = takeTimeName(rest, len(tok) == 3, "a day of week name", func(i int) string {
+				return time.Weekday(i).String()
+			}, 7)
+		case "h":
+			hour, rest, err = takeTimeNumber(rest, 1, 2, "a hour")
+		case "hh":
+			hour, rest, err = takeTimeNumber(rest, 2, 2, "a two-digit hour")
+		case "H":
+			hour12, rest, err = takeTimeNumber(rest, 1, 2, "a 12-hour hour")
+		case "HH":
+			hour12, rest, err = takeTimeNumber(rest, 2, 2, "a two-digit 12-hour hour")
+		case "AA", "aa":
+			var i int
+			i, rest, err = takeTimeName(rest, false, "\"AM\" or \"PM\"", func(i int) string {
+				return [...]string{"AM", "PM"}[i]
+			}, 2)
+			pm = i == 1
+		case "m":
+			minute, rest, err = takeTimeNumber(rest, 1, 2, "a minute")
+		case "mm":
+			minute, rest, err = takeTimeNumber(rest, 2, 2, "a two-digit minute")
+		case "s":
+			second, rest, err = takeTimeNumber(rest, 1, 2, "a second")
+		case "ss":
+			second, rest, err = takeTimeNumber(rest, 2, 2, "a two-digit second")
+		case "Z":
+			hasOffset = true
+			if strings.HasPrefix(rest, "Z") {
+				offset, rest = 0, rest[1:]
+				break
+			}
+			offset, rest, err = takeTimeOffset(rest, true)
+		case "ZZZ":
+			if n := timeZoneAbbrevLen(rest); n > 0 {
+				abbrev, rest = rest[:n], rest[n:]
+				if abbrev == "UTC" {
+					offset, hasOffset, abbrev = 0, true, ""
+				}
+				break
+			}
+			hasOffset = true
+			offset, rest, err = takeTimeZoneOffset(rest)
+		case "ZZZZ":
+			hasOffset = true
+			offset, rest, err = takeTimeOffset(rest, false)
+		case "ZZZZZ":
+			hasOffset = true
+			offset, rest, err = takeTimeOffset(rest, true)
+		default:
+			lit := tok[1:]
+			if !strings.HasPrefix(rest, lit) {
+				err = timeMismatchError(rest, fmt.Sprintf("%q", lit))
+			} else {
+				rest = rest[len(lit):]
+			}
+		}
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+	if rest != "" {
+		return time.Time{}, fmt.Errorf("unexpected %q after the end of the format", rest)
+	}
+
+	if hour12 >= 0 {
+		if hour12 < 1 || hour12 > 12 {
+			return time.Time{}, fmt.Errorf("12-hour hour %d is out of range", hour12)
+		}
+		hour = hour12 % 12
+		if pm {
+			hour += 12
+		}
+	}
+	switch {
+	case month < 1 || month > 12:
+		return time.Time{}, fmt.Errorf("month %d is out of range", month)
+	case hour > 23:
+		return time.Time{}, fmt.Errorf("hour %d is out of range", hour)
+	case minute > 59:
+		return time.Time{}, fmt.Errorf("minute %d is out of range", minute)
+	case second > 59:
+		return time.Time{}, fmt.Errorf("second %d is out of range", second)
+	}
+
+	switch {
+	case hasOffset && offset != 0:
+		loc = time.FixedZone("", offset)
+	case hasOffset || loc == nil:
+		if abbrev != "" {
+			return time.Time{}, fmt.Errorf("can't parse the time zone abbreviation %q without a time zone argument", abbrev)
+		}
+		loc = time.UTC
+	}
+	wall := time.Date(year, time.Month(month), day, hour, minute, second, 0, time.UTC)
+	if day < 1 || wall.Day() != day {
+		return time.Time{}, fmt.Errorf("day %d is out of range for %s %04d", day, time.Month(month), year)
+	}
+	t := time.Date(year, time.Month(month), day, hour, minute, second, 0, loc)
+	if !hasOffset {
+		var err error
+		t, err = wallClockTime(wall, loc, abbrev)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+	if weekday >= 0 && t.Weekday() != time.Weekday(weekday) {
+		return time.Time{}, fmt.Errorf("%d %s %04d was a %s, not a %s", day, time.Month(month), year, t.Weekday(), time.Weekday(weekday))
+	}
+	return t, nil
+}
+
+// takeTimeNumber takes a decimal number of between minDigits and maxDigits
+// digits from the start of s.
+func takeTimeNumber(s string, minDigits, maxDigits int, what string) (int, string, error) {
+	n := 0
+	for n < maxDigits && n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+	if n < minDigits {
+		return 0, s, timeMismatchError(s, what)
+	}
+	v, _ := strconv.Atoi(s[:n])
+	return v, s[n:], nil
+}
+
+// takeTimeName takes one of count names returned by name from the start of
+// s, ignoring case, and returns its index. If short is set then it expects
+// the first three letters of the name instead.
+func takeTimeName(s string, short bool, what string, name func(int) string, count int) (int, string, error) {
+	for i := range count {
+		n := name(i)
+		if short {
+			n = n[:3]
+		}
+		if len(s) >= len(n) && strings.EqualFold(s[:len(n)], n) {
+			return i, s[len(n):], nil
+		}
+	}
+	return 0, s, timeMismatchError(s, what)
+}
+
+// takeTimeOffset takes a UTC offset like "+0100", or like "+01:00" if colon
+// is set, from the start of s, and returns it in seconds east of UTC.
+func takeTimeOffset(s string, colon bool) (int, string, error) {
+	const what = "a UTC offset"
+	if s == "" || (s[0] != '+' && s[0] != '-') {
+		return 0, s, timeMismatchError(s, what)
+	}
+	rest := s[1:]
+	hours, rest, err := takeTimeNumber(rest, 2, 2, what)
+	if err != nil {
+		return 0, s, timeMismatchError(s, what)
+	}
+	if colon {
+		if !strings.HasPrefix(rest, ":") {
+			return 0, s, timeMismatchError(s, what)
+		}
+		rest = rest[1:]
+	}
+	minutes, rest, err := takeTimeNumber(rest, 2, 2, what)
+	if err != nil || hours > 23 || minutes > 59 {
+		return 0, s, timeMismatchError(s, what)
+	}
+	offset := hours*3600 + minutes*60
+	if s[0] == '-' {
+		offset = -offset
+	}
+	return offset, rest, nil
+}
+
+// takeTimeZoneOffset takes a UTC offset like "+0100" or "+01" from the start
+// of s, and returns it in seconds east of UTC. The short form is what
+// formatTime writes for the "ZZZ" verb in time zones without an abbreviation.
+func takeTimeZoneOffset(s string) (int, string, error) {
+	if offset, rest, err := takeTimeOffset(s, false); err == nil {
+		return offset, rest, nil
+	}
+	if len(s) < 3 || (s[0] != '+' && s[0] != '-') {
+		return 0, s, timeMismatchError(s, "a UTC offset")
+	}
+	hours, rest, err := takeTimeNumber(s[1:], 2, 2, "a UTC offset")
+	if err != nil || hours > 23 {
+		return 0, s, timeMismatchError(s, "a UTC offset")
+	}
+	offset := hours * 3600
+	if s[0] == '-' {
+		offset = -offset
+	}
+	return offset, rest, nil
+}
+
+// timeZoneAbbrevLen returns the length of the time zone abbreviation, like
+// "CET", at the start of s, or zero if there isn't one.
+func timeZoneAbbrevLen(s string) int {
+	n := 0
+	for n < len(s) && s[n] >= 'A' && s[n] <= 'Z' {
+		n++
+	}
+	return n
+}
+
+// wallClockTime returns the time at which the clocks in loc show the
+// wall-clock time that wall has in UTC. If abbrev isn't empty then the time
+// zone abbreviation in effect must match it.
+//
+// Around the start of daylight saving time a wall-clock time might not exist,
+// which is an error. Around its end a wall-clock time can happen twice, in
+// which case abbrev can choose between them, and otherwise we return the
+// earlier one.
+func wallClockTime(wall time.Time, loc *time.Location, abbrev string) (time.Time, error) {
+	// No time zone is more than a day away from UTC, so the offsets in
+	// effect a day either side of the wall-clock time include all of those
+	// that it might be in.
+	var found, abbrevFound bool
+	var ret time.Time
+	for _, probe := range []time.Time{wall.Add(-24 * time.Hour), wall, wall.Add(24 * time.Hour)} {
+		_, offset := probe.In(loc).Zone()
+		c := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		if !sameWallClock(c, wall) {
+			continue
+		}
+		found = true
+		if abbrev != "" {
+			if name, _ := c.Zone(); name != abbrev {
+				continue
+			}
+			abbrevFound = true
+		}
+		if ret.IsZero() || c.Before(ret) {
+			ret = c
+		}
+	}
+	switch {
+	case !found:
+		return time.Time{}, fmt.Errorf("%s on %d %s %04d doesn't exist in time zone %s", wall.Format("15:04:05"), wall.Day(), wall.Month(), wall.Year(), loc)
+	case abbrev != "" && !abbrevFound:
+		return time.Time{}, fmt.Errorf("time zone %s doesn't use the abbreviation %q at %s on %d %s %04d", loc, abbrev, wall.Format("15:04:05"), wall.Day(), wall.Month(), wall.Year())
+	}
+	return ret, nil
+}
+
+func sameWallClock(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd && a.Hour() == b.Hour() && a.Minute() == b.Minute() && a.Second() == b.Second()
+}
+
+func timeMismatchError(rest, what string) error {
+	if rest == "" {
+		return fmt.Errorf("end of string where %s is expected", what)
+	}
+	return fmt.Errorf("found %q where %s is expected", rest, what)
+}
+
+// FormatTime formats a timestamp as it appears in the given IANA time zone,
+// using the same format syntax as formatdate.
+func FormatTime(format, timestamp, timezone cty.Value) (cty.Value, error) {
+	return FormatTimeFunc.Call([]cty.Value{format, timestamp, timezone})
+}
+
+// ParseTime parses a string using the same format syntax as formatdate,
+// returning an RFC 3339 timestamp. The optional timezone is the IANA time
+// zone of a string without a UTC offset.
+func ParseTime(format, str cty.Value, timezone ...cty.Value) (cty.Value, error) {
+	args := make([]cty.Value, len(timezone)+2)
+	args[0] = format
+	args[1] = str
+	copy(args[2:], timezone)
+	return ParseTimeFunc.Call(args)
+}
+
+// TimeDiff returns the duration from timestampB to timestampA, which is
+// negative if timestampA is earlier than timestampB.
+func TimeDiff(timestampA, timestampB cty.Value) (cty.Value, error) {
+	return TimeDiffFunc.Call([]cty.Value{timestampA, timestampB})
+}
+
+// Weekday returns the name of the day of the week of a timestamp.
+func Weekday(timestamp cty.Value) (cty.Value, error) {
+	return WeekdayFunc.Call([]cty.Value{timestamp})
+}
@@ -0,0 +1,471 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestFormatTime(t *testing.T) {
+	tests := []struct {
+		Format, Timestamp, TimeZone cty.Value
+		Want                        cty.Value
+		Err                         string
+	}{
+		{
+			cty.StringVal("YYYY-MM-DD hh:mm ZZZ"),
+			cty.StringVal("2024-01-15T09:30:00Z"),
+			cty.StringVal("Europe/Berlin"),
+			cty.StringVal("2024-01-15 10:30 CET"),
+			``,
+		},
+		{
+			// Daylight saving time applies in the summer.
+			cty.StringVal("YYYY-MM-DD hh:mm ZZZ"),
+			cty.StringVal("2024-07-15T09:30:00Z"),
+			cty.StringVal("Europe/Berlin"),
+			cty.StringVal("2024-07-15 11:30 CEST"),
+			``,
+		},
+		{
+			cty.StringVal("EEEE, D MMMM YYYY 'at' H:mmaa ZZZZZ"),
+			cty.StringVal("2024-03-10T12:00:00+05:00"),
+			cty.StringVal("America/New_York"),
+			cty.StringVal("Sunday, 10 March 2024 at 3:00am -04:00"),
+			``,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD'T'hh:mm:ssZ"),
+			cty.StringVal("2024-01-15T09:30:00+01:00"),
+			cty.StringVal("UTC"),
+			cty.StringVal("2024-01-15T08:30:00Z"),
+			``,
+		},
+		{
+			cty.StringVal("hh:mm"),
+			cty.StringVal("2024-01-15T09:30:00Z"),
+			cty.UnknownVal(cty.String),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			``,
+		},
+		{
+			cty.StringVal("hh:mm"),
+			cty.StringVal("2024-01-15T09:30:00Z"),
+			cty.StringVal("Mars/Olympus_Mons"),
+			cty.NilVal,
+			`unknown time zone "Mars/Olympus_Mons": must be the name of a time zone from the IANA time zone database, such as "UTC" or "Europe/Berlin"`,
+		},
+		{
+			cty.StringVal("hh:mm"),
+			cty.StringVal("2024-01-15T09:30:00Z"),
+			cty.StringVal("Local"),
+			cty.NilVal,
+			`must be the name of a time zone from the IANA time zone database, such as "UTC" or "Europe/Berlin"`,
+		},
+		{
+			cty.StringVal("hh:mm"),
+			cty.StringVal("2024-01-15"),
+			cty.StringVal("UTC"),
+			cty.NilVal,
+			`not a valid RFC3339 timestamp: missing required time introducer 'T'`,
+		},
+		{
+			cty.StringVal("YYY"),
+			cty.StringVal("2024-01-15T09:30:00Z"),
+			cty.StringVal("UTC"),
+			cty.NilVal,
+			`invalid date format verb "YYY"`,
+		},
+		{
+			cty.StringVal("'unterminated"),
+			cty.StringVal("2024-01-15T09:30:00Z"),
+			cty.StringVal("UTC"),
+			cty.NilVal,
+			`unterminated literal '`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("formattime(%#v, %#v, %#v)", test.Format, test.Timestamp, test.TimeZone), func(t *testing.T) {
+			got, err := FormatTime(test.Format, test.Timestamp, test.TimeZone)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); got != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		Format, Time cty.Value
+		Want         cty.Value
+		Err          string
+	}{
+		{
+			cty.StringVal("YYYY-MM-DD hh:mm"),
+			cty.StringVal("2024-01-15 09:30"),
+			cty.StringVal("2024-01-15T09:30:00Z"),
+			``,
+		},
+		{
+			cty.StringVal("DD/MM/YY HH:mm:ss AA ZZZZZ"),
+			cty.StringVal("15/01/24 09:30:15 PM +01:00"),
+			cty.StringVal("2024-01-15T21:30:15+01:00"),
+			``,
+		},
+		{
+			cty.StringVal("EEE, D MMM YYYY h:m ZZZZ"),
+			cty.StringVal("mon, 5 feb 2024 7:05 -0430"),
+			cty.StringVal("2024-02-05T07:05:00-04:30"),
+			``,
+		},
+		{
+			cty.StringVal("MMMM D, YYYY 'at' HHaa ZZZ"),
+			cty.StringVal("December 1, 1999 at 12am UTC"),
+			cty.StringVal("1999-12-01T00:00:00Z"),
+			``,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD'T'hh:mm:ssZ"),
+			cty.StringVal("2024-01-15T09:30:00Z"),
+			cty.StringVal("2024-01-15T09:30:00Z"),
+			``,
+		},
+		{
+			cty.StringVal("hh:mm"),
+			cty.StringVal("23:59"),
+			cty.StringVal("0000-01-01T23:59:00Z"),
+			``,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD"),
+			cty.UnknownVal(cty.String),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			``,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD"),
+			cty.StringVal("2024-1-15"),
+			cty.NilVal,
+			`found "1-15" where a two-digit month is expected`,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD"),
+			cty.StringVal("2024-01"),
+			cty.NilVal,
+			`end of string where "-" is expected`,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD"),
+			cty.StringVal("2024-01-15 09:30"),
+			cty.NilVal,
+			`unexpected " 09:30" after the end of the format`,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD"),
+			cty.StringVal("2023-02-29"),
+			cty.NilVal,
+			`day 29 is out of range for February 2023`,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD hh:mm"),
+			cty.StringVal("2024-01-15 24:00"),
+			cty.NilVal,
+			`hour 24 is out of range`,
+		},
+		{
+			cty.StringVal("EEEE YYYY-MM-DD"),
+			cty.StringVal("Tuesday 2024-01-15"),
+			cty.NilVal,
+			`15 January 2024 was a Monday, not a Tuesday`,
+		},
+		{
+			cty.StringVal("hh:mm ZZZ"),
+			cty.StringVal("09:30 CET"),
+			cty.NilVal,
+			`can't parse the time zone abbreviation "CET" without a time zone argument`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("parsetime(%#v, %#v)", test.Format, test.Time), func(t *testing.T) {
+			got, err := ParseTime(test.Format, test.Time)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); got != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestParseTime_timeZone(t *testing.T) {
+	tests := []struct {
+		Format, Time, TimeZone cty.Value
+		Want                   cty.Value
+		Err                    string
+	}{
+		{
+			cty.StringVal("YYYY-MM-DD hh:mm"),
+			cty.StringVal("2024-01-15 09:30"),
+			cty.StringVal("Europe/Berlin"),
+			cty.StringVal("2024-01-15T09:30:00+01:00"),
+			``,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD hh:mm"),
+			cty.StringVal("2024-07-15 09:30"),
+			cty.StringVal("Europe/Berlin"),
+			cty.StringVal("2024-07-15T09:30:00+02:00"),
+			``,
+		},
+		{
+			// An offset in the string takes precedence over the time zone.
+			cty.StringVal("YYYY-MM-DD hh:mm ZZZZZ"),
+			cty.StringVal("2024-01-15 09:30 -05:00"),
+			cty.StringVal("Europe/Berlin"),
+			cty.StringVal("2024-01-15T09:30:00-05:00"),
+			``,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD hh:mm ZZZ"),
+			cty.StringVal("2024-01-15 09:30 CET"),
+			cty.StringVal("Europe/Berlin"),
+			cty.StringVal("2024-01-15T09:30:00+01:00"),
+			``,
+		},
+		{
+			// The abbreviation chooses between the two times that the
+			// clocks show 02:30 when daylight saving time ends.
+			cty.StringVal("YYYY-MM-DD hh:mm ZZZ"),
+			cty.StringVal("2024-10-27 02:30 CET"),
+			cty.StringVal("Europe/Berlin"),
+			cty.StringVal("2024-10-27T02:30:00+01:00"),
+			``,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD hh:mm ZZZ"),
+			cty.StringVal("2024-10-27 02:30 CEST"),
+			cty.StringVal("Europe/Berlin"),
+			cty.StringVal("2024-10-27T02:30:00+02:00"),
+			``,
+		},
+		{
+			// Without an abbreviation, the earlier of the two is used.
+			cty.StringVal("YYYY-MM-DD hh:mm"),
+			cty.StringVal("2024-10-27 02:30"),
+			cty.StringVal("Europe/Berlin"),
+			cty.StringVal("2024-10-27T02:30:00+02:00"),
+			``,
+		},
+		{
+			// Time zones without an abbreviation use a short UTC offset.
+			cty.StringVal("YYYY-MM-DD hh:mm ZZZ"),
+			cty.StringVal("2024-01-15 09:30 +04"),
+			cty.StringVal("Asia/Dubai"),
+			cty.StringVal("2024-01-15T09:30:00+04:00"),
+			``,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD hh:mm ZZZ"),
+			cty.StringVal("2024-01-15 09:30 CEST"),
+			cty.StringVal("Europe/Berlin"),
+			cty.NilVal,
+			`time zone Europe/Berlin doesn't use the abbreviation "CEST" at 09:30:00 on 15 January 2024`,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD hh:mm"),
+			cty.StringVal("2024-03-31 02:30"),
+			cty.StringVal("Europe/Berlin"),
+			cty.NilVal,
+			`02:30:00 on 31 March 2024 doesn't exist in time zone Europe/Berlin`,
+		},
+		{
+			cty.StringVal("YYYY-MM-DD hh:mm"),
+			cty.StringVal("2024-01-15 09:30"),
+			cty.StringVal("Mars/Olympus_Mons"),
+			cty.NilVal,
+			`unknown time zone "Mars/Olympus_Mons": must be the name of a time zone from the IANA time zone database, such as "UTC" or "Europe/Berlin"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("parsetime(%#v, %#v, %#v)", test.Format, test.Time, test.TimeZone), func(t *testing.T) {
+			got, err := ParseTime(test.Format, test.Time, test.TimeZone)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); got != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+// TestParseTime_formatTime checks that parsetime reads back what formattime
+// writes for the same time zone.
+func TestParseTime_formatTime(t *testing.T) {
+	format := cty.StringVal("EEE, D MMM YYYY hh:mm:ss ZZZ")
+	for _, tz := range []string{"UTC", "Europe/Berlin", "America/New_York", "Asia/Dubai", "Asia/Kolkata"} {
+		for _, ts := range []string{"2024-01-15T09:30:00Z", "2024-07-15T09:30:00Z", "2024-10-27T00:30:00Z", "2024-10-27T01:30:00Z"} {
+			t.Run(fmt.Sprintf("%s in %s", ts, tz), func(t *testing.T) {
+				formatted, err := FormatTime(format, cty.StringVal(ts), cty.StringVal(tz))
+				if err != nil {
+					t.Fatalf("unexpected error from formattime: %s", err)
+				}
+				got, err := ParseTime(format, formatted, cty.StringVal(tz))
+				if err != nil {
+					t.Fatalf("unexpected error parsing %#v: %s", formatted, err)
+				}
+				diff, err := TimeDiff(got, cty.StringVal(ts))
+				if err != nil {
+					t.Fatalf("unexpected error from timediff: %s", err)
+				}
+				if diff.AsString() != "0s" {
+					t.Errorf("parsed %#v as %#v, which is %s after %s", formatted, got, diff.AsString(), ts)
+				}
+			})
+		}
+	}
+}
+
+func TestTimeDiff(t *testing.T) {
+	tests := []struct {
+		A, B cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.StringVal("2024-01-15T10:30:00Z"),
+			cty.StringVal("2024-01-15T09:00:00Z"),
+			cty.StringVal("1h30m0s"),
+			``,
+		},
+		{
+			// The UTC offsets are taken into account.
+			cty.StringVal("2024-01-15T10:00:00+01:00"),
+			cty.StringVal("2024-01-15T09:00:00Z"),
+			cty.StringVal("0s"),
+			``,
+		},
+		{
+			cty.StringVal("2024-01-14T00:00:00Z"),
+			cty.StringVal("2024-01-15T00:00:00Z"),
+			cty.StringVal("-24h0m0s"),
+			``,
+		},
+		{
+			cty.StringVal("2024-01-15"),
+			cty.StringVal("2024-01-15T09:00:00Z"),
+			cty.NilVal,
+			`not a valid RFC3339 timestamp: missing required time introducer 'T'`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("timediff(%#v, %#v)", test.A, test.B), func(t *testing.T) {
+			got, err := TimeDiff(test.A, test.B)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); got != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestWeekday(t *testing.T) {
+	tests := []struct {
+		Timestamp cty.Value
+		Want      cty.Value
+		Err       string
+	}{
+		{
+			cty.StringVal("2024-01-15T09:00:00Z"),
+			cty.StringVal("Monday"),
+			``,
+		},
+		{
+			// The day of the week is in the timestamp's own UTC offset.
+			cty.StringVal("2024-01-15T01:00:00+02:00"),
+			cty.StringVal("Monday"),
+			``,
+		},
+		{
+			cty.StringVal("Monday"),
+			cty.NilVal,
+			`not a valid RFC3339 timestamp: cannot use "Monday" as year`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("weekday(%#v)", test.Timestamp), func(t *testing.T) {
+			got, err := Weekday(test.Timestamp)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got := err.Error(); got != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
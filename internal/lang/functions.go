@@ -7,6 +7,7 @@ package lang
 
 import (
 	"fmt"
+	"maps"
 
 	"github.com/hashicorp/hcl/v2/ext/tryfunc"
 	ctyyaml "github.com/zclconf/go-cty-yaml"
@@ -61,12 +62,35 @@ func (s *Scope) Functions() map[string]function.Function {
 		for _, name := range coreNames {
 			s.funcs[addrs.ParseFunction(name).FullyQualified().String()] = s.funcs[name]
 		}
+
+		for pluginName, pluginFuncs := range s.PluginFunctions {
+			for name, f := range pluginFuncs {
+				s.funcs[PluginFunctionName(pluginName, name)] = f
+			}
+		}
+
+		// User-defined functions go in the module:: namespace, and can call
+		// all of the functions above.
+		if len(s.UserFunctions) > 0 {
+			table := newUserFunctionTable(maps.Clone(s.funcs))
+			maps.Copy(s.funcs, table.Functions(s.UserFunctions))
+		}
 	}
 	s.funcsLock.Unlock()
 
 	return s.funcs
 }
 
+// PluginFunctionName returns the name that expressions use to call the
+// function with the given name from the function plugin with the given name,
+// such as "plugin::name::function".
+func PluginFunctionName(plugin, name string) string {
+	return addrs.Function{
+		Namespaces: []string{addrs.FunctionNamespacePlugin, plugin},
+		Name:       name,
+	}.String()
+}
+
 // experimentalFunction checks whether the given experiment is enabled for
 // the receiving scope. If so, it will return the given function verbatim.
 // If not, it will return a placeholder function that just returns an
@@ -129,8 +153,12 @@ func makeBaseFunctionTable(baseDir string) map[string]function.Function {
 		"ceil":             stdlib.CeilFunc,
 		"chomp":            stdlib.ChompFunc,
 		"cidrcontains":     funcs.CidrContainsFunc,
+		"cidrexclude":      funcs.CidrExcludeFunc,
 		"cidrhost":         funcs.CidrHostFunc,
+		"cidrhosts":        funcs.CidrHostsFunc,
+		"cidrmerge":        funcs.CidrMergeFunc,
 		"cidrnetmask":      funcs.CidrNetmaskFunc,
+		"cidroverlap":      funcs.CidrOverlapFunc,
 		"cidrsubnet":       funcs.CidrSubnetFunc,
 		"cidrsubnets":      funcs.CidrSubnetsFunc,
 		"coalesce":         funcs.CoalesceFunc,
@@ -138,6 +166,7 @@ func makeBaseFunctionTable(baseDir string) map[string]function.Function {
 		"compact":          stdlib.CompactFunc,
 		"concat":           stdlib.ConcatFunc,
 		"contains":         stdlib.ContainsFunc,
+		"cronnext":         funcs.CronNextFunc,
 		"csvdecode":        stdlib.CSVDecodeFunc,
 		"dirname":          funcs.DirnameFunc,
 		"distinct":         stdlib.DistinctFunc,
@@ -160,8 +189,13 @@ func makeBaseFunctionTable(baseDir string) map[string]function.Function {
 		"format":           stdlib.FormatFunc,
 		"formatdate":       stdlib.FormatDateFunc,
 		"formatlist":       stdlib.FormatListFunc,
+		"formattime":       funcs.FormatTimeFunc,
+		"hcldecode":        funcs.HCLDecodeFunc,
 		"indent":           stdlib.IndentFunc,
 		"index":            funcs.IndexFunc, // stdlib.IndexFunc is not compatible
+		"inidecode":        funcs.INIDecodeFunc,
+		"ipv6delegate":     funcs.IPv6DelegateFunc,
+		"ipv6eui64":        funcs.IPv6EUI64Func,
 		"join":             stdlib.JoinFunc,
 		"jsondecode":       stdlib.JSONDecodeFunc,
 		"jsonencode":       stdlib.JSONEncodeFunc,
@@ -179,8 +213,10 @@ func makeBaseFunctionTable(baseDir string) map[string]function.Function {
 		"min":              stdlib.MinFunc,
 		"one":              funcs.OneFunc,
 		"parseint":         stdlib.ParseIntFunc,
+		"parsetime":        funcs.ParseTimeFunc,
 		"pathexpand":       funcs.PathExpandFunc,
 		"pow":              stdlib.PowFunc,
+		"query":            funcs.QueryFunc,
 		"range":            stdlib.RangeFunc,
 		"regex":            stdlib.RegexFunc,
 		"regexall":         stdlib.RegexAllFunc,
@@ -190,6 +226,10 @@ func makeBaseFunctionTable(baseDir string) map[string]function.Function {
 		"sensitive":        funcs.SensitiveFunc,
 		"nonsensitive":     funcs.NonsensitiveFunc,
 		"issensitive":      funcs.IsSensitiveFunc,
+		"semvercompare":    funcs.SemverCompareFunc,
+		"semvermatch":      funcs.SemverMatchFunc,
+		"semvermax":        funcs.SemverMaxFunc,
+		"semverparse":      funcs.SemverParseFunc,
 		"setintersection":  stdlib.SetIntersectionFunc,
 		"setproduct":       stdlib.SetProductFunc,
 		"setsubtract":      stdlib.SetSubtractFunc,
@@ -211,6 +251,7 @@ func makeBaseFunctionTable(baseDir string) map[string]function.Function {
 		"timestamp":        funcs.TimestampFunc,
 		"timeadd":          stdlib.TimeAddFunc,
 		"timecmp":          funcs.TimeCmpFunc,
+		"timediff":         funcs.TimeDiffFunc,
 		"title":            stdlib.TitleFunc,
 		"tostring":         funcs.MakeToFunc(cty.String),
 		"tonumber":         funcs.MakeToFunc(cty.Number),
@@ -218,6 +259,8 @@ func makeBaseFunctionTable(baseDir string) map[string]function.Function {
 		"toset":            funcs.MakeToFunc(cty.Set(cty.DynamicPseudoType)),
 		"tolist":           funcs.MakeToFunc(cty.List(cty.DynamicPseudoType)),
 		"tomap":            funcs.MakeToFunc(cty.Map(cty.DynamicPseudoType)),
+		"tomldecode":       funcs.TOMLDecodeFunc,
+		"tomlencode":       funcs.TOMLEncodeFunc,
 		"transpose":        funcs.TransposeFunc,
 		"trim":             stdlib.TrimFunc,
 		"trimprefix":       stdlib.TrimPrefixFunc,
@@ -230,6 +273,8 @@ func makeBaseFunctionTable(baseDir string) map[string]function.Function {
 		"uuid":             funcs.UUIDFunc,
 		"uuidv5":           funcs.UUIDV5Func,
 		"values":           stdlib.ValuesFunc,
+		"weekday":          funcs.WeekdayFunc,
+		"xmldecode":        funcs.XMLDecodeFunc,
 		"yamldecode":       ctyyaml.YAMLDecodeFunc,
 		"yamlencode":       ctyyaml.YAMLEncodeFunc,
 		"zipmap":           stdlib.ZipmapFunc,
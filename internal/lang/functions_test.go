@@ -17,6 +17,7 @@ import (
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
 
 	"github.com/opentofu/opentofu/internal/experiments"
 	"github.com/opentofu/opentofu/internal/lang/marks"
@@ -199,6 +200,16 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"cidrexclude": {
+			{
+				`cidrexclude("10.0.0.0/22", ["10.0.1.0/24"])`,
+				cty.ListVal([]cty.Value{
+					cty.StringVal("10.0.0.0/24"),
+					cty.StringVal("10.0.2.0/23"),
+				}),
+			},
+		},
+
 		"cidrhost": {
 			{
 				`cidrhost("192.168.1.0/24", 5)`,
@@ -206,6 +217,22 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"cidrhosts": {
+			{
+				`cidrhosts("192.168.1.0/24").last`,
+				cty.StringVal("192.168.1.254"),
+			},
+		},
+
+		"cidrmerge": {
+			{
+				`cidrmerge(["10.0.1.0/24", "10.0.0.0/24"])`,
+				cty.ListVal([]cty.Value{
+					cty.StringVal("10.0.0.0/23"),
+				}),
+			},
+		},
+
 		"cidrnetmask": {
 			{
 				`cidrnetmask("192.168.1.0/24")`,
@@ -213,6 +240,13 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"cidroverlap": {
+			{
+				`cidroverlap("10.0.0.0/16", "10.0.1.0/24")`,
+				cty.True,
+			},
+		},
+
 		"cidrsubnet": {
 			{
 				`cidrsubnet("192.168.2.0/20", 4, 6)`,
@@ -295,6 +329,13 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"cronnext": {
+			{
+				`cronnext("CRON_TZ=Europe/Berlin 30 2 * * SUN", "2024-01-15T00:00:00Z")`,
+				cty.StringVal("2024-01-21T02:30:00+01:00"),
+			},
+		},
+
 		"csvdecode": {
 			{
 				`csvdecode("a,b,c\n1,2,3\n4,5,6")`,
@@ -515,6 +556,13 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"formattime": {
+			{
+				`formattime("YYYY-MM-DD hh:mm ZZZ", "2024-01-15T09:30:00Z", "Europe/Berlin")`,
+				cty.StringVal("2024-01-15 10:30 CET"),
+			},
+		},
+
 		"formatdate": {
 			{
 				`formatdate("DD MMM YYYY hh:mm ZZZ", "2018-01-04T23:12:01Z")`,
@@ -522,6 +570,16 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"hcldecode": {
+			{
+				`hcldecode("name = \"web\"\nports = [80, 443]\n")`,
+				cty.ObjectVal(map[string]cty.Value{
+					"name":  cty.StringVal("web"),
+					"ports": cty.TupleVal([]cty.Value{cty.NumberIntVal(80), cty.NumberIntVal(443)}),
+				}),
+			},
+		},
+
 		"indent": {
 			{
 				fmt.Sprintf("indent(4, %#v)", Poem),
@@ -536,6 +594,31 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"inidecode": {
+			{
+				`inidecode("[server]\nport = 8080\n")`,
+				cty.MapVal(map[string]cty.Value{
+					"server": cty.MapVal(map[string]cty.Value{
+						"port": cty.StringVal("8080"),
+					}),
+				}),
+			},
+		},
+
+		"ipv6delegate": {
+			{
+				`ipv6delegate("2001:db8::/48", 56, 1)`,
+				cty.StringVal("2001:db8:0:100::/56"),
+			},
+		},
+
+		"ipv6eui64": {
+			{
+				`ipv6eui64("fe80::/64", "00:1a:2b:3c:4d:5e")`,
+				cty.StringVal("fe80::21a:2bff:fe3c:4d5e"),
+			},
+		},
+
 		"issensitive": {
 			{
 				`issensitive(1)`,
@@ -701,6 +784,17 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"parsetime": {
+			{
+				`parsetime("DD/MM/YYYY hh:mm", "15/01/2024 09:30")`,
+				cty.StringVal("2024-01-15T09:30:00Z"),
+			},
+			{
+				`parsetime("DD/MM/YYYY hh:mm", "15/01/2024 09:30", "Europe/Berlin")`,
+				cty.StringVal("2024-01-15T09:30:00+01:00"),
+			},
+		},
+
 		"pathexpand": {
 			{
 				`pathexpand("~/test-file")`,
@@ -722,6 +816,17 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"query": {
+			{
+				`query({items = [{name = "a", ready = true}, {name = "b", ready = false}]}, "items[?ready].name")`,
+				cty.TupleVal([]cty.Value{cty.StringVal("a")}),
+			},
+			{
+				`query({spec = {containers = [{image = "nginx"}]}}, "spec.containers[0].image")`,
+				cty.StringVal("nginx"),
+			},
+		},
+
 		"range": {
 			{
 				`range(3)`,
@@ -788,6 +893,40 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"semvercompare": {
+			{
+				`semvercompare("1.29.0", "1.9.2")`,
+				cty.NumberIntVal(1),
+			},
+		},
+
+		"semvermatch": {
+			{
+				`semvermatch("1.29", ">= 1.28, < 2.0")`,
+				cty.True,
+			},
+		},
+
+		"semvermax": {
+			{
+				`semvermax(["1.9.2", "1.29.0", "1.28.4"])`,
+				cty.StringVal("1.29.0"),
+			},
+		},
+
+		"semverparse": {
+			{
+				`semverparse("v1.29.2-rc.1+build.5")`,
+				cty.ObjectVal(map[string]cty.Value{
+					"major":      cty.NumberIntVal(1),
+					"minor":      cty.NumberIntVal(29),
+					"patch":      cty.NumberIntVal(2),
+					"prerelease": cty.StringVal("rc.1"),
+					"build":      cty.StringVal("build.5"),
+				}),
+			},
+		},
+
 		"sensitive": {
 			{
 				`sensitive(1)`,
@@ -1018,6 +1157,13 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"timediff": {
+			{
+				`timediff("2017-11-22T01:30:00Z", "2017-11-22T00:00:00Z")`,
+				cty.StringVal("1h30m0s"),
+			},
+		},
+
 		"title": {
 			{
 				`title("hello")`,
@@ -1051,6 +1197,25 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"tomldecode": {
+			{
+				`tomldecode("name = \"web\"\n[server]\nport = 8080\n")`,
+				cty.ObjectVal(map[string]cty.Value{
+					"name": cty.StringVal("web"),
+					"server": cty.ObjectVal(map[string]cty.Value{
+						"port": cty.NumberIntVal(8080),
+					}),
+				}),
+			},
+		},
+
+		"tomlencode": {
+			{
+				`tomlencode({name = "web", server = {port = 8080}})`,
+				cty.StringVal("name = \"web\"\n\n[server]\nport = 8080\n"),
+			},
+		},
+
 		"tonumber": {
 			{
 				`tonumber("42")`,
@@ -1190,6 +1355,29 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"weekday": {
+			{
+				`weekday("2024-01-15T09:30:00Z")`,
+				cty.StringVal("Monday"),
+			},
+		},
+
+		"xmldecode": {
+			{
+				`xmldecode("<server port=\"8080\"><name>web</name></server>")`,
+				cty.ObjectVal(map[string]cty.Value{
+					"server": cty.ObjectVal(map[string]cty.Value{
+						"@port": cty.StringVal("8080"),
+						"name": cty.TupleVal([]cty.Value{
+							cty.ObjectVal(map[string]cty.Value{
+								"#text": cty.StringVal("web"),
+							}),
+						}),
+					}),
+				}),
+			},
+		},
+
 		"yamldecode": {
 			{
 				`yamldecode("true")`,
@@ -1362,6 +1550,33 @@ func TestFunctionsPrefixedCorrectly(t *testing.T) {
 	}
 }
 
+func TestScopePluginFunctions(t *testing.T) {
+	greet := function.New(&function.Spec{
+		Params: []function.Parameter{{Name: "name", Type: cty.String}},
+		Type:   function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return cty.StringVal("Hello, " + args[0].AsString() + "!"), nil
+		},
+	})
+	s := &Scope{
+		PluginFunctions: map[string]map[string]function.Function{
+			"example": {"greet": greet},
+		},
+	}
+
+	expr, diags := hclsyntax.ParseExpression([]byte(`plugin::example::greet("world")`), "test.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	got, moreDiags := s.EvalExpr(t.Context(), expr, cty.String)
+	if moreDiags.HasErrors() {
+		t.Fatal(moreDiags.Err())
+	}
+	if want := cty.StringVal("Hello, world!"); !got.RawEquals(want) {
+		t.Errorf("wrong result %#v; want %#v", got, want)
+	}
+}
+
 const (
 	CipherBase64 = "eczGaDhXDbOFRZGhjx2etVzWbRqWDlmq0bvNt284JHVbwCgObiuyX9uV0LSAMY707IEgMkExJqXmsB4OWKxvB7epRB9G/3+F+pcrQpODlDuL9oDUAsa65zEpYF0Wbn7Oh7nrMQncyUPpyr9WUlALl0gRWytOA23S+y5joa4M34KFpawFgoqTu/2EEH4Xl1zo+0fy73fEto+nfkUY+meuyGZ1nUx/+DljP7ZqxHBFSlLODmtuTMdswUbHbXbWneW51D7Jm7xB8nSdiA2JQNK5+Sg5x8aNfgvFTt/m2w2+qpsyFa5Wjeu6fZmXSl840CA07aXbk9vN4I81WmJyblD/ZA=="
 	PrivateKey   = `
@@ -231,9 +231,7 @@ func (a *Analyzer) metaReferencesResourceInstance(moduleAddr addrs.ModuleInstanc
 		// narrow down where we're searching.
 		bodies = newBodies
 		exprs = append(exprs, newExprs...)
-		steppingThrough = nil
-		steppingThroughType = ""
-		// Caller must also update "schema" if necessary.
+		// Caller must also update "schema" and "steppingThrough" if necessary.
 	}
 	traverseInBlock := func(name string) ([]hcl.Body, []hcl.Expression) {
 		if attr := schema.Block.Attributes[name]; attr != nil {
@@ -254,6 +252,7 @@ func (a *Analyzer) metaReferencesResourceInstance(moduleAddr addrs.ModuleInstanc
 				return traverseNestedBlockSingle(bodies, name)
 			case configschema.NestingMap, configschema.NestingList, configschema.NestingSet:
 				steppingThrough = blockType
+				steppingThroughType = name
 				return bodies, exprs // Preserve current selections for the second step
 			default:
 				// The above should be exhaustive, but just in case
@@ -302,6 +301,7 @@ Steps:
 				}
 				nextStep(traverseNestedBlockMap(bodies, steppingThroughType, step.Name))
 				schema.Block = &steppingThrough.Block
+				steppingThrough = nil
 			default:
 				nextStep(traverseInBlock(step.Name))
 				if schema == nil {
@@ -323,6 +323,7 @@ Steps:
 					}
 					nextStep(traverseNestedBlockMap(bodies, steppingThroughType, keyVal.AsString()))
 					schema.Block = &steppingThrough.Block
+					steppingThrough = nil
 				case configschema.NestingList:
 					idxVal, err := convert.Convert(step.Key, cty.Number)
 					if err != nil { // Invalid traversal, so can't have any refs
@@ -337,6 +338,7 @@ Steps:
 					}
 					nextStep(traverseNestedBlockList(bodies, steppingThroughType, idx))
 					schema.Block = &steppingThrough.Block
+					steppingThrough = nil
 				default:
 					// Note that NestingSet ends up in here because we don't
 					// actually allow traversing into set-backed block types,
@@ -380,9 +382,13 @@ Steps:
 			labelNames = []string{"key"}
 		}
 		blocks := findBlocksInBodies(bodies, steppingThroughType, labelNames)
+		bodies = nil
 		for _, block := range blocks {
-			bodies, exprs = blockParts(block)
+			moreBodies, moreExprs := blockParts(block)
+			bodies = append(bodies, moreBodies...)
+			exprs = append(exprs, moreExprs...)
 		}
+		schema.Block = &steppingThrough.Block
 	}
 
 	if len(bodies) == 0 && len(exprs) == 0 {
@@ -508,6 +514,9 @@ func traverseNestedBlockList(bodies []hcl.Body, typeName string, idx int) ([]hcl
 				retBodies = append(retBodies, moreBodies...)
 				retExprs = append(retExprs, moreExprs...)
 			}
+		case idx < 0 || idx >= len(blocks):
+			// There's no block at this index, so nothing can contribute.
+			continue
 		default:
 			// This is the happier case where we can select just a single
 			// static block based on idx. Note that this one is guaranteed
@@ -8,6 +8,9 @@ package globalref
 import (
 	"fmt"
 
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/lang"
 )
@@ -54,6 +57,34 @@ func (a *Analyzer) ReferencesFromResourceInstance(addr addrs.AbsResourceInstance
 	return a.MetaReferences(fakeRef)
 }
 
+// ReferencesFromResourceAttr returns the direct references from the parts of
+// the definition of the resource instance at the given address that
+// contribute to the given attribute path. It doesn't include any indirect
+// references.
+//
+// As with ReferencesFromResourceInstance, references to count.index,
+// each.key, or each.value are included as-is rather than being resolved to
+// the references in the resource's repetition expression.
+func (a *Analyzer) ReferencesFromResourceAttr(addr addrs.AbsResourceInstance, attr cty.Path) []Reference {
+	traversal := make(hcl.Traversal, 0, len(attr))
+	for _, step := range attr {
+		switch step := step.(type) {
+		case cty.GetAttrStep:
+			traversal = append(traversal, hcl.TraverseAttr{Name: step.Name})
+		case cty.IndexStep:
+			traversal = append(traversal, hcl.TraverseIndex{Key: step.Key})
+		}
+	}
+	fakeRef := Reference{
+		ContainerAddr: addr.Module,
+		LocalRef: &addrs.Reference{
+			Subject:   addr.Resource,
+			Remaining: traversal,
+		},
+	}
+	return a.MetaReferences(fakeRef)
+}
+
 // ReferencesFromResourceRepetition returns the references from the given
 // resource's for_each or count expression, or an empty set if the resource
 // doesn't use repetition.
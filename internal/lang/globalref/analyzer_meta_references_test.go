@@ -81,6 +81,10 @@ func TestAnalyzerMetaReferences(t *testing.T) {
 			`test_thing.count`,
 			[]string{
 				"::local.a",
+				"::local.b",
+				"::local.c",
+				"::test_thing.single.id",
+				"::test_thing.single.number",
 			},
 		},
 		{
@@ -88,6 +92,52 @@ func TestAnalyzerMetaReferences(t *testing.T) {
 			`test_thing.count[0]`,
 			[]string{
 				"::local.a",
+				"::local.b",
+				"::local.c",
+				"::test_thing.single.id",
+				"::test_thing.single.number",
+			},
+		},
+		{
+			``,
+			`test_thing.count[0].list`,
+			[]string{
+				"::local.b",
+				"::test_thing.single.number",
+			},
+		},
+		{
+			``,
+			`test_thing.count[0].list[1].z`,
+			[]string{
+				"::test_thing.single.number",
+			},
+		},
+		{
+			``,
+			`test_thing.count[0].list[0]`,
+			[]string{
+				"::local.b",
+			},
+		},
+		{
+			``,
+			`test_thing.count[0].list[2]`,
+			nil,
+		},
+		{
+			``,
+			`test_thing.count[0].map`,
+			[]string{
+				"::local.c",
+				"::test_thing.single.id",
+			},
+		},
+		{
+			``,
+			`test_thing.count[0].map["b"].z`,
+			[]string{
+				"::test_thing.single.id",
 			},
 		},
 		{
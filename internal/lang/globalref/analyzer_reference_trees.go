@@ -0,0 +1,84 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package globalref
+
+import (
+	"slices"
+	"strings"
+)
+
+// ReferenceTree is a reference along with the trees of the references that
+// contributed to the value of the object it refers to.
+type ReferenceTree struct {
+	Ref Reference
+
+	// Contributors are the trees for the references found by MetaReferences
+	// for Ref, ordered by their source locations and with duplicates removed.
+	//
+	// Contributors is always empty for references to resources, because
+	// ReferenceTrees treats resources as the origin of the values it traces
+	// rather than walking through their configuration. It's also empty for
+	// references to root module input variables and for anything else whose
+	// definition includes no references.
+	Contributors []*ReferenceTree
+
+	// Cycle is true if Ref refers to an object that was already visited on
+	// the path from the root of the tree, which can only happen in an
+	// invalid configuration. Contributors is always empty in that case.
+	Cycle bool
+}
+
+// ReferenceTrees analyzes each of the given references and for each one walks
+// backwards through any named values to find how the value it refers to was
+// derived, stopping at any resources it encounters.
+//
+// This is similar to ContributingResourceReferences, but preserves the path
+// taken to reach each contributing object instead of flattening the result,
+// and so can be used to explain to a user why a value is what it is. Because
+// it doesn't merge paths that reach the same object in different ways it
+// can be considerably more expensive, so callers should start from as
+// specific a set of references as possible, such as those returned by
+// ReferencesFromResourceAttr.
+func (a *Analyzer) ReferenceTrees(refs ...Reference) []*ReferenceTree {
+	return a.referenceTrees(refs, make(map[referenceAddrKey]struct{}))
+}
+
+func (a *Analyzer) referenceTrees(refs []Reference, visiting map[referenceAddrKey]struct{}) []*ReferenceTree {
+	// MetaReferences doesn't guarantee any particular order, so we sort
+	// by source location to produce a stable result that follows the
+	// configuration.
+	refs = slices.Clone(refs)
+	slices.SortStableFunc(refs, func(a, b Reference) int {
+		ra, rb := a.LocalRef.SourceRange, b.LocalRef.SourceRange
+		if c := strings.Compare(ra.Filename, rb.Filename); c != 0 {
+			return c
+		}
+		return ra.Start.Byte - rb.Start.Byte
+	})
+
+	var ret []*ReferenceTree
+	seen := make(map[referenceAddrKey]struct{}, len(refs))
+	for _, ref := range refs {
+		key := ref.addrKey()
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		tree := &ReferenceTree{Ref: ref}
+		ret = append(ret, tree)
+		if _, ok := resourceForAddr(ref.LocalRef.Subject); ok {
+			continue
+		}
+		if _, ok := visiting[key]; ok {
+			tree.Cycle = true
+			continue
+		}
+
+		visiting[key] = struct{}{}
+		tree.Contributors = a.referenceTrees(a.MetaReferences(ref), visiting)
+		delete(visiting, key)
+	}
+	return ret
+}
@@ -0,0 +1,90 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package globalref
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+func TestAnalyzerReferenceTrees(t *testing.T) {
+	azr := testAnalyzer(t, "contributing-resources")
+
+	tests := map[string]struct {
+		Resource addrs.AbsResourceInstance
+		Attr     cty.Path
+		Want     string
+	}{
+		"module.compute.test_thing.load_balancer.string": {
+			mustAbsResourceInstanceAddr(t, "module.compute.test_thing.load_balancer"),
+			cty.GetAttrPath("string"),
+			`
+module.compute::var.network.vpc_id
+  ::module.network
+    module.network::test_thing.vpc.string
+    module.network::test_thing.subnet
+`,
+		},
+		"module.network.test_thing.subnet.single": {
+			mustAbsResourceInstanceAddr(t, `module.network.test_thing.subnet["a"]`),
+			cty.GetAttrPath("single"),
+			`
+module.network.test_thing.subnet["a"]::each.value
+  module.network::local.subnet_cidr_blocks
+    module.network::var.subnet_count
+      ::data.test_thing.environment.any.subnet_count
+    module.network::var.base_cidr_block
+      ::data.test_thing.environment.any.base_cidr_block
+    module.network::local.subnet_newbits
+      module.network::var.subnet_count
+        ::data.test_thing.environment.any.subnet_count
+`,
+		},
+		"data.test_thing.environment.string": {
+			mustAbsResourceInstanceAddr(t, "data.test_thing.environment"),
+			cty.GetAttrPath("string"),
+			`
+::var.environment
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			refs := azr.ReferencesFromResourceAttr(test.Resource, test.Attr)
+			var buf strings.Builder
+			buf.WriteString("\n")
+			writeTestReferenceTrees(&buf, azr.ReferenceTrees(refs...), "")
+
+			if diff := cmp.Diff(test.Want, buf.String()); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func writeTestReferenceTrees(buf *strings.Builder, trees []*ReferenceTree, indent string) {
+	for _, tree := range trees {
+		buf.WriteString(indent + tree.Ref.DebugString())
+		if tree.Cycle {
+			buf.WriteString(" (cycle)")
+		}
+		buf.WriteString("\n")
+		writeTestReferenceTrees(buf, tree.Contributors, indent+"  ")
+	}
+}
+
+func mustAbsResourceInstanceAddr(t *testing.T, s string) addrs.AbsResourceInstance {
+	t.Helper()
+	addr, diags := addrs.ParseAbsResourceInstanceStr(s)
+	if diags.HasErrors() {
+		t.Fatalf("invalid address %q: %s", s, diags.Err())
+	}
+	return addr
+}
@@ -1,6 +1,7 @@
 locals {
   a = "hello world"
   b = 2
+  c = 3
   single = test_thing.single.id
 }
 
@@ -24,6 +25,20 @@ resource "test_thing" "count" {
   for_each = length(local.a)
 
   string = local.a
+
+  list {
+    z = local.b
+  }
+  list {
+    z = test_thing.single.number
+  }
+
+  map "a" {
+    z = local.c
+  }
+  map "b" {
+    z = test_thing.single.id
+  }
 }
 
 module "single" {
@@ -109,6 +109,13 @@ func DeprecationCauseVariable(vaddr addrs.AbsInputVariableInstance, message stri
 		message: message,
 	}
 }
+func DeprecationCauseVariableAttribute(vaddr addrs.AbsInputVariableInstance, path cty.Path, message string) DeprecationCause {
+	return DeprecationCause{
+		module:  vaddr.Module.String(),
+		subject: vaddr.Variable.Name + tfdiags.FormatCtyPath(path),
+		message: message,
+	}
+}
 
 // ExtraInfoKey returns the key used for consolidation of deprecation diagnostics.
 // This will be enhanced by the view with module source address
@@ -74,6 +74,17 @@ type Scope struct {
 	PlanTimestamp time.Time
 
 	ProviderFunctions ProviderFunction
+
+	// UserFunctions are the functions declared by "function" blocks that
+	// are visible in the module this scope belongs to, keyed by name.
+	// Expressions call them as module::<name>.
+	UserFunctions map[string]*UserFunction
+
+	// PluginFunctions are the functions provided by the function plugins
+	// that the module this scope belongs to requires, keyed by plugin name
+	// and then by function name. Expressions call them as
+	// plugin::<plugin>::<function>.
+	PluginFunctions map[string]map[string]function.Function
 }
 
 type ProviderFunction func(context.Context, addrs.ProviderFunction, tfdiags.SourceRange) (*function.Function, tfdiags.Diagnostics)
@@ -0,0 +1,151 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package lang
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+// UserFunction is a function declared by a "function" block in a module,
+// whose result is the value of an expression that can refer to the
+// function's parameters.
+type UserFunction struct {
+	Name        string
+	Description string
+	Params      []UserFunctionParam
+	Result      hcl.Expression
+
+	// Functions are the user-defined functions that are visible in the module
+	// that declared this function, keyed by name, which the Result expression
+	// can call in addition to the built-in functions.
+	Functions map[string]*UserFunction
+}
+
+// UserFunctionParam is a parameter of a [UserFunction]. Arguments are
+// converted to the parameter's type before the function's result is
+// evaluated.
+type UserFunctionParam struct {
+	Name string
+	Type cty.Type
+}
+
+// UserFunctionName returns the name that expressions use to call the
+// user-defined function with the given name, such as "module::name".
+func UserFunctionName(name string) string {
+	return addrs.Function{
+		Namespaces: []string{addrs.FunctionNamespaceModule},
+		Name:       name,
+	}.String()
+}
+
+// IsImpureFunction returns true if the built-in function with the given
+// name, which may be in the core:: namespace, can return a different result
+// each time it is called with the same arguments.
+func IsImpureFunction(name string) bool {
+	fn := addrs.ParseFunction(name)
+	if len(fn.Namespaces) > 0 && !fn.IsNamespace(addrs.FunctionNamespaceCore) {
+		return false
+	}
+	return slices.Contains(impureFunctions, fn.Name)
+}
+
+// userFunctionError is returned by a user-defined function whose result
+// expression couldn't be evaluated. It carries the original diagnostics so
+// that enhanceFunctionDiags can report them with their source ranges, rather
+// than only as the message of an error from the function call.
+type userFunctionError struct {
+	Name  string
+	Diags hcl.Diagnostics
+}
+
+func (e userFunctionError) Error() string {
+	return e.Diags.Error()
+}
+
+// userFunctionTable builds the cty implementations of user-defined functions
+// for a scope.
+//
+// Each function's result is evaluated with the scope's built-in functions
+// and the user-defined functions of the module that declared it. Those are
+// only resolved the first time the function is called, because a function
+// is visible to itself and to the other functions in its module.
+type userFunctionTable struct {
+	builtins map[string]function.Function
+
+	mu    sync.Mutex
+	funcs map[*UserFunction]function.Function
+}
+
+func newUserFunctionTable(builtins map[string]function.Function) *userFunctionTable {
+	return &userFunctionTable{
+		builtins: builtins,
+		funcs:    make(map[*UserFunction]function.Function),
+	}
+}
+
+// Functions returns the implementations of the given user-defined functions,
+// keyed by the names that expressions use to call them.
+func (t *userFunctionTable) Functions(ufs map[string]*UserFunction) map[string]function.Function {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ret := make(map[string]function.Function, len(ufs))
+	for name, uf := range ufs {
+		fn, ok := t.funcs[uf]
+		if !ok {
+			fn = t.function(uf)
+			t.funcs[uf] = fn
+		}
+		ret[UserFunctionName(name)] = fn
+	}
+	return ret
+}
+
+func (t *userFunctionTable) function(uf *UserFunction) function.Function {
+	params := make([]function.Parameter, len(uf.Params))
+	for i, p := range uf.Params {
+		params[i] = function.Parameter{
+			Name:             p.Name,
+			Type:             p.Type,
+			AllowNull:        true,
+			AllowUnknown:     true,
+			AllowDynamicType: true,
+			AllowMarked:      true,
+		}
+	}
+
+	callable := sync.OnceValue(func() map[string]function.Function {
+		ret := maps.Clone(t.builtins)
+		maps.Copy(ret, t.Functions(uf.Functions))
+		return ret
+	})
+
+	return function.New(&function.Spec{
+		Description: uf.Description,
+		Params:      params,
+		Type:        function.StaticReturnType(cty.DynamicPseudoType),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			vars := make(map[string]cty.Value, len(args))
+			for i, p := range uf.Params {
+				vars[p.Name] = args[i]
+			}
+			val, diags := uf.Result.Value(&hcl.EvalContext{
+				Variables: vars,
+				Functions: callable(),
+			})
+			if diags.HasErrors() {
+				return cty.DynamicVal, userFunctionError{Name: uf.Name, Diags: diags}
+			}
+			return val, nil
+		},
+	})
+}
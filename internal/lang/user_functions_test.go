@@ -0,0 +1,190 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package lang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/lang/marks"
+)
+
+func TestScopeUserFunctions(t *testing.T) {
+	parse := func(src string) hcl.Expression {
+		t.Helper()
+		expr, diags := hclsyntax.ParseExpression([]byte(src), "test.tf", hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatal(diags.Error())
+		}
+		return expr
+	}
+
+	ufs := map[string]*UserFunction{}
+	ufs["normalize_tags"] = &UserFunction{
+		Name: "normalize_tags",
+		Params: []UserFunctionParam{
+			{Name: "tags", Type: cty.Map(cty.String)},
+		},
+		Result:    parse(`{ for k, v in tags : lower(k) => module::trim_value(v) }`),
+		Functions: ufs,
+	}
+	ufs["trim_value"] = &UserFunction{
+		Name: "trim_value",
+		Params: []UserFunctionParam{
+			{Name: "v", Type: cty.String},
+		},
+		Result:    parse(`trimspace(v)`),
+		Functions: ufs,
+	}
+	ufs["name"] = &UserFunction{
+		Name: "name",
+		Params: []UserFunctionParam{
+			{Name: "env", Type: cty.String},
+			{Name: "index", Type: cty.Number},
+		},
+		Result:    parse(`format("%s-%02d", env, index)`),
+		Functions: ufs,
+	}
+
+	tests := map[string]struct {
+		expr    string
+		want    cty.Value
+		wantErr string
+	}{
+		"simple": {
+			expr: `module::trim_value("  a  ")`,
+			want: cty.StringVal("a"),
+		},
+		"calls other user function": {
+			expr: `module::normalize_tags({ Owner = " team " })`,
+			want: cty.ObjectVal(map[string]cty.Value{
+				"owner": cty.StringVal("team"),
+			}),
+		},
+		"converts arguments": {
+			expr: `module::name("prod", "3")`,
+			want: cty.StringVal("prod-03"),
+		},
+		"unknown argument": {
+			expr: `module::trim_value(unknown)`,
+			want: cty.UnknownVal(cty.String).RefineNotNull(),
+		},
+		"sensitive argument": {
+			expr: `module::trim_value(secret)`,
+			want: cty.StringVal("s3cr3t").Mark(marks.Sensitive),
+		},
+		"wrong argument type": {
+			expr:    `module::name("prod", "three")`,
+			wantErr: "Invalid function argument",
+		},
+		"error in result": {
+			expr:    `module::name(null, 1)`,
+			wantErr: `Call to function "module::name" failed`,
+		},
+		"wrong number of arguments": {
+			expr:    `module::trim_value()`,
+			wantErr: "Not enough function arguments",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			scope := &Scope{UserFunctions: ufs}
+			ctx, diags := scope.EvalContext(t.Context(), nil)
+			if diags.HasErrors() {
+				t.Fatal(diags.Err())
+			}
+			ctx.Variables = map[string]cty.Value{
+				"unknown": cty.UnknownVal(cty.String),
+				"secret":  cty.StringVal(" s3cr3t ").Mark(marks.Sensitive),
+			}
+
+			got, hclDiags := parse(test.expr).Value(ctx)
+			if test.wantErr != "" {
+				if !hclDiags.HasErrors() {
+					t.Fatalf("unexpected success; want error containing %q", test.wantErr)
+				}
+				if got := hclDiags.Error(); !strings.Contains(got, test.wantErr) {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.wantErr)
+				}
+				return
+			}
+			if hclDiags.HasErrors() {
+				t.Fatal(hclDiags.Error())
+			}
+			if !got.RawEquals(test.want) {
+				t.Fatalf("wrong result\ngot:  %#v\nwant: %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestScopeUserFunctions_resultDiags(t *testing.T) {
+	parse := func(src, filename string) hcl.Expression {
+		t.Helper()
+		expr, diags := hclsyntax.ParseExpression([]byte(src), filename, hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatal(diags.Error())
+		}
+		return expr
+	}
+
+	ufs := map[string]*UserFunction{}
+	ufs["outer"] = &UserFunction{
+		Name:      "outer",
+		Params:    []UserFunctionParam{{Name: "v", Type: cty.String}},
+		Result:    parse(`module::inner(v)`, "outer.tf"),
+		Functions: ufs,
+	}
+	ufs["inner"] = &UserFunction{
+		Name:      "inner",
+		Params:    []UserFunctionParam{{Name: "v", Type: cty.String}},
+		Result:    parse(`v.missing`, "inner.tf"),
+		Functions: ufs,
+	}
+
+	scope := &Scope{UserFunctions: ufs}
+	_, diags := scope.EvalExpr(t.Context(), parse(`module::outer("a")`, "main.tf"), cty.DynamicPseudoType)
+	if len(diags) != 1 {
+		t.Fatalf("wrong number of diagnostics %d; want 1\n%s", len(diags), diags.ErrWithWarnings())
+	}
+
+	// The diagnostic should point at the expression that failed in the
+	// function block, rather than only at the call in main.tf.
+	diag := diags[0]
+	if got, want := diag.Description().Summary, "Unsupported attribute"; got != want {
+		t.Errorf("wrong summary %q; want %q", got, want)
+	}
+	subject := diag.Source().Subject
+	if subject == nil || subject.Filename != "inner.tf" {
+		t.Errorf("wrong subject %#v; want range in inner.tf", subject)
+	}
+	detail := diag.Description().Detail
+	for _, want := range []string{
+		`calling function "module::inner" at outer.tf:1,1-15`,
+		`calling function "module::outer" at main.tf:1,1-15`,
+	} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("detail doesn't mention %q\n%s", want, detail)
+		}
+	}
+}
+
+func TestIsImpureFunction(t *testing.T) {
+	for name, want := range map[string]bool{
+		"timestamp":                true,
+		"core::uuid":               true,
+		"upper":                    false,
+		"module::timestamp":        false,
+		"provider::foo::timestamp": false,
+	} {
+		if got := IsImpureFunction(name); got != want {
+			t.Errorf("IsImpureFunction(%q) = %t; want %t", name, got, want)
+		}
+	}
+}
@@ -45,6 +45,11 @@ type Record struct {
 	// by any other codepaths; use "Version" instead.
 	VersionStr string `json:"Version,omitempty"`
 
+	// Ref is the git tag that the module's version constraint was resolved
+	// to, for a module installed from a git repository with a version
+	// constraint. Empty for all other modules.
+	Ref string `json:"Ref,omitempty"`
+
 	// Dir is the path to the local directory where the module is installed.
 	Dir string `json:"Dir"`
 }
@@ -0,0 +1,59 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package plans
+
+import (
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+// DeferredChange describes an object whose planning was deferred to a later
+// plan/apply round because OpenTofu could not yet determine which instances
+// of it should exist.
+//
+// A deferred object is left out of the plan along with everything that
+// depends on it, in the same way as for an object excluded using the
+// -exclude planning option, and so it must also be excluded when applying
+// the plan.
+type DeferredChange struct {
+	// Addr is the address of the deferred object, which is either a resource
+	// or a call to a module.
+	Addr addrs.Targetable
+
+	// Reason describes why the object was deferred.
+	Reason DeferredReason
+}
+
+// DeferredReason describes why the planning of an object was deferred.
+type DeferredReason rune
+
+//go:generate go tool golang.org/x/tools/cmd/stringer -type=DeferredReason deferred.go
+
+const (
+	// DeferredReasonInvalid is the zero value of DeferredReason and is not
+	// a valid reason for deferral.
+	DeferredReasonInvalid DeferredReason = 0
+
+	// DeferredBecauseUnknownCount indicates that the object's "count"
+	// argument depends on values that won't be known until apply.
+	DeferredBecauseUnknownCount DeferredReason = 'C'
+
+	// DeferredBecauseUnknownForEach indicates that the object's "for_each"
+	// argument depends on values that won't be known until apply.
+	DeferredBecauseUnknownForEach DeferredReason = 'F'
+)
+
+// JSONName returns the name used for the reason in OpenTofu's machine-readable
+// output formats, such as the JSON plan representation and the JSON UI.
+func (r DeferredReason) JSONName() string {
+	switch r {
+	case DeferredBecauseUnknownCount:
+		return "unknown_count"
+	case DeferredBecauseUnknownForEach:
+		return "unknown_for_each"
+	default:
+		// Should not get here because the cases above should cover every
+		// valid value of this type.
+		return "unknown"
+	}
+}
@@ -240,6 +240,53 @@ func (ResourceInstanceActionReason) EnumDescriptor() ([]byte, []int) {
 	return file_planfile_proto_rawDescGZIP(), []int{2}
 }
 
+// DeferredReason describes why the planning of an object was deferred.
+type DeferredReason int32
+
+const (
+	DeferredReason_UNKNOWN_COUNT    DeferredReason = 0
+	DeferredReason_UNKNOWN_FOR_EACH DeferredReason = 1
+)
+
+// Enum value maps for DeferredReason.
+var (
+	DeferredReason_name = map[int32]string{
+		0: "UNKNOWN_COUNT",
+		1: "UNKNOWN_FOR_EACH",
+	}
+	DeferredReason_value = map[string]int32{
+		"UNKNOWN_COUNT":    0,
+		"UNKNOWN_FOR_EACH": 1,
+	}
+)
+
+func (x DeferredReason) Enum() *DeferredReason {
+	p := new(DeferredReason)
+	*p = x
+	return p
+}
+
+func (x DeferredReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeferredReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_planfile_proto_enumTypes[3].Descriptor()
+}
+
+func (DeferredReason) Type() protoreflect.EnumType {
+	return &file_planfile_proto_enumTypes[3]
+}
+
+func (x DeferredReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeferredReason.Descriptor instead.
+func (DeferredReason) EnumDescriptor() ([]byte, []int) {
+	return file_planfile_proto_rawDescGZIP(), []int{3}
+}
+
 // Status describes the status of a particular checkable object at the
 // completion of the plan.
 type CheckResults_Status int32
@@ -278,11 +325,11 @@ func (x CheckResults_Status) String() string {
 }
 
 func (CheckResults_Status) Descriptor() protoreflect.EnumDescriptor {
-	return file_planfile_proto_enumTypes[3].Descriptor()
+	return file_planfile_proto_enumTypes[4].Descriptor()
 }
 
 func (CheckResults_Status) Type() protoreflect.EnumType {
-	return &file_planfile_proto_enumTypes[3]
+	return &file_planfile_proto_enumTypes[4]
 }
 
 func (x CheckResults_Status) Number() protoreflect.EnumNumber {
@@ -333,11 +380,11 @@ func (x CheckResults_ObjectKind) String() string {
 }
 
 func (CheckResults_ObjectKind) Descriptor() protoreflect.EnumDescriptor {
-	return file_planfile_proto_enumTypes[4].Descriptor()
+	return file_planfile_proto_enumTypes[5].Descriptor()
 }
 
 func (CheckResults_ObjectKind) Type() protoreflect.EnumType {
-	return &file_planfile_proto_enumTypes[4]
+	return &file_planfile_proto_enumTypes[5]
 }
 
 func (x CheckResults_ObjectKind) Number() protoreflect.EnumNumber {
@@ -349,6 +396,52 @@ func (CheckResults_ObjectKind) EnumDescriptor() ([]byte, []int) {
 	return file_planfile_proto_rawDescGZIP(), []int{5, 1}
 }
 
+type CheckResults_Failure_Severity int32
+
+const (
+	CheckResults_Failure_ERROR   CheckResults_Failure_Severity = 0
+	CheckResults_Failure_WARNING CheckResults_Failure_Severity = 1
+)
+
+// Enum value maps for CheckResults_Failure_Severity.
+var (
+	CheckResults_Failure_Severity_name = map[int32]string{
+		0: "ERROR",
+		1: "WARNING",
+	}
+	CheckResults_Failure_Severity_value = map[string]int32{
+		"ERROR":   0,
+		"WARNING": 1,
+	}
+)
+
+func (x CheckResults_Failure_Severity) Enum() *CheckResults_Failure_Severity {
+	p := new(CheckResults_Failure_Severity)
+	*p = x
+	return p
+}
+
+func (x CheckResults_Failure_Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CheckResults_Failure_Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_planfile_proto_enumTypes[6].Descriptor()
+}
+
+func (CheckResults_Failure_Severity) Type() protoreflect.EnumType {
+	return &file_planfile_proto_enumTypes[6]
+}
+
+func (x CheckResults_Failure_Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CheckResults_Failure_Severity.Descriptor instead.
+func (CheckResults_Failure_Severity) EnumDescriptor() ([]byte, []int) {
+	return file_planfile_proto_rawDescGZIP(), []int{5, 0, 0}
+}
+
 // Plan is the root message type for the tfplan file
 type Plan struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -401,6 +494,9 @@ type Plan struct {
 	// target addresses are present, the plan applies to the whole
 	// configuration.
 	ExcludeAddrs []string `protobuf:"bytes,6,rep,name=exclude_addrs,json=excludeAddrs,proto3" json:"exclude_addrs,omitempty"`
+	// An unordered set of objects whose planning was deferred to a later
+	// plan/apply round. These must also be excluded when applying.
+	DeferredChanges []*DeferredChange `protobuf:"bytes,23,rep,name=deferred_changes,json=deferredChanges,proto3" json:"deferred_changes,omitempty"`
 	// An unordered set of force-replace addresses to include when applying.
 	// This must match the set of addresses that was used when creating the
 	// plan, or else applying the plan will fail when it reaches a different
@@ -541,6 +637,13 @@ func (x *Plan) GetExcludeAddrs() []string {
 	return nil
 }
 
+func (x *Plan) GetDeferredChanges() []*DeferredChange {
+	if x != nil {
+		return x.DeferredChanges
+	}
+	return nil
+}
+
 func (x *Plan) GetForceReplaceAddrs() []string {
 	if x != nil {
 		return x.ForceReplaceAddrs
@@ -1211,6 +1314,62 @@ func (x *Importing) GetIdentity() *DynamicValue {
 	return nil
 }
 
+// DeferredChange describes an object whose planning was deferred to a later
+// plan/apply round because OpenTofu could not yet determine its instances.
+type DeferredChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The address of the deferred object, using the same syntax as the
+	// exclude_addrs field of Plan.
+	Addr          string         `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Reason        DeferredReason `protobuf:"varint,2,opt,name=reason,proto3,enum=tfplan.DeferredReason" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeferredChange) Reset() {
+	*x = DeferredChange{}
+	mi := &file_planfile_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeferredChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeferredChange) ProtoMessage() {}
+
+func (x *DeferredChange) ProtoReflect() protoreflect.Message {
+	mi := &file_planfile_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeferredChange.ProtoReflect.Descriptor instead.
+func (*DeferredChange) Descriptor() ([]byte, []int) {
+	return file_planfile_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeferredChange) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *DeferredChange) GetReason() DeferredReason {
+	if x != nil {
+		return x.Reason
+	}
+	return DeferredReason_UNKNOWN_COUNT
+}
+
 type PlanResourceAttr struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Resource      string                 `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
@@ -1221,7 +1380,7 @@ type PlanResourceAttr struct {
 
 func (x *PlanResourceAttr) Reset() {
 	*x = PlanResourceAttr{}
-	mi := &file_planfile_proto_msgTypes[10]
+	mi := &file_planfile_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1233,7 +1392,7 @@ func (x *PlanResourceAttr) String() string {
 func (*PlanResourceAttr) ProtoMessage() {}
 
 func (x *PlanResourceAttr) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[10]
+	mi := &file_planfile_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1263,18 +1422,79 @@ func (x *PlanResourceAttr) GetAttr() *Path {
 	return nil
 }
 
+// Failure describes one failed check for a checkable object.
+type CheckResults_Failure struct {
+	state         protoimpl.MessageState        `protogen:"open.v1"`
+	Message       string                        `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	ErrorCode     string                        `protobuf:"bytes,2,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Severity      CheckResults_Failure_Severity `protobuf:"varint,3,opt,name=severity,proto3,enum=tfplan.CheckResults_Failure_Severity" json:"severity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckResults_Failure) Reset() {
+	*x = CheckResults_Failure{}
+	mi := &file_planfile_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckResults_Failure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckResults_Failure) ProtoMessage() {}
+
+func (x *CheckResults_Failure) ProtoReflect() protoreflect.Message {
+	mi := &file_planfile_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckResults_Failure.ProtoReflect.Descriptor instead.
+func (*CheckResults_Failure) Descriptor() ([]byte, []int) {
+	return file_planfile_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *CheckResults_Failure) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CheckResults_Failure) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *CheckResults_Failure) GetSeverity() CheckResults_Failure_Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return CheckResults_Failure_ERROR
+}
+
 type CheckResults_ObjectResult struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	ObjectAddr      string                 `protobuf:"bytes,1,opt,name=object_addr,json=objectAddr,proto3" json:"object_addr,omitempty"`
-	Status          CheckResults_Status    `protobuf:"varint,2,opt,name=status,proto3,enum=tfplan.CheckResults_Status" json:"status,omitempty"`
-	FailureMessages []string               `protobuf:"bytes,3,rep,name=failure_messages,json=failureMessages,proto3" json:"failure_messages,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	ObjectAddr    string                  `protobuf:"bytes,1,opt,name=object_addr,json=objectAddr,proto3" json:"object_addr,omitempty"`
+	Status        CheckResults_Status     `protobuf:"varint,2,opt,name=status,proto3,enum=tfplan.CheckResults_Status" json:"status,omitempty"`
+	Failures      []*CheckResults_Failure `protobuf:"bytes,4,rep,name=failures,proto3" json:"failures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CheckResults_ObjectResult) Reset() {
 	*x = CheckResults_ObjectResult{}
-	mi := &file_planfile_proto_msgTypes[11]
+	mi := &file_planfile_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1286,7 +1506,7 @@ func (x *CheckResults_ObjectResult) String() string {
 func (*CheckResults_ObjectResult) ProtoMessage() {}
 
 func (x *CheckResults_ObjectResult) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[11]
+	mi := &file_planfile_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1299,7 +1519,7 @@ func (x *CheckResults_ObjectResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckResults_ObjectResult.ProtoReflect.Descriptor instead.
 func (*CheckResults_ObjectResult) Descriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{5, 0}
+	return file_planfile_proto_rawDescGZIP(), []int{5, 1}
 }
 
 func (x *CheckResults_ObjectResult) GetObjectAddr() string {
@@ -1316,9 +1536,9 @@ func (x *CheckResults_ObjectResult) GetStatus() CheckResults_Status {
 	return CheckResults_UNKNOWN
 }
 
-func (x *CheckResults_ObjectResult) GetFailureMessages() []string {
+func (x *CheckResults_ObjectResult) GetFailures() []*CheckResults_Failure {
 	if x != nil {
-		return x.FailureMessages
+		return x.Failures
 	}
 	return nil
 }
@@ -1336,7 +1556,7 @@ type Path_Step struct {
 
 func (x *Path_Step) Reset() {
 	*x = Path_Step{}
-	mi := &file_planfile_proto_msgTypes[12]
+	mi := &file_planfile_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1348,7 +1568,7 @@ func (x *Path_Step) String() string {
 func (*Path_Step) ProtoMessage() {}
 
 func (x *Path_Step) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[12]
+	mi := &file_planfile_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1413,7 +1633,7 @@ var File_planfile_proto protoreflect.FileDescriptor
 
 const file_planfile_proto_rawDesc = "" +
 	"\n" +
-	"\x0eplanfile.proto\x12\x06tfplan\"\x8f\b\n" +
+	"\x0eplanfile.proto\x12\x06tfplan\"\xd2\b\n" +
 	"\x04Plan\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\x04R\aversion\x12%\n" +
 	"\aui_mode\x18\x11 \x01(\x0e2\f.tfplan.ModeR\x06uiMode\x12\x18\n" +
@@ -1424,7 +1644,8 @@ const file_planfile_proto_rawDesc = "" +
 	"\x0eoutput_changes\x18\x04 \x03(\v2\x14.tfplan.OutputChangeR\routputChanges\x129\n" +
 	"\rcheck_results\x18\x13 \x03(\v2\x14.tfplan.CheckResultsR\fcheckResults\x12!\n" +
 	"\ftarget_addrs\x18\x05 \x03(\tR\vtargetAddrs\x12#\n" +
-	"\rexclude_addrs\x18\x06 \x03(\tR\fexcludeAddrs\x12.\n" +
+	"\rexclude_addrs\x18\x06 \x03(\tR\fexcludeAddrs\x12A\n" +
+	"\x10deferred_changes\x18\x17 \x03(\v2\x16.tfplan.DeferredChangeR\x0fdeferredChanges\x12.\n" +
 	"\x13force_replace_addrs\x18\x10 \x03(\tR\x11forceReplaceAddrs\x12+\n" +
 	"\x11terraform_version\x18\x0e \x01(\tR\x10terraformVersion\x12)\n" +
 	"\abackend\x18\r \x01(\v2\x0f.tfplan.BackendR\abackend\x12K\n" +
@@ -1468,18 +1689,26 @@ const file_planfile_proto_rawDesc = "" +
 	"\fOutputChange\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12&\n" +
 	"\x06change\x18\x02 \x01(\v2\x0e.tfplan.ChangeR\x06change\x12\x1c\n" +
-	"\tsensitive\x18\x03 \x01(\bR\tsensitive\"\xfc\x03\n" +
+	"\tsensitive\x18\x03 \x01(\bR\tsensitive\"\xbd\x05\n" +
 	"\fCheckResults\x123\n" +
 	"\x04kind\x18\x01 \x01(\x0e2\x1f.tfplan.CheckResults.ObjectKindR\x04kind\x12\x1f\n" +
 	"\vconfig_addr\x18\x02 \x01(\tR\n" +
 	"configAddr\x123\n" +
 	"\x06status\x18\x03 \x01(\x0e2\x1b.tfplan.CheckResults.StatusR\x06status\x12;\n" +
-	"\aobjects\x18\x04 \x03(\v2!.tfplan.CheckResults.ObjectResultR\aobjects\x1a\x8f\x01\n" +
+	"\aobjects\x18\x04 \x03(\v2!.tfplan.CheckResults.ObjectResultR\aobjects\x1a\xa9\x01\n" +
+	"\aFailure\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x02 \x01(\tR\terrorCode\x12A\n" +
+	"\bseverity\x18\x03 \x01(\x0e2%.tfplan.CheckResults.Failure.SeverityR\bseverity\"\"\n" +
+	"\bSeverity\x12\t\n" +
+	"\x05ERROR\x10\x00\x12\v\n" +
+	"\aWARNING\x10\x01\x1a\xa4\x01\n" +
 	"\fObjectResult\x12\x1f\n" +
 	"\vobject_addr\x18\x01 \x01(\tR\n" +
 	"objectAddr\x123\n" +
-	"\x06status\x18\x02 \x01(\x0e2\x1b.tfplan.CheckResults.StatusR\x06status\x12)\n" +
-	"\x10failure_messages\x18\x03 \x03(\tR\x0ffailureMessages\"4\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1b.tfplan.CheckResults.StatusR\x06status\x128\n" +
+	"\bfailures\x18\x04 \x03(\v2\x1c.tfplan.CheckResults.FailureR\bfailuresJ\x04\b\x03\x10\x04\"4\n" +
 	"\x06Status\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\b\n" +
 	"\x04PASS\x10\x01\x12\b\n" +
@@ -1504,7 +1733,10 @@ const file_planfile_proto_rawDesc = "" +
 	"\bselector\"M\n" +
 	"\tImporting\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x120\n" +
-	"\bidentity\x18\x02 \x01(\v2\x14.tfplan.DynamicValueR\bidentity*1\n" +
+	"\bidentity\x18\x02 \x01(\v2\x14.tfplan.DynamicValueR\bidentity\"T\n" +
+	"\x0eDeferredChange\x12\x12\n" +
+	"\x04addr\x18\x01 \x01(\tR\x04addr\x12.\n" +
+	"\x06reason\x18\x02 \x01(\x0e2\x16.tfplan.DeferredReasonR\x06reason*1\n" +
 	"\x04Mode\x12\n" +
 	"\n" +
 	"\x06NORMAL\x10\x00\x12\v\n" +
@@ -1543,7 +1775,10 @@ const file_planfile_proto_rawDesc = "" +
 	"\x1dDELETE_BECAUSE_NO_MOVE_TARGET\x10\f\x12 \n" +
 	"\x1cDELETE_BECAUSE_ENABLED_FALSE\x10\x0e\x12-\n" +
 	")FORGOT_BECAUSE_LIFECYCLE_DESTROY_IN_STATE\x10\x0f\x12.\n" +
-	"*FORGOT_BECAUSE_LIFECYCLE_DESTROY_IN_CONFIG\x10\x10B@Z>github.com/opentofu/opentofu/internal/plans/internal/planprotob\x06proto3"
+	"*FORGOT_BECAUSE_LIFECYCLE_DESTROY_IN_CONFIG\x10\x10*9\n" +
+	"\x0eDeferredReason\x12\x11\n" +
+	"\rUNKNOWN_COUNT\x10\x00\x12\x14\n" +
+	"\x10UNKNOWN_FOR_EACH\x10\x01B@Z>github.com/opentofu/opentofu/internal/plans/internal/planprotob\x06proto3"
 
 var (
 	file_planfile_proto_rawDescOnce sync.Once
@@ -1557,63 +1792,71 @@ func file_planfile_proto_rawDescGZIP() []byte {
 	return file_planfile_proto_rawDescData
 }
 
-var file_planfile_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_planfile_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_planfile_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_planfile_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_planfile_proto_goTypes = []any{
-	(Mode)(0),                         // 0: tfplan.Mode
-	(Action)(0),                       // 1: tfplan.Action
-	(ResourceInstanceActionReason)(0), // 2: tfplan.ResourceInstanceActionReason
-	(CheckResults_Status)(0),          // 3: tfplan.CheckResults.Status
-	(CheckResults_ObjectKind)(0),      // 4: tfplan.CheckResults.ObjectKind
-	(*Plan)(nil),                      // 5: tfplan.Plan
-	(*Backend)(nil),                   // 6: tfplan.Backend
-	(*Change)(nil),                    // 7: tfplan.Change
-	(*ResourceInstanceChange)(nil),    // 8: tfplan.ResourceInstanceChange
-	(*OutputChange)(nil),              // 9: tfplan.OutputChange
-	(*CheckResults)(nil),              // 10: tfplan.CheckResults
-	(*DynamicValue)(nil),              // 11: tfplan.DynamicValue
-	(*Path)(nil),                      // 12: tfplan.Path
-	(*Importing)(nil),                 // 13: tfplan.Importing
-	nil,                               // 14: tfplan.Plan.VariablesEntry
-	(*PlanResourceAttr)(nil),          // 15: tfplan.Plan.resource_attr
-	(*CheckResults_ObjectResult)(nil), // 16: tfplan.CheckResults.ObjectResult
-	(*Path_Step)(nil),                 // 17: tfplan.Path.Step
+	(Mode)(0),                          // 0: tfplan.Mode
+	(Action)(0),                        // 1: tfplan.Action
+	(ResourceInstanceActionReason)(0),  // 2: tfplan.ResourceInstanceActionReason
+	(DeferredReason)(0),                // 3: tfplan.DeferredReason
+	(CheckResults_Status)(0),           // 4: tfplan.CheckResults.Status
+	(CheckResults_ObjectKind)(0),       // 5: tfplan.CheckResults.ObjectKind
+	(CheckResults_Failure_Severity)(0), // 6: tfplan.CheckResults.Failure.Severity
+	(*Plan)(nil),                       // 7: tfplan.Plan
+	(*Backend)(nil),                    // 8: tfplan.Backend
+	(*Change)(nil),                     // 9: tfplan.Change
+	(*ResourceInstanceChange)(nil),     // 10: tfplan.ResourceInstanceChange
+	(*OutputChange)(nil),               // 11: tfplan.OutputChange
+	(*CheckResults)(nil),               // 12: tfplan.CheckResults
+	(*DynamicValue)(nil),               // 13: tfplan.DynamicValue
+	(*Path)(nil),                       // 14: tfplan.Path
+	(*Importing)(nil),                  // 15: tfplan.Importing
+	(*DeferredChange)(nil),             // 16: tfplan.DeferredChange
+	nil,                                // 17: tfplan.Plan.VariablesEntry
+	(*PlanResourceAttr)(nil),           // 18: tfplan.Plan.resource_attr
+	(*CheckResults_Failure)(nil),       // 19: tfplan.CheckResults.Failure
+	(*CheckResults_ObjectResult)(nil),  // 20: tfplan.CheckResults.ObjectResult
+	(*Path_Step)(nil),                  // 21: tfplan.Path.Step
 }
 var file_planfile_proto_depIdxs = []int32{
 	0,  // 0: tfplan.Plan.ui_mode:type_name -> tfplan.Mode
-	14, // 1: tfplan.Plan.variables:type_name -> tfplan.Plan.VariablesEntry
-	8,  // 2: tfplan.Plan.resource_changes:type_name -> tfplan.ResourceInstanceChange
-	8,  // 3: tfplan.Plan.resource_drift:type_name -> tfplan.ResourceInstanceChange
-	9,  // 4: tfplan.Plan.output_changes:type_name -> tfplan.OutputChange
-	10, // 5: tfplan.Plan.check_results:type_name -> tfplan.CheckResults
-	6,  // 6: tfplan.Plan.backend:type_name -> tfplan.Backend
-	15, // 7: tfplan.Plan.relevant_attributes:type_name -> tfplan.Plan.resource_attr
-	11, // 8: tfplan.Backend.config:type_name -> tfplan.DynamicValue
-	1,  // 9: tfplan.Change.action:type_name -> tfplan.Action
-	11, // 10: tfplan.Change.values:type_name -> tfplan.DynamicValue
-	12, // 11: tfplan.Change.before_sensitive_paths:type_name -> tfplan.Path
-	12, // 12: tfplan.Change.after_sensitive_paths:type_name -> tfplan.Path
-	13, // 13: tfplan.Change.importing:type_name -> tfplan.Importing
-	11, // 14: tfplan.Change.before_identity:type_name -> tfplan.DynamicValue
-	11, // 15: tfplan.Change.after_identity:type_name -> tfplan.DynamicValue
-	7,  // 16: tfplan.ResourceInstanceChange.change:type_name -> tfplan.Change
-	12, // 17: tfplan.ResourceInstanceChange.required_replace:type_name -> tfplan.Path
-	2,  // 18: tfplan.ResourceInstanceChange.action_reason:type_name -> tfplan.ResourceInstanceActionReason
-	7,  // 19: tfplan.OutputChange.change:type_name -> tfplan.Change
-	4,  // 20: tfplan.CheckResults.kind:type_name -> tfplan.CheckResults.ObjectKind
-	3,  // 21: tfplan.CheckResults.status:type_name -> tfplan.CheckResults.Status
-	16, // 22: tfplan.CheckResults.objects:type_name -> tfplan.CheckResults.ObjectResult
-	17, // 23: tfplan.Path.steps:type_name -> tfplan.Path.Step
-	11, // 24: tfplan.Importing.identity:type_name -> tfplan.DynamicValue
-	11, // 25: tfplan.Plan.VariablesEntry.value:type_name -> tfplan.DynamicValue
-	12, // 26: tfplan.Plan.resource_attr.attr:type_name -> tfplan.Path
-	3,  // 27: tfplan.CheckResults.ObjectResult.status:type_name -> tfplan.CheckResults.Status
-	11, // 28: tfplan.Path.Step.element_key:type_name -> tfplan.DynamicValue
-	29, // [29:29] is the sub-list for method output_type
-	29, // [29:29] is the sub-list for method input_type
-	29, // [29:29] is the sub-list for extension type_name
-	29, // [29:29] is the sub-list for extension extendee
-	0,  // [0:29] is the sub-list for field type_name
+	17, // 1: tfplan.Plan.variables:type_name -> tfplan.Plan.VariablesEntry
+	10, // 2: tfplan.Plan.resource_changes:type_name -> tfplan.ResourceInstanceChange
+	10, // 3: tfplan.Plan.resource_drift:type_name -> tfplan.ResourceInstanceChange
+	11, // 4: tfplan.Plan.output_changes:type_name -> tfplan.OutputChange
+	12, // 5: tfplan.Plan.check_results:type_name -> tfplan.CheckResults
+	16, // 6: tfplan.Plan.deferred_changes:type_name -> tfplan.DeferredChange
+	8,  // 7: tfplan.Plan.backend:type_name -> tfplan.Backend
+	18, // 8: tfplan.Plan.relevant_attributes:type_name -> tfplan.Plan.resource_attr
+	13, // 9: tfplan.Backend.config:type_name -> tfplan.DynamicValue
+	1,  // 10: tfplan.Change.action:type_name -> tfplan.Action
+	13, // 11: tfplan.Change.values:type_name -> tfplan.DynamicValue
+	14, // 12: tfplan.Change.before_sensitive_paths:type_name -> tfplan.Path
+	14, // 13: tfplan.Change.after_sensitive_paths:type_name -> tfplan.Path
+	15, // 14: tfplan.Change.importing:type_name -> tfplan.Importing
+	13, // 15: tfplan.Change.before_identity:type_name -> tfplan.DynamicValue
+	13, // 16: tfplan.Change.after_identity:type_name -> tfplan.DynamicValue
+	9,  // 17: tfplan.ResourceInstanceChange.change:type_name -> tfplan.Change
+	14, // 18: tfplan.ResourceInstanceChange.required_replace:type_name -> tfplan.Path
+	2,  // 19: tfplan.ResourceInstanceChange.action_reason:type_name -> tfplan.ResourceInstanceActionReason
+	9,  // 20: tfplan.OutputChange.change:type_name -> tfplan.Change
+	5,  // 21: tfplan.CheckResults.kind:type_name -> tfplan.CheckResults.ObjectKind
+	4,  // 22: tfplan.CheckResults.status:type_name -> tfplan.CheckResults.Status
+	20, // 23: tfplan.CheckResults.objects:type_name -> tfplan.CheckResults.ObjectResult
+	21, // 24: tfplan.Path.steps:type_name -> tfplan.Path.Step
+	13, // 25: tfplan.Importing.identity:type_name -> tfplan.DynamicValue
+	3,  // 26: tfplan.DeferredChange.reason:type_name -> tfplan.DeferredReason
+	13, // 27: tfplan.Plan.VariablesEntry.value:type_name -> tfplan.DynamicValue
+	14, // 28: tfplan.Plan.resource_attr.attr:type_name -> tfplan.Path
+	6,  // 29: tfplan.CheckResults.Failure.severity:type_name -> tfplan.CheckResults.Failure.Severity
+	4,  // 30: tfplan.CheckResults.ObjectResult.status:type_name -> tfplan.CheckResults.Status
+	19, // 31: tfplan.CheckResults.ObjectResult.failures:type_name -> tfplan.CheckResults.Failure
+	13, // 32: tfplan.Path.Step.element_key:type_name -> tfplan.DynamicValue
+	33, // [33:33] is the sub-list for method output_type
+	33, // [33:33] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_planfile_proto_init() }
@@ -1621,7 +1864,7 @@ func file_planfile_proto_init() {
 	if File_planfile_proto != nil {
 		return
 	}
-	file_planfile_proto_msgTypes[12].OneofWrappers = []any{
+	file_planfile_proto_msgTypes[14].OneofWrappers = []any{
 		(*Path_Step_AttributeName)(nil),
 		(*Path_Step_ElementKey)(nil),
 	}
@@ -1630,8 +1873,8 @@ func file_planfile_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_planfile_proto_rawDesc), len(file_planfile_proto_rawDesc)),
-			NumEnums:      5,
-			NumMessages:   13,
+			NumEnums:      7,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -61,6 +61,15 @@ type Plan struct {
 	ForceReplaceAddrs []addrs.AbsResourceInstance
 	Backend           Backend
 
+	// DeferredChanges describes objects whose planning was deferred to a
+	// later plan/apply round, because the caller allowed deferral and
+	// OpenTofu could not yet determine which instances of them should exist.
+	//
+	// Deferred objects and everything that depends on them are excluded from
+	// the plan, so applying the plan must exclude them too. Use
+	// [Plan.ApplyExcludeAddrs] to find all of the addresses to exclude.
+	DeferredChanges []*DeferredChange
+
 	// Errored is true if the Changes information is incomplete because
 	// the planning operation failed. An errored plan cannot be applied,
 	// but can be cautiously inspected for debugging purposes.
@@ -194,6 +203,21 @@ func (p *Plan) CanApply() bool {
 	}
 }
 
+// ApplyExcludeAddrs returns the addresses of all of the objects that must be
+// excluded when applying the receiving plan: those excluded by the caller
+// when creating the plan, followed by those whose planning was deferred.
+func (p *Plan) ApplyExcludeAddrs() []addrs.Targetable {
+	if len(p.DeferredChanges) == 0 {
+		return p.ExcludeAddrs
+	}
+	ret := make([]addrs.Targetable, 0, len(p.ExcludeAddrs)+len(p.DeferredChanges))
+	ret = append(ret, p.ExcludeAddrs...)
+	for _, dc := range p.DeferredChanges {
+		ret = append(ret, dc.Addr)
+	}
+	return ret
+}
+
 // ProviderAddrs returns a list of all of the provider configuration addresses
 // referenced throughout the receiving plan.
 //
@@ -0,0 +1,304 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package planfile
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/replacefile"
+)
+
+const approvalsFilename = "tfapprovals.json"
+
+// Approval algorithms, recorded in [Approval.Algorithm].
+const (
+	ApprovalHMACSHA256 = "hmac-sha256"
+	ApprovalEd25519    = "ed25519"
+)
+
+// minHMACKeyLength is the minimum length of a shared secret used to sign
+// approvals with HMAC-SHA256.
+const minHMACKeyLength = 32
+
+// Approval records that someone approved a saved plan, with a signature over
+// the contents of the plan file that proves that the approver held one of
+// the approval keys and that the plan has not changed since.
+type Approval struct {
+	Approver   string    `json:"approver"`
+	ApprovedAt time.Time `json:"approved_at"`
+	Algorithm  string    `json:"algorithm"`
+	Signature  []byte    `json:"signature"`
+}
+
+// ApprovalKey is a key used to sign or verify approvals of saved plans.
+//
+// An approval key is either a shared secret used with HMAC-SHA256, which
+// can both sign and verify approvals, or an Ed25519 key pair. An Ed25519
+// private key can both sign and verify approvals, while a public key can
+// only verify them.
+type ApprovalKey struct {
+	// Filename is the file the key was loaded from, used in messages.
+	Filename string
+
+	secret     []byte
+	privateKey ed25519.PrivateKey
+	publicKey  ed25519.PublicKey
+}
+
+// LoadApprovalKey loads an approval key from the given file.
+//
+// A file containing a PEM-encoded "PRIVATE KEY" or "PUBLIC KEY" block is
+// loaded as an Ed25519 key, in PKCS #8 or PKIX format respectively. Any other
+// file is used as an HMAC-SHA256 shared secret, ignoring leading and
+// trailing whitespace.
+func LoadApprovalKey(filename string) (*ApprovalKey, error) {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	ret := &ApprovalKey{Filename: filename}
+
+	block, _ := pem.Decode(src)
+	if block == nil {
+		ret.secret = bytes.TrimSpace(src)
+		if len(ret.secret) < minHMACKeyLength {
+			return nil, fmt.Errorf("%s: a shared secret for approvals must be at least %d bytes long", filename, minHMACKeyLength)
+		}
+		return ret, nil
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid private key: %w", filename, err)
+		}
+		privateKey, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%s: only Ed25519 private keys can be used for approvals", filename)
+		}
+		ret.privateKey = privateKey
+		ret.publicKey = privateKey.Public().(ed25519.PublicKey)
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid public key: %w", filename, err)
+		}
+		publicKey, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("%s: only Ed25519 public keys can be used for approvals", filename)
+		}
+		ret.publicKey = publicKey
+	default:
+		return nil, fmt.Errorf("%s: unsupported PEM block type %q; expected \"PRIVATE KEY\" or \"PUBLIC KEY\"", filename, block.Type)
+	}
+	return ret, nil
+}
+
+// CanSign returns true if the key can be used to sign approvals, rather than
+// only to verify them.
+func (k *ApprovalKey) CanSign() bool {
+	return k.secret != nil || k.privateKey != nil
+}
+
+func (k *ApprovalKey) sign(msg []byte) (algorithm string, signature []byte) {
+	if k.secret != nil {
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(msg)
+		return ApprovalHMACSHA256, mac.Sum(nil)
+	}
+	return ApprovalEd25519, ed25519.Sign(k.privateKey, msg)
+}
+
+func (k *ApprovalKey) verify(algorithm string, msg, signature []byte) bool {
+	switch algorithm {
+	case ApprovalHMACSHA256:
+		if k.secret == nil {
+			return false
+		}
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(msg)
+		return hmac.Equal(mac.Sum(nil), signature)
+	case ApprovalEd25519:
+		if k.publicKey == nil {
+			return false
+		}
+		return ed25519.Verify(k.publicKey, msg, signature)
+	default:
+		return false
+	}
+}
+
+// ReadApprovals reads the approvals recorded in the plan file, without
+// verifying them.
+func (r *Reader) ReadApprovals() ([]*Approval, error) {
+	for _, file := range r.zip.File {
+		if file.Name != approvalsFilename {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract approvals from plan file: %w", err)
+		}
+		defer rc.Close()
+
+		var approvals []*Approval
+		if err := json.NewDecoder(rc).Decode(&approvals); err != nil {
+			return nil, fmt.Errorf("failed to read approvals from plan file: %w", err)
+		}
+		return approvals, nil
+	}
+	return nil, nil
+}
+
+// VerifiedApprovals returns the approvals recorded in the plan file that
+// have a valid signature by any of the given keys.
+func (r *Reader) VerifiedApprovals(keys []*ApprovalKey) ([]*Approval, error) {
+	approvals, err := r.ReadApprovals()
+	if err != nil || len(approvals) == 0 {
+		return nil, err
+	}
+	digest, err := r.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*Approval
+	for _, approval := range approvals {
+		msg := approvalMessage(digest, approval.Approver, approval.ApprovedAt)
+		for _, key := range keys {
+			if key.verify(approval.Algorithm, msg, approval.Signature) {
+				ret = append(ret, approval)
+				break
+			}
+		}
+	}
+	return ret, nil
+}
+
+// Approve adds an approval by the given approver, signed with the given key,
+// to the plan file with the given filename.
+//
+// The signature covers everything in the plan file except for its other
+// approvals, so a plan can be approved by more than one approver.
+func Approve(filename string, key *ApprovalKey, approver string, now time.Time, enc encryption.PlanEncryption) (*Approval, error) {
+	if !key.CanSign() {
+		return nil, fmt.Errorf("%s is a public key, which can only verify approvals; use the corresponding private key to approve a plan", key.Filename)
+	}
+
+	r, err := Open(filename, enc)
+	if err != nil {
+		return nil, err
+	}
+	approvals, err := r.ReadApprovals()
+	if err != nil {
+		return nil, err
+	}
+	digest, err := r.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	approval := &Approval{
+		Approver:   approver,
+		ApprovedAt: now.UTC(),
+	}
+	approval.Algorithm, approval.Signature = key.sign(approvalMessage(digest, approval.Approver, approval.ApprovedAt))
+	approvals = append(approvals, approval)
+
+	src, err := json.Marshal(approvals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize approvals: %w", err)
+	}
+
+	buff := bytes.NewBuffer(make([]byte, 0))
+	zw := zip.NewWriter(buff)
+	for _, file := range r.zip.File {
+		if file.Name == approvalsFilename {
+			continue
+		}
+		if err := zw.Copy(file); err != nil {
+			return nil, fmt.Errorf("failed to copy %s: %w", file.Name, err)
+		}
+	}
+	w, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     approvalsFilename,
+		Method:   zip.Deflate,
+		Modified: now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create embedded approvals file: %w", err)
+	}
+	if _, err := w.Write(src); err != nil {
+		return nil, fmt.Errorf("failed to write embedded approvals file: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	encrypted, err := enc.EncryptPlan(buff.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// The plan file is replaced rather than overwritten in place, so that a
+	// crash or a concurrent approval can't leave a truncated plan behind.
+	info, err := os.Stat(filename)
+	if err != nil {
+		return nil, err
+	}
+	if err := replacefile.AtomicWriteFile(filename, encrypted, info.Mode().Perm()); err != nil {
+		return nil, err
+	}
+	return approval, nil
+}
+
+// digest returns a SHA-256 digest of the names and contents of every file in
+// the plan file except for its approvals.
+func (r *Reader) digest() ([]byte, error) {
+	files := make([]*zip.File, 0, len(r.zip.File))
+	for _, file := range r.zip.File {
+		if file.Name != approvalsFilename {
+			files = append(files, file)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Name < files[j].Name
+	})
+
+	h := sha256.New()
+	for _, file := range files {
+		rc, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from plan file: %w", file.Name, err)
+		}
+		fh := sha256.New()
+		_, err = io.Copy(fh, rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from plan file: %w", file.Name, err)
+		}
+		fmt.Fprintf(h, "%s\x00%x\n", file.Name, fh.Sum(nil))
+	}
+	return h.Sum(nil), nil
+}
+
+// approvalMessage returns the message that is signed to approve a plan with
+// the given digest.
+func approvalMessage(digest []byte, approver string, approvedAt time.Time) []byte {
+	return fmt.Appendf(nil, "opentofu-plan-approval-v1\n%x\n%s\n%s", digest, approver, approvedAt.UTC().Format(time.RFC3339Nano))
+}
@@ -0,0 +1,255 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package planfile
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/configs/configload"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/states/statefile"
+	tfversion "github.com/opentofu/opentofu/version"
+)
+
+func TestApprove(t *testing.T) {
+	dir := t.TempDir()
+	hmacKey := testApprovalKeyFile(t, dir, "hmac.key", []byte(strings.Repeat("s3cr3t", 8)+"\n"))
+	otherHMACKey := testApprovalKeyFile(t, dir, "other.key", []byte(strings.Repeat("0ther", 8)))
+	privateKey, publicKey := testEd25519KeyFiles(t, dir)
+
+	planFn := testPlanFileForApproval(t, dir)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	enc := encryption.PlanEncryptionDisabled()
+
+	if _, err := Approve(planFn, hmacKey, "alice", now, enc); err != nil {
+		t.Fatalf("failed to approve with HMAC key: %s", err)
+	}
+	if _, err := Approve(planFn, privateKey, "bob", now.Add(time.Minute), enc); err != nil {
+		t.Fatalf("failed to approve with private key: %s", err)
+	}
+	if _, err := Approve(planFn, publicKey, "mallory", now, enc); err == nil {
+		t.Fatal("approving with a public key succeeded; want error")
+	}
+
+	pr, err := Open(planFn, enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pr.ReadPlan(); err != nil {
+		t.Fatalf("plan is no longer readable after approval: %s", err)
+	}
+
+	tests := map[string]struct {
+		keys []*ApprovalKey
+		want []string
+	}{
+		"no keys":        {nil, nil},
+		"hmac key":       {[]*ApprovalKey{hmacKey}, []string{"alice"}},
+		"other hmac key": {[]*ApprovalKey{otherHMACKey}, nil},
+		"public key":     {[]*ApprovalKey{publicKey}, []string{"bob"}},
+		"all keys":       {[]*ApprovalKey{otherHMACKey, publicKey, hmacKey}, []string{"alice", "bob"}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			approvals, err := pr.VerifiedApprovals(test.keys)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, approval := range approvals {
+				got = append(got, approval.Approver)
+			}
+			if strings.Join(got, ",") != strings.Join(test.want, ",") {
+				t.Errorf("wrong approvers %q; want %q", got, test.want)
+			}
+		})
+	}
+
+	t.Run("tampered approver", func(t *testing.T) {
+		approvals, err := pr.ReadApprovals()
+		if err != nil {
+			t.Fatal(err)
+		}
+		approval := approvals[0]
+		digest, err := pr.digest()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hmacKey.verify(approval.Algorithm, approvalMessage(digest, "mallory", approval.ApprovedAt), approval.Signature) {
+			t.Error("signature is valid for a different approver")
+		}
+	})
+
+	t.Run("changed plan", func(t *testing.T) {
+		// Creating the plan file again with different content must
+		// invalidate the approvals, even if they are copied over.
+		approvals, err := pr.ReadApprovals()
+		if err != nil {
+			t.Fatal(err)
+		}
+		otherFn := testPlanFileForApproval(t, t.TempDir(), "changed")
+		other, err := Open(otherFn, enc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		digest, err := other.digest()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, approval := range approvals {
+			msg := approvalMessage(digest, approval.Approver, approval.ApprovedAt)
+			if hmacKey.verify(approval.Algorithm, msg, approval.Signature) || publicKey.verify(approval.Algorithm, msg, approval.Signature) {
+				t.Errorf("approval by %s is valid for a different plan", approval.Approver)
+			}
+		}
+	})
+}
+
+func TestApprove_fileMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not preserved on Windows")
+	}
+	dir := t.TempDir()
+	hmacKey := testApprovalKeyFile(t, dir, "hmac.key", []byte(strings.Repeat("s3cr3t", 8)))
+	planFn := testPlanFileForApproval(t, dir)
+	if err := os.Chmod(planFn, 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Approve(planFn, hmacKey, "alice", time.Now(), encryption.PlanEncryptionDisabled()); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(planFn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := info.Mode().Perm(), os.FileMode(0640); got != want {
+		t.Errorf("wrong mode after approval %s; want %s", got, want)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("approval left extra files behind: %v", entries)
+	}
+}
+
+func TestLoadApprovalKey_invalid(t *testing.T) {
+	dir := t.TempDir()
+	tests := map[string]struct {
+		content string
+		wantErr string
+	}{
+		"short secret": {
+			"too short",
+			"must be at least 32 bytes long",
+		},
+		"certificate": {
+			"-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n",
+			`unsupported PEM block type "CERTIFICATE"`,
+		},
+		"invalid public key": {
+			"-----BEGIN PUBLIC KEY-----\nAAAA\n-----END PUBLIC KEY-----\n",
+			"invalid public key",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fn := filepath.Join(dir, strings.ReplaceAll(name, " ", "-"))
+			if err := os.WriteFile(fn, []byte(test.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadApprovalKey(fn)
+			if err == nil {
+				t.Fatal("succeeded; want error")
+			}
+			if !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("wrong error %q; want %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func testApprovalKeyFile(t *testing.T, dir, name string, content []byte) *ApprovalKey {
+	t.Helper()
+
+	fn := filepath.Join(dir, name)
+	if err := os.WriteFile(fn, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+	key, err := LoadApprovalKey(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func testEd25519KeyFiles(t *testing.T, dir string) (privateKey, publicKey *ApprovalKey) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey = testApprovalKeyFile(t, dir, "approver.pem", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}))
+	publicKey = testApprovalKeyFile(t, dir, "approver.pub.pem", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}))
+	return privateKey, publicKey
+}
+
+func testPlanFileForApproval(t *testing.T, dir string, lineage ...string) string {
+	t.Helper()
+
+	stateFile := &statefile.File{
+		TerraformVersion: tfversion.SemVer,
+		Lineage:          strings.Join(append([]string{"approval"}, lineage...), "-"),
+		State:            states.NewState(),
+	}
+	fn := filepath.Join(dir, "tfplan")
+	err := Create(fn, CreateArgs{
+		ConfigSnapshot: &configload.Snapshot{
+			Modules: map[string]*configload.SnapshotModule{
+				"": {
+					Dir:   ".",
+					Files: map[string][]byte{"main.tf": nil},
+				},
+			},
+		},
+		PreviousRunStateFile: stateFile,
+		StateFile:            stateFile,
+		Plan: &plans.Plan{
+			Changes: plans.NewChanges(),
+			Backend: plans.Backend{
+				Type:      "local",
+				Config:    plans.DynamicValue([]byte("config placeholder")),
+				Workspace: "default",
+			},
+		},
+		DependencyLocks: depsfile.NewLocks(),
+	}, encryption.PlanEncryptionDisabled())
+	if err != nil {
+		t.Fatalf("failed to create plan file: %s", err)
+	}
+	return fn
+}
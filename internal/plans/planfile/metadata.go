@@ -0,0 +1,63 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package planfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const metadataFilename = "tfplanmeta.json"
+
+// Metadata describes when a saved plan was created and, optionally, when it
+// expires.
+//
+// Plan files created by earlier versions of OpenTofu don't include any
+// metadata.
+type Metadata struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExpiresAt is the time after which the plan can no longer be applied,
+	// or the zero time if the plan doesn't expire.
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+}
+
+// NewMetadata returns the metadata for a plan created at the given time that
+// expires after the given duration, or never expires if expiresIn is zero.
+func NewMetadata(now time.Time, expiresIn time.Duration) *Metadata {
+	ret := &Metadata{CreatedAt: now.UTC()}
+	if expiresIn > 0 {
+		ret.ExpiresAt = ret.CreatedAt.Add(expiresIn)
+	}
+	return ret
+}
+
+// Expired returns true if the plan has an expiration time that is before the
+// given time.
+func (m *Metadata) Expired(now time.Time) bool {
+	return !m.ExpiresAt.IsZero() && now.After(m.ExpiresAt)
+}
+
+// ReadMetadata reads the metadata recorded when the plan was created, or
+// returns nil if the plan file doesn't include any.
+func (r *Reader) ReadMetadata() (*Metadata, error) {
+	for _, file := range r.zip.File {
+		if file.Name != metadataFilename {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract metadata from plan file: %w", err)
+		}
+		defer rc.Close()
+
+		var meta Metadata
+		if err := json.NewDecoder(rc).Decode(&meta); err != nil {
+			return nil, fmt.Errorf("failed to read metadata from plan file: %w", err)
+		}
+		return &meta, nil
+	}
+	return nil, nil
+}
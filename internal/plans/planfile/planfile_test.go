@@ -10,6 +10,7 @@ import (
 	"strings"
 	"syscall"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/policy"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/statefile"
 	tfversion "github.com/opentofu/opentofu/version"
@@ -108,6 +110,21 @@ func TestRoundtrip(t *testing.T) {
 		},
 	)
 
+	policyResultsIn := &policy.Results{
+		Policies: []*policy.Result{
+			{
+				Name:        "no_deletes",
+				Enforcement: policy.Mandatory,
+				Status:      policy.StatusFail,
+				Failures: []policy.Failure{
+					{Message: "Deleting resources is not allowed.", Filename: "policies/main.tfpolicy.hcl", Line: 4},
+				},
+			},
+		},
+	}
+
+	metadataIn := NewMetadata(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), 24*time.Hour)
+
 	planFn := filepath.Join(t.TempDir(), "tfplan")
 
 	err = Create(planFn, CreateArgs{
@@ -116,6 +133,8 @@ func TestRoundtrip(t *testing.T) {
 		StateFile:            stateFileIn,
 		Plan:                 planIn,
 		DependencyLocks:      locksIn,
+		PolicyResults:        policyResultsIn,
+		Metadata:             metadataIn,
 	}, encryption.PlanEncryptionDisabled())
 	if err != nil {
 		t.Fatalf("failed to create plan file: %s", err)
@@ -194,6 +213,29 @@ func TestRoundtrip(t *testing.T) {
 			t.Errorf("provider locks did not survive round-trip\n%s", diff)
 		}
 	})
+
+	t.Run("ReadPolicyResults", func(t *testing.T) {
+		policyResultsOut, err := pr.ReadPolicyResults()
+		if err != nil {
+			t.Fatalf("failed to read policy results: %s", err)
+		}
+		if diff := cmp.Diff(policyResultsIn, policyResultsOut); diff != "" {
+			t.Errorf("policy results did not survive round-trip\n%s", diff)
+		}
+	})
+
+	t.Run("ReadMetadata", func(t *testing.T) {
+		metadataOut, err := pr.ReadMetadata()
+		if err != nil {
+			t.Fatalf("failed to read metadata: %s", err)
+		}
+		if diff := cmp.Diff(metadataIn, metadataOut); diff != "" {
+			t.Errorf("metadata did not survive round-trip\n%s", diff)
+		}
+		if !metadataOut.ExpiresAt.Equal(metadataIn.CreatedAt.Add(24 * time.Hour)) {
+			t.Errorf("wrong expiration time %s", metadataOut.ExpiresAt)
+		}
+	})
 }
 
 func TestWrappedError(t *testing.T) {
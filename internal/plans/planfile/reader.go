@@ -9,6 +9,7 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -18,6 +19,7 @@ import (
 	"github.com/opentofu/opentofu/internal/depsfile"
 	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/policy"
 	"github.com/opentofu/opentofu/internal/states/statefile"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
@@ -25,6 +27,7 @@ import (
 const tfstateFilename = "tfstate"
 const tfstatePreviousFilename = "tfstate-prev"
 const dependencyLocksFilename = ".terraform.lock.hcl" // matches the conventional name in an input configuration
+const policyResultsFilename = "tfpolicy.json"
 
 // ErrUnusableLocalPlan is an error wrapper to indicate that we *think* the
 // input represents plan file data, but can't use it for some reason (as
@@ -278,3 +281,26 @@ func (r *Reader) ReadDependencyLocks() (*depsfile.Locks, tfdiags.Diagnostics) {
 	))
 	return nil, diags
 }
+
+// ReadPolicyResults reads the results of the policies that were evaluated
+// against the plan when it was created, or returns nil if the plan was
+// created without evaluating any policies.
+func (r *Reader) ReadPolicyResults() (*policy.Results, error) {
+	for _, file := range r.zip.File {
+		if file.Name != policyResultsFilename {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract policy results from plan file: %w", err)
+		}
+		defer rc.Close()
+
+		var results policy.Results
+		if err := json.NewDecoder(rc).Decode(&results); err != nil {
+			return nil, fmt.Errorf("failed to read policy results from plan file: %w", err)
+		}
+		return &results, nil
+	}
+	return nil, nil
+}
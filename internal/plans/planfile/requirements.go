@@ -0,0 +1,22 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package planfile
+
+import (
+	"time"
+)
+
+// Requirements are conditions, typically set in the CLI configuration, that
+// a saved plan must meet before it can be applied, in addition to those that
+// every saved plan must meet.
+type Requirements struct {
+	// MaxAge is the longest time after its creation that a plan can be
+	// applied, or zero if there is no limit.
+	MaxAge time.Duration
+
+	// RequireApproval requires the plan to have at least one approval with a
+	// valid signature by one of ApprovalKeys.
+	RequireApproval bool
+	ApprovalKeys    []*ApprovalKey
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/plans/internal/planproto"
 	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/opentofu/opentofu/version"
 )
 
@@ -158,8 +159,21 @@ func readTfplan(r io.Reader) (*plans.Plan, error) {
 				return nil, fmt.Errorf("checkable object %s should not be grouped under %s", objectAddr, configAddr)
 			}
 
-			obj := &states.CheckResultObject{
-				FailureMessages: rawCR.FailureMessages,
+			obj := &states.CheckResultObject{}
+			for _, rawFailure := range rawCR.Failures {
+				failure := checks.Failure{
+					Message:   rawFailure.Message,
+					ErrorCode: rawFailure.ErrorCode,
+				}
+				switch rawFailure.Severity {
+				case planproto.CheckResults_Failure_ERROR:
+					failure.Severity = tfdiags.Error
+				case planproto.CheckResults_Failure_WARNING:
+					failure.Severity = tfdiags.Warning
+				default:
+					return nil, fmt.Errorf("object check results for %s has failure with unsupported severity %#v", rawCR.ObjectAddr, rawFailure.Severity)
+				}
+				obj.Failures = append(obj.Failures, failure)
 			}
 			switch rawCR.Status {
 			case planproto.CheckResults_UNKNOWN:
@@ -234,6 +248,14 @@ func readTfplan(r io.Reader) (*plans.Plan, error) {
 		plan.ExcludeAddrs = append(plan.ExcludeAddrs, exclude.Subject)
 	}
 
+	for _, rawDeferred := range rawPlan.DeferredChanges {
+		dc, err := deferredChangeFromTfplan(rawDeferred)
+		if err != nil {
+			return nil, err
+		}
+		plan.DeferredChanges = append(plan.DeferredChanges, dc)
+	}
+
 	for _, rawReplaceAddr := range rawPlan.ForceReplaceAddrs {
 		addr, diags := addrs.ParseAbsResourceInstanceStr(rawReplaceAddr)
 		if diags.HasErrors() {
@@ -620,8 +642,22 @@ func writeTfplan(plan *plans.Plan, w io.Writer) error {
 			for _, objectElem := range configElem.Value.ObjectResults.Elems {
 				cr := objectElem.Value
 				pcr := &planproto.CheckResults_ObjectResult{
-					ObjectAddr:      objectElem.Key.String(),
-					FailureMessages: objectElem.Value.FailureMessages,
+					ObjectAddr: objectElem.Key.String(),
+				}
+				for _, failure := range cr.Failures {
+					pf := &planproto.CheckResults_Failure{
+						Message:   failure.Message,
+						ErrorCode: failure.ErrorCode,
+					}
+					switch failure.Severity {
+					case tfdiags.Error:
+						pf.Severity = planproto.CheckResults_Failure_ERROR
+					case tfdiags.Warning:
+						pf.Severity = planproto.CheckResults_Failure_WARNING
+					default:
+						return fmt.Errorf("checkable object %s has failure with unsupported severity %s", objectElem.Key, failure.Severity)
+					}
+					pcr.Failures = append(pcr.Failures, pf)
 				}
 				switch cr.Status {
 				case checks.StatusUnknown:
@@ -674,6 +710,14 @@ func writeTfplan(plan *plans.Plan, w io.Writer) error {
 		rawPlan.ExcludeAddrs = append(rawPlan.ExcludeAddrs, excludeAddr.String())
 	}
 
+	for _, dc := range plan.DeferredChanges {
+		rawDeferred, err := deferredChangeToTfplan(dc)
+		if err != nil {
+			return err
+		}
+		rawPlan.DeferredChanges = append(rawPlan.DeferredChanges, rawDeferred)
+	}
+
 	for _, replaceAddr := range plan.ForceReplaceAddrs {
 		rawPlan.ForceReplaceAddrs = append(rawPlan.ForceReplaceAddrs, replaceAddr.String())
 	}
@@ -758,6 +802,40 @@ func resourceAttrFromTfplan(ra *planproto.PlanResourceAttr) (globalref.ResourceA
 	return res, nil
 }
 
+func deferredChangeToTfplan(dc *plans.DeferredChange) (*planproto.DeferredChange, error) {
+	ret := &planproto.DeferredChange{
+		Addr: dc.Addr.String(),
+	}
+	switch dc.Reason {
+	case plans.DeferredBecauseUnknownCount:
+		ret.Reason = planproto.DeferredReason_UNKNOWN_COUNT
+	case plans.DeferredBecauseUnknownForEach:
+		ret.Reason = planproto.DeferredReason_UNKNOWN_FOR_EACH
+	default:
+		return nil, fmt.Errorf("deferred change for %s has unsupported reason %s", dc.Addr, dc.Reason)
+	}
+	return ret, nil
+}
+
+func deferredChangeFromTfplan(rawDeferred *planproto.DeferredChange) (*plans.DeferredChange, error) {
+	target, diags := addrs.ParseTargetStr(rawDeferred.Addr)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("plan contains invalid deferred address %q: %w", rawDeferred.Addr, diags.Err())
+	}
+	ret := &plans.DeferredChange{
+		Addr: target.Subject,
+	}
+	switch rawDeferred.Reason {
+	case planproto.DeferredReason_UNKNOWN_COUNT:
+		ret.Reason = plans.DeferredBecauseUnknownCount
+	case planproto.DeferredReason_UNKNOWN_FOR_EACH:
+		ret.Reason = plans.DeferredBecauseUnknownForEach
+	default:
+		return nil, fmt.Errorf("deferred change for %s has unsupported reason %s", rawDeferred.Addr, rawDeferred.Reason)
+	}
+	return ret, nil
+}
+
 func resourceChangeToTfplan(change *plans.ResourceInstanceChangeSrc) (*planproto.ResourceInstanceChange, error) {
 	ret := &planproto.ResourceInstanceChange{}
 
@@ -21,6 +21,7 @@ import (
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/providers"
 	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
 func TestTFPlanRoundTrip(t *testing.T) {
@@ -274,8 +275,10 @@ func TestTFPlanRoundTrip(t *testing.T) {
 									Name: "woot",
 								}.Instance(addrs.IntKey(0)).Absolute(addrs.RootModuleInstance),
 								&states.CheckResultObject{
-									Status:          checks.StatusFail,
-									FailureMessages: []string{"Oh no!"},
+									Status: checks.StatusFail,
+									Failures: []checks.Failure{
+										{Message: "Oh no!", ErrorCode: "OH_NO", Severity: tfdiags.Error},
+									},
 								},
 							),
 						),
@@ -293,8 +296,10 @@ func TestTFPlanRoundTrip(t *testing.T) {
 									Name: "check",
 								}.Absolute(addrs.RootModuleInstance),
 								&states.CheckResultObject{
-									Status:          checks.StatusFail,
-									FailureMessages: []string{"check failed"},
+									Status: checks.StatusFail,
+									Failures: []checks.Failure{
+										{Message: "check failed", Severity: tfdiags.Warning},
+									},
 								},
 							),
 						),
@@ -309,6 +314,20 @@ func TestTFPlanRoundTrip(t *testing.T) {
 				Name: "woot",
 			}.Absolute(addrs.RootModuleInstance),
 		},
+		DeferredChanges: []*plans.DeferredChange{
+			{
+				Addr: addrs.Resource{
+					Mode: addrs.ManagedResourceMode,
+					Type: "test_thing",
+					Name: "later",
+				}.Absolute(addrs.RootModuleInstance),
+				Reason: plans.DeferredBecauseUnknownForEach,
+			},
+			{
+				Addr:   addrs.RootModuleInstance.Child("child", addrs.NoKey),
+				Reason: plans.DeferredBecauseUnknownCount,
+			},
+		},
 		Backend: plans.Backend{
 			Type: "local",
 			Config: mustNewDynamicValue(
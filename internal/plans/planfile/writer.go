@@ -8,6 +8,7 @@ package planfile
 import (
 	"archive/zip"
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"github.com/opentofu/opentofu/internal/depsfile"
 	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/policy"
 	"github.com/opentofu/opentofu/internal/states/statefile"
 )
 
@@ -46,6 +48,16 @@ type CreateArgs struct {
 	// checked prior to creating the plan, so we can make sure that all of the
 	// same dependencies are still available when applying the plan.
 	DependencyLocks *depsfile.Locks
+
+	// PolicyResults records the outcome of any policies that were evaluated
+	// against the plan, so that applying the saved plan can refuse to
+	// proceed if a mandatory policy failed. This is nil if no policies were
+	// evaluated.
+	PolicyResults *policy.Results
+
+	// Metadata records when the plan was created and when it expires. This
+	// is nil only for plans created by callers that don't track it.
+	Metadata *Metadata
 }
 
 // Create creates a new plan file with the given filename, overwriting any
@@ -136,6 +148,49 @@ func Create(filename string, args CreateArgs, enc encryption.PlanEncryption) err
 		}
 	}
 
+	// tfpolicy.json file, containing the results of evaluating policies
+	if args.PolicyResults != nil {
+		src, err := json.Marshal(args.PolicyResults)
+		if err != nil {
+			return fmt.Errorf("failed to serialize policy results: %w", err)
+		}
+
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     policyResultsFilename,
+			Method:   zip.Deflate,
+			Modified: time.Now(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create embedded policy results file: %w", err)
+		}
+		_, err = w.Write(src)
+		if err != nil {
+			return fmt.Errorf("failed to write embedded policy results file: %w", err)
+		}
+	}
+
+	// tfplanmeta.json file, containing the plan's creation and expiration
+	// times
+	if args.Metadata != nil {
+		src, err := json.Marshal(args.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to serialize plan metadata: %w", err)
+		}
+
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     metadataFilename,
+			Method:   zip.Deflate,
+			Modified: time.Now(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create embedded plan metadata file: %w", err)
+		}
+		_, err = w.Write(src)
+		if err != nil {
+			return fmt.Errorf("failed to write embedded plan metadata file: %w", err)
+		}
+	}
+
 	// Finish zip file
 	zw.Close()
 	// Encrypt payload
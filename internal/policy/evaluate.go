@@ -0,0 +1,219 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/opentofu/opentofu/internal/lang"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// Status is the outcome of evaluating a policy.
+type Status string
+
+const (
+	// StatusPass means that all of the policy's assertions passed.
+	StatusPass Status = "pass"
+
+	// StatusFail means that at least one of the policy's assertions failed.
+	StatusFail Status = "fail"
+
+	// StatusError means that at least one of the policy's assertions could
+	// not be evaluated, and so OpenTofu couldn't decide whether it passed.
+	StatusError Status = "error"
+)
+
+// Results are the outcomes of evaluating each of the policies in a set
+// against a plan. They are recorded in saved plan files so that applying a
+// saved plan can't skip a failing mandatory policy.
+type Results struct {
+	Policies []*Result `json:"policies"`
+}
+
+// Result is the outcome of evaluating a single policy.
+type Result struct {
+	Name        string      `json:"name"`
+	Enforcement Enforcement `json:"enforcement"`
+	Status      Status      `json:"status"`
+
+	// Failures describe each assertion that failed or couldn't be
+	// evaluated.
+	Failures []Failure `json:"failures,omitempty"`
+}
+
+// Failure describes an assertion that did not pass, along with the location
+// of its condition in the policy file.
+type Failure struct {
+	Message  string `json:"message"`
+	Filename string `json:"filename"`
+	Line     int    `json:"line"`
+}
+
+// Blocking returns the results for mandatory policies that did not pass,
+// which must prevent the plan from being applied.
+func (r *Results) Blocking() []*Result {
+	if r == nil {
+		return nil
+	}
+	var ret []*Result
+	for _, result := range r.Policies {
+		if result.Enforcement == Mandatory && result.Status != StatusPass {
+			ret = append(ret, result)
+		}
+	}
+	return ret
+}
+
+// Evaluate evaluates all of the policies in the set against the given plan,
+// which must be in the JSON format produced by "tofu show -json".
+//
+// The returned diagnostics include a warning for each advisory policy that
+// did not pass and an error for each mandatory policy that did not pass.
+func (s *Set) Evaluate(planJSON []byte) (*Results, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	ty, err := ctyjson.ImpliedType(planJSON)
+	if err != nil {
+		diags = diags.Append(fmt.Errorf("failed to decode plan for policy evaluation: %w", err))
+		return nil, diags
+	}
+	plan, err := ctyjson.Unmarshal(planJSON, ty)
+	if err != nil {
+		diags = diags.Append(fmt.Errorf("failed to decode plan for policy evaluation: %w", err))
+		return nil, diags
+	}
+
+	scope := &lang.Scope{BaseDir: s.Dir}
+	hclCtx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"plan": plan,
+		},
+		Functions: scope.Functions(),
+	}
+
+	results := &Results{}
+	for _, p := range s.Policies {
+		result, moreDiags := p.evaluate(hclCtx)
+		results.Policies = append(results.Policies, result)
+		diags = diags.Append(moreDiags)
+	}
+	return results, diags
+}
+
+func (p *Policy) evaluate(hclCtx *hcl.EvalContext) (*Result, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	result := &Result{
+		Name:        p.Name,
+		Enforcement: p.Enforcement,
+		Status:      StatusPass,
+	}
+
+	for _, a := range p.Asserts {
+		pass, msg, moreDiags := a.evaluate(hclCtx)
+		if moreDiags.HasErrors() {
+			if p.Enforcement == Advisory {
+				moreDiags = tfdiags.OverrideAll(moreDiags, tfdiags.Warning, nil)
+			}
+			diags = diags.Append(moreDiags)
+			result.Status = StatusError
+			result.Failures = append(result.Failures, newFailure(a, "The condition could not be evaluated."))
+			continue
+		}
+		diags = diags.Append(moreDiags)
+		if pass {
+			continue
+		}
+
+		if result.Status == StatusPass {
+			result.Status = StatusFail
+		}
+		result.Failures = append(result.Failures, newFailure(a, msg))
+
+		severity := hcl.DiagError
+		consequence := "This policy is mandatory, so OpenTofu will not apply this plan."
+		if p.Enforcement == Advisory {
+			severity = hcl.DiagWarning
+			consequence = "This policy is advisory, so it does not prevent applying this plan."
+		}
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: severity,
+			Summary:  fmt.Sprintf("Policy %q failed", p.Name),
+			Detail:   fmt.Sprintf("%s\n\n%s", msg, consequence),
+			Subject:  a.Condition.Range().Ptr(),
+		})
+	}
+
+	return result, diags
+}
+
+// evaluate returns whether the assertion passed, and its error message if
+// it did not.
+//
+// The diagnostics deliberately don't include the expression and evaluation
+// context, because the diagnostic renderer would then describe the entire
+// plan object.
+func (a *Assert) evaluate(hclCtx *hcl.EvalContext) (bool, string, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	val, hclDiags := a.Condition.Value(hclCtx)
+	diags = diags.Append(hclDiags)
+	if hclDiags.HasErrors() {
+		return false, "", diags
+	}
+	val, err := convert.Convert(val, cty.Bool)
+	if err != nil {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid policy condition",
+			Detail:   fmt.Sprintf("Invalid condition result value: %s.", tfdiags.FormatError(err)),
+			Subject:  a.Condition.Range().Ptr(),
+		})
+		return false, "", diags
+	}
+	if val.IsNull() || !val.IsKnown() {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid policy condition",
+			Detail:   "Invalid condition result value: the result must be either true or false.",
+			Subject:  a.Condition.Range().Ptr(),
+		})
+		return false, "", diags
+	}
+	if val.True() {
+		return true, "", diags
+	}
+
+	msgVal, hclDiags := a.ErrorMessage.Value(hclCtx)
+	diags = diags.Append(hclDiags)
+	if hclDiags.HasErrors() {
+		return false, "", diags
+	}
+	msgVal, err = convert.Convert(msgVal, cty.String)
+	if err != nil || msgVal.IsNull() || !msgVal.IsKnown() {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid error message",
+			Detail:   "The error message for a policy assertion must be a string.",
+			Subject:  a.ErrorMessage.Range().Ptr(),
+		})
+		return false, "", diags
+	}
+	return false, strings.TrimSpace(msgVal.AsString()), diags
+}
+
+func newFailure(a *Assert, msg string) Failure {
+	rng := a.Condition.Range()
+	return Failure{
+		Message:  msg,
+		Filename: rng.Filename,
+		Line:     rng.Start.Line,
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package policy implements local policy-as-code checks of plans.
+//
+// Policies are written in HCL files with the suffix ".tfpolicy.hcl", and
+// each one makes assertions about a "plan" object, which is the same
+// representation of a plan that "tofu show -json" produces. OpenTofu
+// evaluates them after planning and before asking for approval to apply, so
+// that a failing policy can't be skipped by forgetting to run an external
+// tool.
+package policy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// FileSuffix is the suffix of the files in a policy directory that
+// LoadDir reads.
+const FileSuffix = ".tfpolicy.hcl"
+
+// Enforcement decides what happens when a policy fails.
+type Enforcement string
+
+const (
+	// Advisory policies produce warnings when they fail, but don't block
+	// the operation.
+	Advisory Enforcement = "advisory"
+
+	// Mandatory policies produce errors when they fail, which prevent the
+	// plan from being applied.
+	Mandatory Enforcement = "mandatory"
+)
+
+// Set is the collection of policies loaded from a policy directory.
+type Set struct {
+	// Dir is the directory the policies were loaded from, which is also the
+	// base directory for functions like file() that take a path.
+	Dir string
+
+	Policies []*Policy
+}
+
+// Policy is a single "policy" block.
+type Policy struct {
+	Name        string
+	Enforcement Enforcement
+
+	// Asserts are the "assert" blocks in the policy, all of which must
+	// pass for the policy to pass.
+	Asserts []*Assert
+
+	DeclRange hcl.Range
+}
+
+// Assert is an "assert" block within a policy, which has the same shape as
+// the "assert" blocks within a "check" block in the main configuration.
+type Assert struct {
+	// Condition is an expression that must return true for the assertion
+	// to pass. It can refer only to the "plan" object.
+	Condition hcl.Expression
+
+	// ErrorMessage is an expression that returns a string describing why
+	// the assertion failed.
+	ErrorMessage hcl.Expression
+
+	DeclRange hcl.Range
+}
+
+// LoadDir loads all of the policy files in the given directory. Files in
+// subdirectories are not loaded.
+func LoadDir(dir string) (*Set, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to read policy directory",
+			fmt.Sprintf("Could not read the policy directory %s: %s.", dir, err),
+		))
+		return nil, diags
+	}
+
+	var filenames []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), FileSuffix) {
+			continue
+		}
+		filenames = append(filenames, filepath.Join(dir, entry.Name()))
+	}
+	sort.Strings(filenames)
+	if len(filenames) == 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"No policy files",
+			fmt.Sprintf("The policy directory %s does not contain any files with the suffix %q.", dir, FileSuffix),
+		))
+		return nil, diags
+	}
+
+	set := &Set{Dir: dir}
+	parser := hclparse.NewParser()
+	declared := make(map[string]*Policy)
+	for _, filename := range filenames {
+		file, hclDiags := parser.ParseHCLFile(filename)
+		diags = diags.Append(hclDiags)
+		if hclDiags.HasErrors() {
+			continue
+		}
+
+		policies, hclDiags := decodeFile(file.Body)
+		diags = diags.Append(hclDiags)
+		for _, p := range policies {
+			if existing, exists := declared[p.Name]; exists {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Duplicate policy",
+					Detail:   fmt.Sprintf("A policy named %q was already declared at %s. Policy names must be unique across all of the files in the policy directory.", p.Name, existing.DeclRange),
+					Subject:  p.DeclRange.Ptr(),
+				})
+				continue
+			}
+			declared[p.Name] = p
+			set.Policies = append(set.Policies, p)
+		}
+	}
+
+	return set, diags
+}
+
+func decodeFile(body hcl.Body) ([]*Policy, hcl.Diagnostics) {
+	content, diags := body.Content(fileSchema)
+
+	var ret []*Policy
+	for _, block := range content.Blocks {
+		p, moreDiags := decodePolicyBlock(block)
+		diags = append(diags, moreDiags...)
+		if p != nil {
+			ret = append(ret, p)
+		}
+	}
+	return ret, diags
+}
+
+func decodePolicyBlock(block *hcl.Block) (*Policy, hcl.Diagnostics) {
+	p := &Policy{
+		Name:        block.Labels[0],
+		Enforcement: Mandatory,
+		DeclRange:   block.DefRange,
+	}
+
+	if !hclsyntax.ValidIdentifier(p.Name) {
+		return nil, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid policy name",
+			Detail:   "A policy name must start with a letter or underscore and may contain only letters, digits, underscores, and dashes.",
+			Subject:  block.LabelRanges[0].Ptr(),
+		}}
+	}
+
+	content, diags := block.Body.Content(policyBlockSchema)
+
+	if attr, exists := content.Attributes["enforcement"]; exists {
+		keyword := hcl.ExprAsKeyword(attr.Expr)
+		switch Enforcement(keyword) {
+		case Advisory, Mandatory:
+			p.Enforcement = Enforcement(keyword)
+		default:
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid enforcement level",
+				Detail:   "The enforcement argument must be either advisory or mandatory.",
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+		}
+	}
+
+	for _, block := range content.Blocks {
+		a := &Assert{
+			DeclRange: block.DefRange,
+		}
+		assertContent, moreDiags := block.Body.Content(assertBlockSchema)
+		diags = append(diags, moreDiags...)
+		if attr, exists := assertContent.Attributes["condition"]; exists {
+			a.Condition = attr.Expr
+		}
+		if attr, exists := assertContent.Attributes["error_message"]; exists {
+			a.ErrorMessage = attr.Expr
+		}
+		p.Asserts = append(p.Asserts, a)
+	}
+
+	if len(p.Asserts) == 0 {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Missing assert block",
+			Detail:   "A policy must contain at least one assert block.",
+			Subject:  p.DeclRange.Ptr(),
+		})
+	}
+
+	return p, diags
+}
+
+var fileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "policy", LabelNames: []string{"name"}},
+	},
+}
+
+var policyBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "enforcement"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "assert"},
+	},
+}
+
+var assertBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "condition", Required: true},
+		{Name: "error_message", Required: true},
+	},
+}
@@ -0,0 +1,168 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package policy
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func TestLoadDir(t *testing.T) {
+	set, diags := LoadDir("testdata/valid")
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	var got []string
+	for _, p := range set.Policies {
+		got = append(got, p.Name+":"+string(p.Enforcement))
+	}
+	want := []string{"no_deletes:mandatory", "tagged:advisory"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong policies\n%s", diff)
+	}
+}
+
+func TestLoadDir_invalid(t *testing.T) {
+	_, diags := LoadDir("testdata/invalid")
+
+	var got []string
+	for _, diag := range diags {
+		got = append(got, diag.Description().Summary)
+	}
+	want := []string{"Invalid enforcement level", "Missing assert block", "Duplicate policy"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong diagnostics\n%s", diff)
+	}
+}
+
+func TestLoadDir_empty(t *testing.T) {
+	_, diags := LoadDir(t.TempDir())
+	if !diags.HasErrors() {
+		t.Fatal("no error for a directory without policy files")
+	}
+	if got, want := diags[0].Description().Summary, "No policy files"; got != want {
+		t.Errorf("wrong error %q; want %q", got, want)
+	}
+}
+
+func TestSetEvaluate(t *testing.T) {
+	set, diags := LoadDir("testdata/valid")
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	tests := map[string]struct {
+		plan        string
+		wantResults []*Result
+		wantDiags   []tfdiags.Severity
+	}{
+		"passing": {
+			plan: `{
+  "resource_changes": [
+    {"address": "test_instance.a", "change": {"actions": ["create"], "after": {"tags": {"owner": "me"}}}}
+  ]
+}`,
+			wantResults: []*Result{
+				{Name: "no_deletes", Enforcement: Mandatory, Status: StatusPass},
+				{Name: "tagged", Enforcement: Advisory, Status: StatusPass},
+			},
+		},
+		"failing": {
+			plan: `{
+  "resource_changes": [
+    {"address": "test_instance.a", "change": {"actions": ["create"], "after": {"tags": {}}}},
+    {"address": "test_instance.b", "change": {"actions": ["delete"], "after": null}}
+  ]
+}`,
+			wantResults: []*Result{
+				{
+					Name:        "no_deletes",
+					Enforcement: Mandatory,
+					Status:      StatusFail,
+					Failures: []Failure{
+						{Message: "Deleting resources is not allowed.", Filename: filepath.Join("testdata", "valid", "deletes.tfpolicy.hcl"), Line: 5},
+					},
+				},
+				{
+					Name:        "tagged",
+					Enforcement: Advisory,
+					Status:      StatusFail,
+					Failures: []Failure{
+						{Message: "Every new resource should have an owner tag.", Filename: filepath.Join("testdata", "valid", "tags.tfpolicy.hcl"), Line: 5},
+					},
+				},
+			},
+			wantDiags: []tfdiags.Severity{tfdiags.Error, tfdiags.Warning},
+		},
+		"invalid plan shape": {
+			plan: `{"resource_changes": [{"change": {}}]}`,
+			wantResults: []*Result{
+				{
+					Name:        "no_deletes",
+					Enforcement: Mandatory,
+					Status:      StatusError,
+					Failures: []Failure{
+						{Message: "The condition could not be evaluated.", Filename: filepath.Join("testdata", "valid", "deletes.tfpolicy.hcl"), Line: 5},
+					},
+				},
+				{
+					Name:        "tagged",
+					Enforcement: Advisory,
+					Status:      StatusError,
+					Failures: []Failure{
+						{Message: "The condition could not be evaluated.", Filename: filepath.Join("testdata", "valid", "tags.tfpolicy.hcl"), Line: 5},
+					},
+				},
+			},
+			wantDiags: []tfdiags.Severity{tfdiags.Error, tfdiags.Warning},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			results, diags := set.Evaluate([]byte(test.plan))
+
+			if diff := cmp.Diff(test.wantResults, results.Policies); diff != "" {
+				t.Errorf("wrong results\n%s", diff)
+			}
+
+			var gotDiags []tfdiags.Severity
+			for _, diag := range diags {
+				gotDiags = append(gotDiags, diag.Severity())
+			}
+			if diff := cmp.Diff(test.wantDiags, gotDiags); diff != "" {
+				t.Errorf("wrong diagnostic severities\n%s\n%s", diff, diags.ErrWithWarnings())
+			}
+		})
+	}
+}
+
+func TestResultsBlocking(t *testing.T) {
+	results := &Results{
+		Policies: []*Result{
+			{Name: "a", Enforcement: Mandatory, Status: StatusPass},
+			{Name: "b", Enforcement: Mandatory, Status: StatusFail},
+			{Name: "c", Enforcement: Mandatory, Status: StatusError},
+			{Name: "d", Enforcement: Advisory, Status: StatusFail},
+		},
+	}
+
+	var got []string
+	for _, result := range results.Blocking() {
+		got = append(got, result.Name)
+	}
+	if diff := cmp.Diff([]string{"b", "c"}, got); diff != "" {
+		t.Errorf("wrong blocking results\n%s", diff)
+	}
+
+	var noResults *Results
+	if got := noResults.Blocking(); len(got) != 0 {
+		t.Errorf("unexpected blocking results for nil results: %#v", got)
+	}
+}
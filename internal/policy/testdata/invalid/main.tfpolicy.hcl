@@ -0,0 +1,11 @@
+policy "a" {
+  enforcement = strict
+
+  assert {
+    condition     = true
+    error_message = "Never fails."
+  }
+}
+
+policy "a" {
+}
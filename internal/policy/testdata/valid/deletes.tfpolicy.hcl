@@ -0,0 +1,10 @@
+policy "no_deletes" {
+  enforcement = mandatory
+
+  assert {
+    condition = alltrue([
+      for rc in plan.resource_changes : !contains(rc.change.actions, "delete")
+    ])
+    error_message = "Deleting resources is not allowed."
+  }
+}
@@ -0,0 +1,11 @@
+policy "tagged" {
+  enforcement = advisory
+
+  assert {
+    condition = alltrue([
+      for rc in plan.resource_changes : can(rc.change.after.tags.owner)
+      if contains(rc.change.actions, "create")
+    ])
+    error_message = "Every new resource should have an owner tag."
+  }
+}
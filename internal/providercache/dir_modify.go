@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
@@ -62,9 +63,90 @@ func (d *Dir) installPackageWithLock(ctx context.Context, meta getproviders.Pack
 		}
 	}
 
-	return meta.Location.InstallProviderPackage(ctx, meta, newPath, allowedHashes)
+	// We install into a staging directory first and rename it into place
+	// once it's complete, so that other processes reading this cache
+	// directory without holding the lock can never observe a
+	// partially-installed package.
+	return d.installPackageStaged(ctx, meta, newPath, allowedHashes)
 }
 
+// installPackageStaged installs the given package into a staging directory
+// alongside newPath and then atomically renames it to newPath.
+//
+// If there's already something at newPath, such as a corrupt package or a
+// link into another cache, then it's renamed aside just before the staged
+// package is renamed into place, and only removed once that has succeeded.
+//
+// The caller must hold the lock for the package.
+func (d *Dir) installPackageStaged(ctx context.Context, meta getproviders.PackageMeta, newPath string, allowedHashes []getproviders.Hash) (*getproviders.PackageAuthenticationResult, error) {
+	// The staging path is the same each time, rather than randomly
+	// generated, so that package locations that keep state alongside their
+	// target directory (such as partial downloads) can find it again after
+	// an interrupted attempt. Anything already at this path must be left
+	// over from an earlier attempt, because we're holding the lock.
+	stagingPath := newPath + stagingDirSuffix
+	if err := os.RemoveAll(stagingPath); err != nil {
+		return nil, fmt.Errorf("failed to remove leftover staging directory %s: %w", stagingPath, err)
+	}
+
+	authResult, err := meta.Location.InstallProviderPackage(ctx, meta, stagingPath, allowedHashes)
+	if err != nil {
+		if rmErr := os.RemoveAll(stagingPath); rmErr != nil {
+			log.Printf("[WARN] Failed to clean up staging directory %s: %s", stagingPath, rmErr)
+		}
+		return authResult, err
+	}
+
+	// As with the staging directory, anything already at the path for the
+	// old entry must be left over from an earlier attempt.
+	oldPath := newPath + replacedDirSuffix
+	if err := os.RemoveAll(oldPath); err != nil {
+		return nil, fmt.Errorf("failed to remove leftover replaced directory %s: %w", oldPath, err)
+	}
+	replacing := true
+	if err := os.Rename(newPath, oldPath); errors.Is(err, fs.ErrNotExist) {
+		replacing = false
+	} else if err != nil {
+		if rmErr := os.RemoveAll(stagingPath); rmErr != nil {
+			log.Printf("[WARN] Failed to clean up staging directory %s: %s", stagingPath, rmErr)
+		}
+		return authResult, fmt.Errorf("failed to move existing %s %s aside in %s: %w", meta.Provider, meta.Version, d.baseDir, err)
+	}
+
+	if err := os.Rename(stagingPath, newPath); err != nil {
+		if rmErr := os.RemoveAll(stagingPath); rmErr != nil {
+			log.Printf("[WARN] Failed to clean up staging directory %s: %s", stagingPath, rmErr)
+		}
+		if replacing {
+			// We'll try to put back what was there before, so that a failed
+			// install doesn't leave the cache worse off than it was.
+			if restoreErr := os.Rename(oldPath, newPath); restoreErr != nil {
+				log.Printf("[WARN] Failed to restore %s after failed install: %s", newPath, restoreErr)
+			}
+		}
+		return authResult, fmt.Errorf("failed to move %s %s into place in %s: %w", meta.Provider, meta.Version, d.baseDir, err)
+	}
+
+	if replacing {
+		if err := os.RemoveAll(oldPath); err != nil {
+			log.Printf("[WARN] Failed to clean up replaced directory %s: %s", oldPath, err)
+		}
+	}
+	return authResult, nil
+}
+
+const (
+	// stagingDirSuffix is added to the path of a package directory to produce
+	// the path of the directory where it's staged before being renamed into
+	// place.
+	stagingDirSuffix = ".tmp"
+
+	// replacedDirSuffix is added to the path of a package directory to
+	// produce the path where an existing entry is moved while it's being
+	// replaced.
+	replacedDirSuffix = ".old"
+)
+
 // LinkFromOtherCache takes a CachedProvider value produced from another Dir
 // and links it into the cache represented by the receiver Dir.
 //
@@ -6,6 +6,7 @@
 package providercache
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -32,6 +33,16 @@ func TestInstallPackage(t *testing.T) {
 
 	tmpDir := NewDirWithPlatform(tmpDirPath, linuxPlatform)
 
+	// A staging directory left behind by an earlier interrupted installation
+	// must not prevent a new installation from succeeding.
+	stagingPath := filepath.Join(tmpDirPath, "registry.opentofu.org/hashicorp/null/2.1.0/linux_amd64"+stagingDirSuffix)
+	if err := os.MkdirAll(stagingPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(stagingPath, "leftover"), []byte("junk"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
 	meta := getproviders.PackageMeta{
 		Provider: nullProvider,
 		Version:  versions.MustParseVersion("2.1.0"),
@@ -67,6 +78,67 @@ func TestInstallPackage(t *testing.T) {
 	if diff := cmp.Diff(want, got); diff != "" {
 		t.Errorf("wrong cache contents after install\n%s", diff)
 	}
+
+	if _, err := os.Lstat(stagingPath); !os.IsNotExist(err) {
+		t.Errorf("staging directory %s still exists after install", stagingPath)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDirPath, "registry.opentofu.org/hashicorp/null/2.1.0/linux_amd64/leftover")); !os.IsNotExist(err) {
+		t.Errorf("leftover content from staging directory was included in the installed package")
+	}
+}
+
+func TestInstallPackage_replacesExisting(t *testing.T) {
+	tmpDirPath, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	linuxPlatform := getproviders.Platform{
+		OS:   "linux",
+		Arch: "amd64",
+	}
+	nullProvider := addrs.NewProvider(
+		addrs.DefaultProviderRegistryHost, "hashicorp", "null",
+	)
+
+	tmpDir := NewDirWithPlatform(tmpDirPath, linuxPlatform)
+
+	// An existing entry that doesn't match the package, as if it were
+	// corrupted, must be replaced as a whole rather than installed over.
+	packagePath := filepath.Join(tmpDirPath, "registry.opentofu.org/hashicorp/null/2.1.0/linux_amd64")
+	if err := os.MkdirAll(packagePath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(packagePath, "stale"), []byte("junk"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	meta := getproviders.PackageMeta{
+		Provider: nullProvider,
+		Version:  versions.MustParseVersion("2.1.0"),
+
+		ProtocolVersions: getproviders.VersionList{versions.MustParseVersion("5.0.0")},
+		TargetPlatform:   linuxPlatform,
+
+		Filename: "provider-null_2.1.0_linux_amd64.zip",
+		Location: getproviders.PackageLocalArchive("testdata/provider-null_2.1.0_linux_amd64.zip"),
+	}
+
+	if _, err := tmpDir.InstallPackage(t.Context(), meta, nil, false); err != nil {
+		t.Fatalf("InstallPackage failed: %s", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(packagePath, "terraform-provider-null")); err != nil {
+		t.Errorf("package was not installed: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(packagePath, "stale")); !os.IsNotExist(err) {
+		t.Errorf("content of the replaced entry is still present in the installed package")
+	}
+	for _, suffix := range []string{stagingDirSuffix, replacedDirSuffix} {
+		if _, err := os.Lstat(packagePath + suffix); !os.IsNotExist(err) {
+			t.Errorf("%s still exists after install", packagePath+suffix)
+		}
+	}
 }
 
 func TestLinkFromOtherCache(t *testing.T) {
@@ -52,6 +52,12 @@ type Installer struct {
 	// file.
 	globalCacheDirMayBreakDependencyLockFile bool
 
+	// globalCacheDirReadOnly, if set, prevents the installer from writing
+	// anything into globalCacheDir. Packages already present there can still
+	// be linked into targetDir, but any other package is installed directly
+	// into targetDir instead of via the global cache.
+	globalCacheDirReadOnly bool
+
 	// builtInProviderTypes is an optional set of types that should be
 	// considered valid to appear in the special terraform.io/builtin/...
 	// namespace, which we use for providers that are built in to OpenTofu
@@ -63,6 +69,11 @@ type Installer struct {
 	// lifecycle for, and therefore does not need to worry about the
 	// installation of.
 	unmanagedProviderTypes map[addrs.Provider]struct{}
+
+	// workerSlots, if non-nil, is a semaphore limiting how many providers
+	// the installer will query or fetch concurrently. Clones of an installer
+	// share the same semaphore, so the limit applies to all of them together.
+	workerSlots chan struct{}
 }
 
 // NewInstaller constructs and returns a new installer with the given target
@@ -96,6 +107,36 @@ func (i *Installer) Clone(targetDir *Dir) *Installer {
 	return &ret
 }
 
+// SetMaxConcurrency limits how many providers the receiving installer will
+// query or fetch at the same time during EnsureProviderVersions.
+//
+// The limit is shared with any installers subsequently created by calling
+// Clone on the receiver, so that a caller installing for several target
+// directories at once can bound the total concurrency across all of them.
+//
+// A limit of zero or less means that there is no limit, which is the default.
+func (i *Installer) SetMaxConcurrency(limit int) {
+	if limit <= 0 {
+		i.workerSlots = nil
+		return
+	}
+	i.workerSlots = make(chan struct{}, limit)
+}
+
+// acquireWorkerSlot blocks until the receiver's concurrency limit allows
+// starting another operation, and then returns a function that the caller
+// must call once that operation is complete.
+func (i *Installer) acquireWorkerSlot() (release func()) {
+	slots := i.workerSlots
+	if slots == nil {
+		return func() {}
+	}
+	slots <- struct{}{}
+	return func() {
+		<-slots
+	}
+}
+
 // ProviderSource returns the getproviders.Source that the installer would
 // use for installing any new providers.
 func (i *Installer) ProviderSource() getproviders.Source {
@@ -132,6 +173,19 @@ func (i *Installer) SetGlobalCacheDirMayBreakDependencyLockFile(mayBreak bool) {
 	i.globalCacheDirMayBreakDependencyLockFile = mayBreak
 }
 
+// SetGlobalCacheDirReadOnly activates or deactivates read-only mode for the
+// global cache directory.
+//
+// In read-only mode the installer never writes to the global cache
+// directory, and so it needs no write access to it. This is intended for
+// situations such as ephemeral CI jobs sharing a cache directory that was
+// populated ahead of time. Packages already in the global cache are linked
+// into the target directory under the same rules as usual, while any other
+// package is installed directly into the target directory.
+func (i *Installer) SetGlobalCacheDirReadOnly(readOnly bool) {
+	i.globalCacheDirReadOnly = readOnly
+}
+
 // HasGlobalCacheDir returns true if someone has previously called
 // SetGlobalCacheDir to configure a global cache directory for this installer.
 func (i *Installer) HasGlobalCacheDir() bool {
@@ -439,6 +493,9 @@ func (i *Installer) ensureProviderVersionsNeed(
 
 	for provider, acceptableVersions := range mightNeed {
 		wg.Go(func() {
+			release := i.acquireWorkerSlot()
+			defer release()
+
 			// Heavy lifting
 			version, err := computeNeeds(provider, acceptableVersions)
 
@@ -485,6 +542,9 @@ func (i *Installer) ensureProviderVersionsInstall(
 	}
 	for provider, version := range need {
 		wg.Go(func() {
+			release := i.acquireWorkerSlot()
+			defer release()
+
 			traceCtx, span := tracing.Tracer().Start(ctx,
 				fmt.Sprintf("Install Provider %q", provider.String()),
 				tracing.SpanAttributes(
@@ -550,10 +610,19 @@ func (i *Installer) ensureProviderVersionInstalled(
 	}
 
 	var installTo, linkTo *Dir
-	if i.globalCacheDir != nil {
+	switch {
+	case i.globalCacheDir != nil && i.globalCacheDirReadOnly:
+		if cached := i.usableReadOnlyGlobalCacheEntry(provider, version, preferredHashes); cached != nil {
+			return i.linkFromReadOnlyGlobalCache(ctx, lock, cached)
+		}
+		// The package isn't usable from the global cache and we're not
+		// allowed to add it there, so we'll install it directly instead.
+		installTo = i.targetDir
+		linkTo = nil // no linking needed
+	case i.globalCacheDir != nil:
 		installTo = i.globalCacheDir
 		linkTo = i.targetDir
-	} else {
+	default:
 		installTo = i.targetDir
 		linkTo = nil // no linking needed
 	}
@@ -608,6 +677,75 @@ func (i *Installer) ensureProviderVersionInstalled(
 	return result, newHashes, err
 }
 
+// usableReadOnlyGlobalCacheEntry returns the global cache entry for the given
+// provider version if it's present and may be used without first consulting
+// the provider source, or nil otherwise.
+//
+// This follows the same rules as when the global cache is writable: an
+// entry must match one of the hashes from the dependency lock file, unless
+// the installer was configured to allow the global cache to break the
+// dependency lock file and there are no hashes to check against.
+func (i *Installer) usableReadOnlyGlobalCacheEntry(provider addrs.Provider, version getproviders.Version, preferredHashes []getproviders.Hash) *CachedProvider {
+	cached := i.globalCacheDir.ProviderVersion(provider, version)
+	if cached == nil {
+		return nil
+	}
+	if len(preferredHashes) == 0 {
+		if i.globalCacheDirMayBreakDependencyLockFile {
+			return cached
+		}
+		return nil
+	}
+	if matches, _ := cached.MatchesAnyHash(preferredHashes); matches {
+		return cached
+	}
+	return nil
+}
+
+// linkFromReadOnlyGlobalCache links the given entry from a read-only global
+// cache directory into the target directory, returning the hashes that
+// should be recorded for it in the dependency lock file.
+func (i *Installer) linkFromReadOnlyGlobalCache(ctx context.Context, lock *depsfile.ProviderLock, cached *CachedProvider) (*getproviders.PackageAuthenticationResult, []getproviders.Hash, error) {
+	evts := installerEventsForContext(ctx)
+	provider, version := cached.Provider, cached.Version
+
+	if cb := evts.ProviderAlreadyInstalled; cb != nil {
+		cb(provider, version, true)
+	}
+	if cb := evts.LinkFromCacheBegin; cb != nil {
+		cb(provider, version, i.globalCacheDir.BasePath())
+	}
+	err := i.targetDir.LinkFromOtherCache(ctx, cached, nil)
+	if err == nil {
+		if linked := i.targetDir.ProviderVersion(provider, version); linked == nil {
+			err = fmt.Errorf("after installing %s it is still not detected in %s; this is a bug in OpenTofu", provider, i.targetDir.BasePath())
+		} else if _, exeErr := linked.ExecutableFile(); exeErr != nil {
+			err = fmt.Errorf("provider binary not found: %w", exeErr)
+		}
+	}
+	if err != nil {
+		if cb := evts.LinkFromCacheFailure; cb != nil {
+			cb(provider, version, err)
+		}
+		return nil, nil, err
+	}
+	if cb := evts.LinkFromCacheSuccess; cb != nil {
+		cb(provider, version, i.targetDir.ProviderVersion(provider, version).PackageDir)
+	}
+
+	if lock != nil && lock.Version() == version && len(lock.PreferredHashes()) > 0 {
+		return nil, lock.AllHashes(), nil
+	}
+	// If we get here then the installer allows the global cache to break
+	// the dependency lock file, and so the only hash we can record is the
+	// one for the package we found in the cache.
+	hash, err := cached.Hash()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to compute a checksum for %s in the global cache: %w", provider, err)
+	}
+	return nil, []getproviders.Hash{hash}, nil
+}
+
 func (i *Installer) ensureProviderVersionInDirectory(
 	ctx context.Context,
 	lock *depsfile.ProviderLock,
@@ -668,8 +806,15 @@ func (i *Installer) ensureProviderVersionInDirectory(
 		allowedHashes = []getproviders.Hash{}
 	}
 
+	installCtx := ctx
+	if cb := evts.FetchPackageProgress; cb != nil {
+		installCtx = getproviders.ContextWithDownloadProgress(ctx, func(downloaded, total int64) {
+			cb(provider, version, downloaded, total)
+		})
+	}
+
 	allowSkippingInstallWithoutHashes := i.globalCacheDirMayBreakDependencyLockFile && isGlobalCache
-	authResult, err := installTo.InstallPackage(ctx, meta, allowedHashes, allowSkippingInstallWithoutHashes)
+	authResult, err := installTo.InstallPackage(installCtx, meta, allowedHashes, allowSkippingInstallWithoutHashes)
 	if err != nil {
 		// TODO: Consider retrying for certain kinds of error that seem
 		// likely to be transient. For now, we just treat all errors equally.
@@ -113,6 +113,16 @@ type InstallerEvents struct {
 	FetchPackageSuccess func(provider addrs.Provider, version getproviders.Version, localDir string, authResult *getproviders.PackageAuthenticationResult)
 	FetchPackageFailure func(provider addrs.Provider, version getproviders.Version, err error)
 
+	// FetchPackageProgress is called zero or more times between
+	// FetchPackageBegin and FetchPackageSuccess or FetchPackageFailure for
+	// the same provider to report how much of the package has been
+	// retrieved so far. total is -1 if the final size isn't known.
+	//
+	// Only package locations that retrieve packages over the network
+	// report progress. If an earlier interrupted download is being resumed
+	// then the first event reports the number of bytes already retrieved.
+	FetchPackageProgress func(provider addrs.Provider, version getproviders.Version, downloaded, total int64)
+
 	// CacheDirLockContended is called if acquiring a lock on the specified
 	// cache directory takes more than a few seconds, suggesting that some
 	// other process is already holding a lock.
@@ -264,6 +274,13 @@ func (e *InstallerEvents) Sync() *InstallerEvents {
 				e.FetchPackageFailure(provider, version, err)
 			}
 		},
+		FetchPackageProgress: func(provider addrs.Provider, version getproviders.Version, downloaded, total int64) {
+			lock.Lock()
+			defer lock.Unlock()
+			if e.FetchPackageProgress != nil {
+				e.FetchPackageProgress(provider, version, downloaded, total)
+			}
+		},
 		CacheDirLockContended: func(cacheDir string) {
 			lock.Lock()
 			defer lock.Unlock()
@@ -16,7 +16,10 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/apparentlymart/go-versions/versions"
 	"github.com/apparentlymart/go-versions/versions/constraints"
@@ -2742,6 +2745,202 @@ func TestRaceConditionOnLocks(t *testing.T) {
 	// NOTE: No assertions since this test is meant to be executed during race detection to ensure proper locking.
 }
 
+func TestEnsureProviderVersions_readOnlyGlobalCache(t *testing.T) {
+	beepProvider := addrs.MustParseProviderSourceString("example.com/foo/beep")
+	beepProviderDir := getproviders.PackageLocalDir("testdata/beep-provider")
+	beepProviderHash, err := getproviders.PackageHashV1(beepProviderDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fakePlatform := getproviders.Platform{OS: "bleep", Arch: "bloop"}
+	version := getproviders.MustParseVersion("2.1.0")
+	source := getproviders.NewMockSource(
+		[]getproviders.PackageMeta{
+			{
+				Provider:       beepProvider,
+				Version:        version,
+				TargetPlatform: fakePlatform,
+				Location:       beepProviderDir,
+			},
+		},
+		nil,
+	)
+	reqs := getproviders.Requirements{
+		beepProvider: getproviders.MustParseVersionConstraints(">= 2.0.0"),
+	}
+	lockedLocks := depsfile.NewLocks()
+	lockedLocks.SetProvider(beepProvider, version, reqs[beepProvider], []getproviders.Hash{beepProviderHash})
+
+	tests := map[string]struct {
+		warmCache bool
+		locks     *depsfile.Locks
+		wantLink  bool
+	}{
+		"warm cache with matching lock": {
+			warmCache: true,
+			locks:     lockedLocks,
+			wantLink:  true,
+		},
+		"warm cache without lock": {
+			// Without a lock file entry to verify against, the cache entry
+			// isn't trusted, just as when the cache is writable.
+			warmCache: true,
+			locks:     depsfile.NewLocks(),
+			wantLink:  false,
+		},
+		"cold cache": {
+			warmCache: false,
+			locks:     lockedLocks,
+			wantLink:  false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			globalCacheDir := NewDirWithPlatform(tmpDir(t), fakePlatform)
+			if test.warmCache {
+				_, err := globalCacheDir.InstallPackage(t.Context(), getproviders.PackageMeta{
+					Provider:       beepProvider,
+					Version:        version,
+					TargetPlatform: fakePlatform,
+					Location:       beepProviderDir,
+				}, nil, false)
+				if err != nil {
+					t.Fatalf("failed to populate global cache: %s", err)
+				}
+			}
+			targetDir := NewDirWithPlatform(tmpDir(t), fakePlatform)
+			inst := NewInstaller(targetDir, source)
+			inst.SetGlobalCacheDir(globalCacheDir)
+			inst.SetGlobalCacheDirReadOnly(true)
+
+			newLocks, err := inst.EnsureProviderVersions(t.Context(), test.locks, reqs, InstallNewProvidersOnly)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			wantLock := depsfile.NewProviderLock(beepProvider, version, reqs[beepProvider], []getproviders.Hash{beepProviderHash})
+			if diff := cmp.Diff(wantLock, newLocks.Provider(beepProvider), depsfile.ProviderLockComparer); diff != "" {
+				t.Errorf("wrong lock entry\n%s", diff)
+			}
+
+			installed := targetDir.ProviderVersion(beepProvider, version)
+			if installed == nil {
+				t.Fatalf("provider was not installed in the target directory")
+			}
+			// The fixture package is a local directory, so both the global
+			// cache and the target directory contain symlinks rather than
+			// copies and we can tell where the target entry came from by
+			// where its link points.
+			linkDest, err := os.Readlink(installed.PackageDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			linked := strings.HasPrefix(linkDest, globalCacheDir.BasePath())
+			if linked != test.wantLink {
+				t.Errorf("wrong installation method: linked from global cache = %t, want %t", linked, test.wantLink)
+			}
+			if !test.warmCache {
+				if cached := globalCacheDir.ProviderVersion(beepProvider, version); cached != nil {
+					t.Errorf("installer wrote to the read-only global cache directory")
+				}
+			}
+		})
+	}
+}
+
+func TestEnsureProviderVersions_maxConcurrency(t *testing.T) {
+	ctx := t.Context()
+	providerLocation := t.TempDir()
+	reqs := getproviders.Requirements{}
+	var mockedPkgs []getproviders.PackageMeta
+	for i := range 20 {
+		providerName := fmt.Sprintf("example%d", i)
+		provAddr := addrs.MustParseProviderSourceString(fmt.Sprintf("test/%s", providerName))
+		reqs[provAddr] = getproviders.MustParseVersionConstraints(">= 2.0.0")
+		mockedPkgs = append(mockedPkgs, getproviders.PackageMeta{
+			Provider:       provAddr,
+			Version:        versions.MustParseVersion("2.0.1"),
+			TargetPlatform: getproviders.CurrentPlatform,
+			Location:       getproviders.PackageLocalDir(providerLocation),
+		})
+		err := os.WriteFile(
+			filepath.Join(providerLocation, fmt.Sprintf("terraform-provider-%s", providerName)),
+			fmt.Appendf(nil, "binary content for provider %s", providerName),
+			0644,
+		)
+		if err != nil {
+			t.Fatalf("failed to write the binary for terraform-provider-example: %s", err)
+		}
+	}
+
+	const limit = 3
+	source := &concurrencyTrackingSource{Source: getproviders.NewMockSource(mockedPkgs, nil)}
+	installer := NewInstaller(NewDir(t.TempDir()), source)
+	installer.SetMaxConcurrency(limit)
+
+	// The limit must be shared between an installer and its clones, so we'll
+	// run two clones at once to make sure they're counted together.
+	var wg sync.WaitGroup
+	var results [2]*depsfile.Locks
+	for i := range results {
+		clone := installer.Clone(NewDir(t.TempDir()))
+		wg.Go(func() {
+			locks, err := clone.EnsureProviderVersions(ctx, depsfile.NewLocks(), reqs, InstallNewProvidersOnly)
+			if err != nil {
+				t.Errorf("unexpected error from clone %d: %s", i, err)
+			}
+			results[i] = locks
+		})
+	}
+	wg.Wait()
+
+	if got := source.maxActive.Load(); got > limit {
+		t.Errorf("too many concurrent source requests: got %d, but limit is %d", got, limit)
+	}
+
+	// The lock file content must not depend on the order in which the
+	// concurrent installations completed.
+	if !results[0].Equal(results[1]) {
+		t.Errorf("clones produced different locks")
+	}
+}
+
+// concurrencyTrackingSource is a getproviders.Source that records the
+// maximum number of requests that were in progress at the same time.
+type concurrencyTrackingSource struct {
+	getproviders.Source
+
+	active    atomic.Int32
+	maxActive atomic.Int32
+}
+
+func (s *concurrencyTrackingSource) AvailableVersions(ctx context.Context, provider addrs.Provider) (getproviders.VersionList, getproviders.Warnings, error) {
+	defer s.track()()
+	return s.Source.AvailableVersions(ctx, provider)
+}
+
+func (s *concurrencyTrackingSource) PackageMeta(ctx context.Context, provider addrs.Provider, version getproviders.Version, target getproviders.Platform) (getproviders.PackageMeta, error) {
+	defer s.track()()
+	return s.Source.PackageMeta(ctx, provider, version, target)
+}
+
+func (s *concurrencyTrackingSource) track() (done func()) {
+	active := s.active.Add(1)
+	for {
+		prevMax := s.maxActive.Load()
+		if active <= prevMax || s.maxActive.CompareAndSwap(prevMax, active) {
+			break
+		}
+	}
+	// A short sleep makes it more likely that other requests will overlap
+	// with this one, if the installer is incorrectly allowing that.
+	time.Sleep(5 * time.Millisecond)
+	return func() {
+		s.active.Add(-1)
+	}
+}
+
 // testServices starts up a local HTTP server running a fake provider registry
 // service and returns a service discovery object pre-configured to consider
 // the host "example.com" to be served by the fake registry service.
@@ -0,0 +1,206 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package localserver
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/opentofu/opentofu/internal/registry/response"
+)
+
+// moduleArchiveSuffixes are the filename suffixes recognized as module
+// package archives, in the order they are checked.
+var moduleArchiveSuffixes = []string{".tar.gz", ".tgz", ".zip"}
+
+// modulePackage describes one version of a module package found in the
+// local directory.
+type modulePackage struct {
+	version *version.Version
+	// path is either a directory containing the module source code, or an
+	// archive file to be served verbatim, depending on isDir.
+	path  string
+	isDir bool
+}
+
+func (s *Server) handleModuleVersions(w http.ResponseWriter, r *http.Request) {
+	namespace, name, system := r.PathValue("namespace"), r.PathValue("name"), r.PathValue("system")
+	packages, err := s.modulePackages(namespace, name, system)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if len(packages) == 0 {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	versions := make([]*response.ModuleVersion, 0, len(packages))
+	for _, pkg := range packages {
+		versions = append(versions, &response.ModuleVersion{
+			Version: pkg.version.String(),
+		})
+	}
+	writeJSON(w, http.StatusOK, &response.ModuleVersions{
+		Modules: []*response.ModuleProviderVersions{
+			{
+				Source:   path.Join(namespace, name, system),
+				Versions: versions,
+			},
+		},
+	})
+}
+
+func (s *Server) handleModuleDownload(w http.ResponseWriter, r *http.Request) {
+	namespace, name, system := r.PathValue("namespace"), r.PathValue("name"), r.PathValue("system")
+	pkg, err := s.modulePackage(namespace, name, system, r.PathValue("version"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if pkg == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	// We always host the packages ourselves, so we use the "direct" form of
+	// the download response that asks the client to fetch the package
+	// from the given URL without involving go-getter.
+	useCreds := response.StrictBool(false)
+	writeJSON(w, http.StatusOK, &response.ModuleLocationRegistryResp{
+		Location:               modulePackagesPath + path.Join(namespace, name, system, pkg.version.String()),
+		UseRegistryCredentials: &useCreds,
+	})
+}
+
+func (s *Server) handleModulePackage(w http.ResponseWriter, r *http.Request) {
+	namespace, name, system := r.PathValue("namespace"), r.PathValue("name"), r.PathValue("system")
+	pkg, err := s.modulePackage(namespace, name, system, r.PathValue("version"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if pkg == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	if !pkg.isDir {
+		http.ServeFile(w, r, pkg.path)
+		return
+	}
+
+	// A module stored as a plain directory is packaged on the fly. We can't
+	// know the final size in advance, so the response is streamed without
+	// a Content-Length header.
+	w.Header().Set("Content-Type", "application/gzip")
+	w.WriteHeader(http.StatusOK)
+	if err := writeTarGz(w, pkg.path); err != nil {
+		// The status code has already been sent, so we can only abandon the
+		// response and let the client detect the truncated archive.
+		log.Printf("[ERROR] localserver: failed to package module directory %s: %s", pkg.path, err)
+	}
+}
+
+// modulePackage returns the package for the given version of the given
+// module, or nil if there is no such version.
+func (s *Server) modulePackage(namespace, name, system, versionStr string) (*modulePackage, error) {
+	want, err := version.NewVersion(versionStr)
+	if err != nil {
+		return nil, nil // an invalid version can't possibly be available
+	}
+	packages, err := s.modulePackages(namespace, name, system)
+	if err != nil {
+		return nil, err
+	}
+	for _, pkg := range packages {
+		if pkg.version.Equal(want) {
+			return pkg, nil
+		}
+	}
+	return nil, nil
+}
+
+// modulePackages returns all of the available versions of the given module,
+// ordered by increasing version number. The result is empty if the module
+// doesn't exist at all.
+func (s *Server) modulePackages(namespace, name, system string) ([]*modulePackage, error) {
+	dir, ok := s.localPath("modules", namespace, name, system)
+	if !ok {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read module directory: %w", err)
+	}
+
+	var ret []*modulePackage
+	for _, entry := range entries {
+		entryPath := filepath.Join(dir, entry.Name())
+		info, err := os.Stat(entryPath) // follows symlinks
+		if err != nil {
+			log.Printf("[WARN] localserver: ignoring %s: %s", entryPath, err)
+			continue
+		}
+
+		versionStr := entry.Name()
+		if !info.IsDir() {
+			found := false
+			for _, suffix := range moduleArchiveSuffixes {
+				if strings.HasSuffix(versionStr, suffix) {
+					versionStr = strings.TrimSuffix(versionStr, suffix)
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		v, err := version.NewVersion(versionStr)
+		if err != nil {
+			log.Printf("[WARN] localserver: ignoring %s: not named after a valid version number", entryPath)
+			continue
+		}
+		ret = append(ret, &modulePackage{
+			version: v,
+			path:    entryPath,
+			isDir:   info.IsDir(),
+		})
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].version.LessThan(ret[j].version)
+	})
+	return ret, nil
+}
+
+// writeTarGz writes a gzip-compressed tar archive of the contents of the
+// given directory to w.
+func writeTarGz(w io.Writer, dir string) error {
+	zw := gzip.NewWriter(w)
+	tw := tar.NewWriter(zw)
+	if err := tw.AddFS(os.DirFS(dir)); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
@@ -0,0 +1,361 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package localserver
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/getproviders"
+)
+
+// providerSigningKeyFilename is the name of the file in a provider's
+// directory that contains the ASCII-armored public key that its checksums
+// document is signed with.
+const providerSigningKeyFilename = "signing-key.asc"
+
+// providerPackage describes one provider package archive found in the local
+// directory.
+type providerPackage struct {
+	version  getproviders.Version
+	platform getproviders.Platform
+	filename string
+	path     string
+}
+
+func (s *Server) handleProviderVersions(w http.ResponseWriter, r *http.Request) {
+	packages, err := s.providerPackages(r.PathValue("namespace"), r.PathValue("type"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if len(packages) == 0 {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	type Platform struct {
+		OS   string `json:"os"`
+		Arch string `json:"arch"`
+	}
+	type Version struct {
+		Version   string     `json:"version"`
+		Protocols []string   `json:"protocols,omitempty"`
+		Platforms []Platform `json:"platforms"`
+	}
+	var versions []*Version
+	for _, pkg := range packages {
+		// packages is sorted by version, so all of the platforms for
+		// a particular version are adjacent.
+		if len(versions) == 0 || versions[len(versions)-1].Version != pkg.version.String() {
+			versions = append(versions, &Version{Version: pkg.version.String()})
+		}
+		current := versions[len(versions)-1]
+		current.Platforms = append(current.Platforms, Platform{pkg.platform.OS, pkg.platform.Arch})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"versions": versions,
+	})
+}
+
+func (s *Server) handleProviderDownload(w http.ResponseWriter, r *http.Request) {
+	namespace, typeName := r.PathValue("namespace"), r.PathValue("type")
+	platform := getproviders.Platform{OS: r.PathValue("os"), Arch: r.PathValue("arch")}
+	v, err := getproviders.ParseVersion(r.PathValue("version"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	packages, err := s.providerPackages(namespace, typeName)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	var pkg *providerPackage
+	for _, candidate := range packages {
+		if candidate.version.Same(v) && candidate.platform == platform {
+			pkg = candidate
+			break
+		}
+	}
+	if pkg == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	hash, err := getproviders.PackageHashLegacyZipSHA(getproviders.PackageLocalArchive(pkg.path))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	type GPGPublicKey struct {
+		ASCIIArmor string `json:"ascii_armor"`
+	}
+	type SigningKeys struct {
+		GPGPublicKeys []GPGPublicKey `json:"gpg_public_keys"`
+	}
+	type ResponseBody struct {
+		Protocols              []string    `json:"protocols,omitempty"`
+		OS                     string      `json:"os"`
+		Arch                   string      `json:"arch"`
+		Filename               string      `json:"filename"`
+		DownloadURL            string      `json:"download_url"`
+		SHA256Sum              string      `json:"shasum"`
+		SHA256SumsURL          string      `json:"shasums_url"`
+		SHA256SumsSignatureURL string      `json:"shasums_signature_url"`
+		SigningKeys            SigningKeys `json:"signing_keys"`
+	}
+
+	baseURL := providerPackagesPath + path.Join(namespace, typeName) + "/"
+	shasumsFilename := providerSHA256SumsFilename(typeName, pkg.version)
+	body := ResponseBody{
+		OS:                     pkg.platform.OS,
+		Arch:                   pkg.platform.Arch,
+		Filename:               pkg.filename,
+		DownloadURL:            baseURL + pkg.filename,
+		SHA256Sum:              hash.Value(),
+		SHA256SumsURL:          baseURL + shasumsFilename,
+		SHA256SumsSignatureURL: baseURL + shasumsFilename + ".sig",
+		SigningKeys:            SigningKeys{GPGPublicKeys: []GPGPublicKey{}},
+	}
+	keyPath, _ := s.localPath("providers", namespace, typeName, providerSigningKeyFilename)
+	key, err := os.ReadFile(keyPath)
+	switch {
+	case err == nil:
+		body.SigningKeys.GPGPublicKeys = append(body.SigningKeys.GPGPublicKeys, GPGPublicKey{ASCIIArmor: string(key)})
+	case !errors.Is(err, fs.ErrNotExist):
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+func (s *Server) handleProviderPackage(w http.ResponseWriter, r *http.Request) {
+	namespace, typeName, filename := r.PathValue("namespace"), r.PathValue("type"), r.PathValue("filename")
+	packages, err := s.providerPackages(namespace, typeName)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	for _, pkg := range packages {
+		if pkg.filename == filename {
+			http.ServeFile(w, r, pkg.path)
+			return
+		}
+	}
+
+	// The remaining possibilities are the checksums document and its
+	// signature, which we serve verbatim if they are present on disk.
+	localPath, ok := s.localPath("providers", namespace, typeName, filename)
+	if !ok || !strings.HasPrefix(filename, providerFilenamePrefix(typeName)) {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	if _, err := os.Stat(localPath); err == nil {
+		http.ServeFile(w, r, localPath)
+		return
+	}
+
+	// If there is no checksums document on disk then we generate one from
+	// the packages we have for the requested version. There's no way to
+	// generate a signature, so a missing signature is always "not found".
+	for _, pkg := range packages {
+		if filename != providerSHA256SumsFilename(typeName, pkg.version) {
+			continue
+		}
+		doc, err := providerSHA256Sums(packages, pkg.version)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		http.ServeContent(w, r, filename, time.Time{}, bytes.NewReader(doc))
+		return
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+// handleMirror implements the provider network mirror protocol. The mirror
+// serves the same providers for any origin hostname, so that a single
+// directory can stand in for several registries at once.
+func (s *Server) handleMirror(w http.ResponseWriter, r *http.Request) {
+	namespace, typeName, filename := r.PathValue("namespace"), r.PathValue("type"), r.PathValue("filename")
+	packages, err := s.providerPackages(namespace, typeName)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if filename == "index.json" {
+		if len(packages) == 0 {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		versions := make(map[string]struct{})
+		for _, pkg := range packages {
+			versions[pkg.version.String()] = struct{}{}
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"versions": versions,
+		})
+		return
+	}
+
+	if versionStr, ok := strings.CutSuffix(filename, ".json"); ok {
+		v, err := getproviders.ParseVersion(versionStr)
+		if err != nil {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		type Archive struct {
+			URL    string   `json:"url"`
+			Hashes []string `json:"hashes"`
+		}
+		archives := make(map[string]Archive)
+		for _, pkg := range packages {
+			if !pkg.version.Same(v) {
+				continue
+			}
+			hashes, err := providerPackageHashes(pkg)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			archives[pkg.platform.String()] = Archive{
+				// Relative to the URL of this document, and so refers
+				// to the zip file case below.
+				URL:    pkg.filename,
+				Hashes: hashes,
+			}
+		}
+		if len(archives) == 0 {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"archives": archives,
+		})
+		return
+	}
+
+	for _, pkg := range packages {
+		if pkg.filename == filename {
+			http.ServeFile(w, r, pkg.path)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+// providerPackages returns all of the package archives available for the
+// given provider, ordered by increasing version number and then by platform.
+// The result is empty if the provider doesn't exist at all.
+func (s *Server) providerPackages(namespace, typeName string) ([]*providerPackage, error) {
+	dir, ok := s.localPath("providers", namespace, typeName)
+	if !ok {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read provider directory: %w", err)
+	}
+
+	prefix := providerFilenamePrefix(typeName)
+	var ret []*providerPackage
+	for _, entry := range entries {
+		name := entry.Name()
+		rest, ok := strings.CutPrefix(name, prefix)
+		if !ok {
+			continue
+		}
+		rest, ok = strings.CutSuffix(rest, ".zip")
+		if !ok {
+			continue
+		}
+		// The remainder should be VERSION_OS_ARCH. Version numbers can't
+		// contain underscores, so we can split on those unambiguously.
+		parts := strings.Split(rest, "_")
+		if len(parts) != 3 {
+			log.Printf("[WARN] localserver: ignoring %s: filename must have the form %sVERSION_OS_ARCH.zip", name, prefix)
+			continue
+		}
+		v, err := getproviders.ParseVersion(parts[0])
+		if err != nil {
+			log.Printf("[WARN] localserver: ignoring %s: invalid version number: %s", name, err)
+			continue
+		}
+		platform, err := getproviders.ParsePlatform(parts[1] + "_" + parts[2])
+		if err != nil {
+			log.Printf("[WARN] localserver: ignoring %s: invalid platform: %s", name, err)
+			continue
+		}
+		ret = append(ret, &providerPackage{
+			version:  v,
+			platform: platform,
+			filename: name,
+			path:     filepath.Join(dir, name),
+		})
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		if !ret[i].version.Same(ret[j].version) {
+			return ret[i].version.LessThan(ret[j].version)
+		}
+		return ret[i].platform.LessThan(ret[j].platform)
+	})
+	return ret, nil
+}
+
+// providerSHA256Sums generates a checksums document in the same format as
+// the official provider release process produces, covering all of the
+// given packages that have the given version.
+func providerSHA256Sums(packages []*providerPackage, v getproviders.Version) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, pkg := range packages {
+		if !pkg.version.Same(v) {
+			continue
+		}
+		hash, err := getproviders.PackageHashLegacyZipSHA(getproviders.PackageLocalArchive(pkg.path))
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "%s  %s\n", hash.Value(), pkg.filename)
+	}
+	return buf.Bytes(), nil
+}
+
+// providerPackageHashes returns the hashes of the given package in the
+// string forms used in the network mirror protocol.
+func providerPackageHashes(pkg *providerPackage) ([]string, error) {
+	h1, err := getproviders.PackageHashV1(getproviders.PackageLocalArchive(pkg.path))
+	if err != nil {
+		return nil, err
+	}
+	zh, err := getproviders.PackageHashLegacyZipSHA(getproviders.PackageLocalArchive(pkg.path))
+	if err != nil {
+		return nil, err
+	}
+	return []string{h1.String(), zh.String()}, nil
+}
+
+func providerFilenamePrefix(typeName string) string {
+	return "terraform-provider-" + typeName + "_"
+}
+
+func providerSHA256SumsFilename(typeName string, v getproviders.Version) string {
+	return providerFilenamePrefix(typeName) + v.String() + "_SHA256SUMS"
+}
@@ -0,0 +1,144 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+// Package localserver implements an HTTP server that publishes modules and
+// providers from a directory on the local filesystem using the module
+// registry protocol, the provider registry protocol, and the provider network
+// mirror protocol.
+//
+// The server is intended for testing and for air-gapped environments where
+// there is no access to a public registry. It makes no attempt to implement
+// the optional search or listing endpoints of the registry protocols.
+//
+// The directory given to [New] is expected to have the following layout:
+//
+//	modules/NAMESPACE/NAME/SYSTEM/VERSION/          (a directory containing the module)
+//	modules/NAMESPACE/NAME/SYSTEM/VERSION.zip       (or .tar.gz / .tgz: a module package archive)
+//	providers/NAMESPACE/TYPE/terraform-provider-TYPE_VERSION_OS_ARCH.zip
+//	providers/NAMESPACE/TYPE/terraform-provider-TYPE_VERSION_SHA256SUMS      (optional)
+//	providers/NAMESPACE/TYPE/terraform-provider-TYPE_VERSION_SHA256SUMS.sig  (optional)
+//	providers/NAMESPACE/TYPE/signing-key.asc                                  (optional)
+//
+// The directory is read on each request, so packages can be added or removed
+// while the server is running.
+package localserver
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"path/filepath"
+)
+
+const (
+	// ModulesPath is the base path of the module registry protocol
+	// endpoints, as announced in the service discovery document.
+	ModulesPath = "/v1/modules/"
+
+	// ProvidersPath is the base path of the provider registry protocol
+	// endpoints, as announced in the service discovery document.
+	ProvidersPath = "/v1/providers/"
+
+	// MirrorPath is the base path of the provider network mirror protocol
+	// endpoints. Network mirrors are not announced through service discovery,
+	// and so a URL with this path must be configured explicitly in a
+	// network_mirror block in the CLI configuration.
+	MirrorPath = "/v1/mirror/"
+
+	// modulePackagesPath and providerPackagesPath are the base paths that
+	// the download endpoints refer to for the package contents themselves.
+	modulePackagesPath   = "/v1/module-packages/"
+	providerPackagesPath = "/v1/provider-packages/"
+)
+
+// Server is an [http.Handler] serving the registry protocols from a local
+// directory.
+type Server struct {
+	dir string
+	mux *http.ServeMux
+}
+
+var _ http.Handler = (*Server)(nil)
+
+// New returns a server that publishes the modules and providers found in
+// the given directory.
+func New(dir string) *Server {
+	s := &Server{
+		dir: dir,
+		mux: http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("GET /.well-known/terraform.json", s.handleDiscovery)
+
+	s.mux.HandleFunc("GET "+ModulesPath+"{namespace}/{name}/{system}/versions", s.handleModuleVersions)
+	s.mux.HandleFunc("GET "+ModulesPath+"{namespace}/{name}/{system}/{version}/download", s.handleModuleDownload)
+	s.mux.HandleFunc("GET "+modulePackagesPath+"{namespace}/{name}/{system}/{version}", s.handleModulePackage)
+
+	s.mux.HandleFunc("GET "+ProvidersPath+"{namespace}/{type}/versions", s.handleProviderVersions)
+	s.mux.HandleFunc("GET "+ProvidersPath+"{namespace}/{type}/{version}/download/{os}/{arch}", s.handleProviderDownload)
+	s.mux.HandleFunc("GET "+providerPackagesPath+"{namespace}/{type}/{filename}", s.handleProviderPackage)
+
+	s.mux.HandleFunc("GET "+MirrorPath+"{hostname}/{namespace}/{type}/{filename}", s.handleMirror)
+
+	return s
+}
+
+// ServeHTTP implements [http.Handler].
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log.Printf("[TRACE] localserver: %s %s", r.Method, r.URL.Path)
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleDiscovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"modules.v1":   ModulesPath,
+		"providers.v1": ProvidersPath,
+	})
+}
+
+// localPath returns the path on disk corresponding to the given path
+// segments beneath the server's base directory, or ok=false if any of the
+// segments is not acceptable as a single path component.
+func (s *Server) localPath(segments ...string) (string, bool) {
+	parts := make([]string, 0, len(segments)+1)
+	parts = append(parts, s.dir)
+	for _, seg := range segments {
+		if !validPathSegment(seg) {
+			return "", false
+		}
+		parts = append(parts, seg)
+	}
+	return filepath.Join(parts...), true
+}
+
+// validPathSegment returns true if the given string can be safely used as a
+// single component of a filesystem path without escaping its parent.
+func validPathSegment(seg string) bool {
+	if seg == "" || seg == "." || seg == ".." {
+		return false
+	}
+	for _, r := range seg {
+		if r == '/' || r == '\\' || r == 0 {
+			return false
+		}
+	}
+	return filepath.Base(seg) == seg
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(body); err != nil {
+		log.Printf("[ERROR] localserver: failed to write response: %s", err)
+	}
+}
+
+// writeError writes an error response using the "errors" format that the
+// registry protocols use for error responses.
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string][]string{
+		"errors": {msg},
+	})
+}
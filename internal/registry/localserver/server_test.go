@@ -0,0 +1,392 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package localserver
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	regaddr "github.com/opentofu/registry-address/v2"
+
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/registry"
+	"github.com/opentofu/opentofu/internal/registry/test"
+)
+
+func TestServer_discovery(t *testing.T) {
+	server := httptest.NewServer(New(t.TempDir()))
+	defer server.Close()
+
+	var got map[string]string
+	getJSON(t, server.URL+"/.well-known/terraform.json", http.StatusOK, &got)
+	want := map[string]string{
+		"modules.v1":   "/v1/modules/",
+		"providers.v1": "/v1/providers/",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong discovery document\n%s", diff)
+	}
+}
+
+func TestServer_modules(t *testing.T) {
+	dir := t.TempDir()
+	moduleDir := filepath.Join(dir, "modules", "hashicorp", "consul", "aws")
+	writeTestFile(t, filepath.Join(moduleDir, "1.0.0", "main.tf"), []byte("# v1.0.0\n"))
+	writeTestFile(t, filepath.Join(moduleDir, "1.0.0", "modules", "child", "main.tf"), []byte("# child\n"))
+	archive := testZip(t, map[string]string{"main.tf": "# v1.1.0\n"})
+	writeTestFile(t, filepath.Join(moduleDir, "1.1.0.zip"), archive)
+	writeTestFile(t, filepath.Join(moduleDir, "README.md"), []byte("not a version"))
+	writeTestFile(t, filepath.Join(moduleDir, "latest", "main.tf"), []byte("not a version"))
+
+	server := httptest.NewServer(New(dir))
+	defer server.Close()
+
+	t.Run("versions", func(t *testing.T) {
+		var got struct {
+			Modules []struct {
+				Source   string `json:"source"`
+				Versions []struct {
+					Version string `json:"version"`
+				} `json:"versions"`
+			} `json:"modules"`
+		}
+		getJSON(t, server.URL+"/v1/modules/hashicorp/consul/aws/versions", http.StatusOK, &got)
+		if len(got.Modules) != 1 {
+			t.Fatalf("wrong number of modules %d; want 1", len(got.Modules))
+		}
+		if got, want := got.Modules[0].Source, "hashicorp/consul/aws"; got != want {
+			t.Errorf("wrong source %q; want %q", got, want)
+		}
+		var gotVersions []string
+		for _, v := range got.Modules[0].Versions {
+			gotVersions = append(gotVersions, v.Version)
+		}
+		if diff := cmp.Diff([]string{"1.0.0", "1.1.0"}, gotVersions); diff != "" {
+			t.Errorf("wrong versions\n%s", diff)
+		}
+	})
+	t.Run("unknown module", func(t *testing.T) {
+		getJSON(t, server.URL+"/v1/modules/hashicorp/nope/aws/versions", http.StatusNotFound, nil)
+	})
+	t.Run("download", func(t *testing.T) {
+		var got map[string]any
+		getJSON(t, server.URL+"/v1/modules/hashicorp/consul/aws/1.1.0/download", http.StatusOK, &got)
+		want := map[string]any{
+			"location":                 "/v1/module-packages/hashicorp/consul/aws/1.1.0",
+			"use_registry_credentials": false,
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong download response\n%s", diff)
+		}
+		getJSON(t, server.URL+"/v1/modules/hashicorp/consul/aws/2.0.0/download", http.StatusNotFound, nil)
+	})
+	t.Run("archive package", func(t *testing.T) {
+		got := getBody(t, server.URL+"/v1/module-packages/hashicorp/consul/aws/1.1.0", http.StatusOK)
+		if !bytes.Equal(got, archive) {
+			t.Errorf("archive was not served verbatim")
+		}
+	})
+	t.Run("directory package", func(t *testing.T) {
+		body := getBody(t, server.URL+"/v1/module-packages/hashicorp/consul/aws/1.0.0", http.StatusOK)
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make(map[string]string)
+		tr := tar.NewReader(zr)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if hdr.Typeflag != tar.TypeReg {
+				continue
+			}
+			content, err := io.ReadAll(tr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got[hdr.Name] = string(content)
+		}
+		want := map[string]string{
+			"main.tf":               "# v1.0.0\n",
+			"modules/child/main.tf": "# child\n",
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong package contents\n%s", diff)
+		}
+	})
+}
+
+// TestServer_moduleClient checks that the module registry client can install
+// a module from the server, as "tofu init" would.
+func TestServer_moduleClient(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "modules", "hashicorp", "consul", "aws", "1.0.0", "main.tf"), []byte("# v1.0.0\n"))
+
+	server := httptest.NewServer(New(dir))
+	defer server.Close()
+	client := registry.NewClient(t.Context(), test.Disco(server), nil)
+
+	addr, err := regaddr.ParseModuleSource("example.com/hashicorp/consul/aws")
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions, err := client.ModulePackageVersions(t.Context(), addr.Package)
+	if err != nil {
+		t.Fatalf("unexpected error listing versions: %s", err)
+	}
+	if got := len(versions.Modules[0].Versions); got != 1 {
+		t.Fatalf("wrong number of versions %d; want 1", got)
+	}
+
+	location, err := client.ModulePackageLocation(t.Context(), addr.Package, "1.0.0", "")
+	if err != nil {
+		t.Fatalf("unexpected error finding package: %s", err)
+	}
+	direct, ok := location.(registry.PackageLocationDirect)
+	if !ok {
+		t.Fatalf("wrong location type %T; want registry.PackageLocationDirect", location)
+	}
+	targetDir := t.TempDir()
+	modDir, err := client.InstallModulePackage(t.Context(), direct, targetDir)
+	if err != nil {
+		t.Fatalf("unexpected error installing package: %s", err)
+	}
+	got, err := os.ReadFile(filepath.Join(modDir, "main.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "# v1.0.0\n" {
+		t.Errorf("wrong module content %q", got)
+	}
+}
+
+func TestServer_providers(t *testing.T) {
+	dir := t.TempDir()
+	providerDir := filepath.Join(dir, "providers", "hashicorp", "null")
+	linuxZip := testZip(t, map[string]string{"terraform-provider-null": "linux"})
+	darwinZip := testZip(t, map[string]string{"terraform-provider-null": "darwin"})
+	writeTestFile(t, filepath.Join(providerDir, "terraform-provider-null_1.0.0_linux_amd64.zip"), linuxZip)
+	writeTestFile(t, filepath.Join(providerDir, "terraform-provider-null_1.0.0_darwin_arm64.zip"), darwinZip)
+	writeTestFile(t, filepath.Join(providerDir, "terraform-provider-null_2.0.0_linux_amd64.zip"), linuxZip)
+	writeTestFile(t, filepath.Join(providerDir, "terraform-provider-null_2.0.0_SHA256SUMS.sig"), []byte("signature"))
+	writeTestFile(t, filepath.Join(providerDir, "terraform-provider-null_bad.zip"), linuxZip)
+	writeTestFile(t, filepath.Join(providerDir, "signing-key.asc"), []byte("public key"))
+
+	linuxHash := zipHash(t, filepath.Join(providerDir, "terraform-provider-null_1.0.0_linux_amd64.zip"))
+	darwinHash := zipHash(t, filepath.Join(providerDir, "terraform-provider-null_1.0.0_darwin_arm64.zip"))
+
+	server := httptest.NewServer(New(dir))
+	defer server.Close()
+
+	t.Run("versions", func(t *testing.T) {
+		var got any
+		getJSON(t, server.URL+"/v1/providers/hashicorp/null/versions", http.StatusOK, &got)
+		want := map[string]any{
+			"versions": []any{
+				map[string]any{
+					"version": "1.0.0",
+					"platforms": []any{
+						map[string]any{"os": "darwin", "arch": "arm64"},
+						map[string]any{"os": "linux", "arch": "amd64"},
+					},
+				},
+				map[string]any{
+					"version": "2.0.0",
+					"platforms": []any{
+						map[string]any{"os": "linux", "arch": "amd64"},
+					},
+				},
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong versions response\n%s", diff)
+		}
+		getJSON(t, server.URL+"/v1/providers/hashicorp/nope/versions", http.StatusNotFound, nil)
+	})
+	t.Run("download", func(t *testing.T) {
+		var got any
+		getJSON(t, server.URL+"/v1/providers/hashicorp/null/1.0.0/download/linux/amd64", http.StatusOK, &got)
+		want := map[string]any{
+			"os":                    "linux",
+			"arch":                  "amd64",
+			"filename":              "terraform-provider-null_1.0.0_linux_amd64.zip",
+			"download_url":          "/v1/provider-packages/hashicorp/null/terraform-provider-null_1.0.0_linux_amd64.zip",
+			"shasum":                linuxHash,
+			"shasums_url":           "/v1/provider-packages/hashicorp/null/terraform-provider-null_1.0.0_SHA256SUMS",
+			"shasums_signature_url": "/v1/provider-packages/hashicorp/null/terraform-provider-null_1.0.0_SHA256SUMS.sig",
+			"signing_keys": map[string]any{
+				"gpg_public_keys": []any{
+					map[string]any{"ascii_armor": "public key"},
+				},
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong download response\n%s", diff)
+		}
+		getJSON(t, server.URL+"/v1/providers/hashicorp/null/1.0.0/download/windows/amd64", http.StatusNotFound, nil)
+	})
+	t.Run("packages", func(t *testing.T) {
+		got := getBody(t, server.URL+"/v1/provider-packages/hashicorp/null/terraform-provider-null_1.0.0_darwin_arm64.zip", http.StatusOK)
+		if !bytes.Equal(got, darwinZip) {
+			t.Errorf("package was not served verbatim")
+		}
+
+		got = getBody(t, server.URL+"/v1/provider-packages/hashicorp/null/terraform-provider-null_1.0.0_SHA256SUMS", http.StatusOK)
+		wantSums := darwinHash + "  terraform-provider-null_1.0.0_darwin_arm64.zip\n" +
+			linuxHash + "  terraform-provider-null_1.0.0_linux_amd64.zip\n"
+		if diff := cmp.Diff(wantSums, string(got)); diff != "" {
+			t.Errorf("wrong generated checksums document\n%s", diff)
+		}
+
+		getBody(t, server.URL+"/v1/provider-packages/hashicorp/null/terraform-provider-null_1.0.0_SHA256SUMS.sig", http.StatusNotFound)
+		got = getBody(t, server.URL+"/v1/provider-packages/hashicorp/null/terraform-provider-null_2.0.0_SHA256SUMS.sig", http.StatusOK)
+		if string(got) != "signature" {
+			t.Errorf("wrong signature %q", got)
+		}
+		getBody(t, server.URL+"/v1/provider-packages/hashicorp/null/signing-key.asc", http.StatusNotFound)
+	})
+}
+
+func TestServer_mirror(t *testing.T) {
+	dir := t.TempDir()
+	providerDir := filepath.Join(dir, "providers", "hashicorp", "null")
+	archive := testZip(t, map[string]string{"terraform-provider-null": "linux"})
+	archivePath := filepath.Join(providerDir, "terraform-provider-null_1.0.0_linux_amd64.zip")
+	writeTestFile(t, archivePath, archive)
+
+	h1, err := getproviders.PackageHashV1(getproviders.PackageLocalArchive(archivePath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(New(dir))
+	defer server.Close()
+
+	// The mirror serves the same providers regardless of the hostname.
+	for _, hostname := range []string{"registry.opentofu.org", "example.com"} {
+		t.Run(hostname, func(t *testing.T) {
+			baseURL := server.URL + "/v1/mirror/" + hostname + "/hashicorp/null/"
+
+			var gotIndex any
+			getJSON(t, baseURL+"index.json", http.StatusOK, &gotIndex)
+			wantIndex := map[string]any{
+				"versions": map[string]any{"1.0.0": map[string]any{}},
+			}
+			if diff := cmp.Diff(wantIndex, gotIndex); diff != "" {
+				t.Errorf("wrong index\n%s", diff)
+			}
+
+			var gotVersion any
+			getJSON(t, baseURL+"1.0.0.json", http.StatusOK, &gotVersion)
+			wantVersion := map[string]any{
+				"archives": map[string]any{
+					"linux_amd64": map[string]any{
+						"url":    "terraform-provider-null_1.0.0_linux_amd64.zip",
+						"hashes": []any{h1.String(), "zh:" + zipHash(t, archivePath)},
+					},
+				},
+			}
+			if diff := cmp.Diff(wantVersion, gotVersion); diff != "" {
+				t.Errorf("wrong version document\n%s", diff)
+			}
+			getJSON(t, baseURL+"2.0.0.json", http.StatusNotFound, nil)
+
+			got := getBody(t, baseURL+"terraform-provider-null_1.0.0_linux_amd64.zip", http.StatusOK)
+			if !bytes.Equal(got, archive) {
+				t.Errorf("package was not served verbatim")
+			}
+		})
+	}
+}
+
+func TestServer_pathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "secret", "terraform-provider-x_1.0.0_linux_amd64.zip"), []byte("secret"))
+
+	server := httptest.NewServer(New(filepath.Join(dir, "root")))
+	defer server.Close()
+
+	getBody(t, server.URL+"/v1/mirror/example.com/..%2F..%2Fsecret/x/index.json", http.StatusNotFound)
+	getBody(t, server.URL+"/v1/provider-packages/%2E%2E/x/terraform-provider-x_1.0.0_linux_amd64.zip", http.StatusNotFound)
+}
+
+func getBody(t *testing.T, url string, wantStatus int) []byte {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != wantStatus {
+		t.Fatalf("GET %s: wrong status %d; want %d\n%s", url, resp.StatusCode, wantStatus, body)
+	}
+	return body
+}
+
+func getJSON(t *testing.T, url string, wantStatus int, into any) {
+	t.Helper()
+	body := getBody(t, url, wantStatus)
+	if into == nil {
+		return
+	}
+	if err := json.Unmarshal(body, into); err != nil {
+		t.Fatalf("GET %s: invalid JSON response: %s\n%s", url, err, body)
+	}
+}
+
+func writeTestFile(t *testing.T, filename string, content []byte) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filename, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(w, content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func zipHash(t *testing.T, filename string) string {
+	t.Helper()
+	hash, err := getproviders.PackageHashLegacyZipSHA(getproviders.PackageLocalArchive(filename))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash.Value()
+}
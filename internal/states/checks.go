@@ -70,13 +70,13 @@ type CheckResultObject struct {
 	// results of all of its individual checks.
 	Status checks.Status
 
-	// FailureMessages is an optional set of module-author-defined messages
-	// describing the problems that the checks detected, for objects whose
-	// status is checks.StatusFail.
+	// Failures is an optional set of module-author-defined messages and
+	// error codes describing the problems that the checks detected, for
+	// objects whose status is checks.StatusFail.
 	//
 	// (checks.StatusError problems get reported as normal diagnostics during
 	// evaluation instead, and so will not appear here.)
-	FailureMessages []string
+	Failures []checks.Failure
 }
 
 // NewCheckResults constructs a new states.CheckResults object that is a
@@ -97,8 +97,8 @@ func NewCheckResults(source *checks.State) *CheckResults {
 
 		for _, objectAddr := range source.ObjectAddrs(configAddr) {
 			obj := &CheckResultObject{
-				Status:          source.ObjectCheckStatus(objectAddr),
-				FailureMessages: source.ObjectFailureMessages(objectAddr),
+				Status:   source.ObjectCheckStatus(objectAddr),
+				Failures: source.ObjectFailures(objectAddr),
 			}
 			aggr.ObjectResults.Put(objectAddr, obj)
 		}
@@ -159,7 +159,7 @@ func (r *CheckResults) DeepCopy() *CheckResults {
 
 					// NOTE: We don't deep-copy this slice because it's
 					// immutable once constructed by convention.
-					FailureMessages: objectElem.Value.FailureMessages,
+					Failures: objectElem.Value.Failures,
 				}
 				aggr.ObjectResults.Put(objectElem.Key, result)
 			}
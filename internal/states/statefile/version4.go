@@ -714,8 +714,8 @@ func decodeCheckResultsV4(in []checkResultsV4) (*states.CheckResults, tfdiags.Di
 				}
 
 				obj := &states.CheckResultObject{
-					Status:          decodeCheckStatusV4(objectIn.Status),
-					FailureMessages: objectIn.FailureMessages,
+					Status:   decodeCheckStatusV4(objectIn.Status),
+					Failures: decodeCheckFailuresV4(objectIn),
 				}
 				aggr.ObjectResults.Put(objectAddr, obj)
 			}
@@ -742,11 +742,21 @@ func encodeCheckResultsV4(in *states.CheckResults) []checkResultsV4 {
 			Status:     encodeCheckStatusV4(configElem.Value.Status),
 		}
 		for _, objectElem := range configElem.Value.ObjectResults.Elems {
-			configResultsOut.Objects = append(configResultsOut.Objects, checkResultsObjectV4{
-				ObjectAddr:      objectElem.Key.String(),
-				Status:          encodeCheckStatusV4(objectElem.Value.Status),
-				FailureMessages: objectElem.Value.FailureMessages,
-			})
+			objectOut := checkResultsObjectV4{
+				ObjectAddr: objectElem.Key.String(),
+				Status:     encodeCheckStatusV4(objectElem.Value.Status),
+			}
+			for _, failure := range objectElem.Value.Failures {
+				// We still write the messages alone as well, so that older
+				// versions of OpenTofu can read them.
+				objectOut.FailureMessages = append(objectOut.FailureMessages, failure.Message)
+				objectOut.Failures = append(objectOut.Failures, checkFailureV4{
+					Message:   failure.Message,
+					ErrorCode: failure.ErrorCode,
+					Severity:  encodeCheckFailureSeverityV4(failure.Severity),
+				})
+			}
+			configResultsOut.Objects = append(configResultsOut.Objects, objectOut)
 		}
 
 		ret = append(ret, configResultsOut)
@@ -755,6 +765,45 @@ func encodeCheckResultsV4(in *states.CheckResults) []checkResultsV4 {
 	return ret
 }
 
+func decodeCheckFailuresV4(in checkResultsObjectV4) []checks.Failure {
+	if in.Failures == nil {
+		// State written by older versions of OpenTofu only has the messages,
+		// and all of those failures were errors.
+		var ret []checks.Failure
+		for _, msg := range in.FailureMessages {
+			ret = append(ret, checks.Failure{
+				Message:  msg,
+				Severity: tfdiags.Error,
+			})
+		}
+		return ret
+	}
+
+	ret := make([]checks.Failure, 0, len(in.Failures))
+	for _, failureIn := range in.Failures {
+		ret = append(ret, checks.Failure{
+			Message:   failureIn.Message,
+			ErrorCode: failureIn.ErrorCode,
+			Severity:  decodeCheckFailureSeverityV4(failureIn.Severity),
+		})
+	}
+	return ret
+}
+
+func decodeCheckFailureSeverityV4(in string) tfdiags.Severity {
+	if in == "warning" {
+		return tfdiags.Warning
+	}
+	return tfdiags.Error
+}
+
+func encodeCheckFailureSeverityV4(in tfdiags.Severity) string {
+	if in == tfdiags.Warning {
+		return "warning"
+	}
+	return "error"
+}
+
 func decodeCheckStatusV4(in string) checks.Status {
 	switch in {
 	case "pass":
@@ -889,9 +938,16 @@ type checkResultsV4 struct {
 }
 
 type checkResultsObjectV4 struct {
-	ObjectAddr      string   `json:"object_addr"`
-	Status          string   `json:"status"`
-	FailureMessages []string `json:"failure_messages,omitempty"`
+	ObjectAddr      string           `json:"object_addr"`
+	Status          string           `json:"status"`
+	FailureMessages []string         `json:"failure_messages,omitempty"`
+	Failures        []checkFailureV4 `json:"failures,omitempty"`
+}
+
+type checkFailureV4 struct {
+	Message   string `json:"message"`
+	ErrorCode string `json:"error_code,omitempty"`
+	Severity  string `json:"severity"`
 }
 
 // stateVersionV4 is a weird special type we use to produce our hard-coded
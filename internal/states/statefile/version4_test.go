@@ -12,6 +12,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/checks"
 	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/lang/marks"
 	"github.com/opentofu/opentofu/internal/states"
@@ -366,3 +367,88 @@ func TestVersion4_writeStateWithEphemeralMarks(t *testing.T) {
 	}
 
 }
+
+func TestVersion4_checkFailures(t *testing.T) {
+	testCases := map[string]struct {
+		in   checkResultsObjectV4
+		want []checks.Failure
+	}{
+		"no failures": {
+			in:   checkResultsObjectV4{},
+			want: nil,
+		},
+		"only messages": {
+			// This is how older versions of OpenTofu wrote failures.
+			in: checkResultsObjectV4{
+				FailureMessages: []string{"Not enough boops."},
+			},
+			want: []checks.Failure{
+				{Message: "Not enough boops.", Severity: tfdiags.Error},
+			},
+		},
+		"failures": {
+			in: checkResultsObjectV4{
+				FailureMessages: []string{"Not enough boops.", "Too many beeps."},
+				Failures: []checkFailureV4{
+					{Message: "Not enough boops.", Severity: "error"},
+					{Message: "Too many beeps.", ErrorCode: "TOO_MANY_BEEPS", Severity: "warning"},
+				},
+			},
+			want: []checks.Failure{
+				{Message: "Not enough boops.", Severity: tfdiags.Error},
+				{Message: "Too many beeps.", ErrorCode: "TOO_MANY_BEEPS", Severity: tfdiags.Warning},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := decodeCheckFailuresV4(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("wrong failures\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("encode", func(t *testing.T) {
+		addr := addrs.Check{Name: "beeps"}
+		in := &states.CheckResults{
+			ConfigResults: addrs.MakeMap(
+				addrs.MakeMapElem[addrs.ConfigCheckable](addr.InModule(addrs.RootModule), &states.CheckResultAggregate{
+					Status: checks.StatusFail,
+					ObjectResults: addrs.MakeMap(
+						addrs.MakeMapElem[addrs.Checkable](addr.Absolute(addrs.RootModuleInstance), &states.CheckResultObject{
+							Status: checks.StatusFail,
+							Failures: []checks.Failure{
+								{Message: "Too many beeps.", ErrorCode: "TOO_MANY_BEEPS", Severity: tfdiags.Warning},
+							},
+						}),
+					),
+				}),
+			),
+		}
+		got := encodeCheckResultsV4(in)
+		want := []checkResultsV4{
+			{
+				ObjectKind: "check",
+				ConfigAddr: "check.beeps",
+				Status:     "fail",
+				Objects: []checkResultsObjectV4{
+					{
+						ObjectAddr: "check.beeps",
+						Status:     "fail",
+						// The messages are still written on their own for
+						// older versions of OpenTofu.
+						FailureMessages: []string{"Too many beeps."},
+						Failures: []checkFailureV4{
+							{Message: "Too many beeps.", ErrorCode: "TOO_MANY_BEEPS", Severity: "warning"},
+						},
+					},
+				},
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("wrong result\n%s", diff)
+		}
+	})
+}
@@ -203,3 +203,25 @@ func DoNotConsolidateDiagnostic(diag Diagnostic) bool {
 	}
 	return maybe.DoNotConsolidateDiagnostic()
 }
+
+// DiagnosticExtraErrorCode is an interface implemented by values in the Extra
+// field of Diagnostic when the diagnostic has a machine-readable error code,
+// such as one given by the error_code argument of a custom condition.
+type DiagnosticExtraErrorCode interface {
+	// DiagnosticErrorCode returns the error code of the associated
+	// diagnostic, or an empty string if it has none.
+	DiagnosticErrorCode() string
+}
+
+// DiagnosticErrorCode returns the machine-readable error code of the given
+// diagnostic, or an empty string if it doesn't have one.
+//
+// This is a wrapper around checking if the diagnostic's extra info implements
+// interface DiagnosticExtraErrorCode and then calling its method if so.
+func DiagnosticErrorCode(diag Diagnostic) string {
+	maybe := ExtraInfo[DiagnosticExtraErrorCode](diag)
+	if maybe == nil {
+		return ""
+	}
+	return maybe.DiagnosticErrorCode()
+}
@@ -0,0 +1,117 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+// ApplyOutcome describes what happened to a planned change during an apply
+// operation.
+type ApplyOutcome string
+
+const (
+	// ApplySucceeded means that the change was applied successfully.
+	ApplySucceeded ApplyOutcome = "succeeded"
+
+	// ApplyFailed means that the provider returned an error when applying
+	// the change.
+	ApplyFailed ApplyOutcome = "failed"
+
+	// ApplySkipped means that the change was never attempted, for the
+	// reason given in [ApplyReportChange.SkipReason].
+	ApplySkipped ApplyOutcome = "skipped"
+)
+
+// ApplySkipReason describes why a planned change was skipped during an
+// apply operation.
+type ApplySkipReason string
+
+const (
+	// ApplySkipNone is the reason for a change that wasn't skipped.
+	ApplySkipNone ApplySkipReason = ""
+
+	// ApplySkipDependencyFailed means that the change was skipped because
+	// something it depends on failed.
+	ApplySkipDependencyFailed ApplySkipReason = "dependency_failed"
+
+	// ApplySkipInterrupted means that the apply operation was interrupted
+	// before it could complete the change.
+	ApplySkipInterrupted ApplySkipReason = "interrupted"
+
+	// ApplySkipNotAttempted means that the change was never attempted even
+	// though nothing it depends on failed, such as when OpenTofu couldn't
+	// evaluate the change's own configuration.
+	ApplySkipNotAttempted ApplySkipReason = "not_attempted"
+)
+
+// ApplyReport describes the outcome of each of the planned changes to
+// managed resource instances in an apply operation.
+type ApplyReport struct {
+	Changes []ApplyReportChange
+}
+
+// ApplyReportChange is the outcome of a single planned change in an
+// [ApplyReport].
+type ApplyReportChange struct {
+	Addr       addrs.AbsResourceInstance
+	DeposedKey states.DeposedKey
+	Action     plans.Action
+	Outcome    ApplyOutcome
+
+	// SkipReason is set only when Outcome is ApplySkipped.
+	SkipReason ApplySkipReason
+}
+
+// Count returns the number of changes in the report with the given outcome.
+func (r *ApplyReport) Count(outcome ApplyOutcome) int {
+	n := 0
+	for _, change := range r.Changes {
+		if change.Outcome == outcome {
+			n++
+		}
+	}
+	return n
+}
+
+// Filter returns the changes in the report with the given outcome, in the
+// same order as they appear in the report.
+func (r *ApplyReport) Filter(outcome ApplyOutcome) []ApplyReportChange {
+	var ret []ApplyReportChange
+	for _, change := range r.Changes {
+		if change.Outcome == outcome {
+			ret = append(ret, change)
+		}
+	}
+	return ret
+}
+
+// Skipped returns the skipped changes in the report with the given reason,
+// in the same order as they appear in the report.
+func (r *ApplyReport) Skipped(reason ApplySkipReason) []ApplyReportChange {
+	var ret []ApplyReportChange
+	for _, change := range r.Filter(ApplySkipped) {
+		if change.SkipReason == reason {
+			ret = append(ret, change)
+		}
+	}
+	return ret
+}
+
+// ApplyReportable returns true if the given planned change is one that an
+// apply operation would actually ask a provider to apply, and so is included
+// in an [ApplyReport].
+func ApplyReportable(change *plans.ResourceInstanceChangeSrc) bool {
+	if change.Addr.Resource.Resource.Mode != addrs.ManagedResourceMode {
+		return false
+	}
+	switch change.Action {
+	case plans.NoOp, plans.Read, plans.Forget:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"sync"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+)
+
+// concurrencyLimiter limits how many resource instance nodes can execute at
+// once for a particular provider, provider configuration, or resource type.
+//
+// The limits are intended to protect the remote APIs behind providers, and
+// so they apply only to the nodes that make requests to their provider, and
+// only in the walks where those requests happen. See concurrencyLimitedWalk.
+//
+// These limits apply in addition to the overall limit set by
+// ContextOpts.Parallelism, so that a configuration can protect one
+// rate-limited API without reducing the parallelism for everything else.
+type concurrencyLimiter struct {
+	// providerConfigLimits are the limits set by the max_concurrency argument
+	// in provider blocks, keyed by the string form of the provider
+	// configuration address.
+	providerConfigLimits map[string]int
+	providerLimits       map[addrs.Provider]int
+	resourceTypeLimits   map[string]int
+
+	mu   sync.Mutex
+	sems map[string]Semaphore
+}
+
+func newConcurrencyLimiter(config *configs.Config, providerLimits map[addrs.Provider]int, resourceTypeLimits map[string]int) *concurrencyLimiter {
+	ret := &concurrencyLimiter{
+		providerConfigLimits: make(map[string]int),
+		providerLimits:       providerLimits,
+		resourceTypeLimits:   resourceTypeLimits,
+		sems:                 make(map[string]Semaphore),
+	}
+	if config == nil {
+		return ret
+	}
+	config.DeepEach(func(c *configs.Config) {
+		for _, pc := range c.Module.ProviderConfigs {
+			if pc.MaxConcurrency <= 0 {
+				continue
+			}
+			addr := c.ResolveAbsProviderAddr(pc.Addr(), c.Path)
+			ret.providerConfigLimits[addr.String()] = pc.MaxConcurrency
+		}
+	})
+	return ret
+}
+
+// Acquire waits until the given node is allowed to execute under all of the
+// limits that apply to it, and then returns a function that the caller must
+// call once the node has finished executing.
+//
+// Only resource instance nodes that make requests to their provider are
+// subject to these limits. Acquire returns immediately for any other node.
+func (l *concurrencyLimiter) Acquire(n GraphNodeExecutable) (release func()) {
+	sems := l.semaphoresFor(n)
+	// All callers acquire the semaphores in the same order, so that two
+	// nodes can't each be holding one that the other is waiting for.
+	for _, sem := range sems {
+		sem.Acquire()
+	}
+	return func() {
+		for i := len(sems) - 1; i >= 0; i-- {
+			sems[i].Release()
+		}
+	}
+}
+
+func (l *concurrencyLimiter) semaphoresFor(n GraphNodeExecutable) []Semaphore {
+	if l == nil {
+		return nil
+	}
+	rn, ok := n.(GraphNodeResourceInstance)
+	if !ok {
+		return nil
+	}
+	pn, ok := n.(GraphNodeProviderConsumer)
+	if !ok {
+		return nil
+	}
+	switch n.(type) {
+	case *NodeForgetResourceInstance, *NodeForgetDeposedResourceInstanceObject:
+		// Forgetting an object only removes it from the state.
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var ret []Semaphore
+	resourceType := rn.ResourceInstanceAddr().Resource.Resource.Type
+	if limit := l.resourceTypeLimits[resourceType]; limit > 0 {
+		ret = append(ret, l.semaphore("resource_type:"+resourceType, limit))
+	}
+	provider := pn.Provider()
+	if limit := l.providerLimits[provider]; limit > 0 {
+		ret = append(ret, l.semaphore("provider:"+provider.String(), limit))
+	}
+	if addr, ok := pn.ProvidedBy().ProviderConfig.(addrs.AbsProviderConfig); ok {
+		key := addr.String()
+		if limit := l.providerConfigLimits[key]; limit > 0 {
+			ret = append(ret, l.semaphore("provider_config:"+key, limit))
+		}
+	}
+	return ret
+}
+
+// semaphore returns the semaphore with the given key, creating it with the
+// given limit if it doesn't exist yet. The caller must hold l.mu.
+func (l *concurrencyLimiter) semaphore(key string, limit int) Semaphore {
+	sem, ok := l.sems[key]
+	if !ok {
+		sem = NewSemaphore(limit)
+		l.sems[key] = sem
+	}
+	return sem
+}
+
+// concurrencyLimitedWalk returns true if the given walk operation makes
+// requests to providers for resource instances, and so is subject to the
+// limits of a concurrencyLimiter.
+//
+// Validating and evaluating don't make any requests that would count against
+// a remote API's rate limits, so there's no reason to slow them down.
+func concurrencyLimitedWalk(op walkOperation) bool {
+	switch op {
+	case walkPlan, walkPlanDestroy, walkApply, walkDestroy, walkImport:
+		return true
+	default:
+		return false
+	}
+}
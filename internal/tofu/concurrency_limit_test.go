@@ -0,0 +1,203 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/plugins"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+func TestContext2Apply_concurrencyLimits(t *testing.T) {
+	tests := map[string]struct {
+		config                  string
+		providerConcurrency     map[addrs.Provider]int
+		resourceTypeConcurrency map[string]int
+		// wantLimit is the most changes that may be applied at once, or
+		// zero if they should not be limited.
+		wantLimit int
+	}{
+		"no limits": {
+			config: `
+resource "test_object" "a" {
+  count       = 4
+  test_string = "foo"
+}
+`,
+		},
+		"provider block": {
+			config: `
+provider "test" {
+  max_concurrency = 1
+}
+
+resource "test_object" "a" {
+  count       = 4
+  test_string = "foo"
+}
+`,
+			wantLimit: 1,
+		},
+		"provider": {
+			config: `
+resource "test_object" "a" {
+  count       = 4
+  test_string = "foo"
+}
+`,
+			providerConcurrency: map[addrs.Provider]int{
+				addrs.NewDefaultProvider("test"): 2,
+			},
+			wantLimit: 2,
+		},
+		"resource type": {
+			config: `
+resource "test_object" "a" {
+  count       = 4
+  test_string = "foo"
+}
+`,
+			resourceTypeConcurrency: map[string]int{
+				"test_object": 1,
+			},
+			wantLimit: 1,
+		},
+		"other resource type": {
+			config: `
+resource "test_object" "a" {
+  count       = 4
+  test_string = "foo"
+}
+`,
+			resourceTypeConcurrency: map[string]int{
+				"test_other": 1,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := testModuleInline(t, map[string]string{
+				"main.tf": test.config,
+			})
+
+			p := simpleMockProvider()
+			hook := &concurrencyTrackingHook{}
+
+			ctx := testContext2(t, &ContextOpts{
+				Hooks: []Hook{hook},
+				Plugins: plugins.NewLibrary(map[addrs.Provider]providers.Factory{
+					addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+				}, nil),
+				ProviderConcurrency:     test.providerConcurrency,
+				ResourceTypeConcurrency: test.resourceTypeConcurrency,
+			})
+
+			plan, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+			assertNoErrors(t, diags)
+
+			_, diags = ctx.Apply(context.Background(), plan, m, nil)
+			assertNoErrors(t, diags)
+
+			if test.wantLimit == 0 {
+				if hook.maxRunning < 2 {
+					t.Errorf("changes were applied one at a time; want them applied concurrently")
+				}
+			} else if hook.maxRunning > test.wantLimit {
+				t.Errorf("%d changes were applied at once; want at most %d", hook.maxRunning, test.wantLimit)
+			}
+		})
+	}
+}
+
+func TestConcurrencyLimiter_scope(t *testing.T) {
+	provider := addrs.NewDefaultProvider("test")
+	l := newConcurrencyLimiter(nil, map[addrs.Provider]int{provider: 1}, nil)
+
+	abstract := func() *NodeAbstractResourceInstance {
+		ret := NewNodeAbstractResourceInstance(mustResourceInstanceAddr("test_object.a"))
+		ret.ResolvedProvider = ResolvedProvider{ProviderConfig: mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`)}
+		return ret
+	}
+	tests := map[string]struct {
+		node GraphNodeExecutable
+		want int
+	}{
+		"apply": {
+			node: &NodeApplyableResourceInstance{NodeAbstractResourceInstance: abstract()},
+			want: 1,
+		},
+		"destroy": {
+			node: &NodeDestroyResourceInstance{NodeAbstractResourceInstance: abstract()},
+			want: 1,
+		},
+		"forget": {
+			node: &NodeForgetResourceInstance{NodeAbstractResourceInstance: abstract()},
+			want: 0,
+		},
+		"forget deposed": {
+			node: &NodeForgetDeposedResourceInstanceObject{NodeAbstractResourceInstance: abstract(), DeposedKey: states.NewDeposedKey()},
+			want: 0,
+		},
+		"not a resource instance": {
+			node: &NodeApplyableOutput{Addr: addrs.OutputValue{Name: "foo"}.Absolute(addrs.RootModuleInstance)},
+			want: 0,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := len(l.semaphoresFor(test.node)); got != test.want {
+				t.Errorf("node is subject to %d limits; want %d", got, test.want)
+			}
+		})
+	}
+
+	for _, op := range []walkOperation{walkValidate, walkEval} {
+		if concurrencyLimitedWalk(op) {
+			t.Errorf("%s walk is subject to concurrency limits", op)
+		}
+	}
+	for _, op := range []walkOperation{walkPlan, walkPlanDestroy, walkApply, walkDestroy, walkImport} {
+		if !concurrencyLimitedWalk(op) {
+			t.Errorf("%s walk is not subject to concurrency limits", op)
+		}
+	}
+}
+
+// concurrencyTrackingHook records the largest number of resource instances
+// that were being applied at the same time. It delays each apply a little
+// so that concurrent applies overlap.
+type concurrencyTrackingHook struct {
+	NilHook
+
+	mu         sync.Mutex
+	running    int
+	maxRunning int
+}
+
+func (h *concurrencyTrackingHook) PreApply(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, priorState, plannedNewState cty.Value) (HookAction, error) {
+	h.mu.Lock()
+	h.running++
+	h.maxRunning = max(h.maxRunning, h.running)
+	h.mu.Unlock()
+
+	time.Sleep(50 * time.Millisecond)
+	return HookActionContinue, nil
+}
+
+func (h *concurrencyTrackingHook) PostApply(addr addrs.AbsResourceInstance, gen states.Generation, newState cty.Value, err error) (HookAction, error) {
+	h.mu.Lock()
+	h.running--
+	h.mu.Unlock()
+	return HookActionContinue, nil
+}
@@ -9,14 +9,19 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"maps"
+	"slices"
 	"sort"
 	"sync"
 
+	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
 
+	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/lang/eval"
+	"github.com/opentofu/opentofu/internal/lang/funcplugin"
 	"github.com/opentofu/opentofu/internal/logging"
 	"github.com/opentofu/opentofu/internal/plugins"
 	"github.com/opentofu/opentofu/internal/states"
@@ -46,6 +51,22 @@ type ContextOpts struct {
 	Encryption  encryption.Encryption
 	Modules     eval.ExternalModules
 
+	// DefaultRetryPolicy, if set, is the policy for retrying failed changes
+	// to managed resources that don't have a retry policy of their own.
+	DefaultRetryPolicy *configs.RetryPolicy
+
+	// ProviderConcurrency and ResourceTypeConcurrency optionally limit how
+	// many operations on resource instances belonging to a particular
+	// provider or of a particular resource type can run at once, in
+	// addition to the overall limit set by Parallelism.
+	ProviderConcurrency     map[addrs.Provider]int
+	ResourceTypeConcurrency map[string]int
+
+	// FunctionPlugins are the function plugins defined in the CLI
+	// configuration, keyed by name. Modules can call the functions of the
+	// plugins they list in their required_functions argument.
+	FunctionPlugins map[string]*funcplugin.Plugin
+
 	UIInput UIInput
 }
 
@@ -82,6 +103,8 @@ type Context struct {
 	plugins *contextPlugins
 	modules eval.ExternalModules
 
+	functionPlugins map[string]*funcplugin.Plugin
+
 	hooks   []Hook
 	sh      *stopHook
 	uiInput UIInput
@@ -94,6 +117,11 @@ type Context struct {
 	runContextCancel    context.CancelFunc
 
 	encryption encryption.Encryption
+
+	defaultRetryPolicy *configs.RetryPolicy
+
+	providerConcurrency     map[addrs.Provider]int
+	resourceTypeConcurrency map[string]int
 }
 
 // (additional methods on Context can be found in context_*.go files.)
@@ -147,11 +175,18 @@ func NewContext(opts *ContextOpts) (*Context, tfdiags.Diagnostics) {
 		plugins: plugins,
 		modules: opts.Modules,
 
+		functionPlugins: opts.FunctionPlugins,
+
 		parallelSem:         NewSemaphore(par),
 		providerInputConfig: make(map[string]map[string]cty.Value),
 		sh:                  sh,
 
 		encryption: opts.Encryption,
+
+		defaultRetryPolicy: opts.DefaultRetryPolicy,
+
+		providerConcurrency:     opts.ProviderConcurrency,
+		resourceTypeConcurrency: opts.ResourceTypeConcurrency,
 	}, diags
 }
 
@@ -394,7 +429,7 @@ func (c *Context) checkConfigDependencies(config *configs.Config) tfdiags.Diagno
 	})
 
 	// Because we were doing a lot of map iteration above, and we're only
-	// generating sourceless diagnostics anyway, our diagnostics will not be
+	// generating sourceless diagnostics so far, our diagnostics will not be
 	// in a deterministic order. To ensure stable output when there are
 	// multiple errors to report, we'll sort these particular diagnostics
 	// so they are at least always consistent alone. This ordering is
@@ -412,5 +447,51 @@ func (c *Context) checkConfigDependencies(config *configs.Config) tfdiags.Diagno
 		}
 	})
 
+	diags = diags.Append(c.checkFunctionPlugins(config))
+
+	return diags
+}
+
+// checkFunctionPlugins checks that each function plugin that the given
+// configuration requires is defined in the CLI configuration, and loads the
+// functions that each of them provides, so that we can report any problem
+// with running a plugin before we evaluate any expressions that call its
+// functions.
+func (c *Context) checkFunctionPlugins(config *configs.Config) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	checked := make(map[string]bool)
+	config.DeepEach(func(modCfg *configs.Config) {
+		if modCfg == nil || modCfg.Module == nil {
+			return // should not happen, but we'll be robust
+		}
+		for _, name := range slices.Sorted(maps.Keys(modCfg.Module.RequiredFunctions)) {
+			if checked[name] {
+				continue // we only report each problem once
+			}
+			checked[name] = true
+
+			req := modCfg.Module.RequiredFunctions[name]
+			plugin, ok := c.functionPlugins[name]
+			if !ok {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Missing required function plugin",
+					Detail:   fmt.Sprintf("This configuration requires function plugin %q, which isn't defined in the CLI configuration. To use this configuration, add a \"function_plugin\" block named %q to the CLI configuration, giving the command that provides its functions.", name, name),
+					Subject:  req.DeclRange.Ptr(),
+				})
+				continue
+			}
+			if _, err := plugin.Functions(c.runContext); err != nil {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Failed to load function plugin",
+					Detail:   fmt.Sprintf("OpenTofu could not load the functions provided by function plugin %q: %s", name, err),
+					Subject:  req.DeclRange.Ptr(),
+				})
+			}
+		}
+	})
+
 	return diags
 }
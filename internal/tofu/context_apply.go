@@ -79,6 +79,13 @@ func (c *Context) Apply(ctx context.Context, plan *plans.Plan, config *configs.C
 		return nil, diags
 	}
 
+	// The plan phase already checked the function plugins, but we might
+	// be applying a saved plan in a new process that has to load them again.
+	diags = diags.Append(c.checkFunctionPlugins(config))
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
 	var forgetCount int
 
 	for _, rc := range plan.Changes.Resources {
@@ -203,6 +210,17 @@ Note that the -target and -exclude options are not suitable for routine use, and
 		))
 	}
 
+	if len(plan.DeferredChanges) > 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			"Applied changes are incomplete",
+			fmt.Sprintf(
+				`The plan deferred %d object(s) whose instances could not be determined until apply, along with everything that depends on them. Now that more values are known, run "tofu apply" again to plan and apply the deferred changes.`,
+				len(plan.DeferredChanges),
+			),
+		))
+	}
+
 	// FIXME: we cannot check for an empty plan for refresh-only, because root
 	// outputs are always stored as changes. The final condition of the state
 	// also depends on some cleanup which happens during the apply walk. It
@@ -249,8 +267,9 @@ func (c *Context) applyGraph(ctx context.Context, plan *plans.Plan, config *conf
 		RootVariableValues:      variables,
 		Plugins:                 c.plugins,
 		Targets:                 plan.TargetAddrs,
-		Excludes:                plan.ExcludeAddrs,
+		Excludes:                plan.ApplyExcludeAddrs(),
 		ForceReplace:            plan.ForceReplaceAddrs,
+		DefaultRetryPolicy:      c.defaultRetryPolicy,
 		Operation:               operation,
 		ExternalReferences:      plan.ExternalReferences,
 		ProviderFunctionTracker: providerFunctionTracker,
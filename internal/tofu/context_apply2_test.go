@@ -2273,7 +2273,7 @@ output "from_resource" {
 
 	resCheck := state.CheckResults.GetObjectResult(mustResourceInstanceAddr("test_object.x"))
 	if resCheck.Status != checks.StatusPass {
-		t.Fatalf("unexpected check %s: %s\n", resCheck.Status, resCheck.FailureMessages)
+		t.Fatalf("unexpected check %s: %s\n", resCheck.Status, resCheck.Failures)
 	}
 
 	outAddr := addrs.AbsOutputValue{
@@ -2284,7 +2284,7 @@ output "from_resource" {
 	}
 	outCheck := state.CheckResults.GetObjectResult(outAddr)
 	if outCheck.Status != checks.StatusPass {
-		t.Fatalf("unexpected check %s: %s\n", outCheck.Status, outCheck.FailureMessages)
+		t.Fatalf("unexpected check %s: %s\n", outCheck.Status, outCheck.Failures)
 	}
 }
 
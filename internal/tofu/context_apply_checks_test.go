@@ -798,13 +798,13 @@ func validateCheckResults(t *testing.T, stage string, expected map[string]checks
 			t.Errorf("%s: wanted %s but got %s after %s", check, want.status, results.Status, stage)
 		}
 
-		if len(want.messages) != len(results.FailureMessages) {
-			t.Errorf("%s: expected %d failure messages but had %d after %s", check, len(want.messages), len(results.FailureMessages), stage)
+		if len(want.messages) != len(results.Failures) {
+			t.Errorf("%s: expected %d failure messages but had %d after %s", check, len(want.messages), len(results.Failures), stage)
 		}
 
 		max := len(want.messages)
-		if len(results.FailureMessages) > max {
-			max = len(results.FailureMessages)
+		if len(results.Failures) > max {
+			max = len(results.Failures)
 		}
 
 		for ix := 0; ix < max; ix++ {
@@ -812,8 +812,8 @@ func validateCheckResults(t *testing.T, stage string, expected map[string]checks
 			if ix < len(want.messages) {
 				expected = want.messages[ix]
 			}
-			if ix < len(results.FailureMessages) {
-				actual = results.FailureMessages[ix]
+			if ix < len(results.Failures) {
+				actual = results.Failures[ix].Message
 			}
 
 			// Order matters!
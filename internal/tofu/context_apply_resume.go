@@ -0,0 +1,168 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ResumeOpts describes how far an earlier, interrupted apply of a plan got,
+// for use with [Context.PlanResume].
+type ResumeOpts struct {
+	// Completed are the managed resource instances whose planned changes the
+	// earlier apply completed.
+	Completed addrs.Set[addrs.AbsResourceInstance]
+
+	// Incomplete are the managed resource instances whose planned changes the
+	// earlier apply started but didn't complete, either because it was
+	// interrupted while they were in progress or because they failed.
+	Incomplete addrs.Set[addrs.AbsResourceInstance]
+
+	// SetVariables are the raw values for root module variables, as for
+	// ApplyOpts.SetVariables.
+	SetVariables InputValues
+}
+
+// PlanResume returns a plan for continuing an apply of the given plan that
+// was interrupted, using the given state that the interrupted apply left
+// behind.
+//
+// The result includes the changes from the original plan that weren't yet
+// started. The resource instances that the earlier apply touched are
+// refreshed first, and then those that it didn't complete are planned again
+// so that their new changes take into account whatever partial progress was
+// made. The changes that were completed are discarded.
+//
+// The result can then be passed to [Context.Apply], along with the same
+// configuration that the original plan was created from.
+func (c *Context) PlanResume(ctx context.Context, plan *plans.Plan, config *configs.Config, state *states.State, opts *ResumeOpts) (*plans.Plan, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	if plan.Errored {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Cannot resume failed plan",
+			`The given plan is incomplete due to errors during planning, and so it cannot be applied.`,
+		))
+		return nil, diags
+	}
+
+	// If the earlier apply completed a change that created a new object but
+	// then didn't manage to persist it, we have no record of the object and
+	// so can't safely continue: planning it again would create a duplicate.
+	var lost []string
+	for addr := range opts.Completed.All() {
+		change := plan.Changes.ResourceInstance(addr)
+		if change == nil || !(change.Action == plans.Create || change.Action.IsReplace()) {
+			continue
+		}
+		if rs := state.ResourceInstance(addr); rs == nil || rs.Current == nil {
+			lost = append(lost, addr.String())
+		}
+	}
+	if len(lost) != 0 {
+		slices.Sort(lost)
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Cannot resume apply",
+			fmt.Sprintf(
+				"The interrupted apply created the following objects but they are not recorded in the current state:\n  - %s\n\nImport each of these objects into the state at the given address, and then try resuming again.",
+				strings.Join(lost, "\n  - "),
+			),
+		))
+		return nil, diags
+	}
+
+	touched := opts.Completed.Union(opts.Incomplete)
+	if len(touched) == 0 {
+		// Nothing was started, so we can just apply the original changes
+		// to the current state.
+		ret := *plan
+		ret.PrevRunState = state.DeepCopy()
+		ret.PriorState = state.DeepCopy()
+		return &ret, diags
+	}
+
+	variables, varDiags := c.mergePlanAndApplyVariables(config, plan, &ApplyOpts{SetVariables: opts.SetVariables})
+	diags = diags.Append(varDiags)
+	if varDiags.HasErrors() {
+		return nil, diags
+	}
+
+	planOpts := &PlanOpts{
+		Mode:         plan.UIMode,
+		SetVariables: variables,
+	}
+	for addr := range touched.All() {
+		planOpts.Targets = append(planOpts.Targets, addr)
+	}
+	for _, addr := range plan.ForceReplaceAddrs {
+		if opts.Incomplete.Has(addr) {
+			planOpts.ForceReplace = append(planOpts.ForceReplace, addr)
+		}
+	}
+
+	log.Printf("[DEBUG] PlanResume: refreshing %d resource instances touched by the interrupted apply", len(touched))
+	replan, planDiags := c.Plan(ctx, config, state, planOpts)
+	// The targeting in the plan we just made is an implementation detail,
+	// so we only report its errors and not the usual warnings about it.
+	for _, diag := range planDiags {
+		if diag.Severity() == tfdiags.Error {
+			diags = diags.Append(diag)
+		}
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	var created []string
+	changes := plans.NewChanges()
+	changes.Outputs = plan.Changes.Outputs
+	for _, change := range plan.Changes.Resources {
+		if change.Addr.Resource.Resource.Mode != addrs.ManagedResourceMode {
+			changes.Resources = append(changes.Resources, change)
+			continue
+		}
+		if touched.Has(change.Addr) {
+			continue
+		}
+		changes.Resources = append(changes.Resources, change)
+	}
+	for _, change := range replan.Changes.Resources {
+		if !opts.Incomplete.Has(change.Addr) {
+			continue
+		}
+		if change.Action == plans.Create && change.DeposedKey == states.NotDeposed {
+			created = append(created, change.Addr.String())
+		}
+		changes.Resources = append(changes.Resources, change)
+	}
+	if len(created) != 0 {
+		slices.Sort(created)
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			"Retrying interrupted changes",
+			fmt.Sprintf(
+				"The interrupted apply didn't complete its changes to the following objects, and so OpenTofu will now create them:\n  - %s\n\nIf the interrupted apply had already created any of these objects then OpenTofu is no longer tracking them, and you will need to delete them manually.",
+				strings.Join(created, "\n  - "),
+			),
+		))
+	}
+
+	ret := *plan
+	ret.Changes = changes
+	ret.PrevRunState = state.DeepCopy()
+	ret.PriorState = replan.PriorState
+	return &ret, diags
+}
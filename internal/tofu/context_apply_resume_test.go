@@ -0,0 +1,105 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plugins"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+func TestContext2Apply_resume(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_object" "a" {
+  test_string = "a"
+}
+
+resource "test_object" "b" {
+  test_string = "b"
+}
+
+resource "test_object" "c" {
+  test_string = "c"
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Plugins: plugins.NewLibrary(map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		}, nil),
+	})
+
+	plan, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	// The interrupted apply created test_object.a and saved it to the state,
+	// and had started creating test_object.b.
+	interrupted := states.NewState()
+	interrupted.EnsureModule(addrs.RootModuleInstance).SetResourceInstanceCurrent(
+		mustResourceInstanceAddr("test_object.a").Resource,
+		&states.ResourceInstanceObjectSrc{
+			Status:    states.ObjectReady,
+			AttrsJSON: []byte(`{"test_string":"a"}`),
+		},
+		mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`),
+		addrs.NoKey,
+	)
+
+	t.Run("resume", func(t *testing.T) {
+		resumed, diags := ctx.PlanResume(context.Background(), plan, m, interrupted, &ResumeOpts{
+			Completed:  addrs.MakeSet(mustResourceInstanceAddr("test_object.a")),
+			Incomplete: addrs.MakeSet(mustResourceInstanceAddr("test_object.b")),
+		})
+		assertNoErrors(t, diags)
+		if len(diags) != 1 || !strings.Contains(diags[0].Description().Detail, "test_object.b") {
+			t.Errorf("expected a warning about retrying test_object.b, got: %s", diags.ErrWithWarnings())
+		}
+
+		var gotChanges []string
+		for _, change := range resumed.Changes.Resources {
+			gotChanges = append(gotChanges, fmt.Sprintf("%s %s", change.Addr, change.Action))
+		}
+		slices.Sort(gotChanges)
+		wantChanges := []string{"test_object.b Create", "test_object.c Create"}
+		if diff := cmp.Diff(wantChanges, gotChanges); diff != "" {
+			t.Errorf("wrong planned changes\n%s", diff)
+		}
+
+		state, diags := ctx.Apply(context.Background(), resumed, m, nil)
+		assertNoErrors(t, diags)
+		for _, name := range []string{"a", "b", "c"} {
+			addr := mustResourceInstanceAddr("test_object." + name)
+			if state.ResourceInstance(addr) == nil {
+				t.Errorf("%s is not in the final state", addr)
+			}
+		}
+	})
+
+	t.Run("completed create not in state", func(t *testing.T) {
+		_, diags := ctx.PlanResume(context.Background(), plan, m, interrupted, &ResumeOpts{
+			Completed: addrs.MakeSet(
+				mustResourceInstanceAddr("test_object.a"),
+				mustResourceInstanceAddr("test_object.c"),
+			),
+		})
+		if !diags.HasErrors() {
+			t.Fatal("succeeded; want error about test_object.c not being in the state")
+		}
+		if got := diags.Err().Error(); !strings.Contains(got, "test_object.c") {
+			t.Errorf("wrong error: %s", got)
+		}
+	})
+}
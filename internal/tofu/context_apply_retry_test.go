@@ -0,0 +1,277 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/plugins"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func TestContext2Apply_retry(t *testing.T) {
+	tests := map[string]struct {
+		config        string
+		defaultPolicy *configs.RetryPolicy
+		failures      int
+		wantCalls     int32
+		wantRetries   int
+		wantErr       bool
+	}{
+		"transient error": {
+			config: `
+resource "test_object" "a" {
+  test_string = "foo"
+  lifecycle {
+    retry {
+      max_attempts      = 3
+      backoff           = "1ms"
+      on_error_matching = ["Throttling"]
+    }
+  }
+}
+`,
+			failures:    1,
+			wantCalls:   2,
+			wantRetries: 1,
+		},
+		"too many errors": {
+			config: `
+resource "test_object" "a" {
+  test_string = "foo"
+  lifecycle {
+    retry {
+      max_attempts = 2
+      backoff      = "1ms"
+    }
+  }
+}
+`,
+			failures:    5,
+			wantCalls:   2,
+			wantRetries: 1,
+			wantErr:     true,
+		},
+		"non-matching error": {
+			config: `
+resource "test_object" "a" {
+  test_string = "foo"
+  lifecycle {
+    retry {
+      max_attempts      = 3
+      backoff           = "1ms"
+      on_error_matching = ["^Conflict"]
+    }
+  }
+}
+`,
+			failures:  1,
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		"no policy": {
+			config: `
+resource "test_object" "a" {
+  test_string = "foo"
+}
+`,
+			failures:  1,
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		"default policy": {
+			config: `
+resource "test_object" "a" {
+  test_string = "foo"
+}
+`,
+			defaultPolicy: &configs.RetryPolicy{MaxAttempts: 2},
+			failures:      1,
+			wantCalls:     2,
+			wantRetries:   1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := testModuleInline(t, map[string]string{
+				"main.tf": test.config,
+			})
+
+			p := simpleMockProvider()
+			var calls atomic.Int32
+			p.ApplyResourceChangeFn = func(req providers.ApplyResourceChangeRequest) (resp providers.ApplyResourceChangeResponse) {
+				if int(calls.Add(1)) <= test.failures {
+					resp.NewState = req.PriorState
+					resp.Diagnostics = resp.Diagnostics.Append(tfdiags.Sourceless(tfdiags.Error, "Throttling", "Rate exceeded."))
+					return resp
+				}
+				resp.NewState = req.PlannedState
+				return resp
+			}
+
+			hook := new(MockHook)
+			ctx := testContext2(t, &ContextOpts{
+				Hooks: []Hook{hook},
+				Plugins: plugins.NewLibrary(map[addrs.Provider]providers.Factory{
+					addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+				}, nil),
+				DefaultRetryPolicy: test.defaultPolicy,
+			})
+
+			plan, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+			assertNoErrors(t, diags)
+
+			state, diags := ctx.Apply(context.Background(), plan, m, nil)
+			if got, want := diags.HasErrors(), test.wantErr; got != want {
+				t.Fatalf("wrong error result %t; want %t\n%s", got, want, diags.Err())
+			}
+			if got, want := calls.Load(), test.wantCalls; got != want {
+				t.Errorf("wrong number of ApplyResourceChange calls %d; want %d", got, want)
+			}
+			if got, want := hook.ApplyRetryCalled, test.wantRetries; got != want {
+				t.Errorf("wrong number of ApplyRetry hook calls %d; want %d", got, want)
+			}
+			if test.wantRetries > 0 {
+				if got, want := hook.ApplyRetryAttempt, test.wantRetries+1; got != want {
+					t.Errorf("wrong attempt in last ApplyRetry hook call %d; want %d", got, want)
+				}
+			}
+
+			obj := state.ResourceInstance(mustResourceInstanceAddr("test_object.a"))
+			if test.wantErr {
+				if obj != nil && obj.Current != nil {
+					t.Errorf("unexpected object in state after failed apply")
+				}
+				return
+			}
+			if obj == nil || obj.Current == nil {
+				t.Fatalf("test_object.a is missing from the state")
+			}
+			if obj.Current.Status != states.ObjectReady {
+				t.Errorf("wrong object status %s; want %s", obj.Current.Status, states.ObjectReady)
+			}
+		})
+	}
+}
+
+func TestContext2Apply_retryDestroy(t *testing.T) {
+	retryBlock := `
+  lifecycle {
+    create_before_destroy = %t
+    retry {
+      max_attempts = 3
+      backoff      = "1ms"
+    }
+  }
+`
+	tests := map[string]struct {
+		config        string
+		defaultPolicy *configs.RetryPolicy
+		wantAction    plans.Action
+		wantDeposed   bool
+	}{
+		"removed from configuration": {
+			config:        ``,
+			defaultPolicy: &configs.RetryPolicy{MaxAttempts: 2},
+			wantAction:    plans.Delete,
+		},
+		"destroy before create": {
+			config: `
+resource "test_object" "a" {
+  test_string = "bar"
+` + fmt.Sprintf(retryBlock, false) + `
+}
+`,
+			wantAction: plans.Delete,
+		},
+		"create before destroy": {
+			config: `
+resource "test_object" "a" {
+  test_string = "bar"
+` + fmt.Sprintf(retryBlock, true) + `
+}
+`,
+			wantAction:  plans.Delete,
+			wantDeposed: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := testModuleInline(t, map[string]string{
+				"main.tf": test.config,
+			})
+
+			state := states.NewState()
+			state.EnsureModule(addrs.RootModuleInstance).SetResourceInstanceCurrent(
+				mustResourceInstanceAddr("test_object.a").Resource,
+				&states.ResourceInstanceObjectSrc{
+					Status:    states.ObjectReady,
+					AttrsJSON: []byte(`{"test_string":"foo"}`),
+				},
+				mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`),
+				addrs.NoKey,
+			)
+
+			p := simpleMockProvider()
+			var deleteCalls atomic.Int32
+			p.ApplyResourceChangeFn = func(req providers.ApplyResourceChangeRequest) (resp providers.ApplyResourceChangeResponse) {
+				if req.PlannedState.IsNull() && deleteCalls.Add(1) == 1 {
+					resp.NewState = req.PriorState
+					resp.Diagnostics = resp.Diagnostics.Append(tfdiags.Sourceless(tfdiags.Error, "Throttling", "Rate exceeded."))
+					return resp
+				}
+				resp.NewState = req.PlannedState
+				return resp
+			}
+
+			hook := new(MockHook)
+			ctx := testContext2(t, &ContextOpts{
+				Hooks: []Hook{hook},
+				Plugins: plugins.NewLibrary(map[addrs.Provider]providers.Factory{
+					addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+				}, nil),
+				DefaultRetryPolicy: test.defaultPolicy,
+			})
+
+			plan, diags := ctx.Plan(context.Background(), m, state, &PlanOpts{
+				Mode:         plans.NormalMode,
+				ForceReplace: []addrs.AbsResourceInstance{mustResourceInstanceAddr("test_object.a")},
+			})
+			assertNoErrors(t, diags)
+
+			state, diags = ctx.Apply(context.Background(), plan, m, nil)
+			assertNoErrors(t, diags)
+			if got, want := deleteCalls.Load(), int32(2); got != want {
+				t.Errorf("wrong number of delete calls %d; want %d", got, want)
+			}
+			if got, want := hook.ApplyRetryCalled, 1; got != want {
+				t.Fatalf("wrong number of ApplyRetry hook calls %d; want %d", got, want)
+			}
+			if got, want := hook.ApplyRetryAction, test.wantAction; got != want {
+				t.Errorf("wrong action in ApplyRetry hook call %s; want %s", got, want)
+			}
+			if _, deposed := hook.ApplyRetryGen.(states.DeposedKey); deposed != test.wantDeposed {
+				t.Errorf("wrong generation in ApplyRetry hook call %#v", hook.ApplyRetryGen)
+			}
+
+			obj := state.ResourceInstance(mustResourceInstanceAddr("test_object.a"))
+			if obj != nil && len(obj.Deposed) != 0 {
+				t.Errorf("deposed object was not destroyed")
+			}
+			if test.config == "" && obj != nil {
+				t.Errorf("test_object.a was not destroyed")
+			}
+		})
+	}
+}
@@ -69,6 +69,12 @@ func (c *Context) Eval(ctx context.Context, config *configs.Config, state *state
 	varDiags := checkInputVariables(config.Module.Variables, variables)
 	diags = diags.Append(varDiags)
 
+	pluginDiags := c.checkFunctionPlugins(config)
+	diags = diags.Append(pluginDiags)
+	if pluginDiags.HasErrors() {
+		return nil, diags
+	}
+
 	log.Printf("[DEBUG] Building and walking 'eval' graph")
 
 	providerFunctionTracker := make(ProviderFunctionMapping)
@@ -108,5 +114,11 @@ func (c *Context) Eval(ctx context.Context, config *configs.Config, state *state
 	// caches its contexts, so we should get hold of the context that was
 	// previously used for evaluation here, unless we skipped walking.
 	evalCtx := walker.EnterPath(moduleAddr)
-	return evalCtx.EvaluationScope(nil, nil, EvalDataForNoInstanceKey), diags
+	scope := evalCtx.EvaluationScope(nil, nil, EvalDataForNoInstanceKey)
+
+	// The run context is canceled when we return, but the caller evaluates
+	// expressions in the scope afterwards, so the function plugins' commands
+	// run under the caller's context instead.
+	scope.PluginFunctions = pluginFunctions(ctx, c.functionPlugins, config.DescendentForInstance(moduleAddr))
+	return scope, diags
 }
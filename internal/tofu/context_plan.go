@@ -80,6 +80,18 @@ type PlanOpts struct {
 	// warnings as part of the planning result.
 	Excludes []addrs.Targetable
 
+	// AllowDeferral allows planning to succeed even when the "count" or
+	// "for_each" argument of a resource or module call depends on values
+	// that won't be known until apply. Instead of returning an error,
+	// OpenTofu then skips planning that object and everything that depends
+	// on it, and records it in the DeferredChanges field of the plan so that
+	// the caller can arrange for another plan/apply round once the values are
+	// known. Applying the plan excludes the deferred objects in the same way
+	// as for Excludes.
+	//
+	// Deferral cannot be combined with Targets.
+	AllowDeferral bool
+
 	// ForceReplace is a set of resource instance addresses whose corresponding
 	// objects should be forced planned for replacement if the provider's
 	// plan would otherwise have been to either update the object in-place or
@@ -193,6 +205,16 @@ func (c *Context) Plan(ctx context.Context, config *configs.Config, prevRunState
 		))
 		return nil, diags
 	}
+	if opts.AllowDeferral && len(opts.Targets) > 0 {
+		// The CLI layer (and other similar callers) should prevent this
+		// combination of options.
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Incompatible plan options",
+			"Deferring objects whose instances cannot be determined yet is not supported in combination with the -target option.",
+		))
+		return nil, diags
+	}
 	if len(opts.ForceReplace) > 0 && opts.Mode != plans.NormalMode {
 		// The other modes don't generate no-op or update actions that we might
 		// upgrade to be "replace", so doesn't make sense to combine those.
@@ -241,6 +263,9 @@ The -target and -exclude options are not for routine use, and are provided only
 		panic(fmt.Sprintf("unsupported plan mode %s", opts.Mode))
 	}
 	diags = diags.Append(planDiags)
+	if plan != nil && len(plan.DeferredChanges) > 0 {
+		diags = diags.Append(deferredChangesWarning(plan.DeferredChanges))
+	}
 	// NOTE: We're intentionally not returning early when diags.HasErrors
 	// here because we'll still populate other metadata below on a best-effort
 	// basis to try to give the UI some extra context to return alongside the
@@ -808,6 +833,11 @@ func (c *Context) planWalk(ctx context.Context, config *configs.Config, prevRunS
 
 	timestamp := time.Now().UTC()
 
+	var deferrals *Deferrals
+	if opts.AllowDeferral && walkOp == walkPlan {
+		deferrals = NewDeferrals()
+	}
+
 	// If we get here then we should definitely have a non-nil "graph", which
 	// we can now walk.
 	changes := plans.NewChanges()
@@ -818,6 +848,7 @@ func (c *Context) planWalk(ctx context.Context, config *configs.Config, prevRunS
 		MoveResults:             moveResults,
 		PlanTimeTimestamp:       timestamp,
 		ProviderFunctionTracker: providerFunctionTracker,
+		Deferrals:               deferrals,
 	})
 	diags = diags.Append(walker.NonFatalDiagnostics)
 	diags = diags.Append(walkDiags)
@@ -875,6 +906,7 @@ func (c *Context) planWalk(ctx context.Context, config *configs.Config, prevRunS
 		ExternalReferences: opts.ExternalReferences,
 		Checks:             states.NewCheckResults(walker.Checks),
 		Timestamp:          timestamp,
+		DeferredChanges:    deferrals.Changes(),
 
 		// Other fields get populated by Context.Plan after we return
 	}
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"runtime"
 	"slices"
 	"strconv"
 	"strings"
@@ -30,6 +31,7 @@ import (
 	"github.com/opentofu/opentofu/internal/shared"
 
 	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/lang/funcplugin"
 	"github.com/opentofu/opentofu/internal/lang/marks"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/providers"
@@ -4524,8 +4526,8 @@ resource "test_resource" "a" {
 		} else {
 			wantResult := &states.CheckResultObject{
 				Status: checks.StatusFail,
-				FailureMessages: []string{
-					"Results cannot be empty.",
+				Failures: []checks.Failure{
+					{Message: "Results cannot be empty.", Severity: tfdiags.Warning},
 				},
 			}
 			if diff := cmp.Diff(wantResult, gotResult, valueComparer); diff != "" {
@@ -4683,8 +4685,10 @@ output "a" {
 			t.Errorf("no condition result for %s", addr)
 		} else {
 			wantResult := &states.CheckResultObject{
-				Status:          checks.StatusFail,
-				FailureMessages: []string{"Wrong boop."},
+				Status: checks.StatusFail,
+				Failures: []checks.Failure{
+					{Message: "Wrong boop.", Severity: tfdiags.Warning},
+				},
 			}
 			if diff := cmp.Diff(wantResult, gotResult, valueComparer); diff != "" {
 				t.Errorf("wrong condition result\n%s", diff)
@@ -4693,6 +4697,112 @@ output "a" {
 	})
 }
 
+func TestContext2Plan_conditionErrorCodeAndSeverity(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+variable "quota" {
+  type = number
+
+  validation {
+    condition     = var.quota >= 10
+    error_message = "A quota below 10 is unlikely to be enough."
+    error_code    = "QUOTA_LOW"
+    severity      = warning
+  }
+}
+
+output "a" {
+  value = var.quota
+
+  precondition {
+    condition     = var.quota <= 100
+    error_message = "The quota must not exceed 100."
+    error_code    = "QUOTA_EXCEEDED"
+  }
+  precondition {
+    condition     = var.quota != 42
+    error_message = "The quota is suspicious."
+    severity      = warning
+  }
+}
+`,
+	})
+
+	p := testProvider("test")
+
+	ctx := testContext2(t, &ContextOpts{
+		Plugins: plugins.NewLibrary(map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		}, nil),
+	})
+
+	planWithQuota := func(quota int64) (*plans.Plan, tfdiags.Diagnostics) {
+		return ctx.Plan(context.Background(), m, states.NewState(), &PlanOpts{
+			Mode: plans.NormalMode,
+			SetVariables: InputValues{
+				"quota": &InputValue{
+					Value:      cty.NumberIntVal(quota),
+					SourceType: ValueFromCLIArg,
+				},
+			},
+		})
+	}
+
+	t.Run("warnings", func(t *testing.T) {
+		plan, diags := planWithQuota(5)
+		assertNoErrors(t, diags)
+		if got, want := len(diags), 1; got != want {
+			t.Fatalf("wrong number of diagnostics %d; want %d\n%s", got, want, diags.ErrWithWarnings())
+		}
+		if got, want := diags[0].Description().Summary, "Invalid value for variable"; got != want {
+			t.Errorf("wrong summary\ngot:  %s\nwant: %s", got, want)
+		}
+		if got, want := tfdiags.DiagnosticErrorCode(diags[0]), "QUOTA_LOW"; got != want {
+			t.Errorf("wrong error code\ngot:  %s\nwant: %s", got, want)
+		}
+
+		addr := addrs.RootModuleInstance.InputVariable("quota")
+		if gotResult := plan.Checks.GetObjectResult(addr); gotResult == nil {
+			t.Errorf("no check result for %s", addr)
+		} else {
+			wantResult := &states.CheckResultObject{
+				Status: checks.StatusFail,
+				Failures: []checks.Failure{
+					{Message: "A quota below 10 is unlikely to be enough.", ErrorCode: "QUOTA_LOW", Severity: tfdiags.Warning},
+				},
+			}
+			if diff := cmp.Diff(wantResult, gotResult, valueComparer); diff != "" {
+				t.Errorf("wrong check result\n%s", diff)
+			}
+		}
+
+		plan, diags = planWithQuota(42)
+		assertNoErrors(t, diags)
+		if got, want := diags.ErrWithWarnings().Error(), "Module output value precondition failed: The quota is suspicious."; got != want {
+			t.Errorf("wrong warning:\ngot:  %s\nwant: %q", got, want)
+		}
+		if got := tfdiags.DiagnosticErrorCode(diags[0]); got != "" {
+			t.Errorf("unexpected error code %q", got)
+		}
+		if outputPlan := plan.Changes.OutputValue(addrs.RootModuleInstance.OutputValue("a")); outputPlan == nil {
+			t.Errorf("no plan for output value a; a warning shouldn't block it")
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, diags := planWithQuota(200)
+		if !diags.HasErrors() {
+			t.Fatal("succeeded; want errors")
+		}
+		if got, want := diags.Err().Error(), "Module output value precondition failed: The quota must not exceed 100."; got != want {
+			t.Fatalf("wrong error:\ngot:  %s\nwant: %q", got, want)
+		}
+		if got, want := tfdiags.DiagnosticErrorCode(diags[0]), "QUOTA_EXCEEDED"; got != want {
+			t.Errorf("wrong error code\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+}
+
 func TestContext2Plan_preconditionErrors(t *testing.T) {
 	SkipExperimental(t, ExperimentalChangeDiagWording, ExperimentalBugVariableInput)
 
@@ -9728,3 +9838,507 @@ resource "test_resource" "example" {
 		t.Fatalf("expected to have exactly one resource change but got %d", changes)
 	}
 }
+
+func TestContext2Plan_allowDeferral(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_object" "source" {
+}
+
+resource "test_object" "fixed" {
+  test_string = "fixed"
+}
+
+resource "test_object" "each" {
+  for_each = toset(["a${test_object.source.id}"])
+
+  test_string = each.key
+}
+
+resource "test_object" "downstream" {
+  test_number = length(test_object.each)
+}
+
+module "child" {
+  source = "./child"
+  count  = test_object.source.id == "" ? 2 : 0
+}
+
+module "derived" {
+  source = "./child"
+  count  = length(test_object.each)
+}
+
+output "each" {
+  value = test_object.each
+}
+`,
+		"child/main.tf": `
+resource "test_object" "inner" {
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	schema := p.GetProviderSchemaResponse.ResourceTypes["test_object"]
+	schema.Block.Attributes["id"] = &configschema.Attribute{
+		Type:     cty.String,
+		Computed: true,
+	}
+	p.GetProviderSchemaResponse.ResourceTypes["test_object"] = schema
+	h := &testHook{}
+	ctx := testContext2(t, &ContextOpts{
+		Plugins: plugins.NewLibrary(map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		}, nil),
+		Hooks: []Hook{h},
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		_, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+		if !diags.HasErrors() {
+			t.Fatal("plan succeeded; want errors about unknown count and for_each")
+		}
+	})
+
+	h.Calls = nil
+	plan, diags := ctx.Plan(context.Background(), m, states.NewState(), &PlanOpts{
+		Mode:          plans.NormalMode,
+		AllowDeferral: true,
+	})
+	assertNoErrors(t, diags)
+
+	var gotDeferred []string
+	for _, dc := range plan.DeferredChanges {
+		gotDeferred = append(gotDeferred, fmt.Sprintf("%s (%s)", dc.Addr, dc.Reason))
+	}
+	wantDeferred := []string{
+		"module.child (DeferredBecauseUnknownCount)",
+		"module.derived (DeferredBecauseUnknownCount)",
+		"test_object.each (DeferredBecauseUnknownForEach)",
+	}
+	slices.Sort(gotDeferred)
+	if diff := cmp.Diff(wantDeferred, gotDeferred); diff != "" {
+		t.Errorf("wrong deferred changes\n%s", diff)
+	}
+	if len(plan.ExcludeAddrs) != 0 {
+		t.Errorf("deferred objects were recorded as excluded: %s", plan.ExcludeAddrs)
+	}
+
+	var gotChanges []string
+	for _, change := range plan.Changes.Resources {
+		gotChanges = append(gotChanges, change.Addr.String())
+	}
+	slices.Sort(gotChanges)
+	wantChanges := []string{"test_object.fixed", "test_object.source"}
+	if diff := cmp.Diff(wantChanges, gotChanges); diff != "" {
+		t.Errorf("wrong planned changes\n%s", diff)
+	}
+	if change := plan.Changes.OutputValue(addrs.OutputValue{Name: "each"}.Absolute(addrs.RootModuleInstance)); change != nil {
+		t.Errorf("planned a change for an output that depends on a deferred resource: %s", change.Action)
+	}
+
+	// Deferral happens during a single walk, so each resource instance is
+	// planned only once.
+	var gotDiffs []string
+	for _, call := range h.Calls {
+		if call.Action == "PreDiff" {
+			gotDiffs = append(gotDiffs, call.InstanceID)
+		}
+	}
+	slices.Sort(gotDiffs)
+	if diff := cmp.Diff(wantChanges, gotDiffs); diff != "" {
+		t.Errorf("wrong resource instances planned\n%s", diff)
+	}
+
+	var warned bool
+	for _, diag := range diags {
+		if diag.Severity() == tfdiags.Warning && diag.Description().Summary == "Some changes were deferred" {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Errorf("missing warning about deferred changes in %s", diags.ErrWithWarnings())
+	}
+
+	state, diags := ctx.Apply(context.Background(), plan, m, nil)
+	assertNoErrors(t, diags)
+	if got, want := len(state.AllResourceInstanceObjectAddrs()), 2; got != want {
+		t.Fatalf("applied %d resource instances; want %d", got, want)
+	}
+
+	// Now that test_object.source exists, the next round can plan
+	// everything that was deferred.
+	plan, diags = ctx.Plan(context.Background(), m, state, &PlanOpts{
+		Mode:          plans.NormalMode,
+		AllowDeferral: true,
+	})
+	assertNoErrors(t, diags)
+	if len(plan.DeferredChanges) != 0 {
+		t.Errorf("unexpected deferred changes in second round: %#v", plan.DeferredChanges)
+	}
+	gotChanges = nil
+	for _, change := range plan.Changes.Resources {
+		if change.Action != plans.NoOp {
+			gotChanges = append(gotChanges, change.Addr.String())
+		}
+	}
+	slices.Sort(gotChanges)
+	wantChanges = []string{
+		"module.child[0].test_object.inner",
+		"module.child[1].test_object.inner",
+		"module.derived[0].test_object.inner",
+		"test_object.downstream",
+		`test_object.each["a"]`,
+	}
+	if diff := cmp.Diff(wantChanges, gotChanges); diff != "" {
+		t.Errorf("wrong planned changes in second round\n%s", diff)
+	}
+}
+
+func TestContext2Plan_allowDeferralExistingInstances(t *testing.T) {
+	// Deferring an object must not plan to destroy its existing instances,
+	// or anything that depends on it, even though we don't know yet which
+	// instances should exist.
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_object" "source" {
+}
+
+resource "test_object" "each" {
+  for_each = toset(["a${test_object.source.id}"])
+}
+
+resource "test_object" "downstream" {
+  count = 1
+  test_string = length(test_object.each)
+}
+
+module "child" {
+  source = "./child"
+  count  = test_object.source.id == "" ? 2 : 0
+}
+`,
+		"child/main.tf": `
+resource "test_object" "inner" {
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	schema := p.GetProviderSchemaResponse.ResourceTypes["test_object"]
+	schema.Block.Attributes["id"] = &configschema.Attribute{
+		Type:     cty.String,
+		Computed: true,
+	}
+	p.GetProviderSchemaResponse.ResourceTypes["test_object"] = schema
+
+	provider := mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`)
+	state := states.BuildState(func(s *states.SyncState) {
+		for _, addr := range []string{
+			`test_object.each["old"]`,
+			`test_object.downstream[0]`,
+			`module.child[0].test_object.inner`,
+		} {
+			s.SetResourceInstanceCurrent(mustResourceInstanceAddr(addr), &states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{"id":"old"}`),
+				Status:    states.ObjectReady,
+			}, provider, addrs.NoKey)
+		}
+	})
+
+	ctx := testContext2(t, &ContextOpts{
+		Plugins: plugins.NewLibrary(map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		}, nil),
+	})
+
+	plan, diags := ctx.Plan(context.Background(), m, state, &PlanOpts{
+		Mode:          plans.NormalMode,
+		AllowDeferral: true,
+	})
+	assertNoErrors(t, diags)
+
+	var gotDeferred []string
+	for _, dc := range plan.DeferredChanges {
+		gotDeferred = append(gotDeferred, dc.Addr.String())
+	}
+	if diff := cmp.Diff([]string{"module.child", "test_object.each"}, gotDeferred); diff != "" {
+		t.Errorf("wrong deferred changes\n%s", diff)
+	}
+
+	var gotChanges []string
+	for _, change := range plan.Changes.Resources {
+		gotChanges = append(gotChanges, fmt.Sprintf("%s %s", change.Action, change.Addr))
+	}
+	if diff := cmp.Diff([]string{"Create test_object.source"}, gotChanges); diff != "" {
+		t.Errorf("wrong planned changes\n%s", diff)
+	}
+
+	for _, addr := range []string{
+		`test_object.each["old"]`,
+		`test_object.downstream[0]`,
+		`module.child[0].test_object.inner`,
+	} {
+		if plan.PlannedState.ResourceInstance(mustResourceInstanceAddr(addr)) == nil {
+			t.Errorf("%s was removed from the planned state", addr)
+		}
+	}
+}
+
+func TestContext2Plan_userFunctions(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+function "resource_name" {
+  parameters = {
+    env  = string
+    name = string
+  }
+  result = "${module::prefix(env)}-${name}"
+  export = true
+}
+
+function "prefix" {
+  parameters = {
+    env = string
+  }
+  result = upper(env)
+}
+
+module "child" {
+  source = "./child"
+}
+
+output "root" {
+  value = module::resource_name("prod", "web")
+}
+
+output "child" {
+  value = module.child.out
+}
+`,
+		"child/main.tf": `
+function "prefix" {
+  parameters = {
+    env = string
+  }
+  result = lower(env)
+}
+
+output "out" {
+  value = "${module::resource_name("Test", "db")}/${module::prefix("Test")}"
+}
+`,
+	})
+
+	ctx := testContext2(t, &ContextOpts{})
+
+	plan, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	for name, want := range map[string]cty.Value{
+		// The exported function keeps calling the functions of the module
+		// that declared it, even when a child module shadows them.
+		"root":  cty.StringVal("PROD-web"),
+		"child": cty.StringVal("TEST-db/test"),
+	} {
+		changeSrc := plan.Changes.OutputValue(addrs.RootModuleInstance.OutputValue(name))
+		if changeSrc == nil {
+			t.Fatalf("no change planned for output value %q", name)
+		}
+		change, err := changeSrc.Decode()
+		if err != nil {
+			t.Fatalf("failed to decode output value %q: %s", name, err)
+		}
+		if !want.RawEquals(change.After) {
+			t.Errorf("wrong value for output value %q\ngot:  %#v\nwant: %#v", name, change.After, want)
+		}
+	}
+}
+
+func TestContext2Plan_functionPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("this test uses a POSIX shell")
+	}
+
+	plugin, err := funcplugin.New("example", []string{"sh", "-c", `
+echo '{"magic":"OpenTofu-Function-Plugin","version":1}'
+input=$(cat)
+case "$input" in
+  *'"method":"functions"'*)
+    echo '{"functions":{"greet":{"params":[{"name":"name","type":"string"}],"return_type":"string"}}}'
+    ;;
+  *)
+    echo "{\"result\":\"Hello, $(printf '%s' "$input" | sed 's/.*"arguments":\["\([^"]*\)"\].*/\1/')!\"}"
+    ;;
+esac
+`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := &ContextOpts{
+		FunctionPlugins: map[string]*funcplugin.Plugin{
+			"example": plugin,
+		},
+	}
+
+	t.Run("required", func(t *testing.T) {
+		m := testModuleInline(t, map[string]string{
+			"main.tf": `
+terraform {
+  required_functions = ["example"]
+}
+
+output "greeting" {
+  value = plugin::example::greet("world")
+}
+`,
+		})
+
+		ctx := testContext2(t, opts)
+		plan, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+		assertNoErrors(t, diags)
+
+		changeSrc := plan.Changes.OutputValue(addrs.RootModuleInstance.OutputValue("greeting"))
+		if changeSrc == nil {
+			t.Fatal("no change planned for output value")
+		}
+		change, err := changeSrc.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := cty.StringVal("Hello, world!"); !want.RawEquals(change.After) {
+			t.Errorf("wrong value\ngot:  %#v\nwant: %#v", change.After, want)
+		}
+	})
+
+	t.Run("not required", func(t *testing.T) {
+		m := testModuleInline(t, map[string]string{
+			"main.tf": `
+output "greeting" {
+  value = plugin::example::greet("world")
+}
+`,
+		})
+
+		ctx := testContext2(t, opts)
+		_, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+		if !diags.HasErrors() {
+			t.Fatal("plan succeeded; want error")
+		}
+		if got, want := diags.Err().Error(), `There is no function named "greet" provided by function plugin "example".`; !strings.Contains(got, want) {
+			t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		m := testModuleInline(t, map[string]string{
+			"main.tf": `
+terraform {
+  required_functions = ["missing"]
+}
+`,
+		})
+
+		ctx := testContext2(t, opts)
+		_, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+		if !diags.HasErrors() {
+			t.Fatal("plan succeeded; want error")
+		}
+		if got, want := diags.Err().Error(), `Missing required function plugin: This configuration requires function plugin "missing"`; !strings.Contains(got, want) {
+			t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+}
+
+func TestContext2Plan_deprecatedVariableAttributes(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"mod/main.tf": `
+variable "settings" {
+  type = object({
+    name     = string
+    old_name = {
+      type       = optional(string, "fallback")
+      deprecated = "Use name instead."
+    }
+    servers = optional(list(object({
+      host = string
+      port = {
+        type       = optional(number)
+        deprecated = "Ports are chosen automatically."
+      }
+    })), [])
+  })
+}
+
+output "old_name" {
+  value = var.settings.old_name
+}
+`,
+		"main.tf": `
+module "unset" {
+  source = "./mod"
+  settings = {
+    name     = "a"
+    old_name = null
+  }
+}
+
+module "set" {
+  source = "./mod"
+  settings = {
+    name     = "b"
+    old_name = "legacy"
+    servers = [
+      { host = "x" },
+      { host = "y", port = 8080 },
+    ]
+  }
+}
+
+output "defaulted" {
+  value = module.unset.old_name
+}
+`,
+	})
+
+	ctx := testContext2(t, &ContextOpts{})
+	plan, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	var got []string
+	for _, diag := range diags {
+		desc := diag.Description()
+		if desc.Summary != "Variable attribute marked as deprecated by the module author" {
+			t.Errorf("unexpected diagnostic: %s", desc.Summary)
+			continue
+		}
+		cause, ok := marks.DiagnosticDeprecationCause(diag)
+		if !ok {
+			t.Errorf("diagnostic has no deprecation cause: %s", desc.Detail)
+		}
+		got = append(got, fmt.Sprintf("%s:%d: %s", cause.ModuleInstance(), diag.Source().Subject.Start.Line, desc.Detail))
+	}
+	slices.Sort(got)
+	want := []string{
+		"module.set:14: Attribute \"old_name\" of variable \"settings\" is marked as deprecated with the following message:\nUse name instead.",
+		"module.set:17: Attribute \"servers[1].port\" of variable \"settings\" is marked as deprecated with the following message:\nPorts are chosen automatically.",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong warnings\n%s", diff)
+	}
+
+	// The default of the deprecated attribute is still used for callers
+	// that don't set it.
+	oc := plan.Changes.OutputValue(addrs.OutputValue{Name: "defaulted"}.Absolute(addrs.RootModuleInstance))
+	if oc == nil {
+		t.Fatal("no change for output.defaulted")
+	}
+	change, err := oc.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !change.After.RawEquals(cty.StringVal("fallback")) {
+		t.Errorf("wrong value for output.defaulted: %#v", change.After)
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/dag"
+	"github.com/opentofu/opentofu/internal/lang/evalchecks"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// Deferrals tracks the objects whose planning is deferred during a plan walk,
+// because the caller allowed deferral and OpenTofu can't yet determine which
+// instances of them should exist.
+//
+// Deferral happens within a single walk of the plan graph. A resource or module
+// call whose "count" or "for_each" value is unknown records itself here instead
+// of returning an error, and then the graph walk skips planning anything that
+// depends on it, either directly or indirectly. References to a deferred object
+// evaluate to an unknown value, so that any other dependents can still be
+// evaluated.
+type Deferrals struct {
+	mu sync.Mutex
+
+	// changes describes the deferred objects, in the order they were deferred.
+	changes []*plans.DeferredChange
+
+	// vertices contains every graph node that is either deferred itself or
+	// depends on something that is.
+	vertices dag.Set
+}
+
+// NewDeferrals returns a new, empty Deferrals object.
+func NewDeferrals() *Deferrals {
+	return &Deferrals{
+		vertices: make(dag.Set),
+	}
+}
+
+// Changes returns a description of each object that has been deferred so far,
+// ordered by address.
+func (d *Deferrals) Changes() []*plans.DeferredChange {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	ret := slices.Clone(d.changes)
+	slices.SortFunc(ret, func(a, b *plans.DeferredChange) int {
+		return strings.Compare(a.Addr.String(), b.Addr.String())
+	})
+	return ret
+}
+
+// deferObject records that the planning of the object at the given address,
+// which belongs to graph node v, is deferred for the given reason.
+func (d *Deferrals) deferObject(v dag.Vertex, addr addrs.Targetable, reason plans.DeferredReason) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	log.Printf("[DEBUG] Plan: deferring %s because its %s", addr, deferredReasonPhrase(reason))
+	d.changes = append(d.changes, &plans.DeferredChange{
+		Addr:   addr,
+		Reason: reason,
+	})
+	d.vertices.Add(v)
+}
+
+// dependsOnDeferred returns true if the given graph node depends on any node
+// that is deferred or itself depends on a deferred node, in which case the
+// given node is recorded as depending on a deferred node too.
+//
+// The graph walk visits every node only after visiting all of its
+// dependencies, so checking only the direct dependencies is enough.
+func (d *Deferrals) dependsOnDeferred(g *Graph, v dag.Vertex) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, dep := range g.DownEdges(v) {
+		if d.vertices.Include(dep) {
+			d.vertices.Add(v)
+			return true
+		}
+	}
+	return false
+}
+
+// resourceDeferred returns true if the given resource has been deferred.
+func (d *Deferrals) resourceDeferred(addr addrs.AbsResource) bool {
+	if d == nil {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, dc := range d.changes {
+		if deferred, ok := dc.Addr.(addrs.AbsResource); ok && deferred.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// moduleCallDeferred returns true if the given call from the given module
+// instance has been deferred.
+func (d *Deferrals) moduleCallDeferred(module addrs.ModuleInstance, call addrs.ModuleCall) bool {
+	if d == nil {
+		return false
+	}
+	addr := module.Child(call.Name, addrs.NoKey)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, dc := range d.changes {
+		if deferred, ok := dc.Addr.(addrs.ModuleInstance); ok && deferred.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// deferUnknownExpansion defers the planning of the object at the given
+// address, which belongs to graph node v, if deferral is allowed and the given
+// diagnostics from evaluating its "count" or "for_each" argument only report
+// that the argument's value is unknown.
+//
+// It returns true if the object was deferred, in which case the caller must
+// discard the diagnostics and must not plan any instances of the object.
+func deferUnknownExpansion(evalCtx EvalContext, v dag.Vertex, addr addrs.Targetable, diags tfdiags.Diagnostics) bool {
+	deferrals := evalCtx.Deferrals()
+	if deferrals == nil || !diags.HasErrors() {
+		return false
+	}
+
+	reason := plans.DeferredReasonInvalid
+	for _, diag := range diags {
+		if diag.Severity() != tfdiags.Error {
+			continue
+		}
+		expansion, ok := evalchecks.UnknownExpansion(diag)
+		if !ok {
+			// Something else is wrong with the argument, which deferral
+			// can't help with.
+			return false
+		}
+		reason = plans.DeferredBecauseUnknownCount
+		if expansion.Argument == "for_each" {
+			reason = plans.DeferredBecauseUnknownForEach
+		}
+	}
+
+	deferrals.deferObject(v, addr, reason)
+	return true
+}
+
+// skipWhenDeferred returns true if the graph walk must not visit the given
+// node when it depends on a deferred object, because visiting it would plan
+// changes that can't be planned until the deferred object is.
+//
+// Other nodes are still visited, but any references they make to deferred
+// objects evaluate to unknown values. In particular, a module call that
+// depends on a deferred object is deferred itself only if its own "count" or
+// "for_each" value is then unknown, but everything it contains that depends
+// on the deferred object is skipped.
+func skipWhenDeferred(v dag.Vertex) bool {
+	switch v := v.(type) {
+	case GraphNodeConfigResource, GraphNodeResourceInstance, *nodeExpandCheck:
+		return true
+	case graphNodeTemporaryValue:
+		// Root module output values are part of the plan, so we leave them
+		// unchanged until their value can be planned.
+		return !v.temporaryValue(walkPlan)
+	default:
+		return false
+	}
+}
+
+// deferredChangesWarning returns a warning describing the given deferred
+// changes, which tells the user that another plan/apply round is needed.
+func deferredChangesWarning(deferred []*plans.DeferredChange) tfdiags.Diagnostic {
+	var buf strings.Builder
+	buf.WriteString("OpenTofu cannot yet determine the instances of the following objects, so it has deferred planning them and everything that depends on them:\n")
+	for _, dc := range deferred {
+		fmt.Fprintf(&buf, "  - %s, because its %s\n", dc.Addr, deferredReasonPhrase(dc.Reason))
+	}
+	buf.WriteString("\nAfter applying this plan, run OpenTofu again to plan the deferred changes.")
+	return tfdiags.Sourceless(
+		tfdiags.Warning,
+		"Some changes were deferred",
+		buf.String(),
+	)
+}
+
+func deferredReasonPhrase(reason plans.DeferredReason) string {
+	switch reason {
+	case plans.DeferredBecauseUnknownCount:
+		return `"count" value depends on values that will be known only after apply`
+	case plans.DeferredBecauseUnknownForEach:
+		return `"for_each" value depends on values that will be known only after apply`
+	default:
+		return "instances cannot be determined yet"
+	}
+}
@@ -48,6 +48,10 @@ type graphWalkOpts struct {
 
 	ProviderFunctionTracker ProviderFunctionMapping
 
+	// Deferrals should be populated during the plan phase when the caller
+	// allows deferring objects whose instances can't be determined yet.
+	Deferrals *Deferrals
+
 	BackupStateForPanic func(*states.State)
 }
 
@@ -155,6 +159,7 @@ func (c *Context) graphWalker(operation walkOperation, opts *graphWalkOpts) *Con
 		InstanceExpander:        instances.NewExpander(),
 		MoveResults:             opts.MoveResults,
 		ImportResolver:          NewImportResolver(),
+		Deferrals:               opts.Deferrals,
 		Operation:               operation,
 		StopContext:             c.runContext,
 		PlanTimestamp:           opts.PlanTimeTimestamp,
@@ -51,15 +51,13 @@ func evalCheckRules(ctx context.Context, typ addrs.CheckRuleType, rules []*confi
 		return nil
 	}
 
-	severity := diagSeverity.ToHCL()
-
 	for i, rule := range rules {
-		result, ruleDiags := evalCheckRule(ctx, addrs.NewCheckRule(self, typ, i), rule, evalCtx, keyData, severity)
+		result, ruleDiags := evalCheckRule(ctx, addrs.NewCheckRule(self, typ, i), rule, evalCtx, keyData, rule.FailureSeverity(diagSeverity))
 		diags = diags.Append(ruleDiags)
 
 		log.Printf("[TRACE] evalCheckRules: %s status is now %s", self, result.Status)
 		if result.Status == checks.StatusFail {
-			checkState.ReportCheckFailure(self, typ, i, result.FailureMessage)
+			checkState.ReportCheckFailure(self, typ, i, result.Failure)
 		} else {
 			checkState.ReportCheckResult(self, typ, i, result.Status)
 		}
@@ -69,8 +67,8 @@ func evalCheckRules(ctx context.Context, typ addrs.CheckRuleType, rules []*confi
 }
 
 type checkResult struct {
-	Status         checks.Status
-	FailureMessage string
+	Status  checks.Status
+	Failure checks.Failure
 }
 
 func validateCheckRule(ctx context.Context, addr addrs.CheckRule, rule *configs.CheckRule, evalCtx EvalContext, keyData instances.RepetitionData) (string, *hcl.EvalContext, tfdiags.Diagnostics) {
@@ -113,7 +111,7 @@ func validateCheckRule(ctx context.Context, addr addrs.CheckRule, rule *configs.
 	return errorMessage, hclCtx, diags
 }
 
-func evalCheckRule(ctx context.Context, addr addrs.CheckRule, rule *configs.CheckRule, evalCtx EvalContext, keyData instances.RepetitionData, severity hcl.DiagnosticSeverity) (checkResult, tfdiags.Diagnostics) {
+func evalCheckRule(ctx context.Context, addr addrs.CheckRule, rule *configs.CheckRule, evalCtx EvalContext, keyData instances.RepetitionData, severity tfdiags.Severity) (checkResult, tfdiags.Diagnostics) {
 	// NOTE: Intentionally not passing the caller's selected severity in here,
 	// because this reports errors in the configuration itself, not the failure
 	// of an otherwise-valid condition.
@@ -201,8 +199,9 @@ func evalCheckRule(ctx context.Context, addr addrs.CheckRule, rule *configs.Chec
 	diags = diags.Append(&hcl.Diagnostic{
 		// The caller gets to choose the severity of this one, because we
 		// treat condition failures as warnings in the presence of
-		// certain special planning options.
-		Severity:    severity,
+		// certain special planning options, and the rule itself may
+		// downgrade it to a warning.
+		Severity:    severity.ToHCL(),
 		Summary:     fmt.Sprintf("%s failed", addr.Type.Description()),
 		Detail:      errorMessageForDiags,
 		Subject:     rule.Condition.Range().Ptr(),
@@ -210,12 +209,17 @@ func evalCheckRule(ctx context.Context, addr addrs.CheckRule, rule *configs.Chec
 		EvalContext: hclCtx,
 		Extra: &addrs.CheckRuleDiagnosticExtra{
 			CheckRule: addr,
+			ErrorCode: rule.ErrorCode,
 		},
 	})
 
 	return checkResult{
-		Status:         status,
-		FailureMessage: errorMessage,
+		Status: status,
+		Failure: checks.Failure{
+			Message:   errorMessage,
+			ErrorCode: rule.ErrorCode,
+			Severity:  severity,
+		},
 	}, diags
 }
 
@@ -171,6 +171,13 @@ type EvalContext interface {
 	// and have a configuration
 	ImportResolver() *ImportResolver
 
+	// Deferrals returns a helper object for tracking the objects whose
+	// planning is deferred because their instances can't be determined yet.
+	//
+	// This is nil unless the caller of the plan operation allowed deferral,
+	// and it is always nil for walks other than the plan walk.
+	Deferrals() *Deferrals
+
 	// WithPath returns a copy of the context with the internal path set to the
 	// path argument.
 	WithPath(path addrs.ModuleInstance) EvalContext
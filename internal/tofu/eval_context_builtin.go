@@ -75,6 +75,7 @@ type BuiltinEvalContext struct {
 	InstanceExpanderValue   *instances.Expander
 	MoveResultsValue        refactoring.MoveResults
 	ImportResolverValue     *ImportResolver
+	DeferralsValue          *Deferrals
 	Encryption              encryption.Encryption
 	ProviderFunctionTracker ProviderFunctionMapping
 }
@@ -320,8 +321,17 @@ func (c *BuiltinEvalContext) EvaluationScope(self addrs.Referenceable, source ad
 	// real situations.
 	mc := c.Evaluator.Config.DescendentForInstance(c.PathValue)
 
+	// StopContext can be nil during tests, as in Stopped.
+	stopCtx := c.StopContext
+	if stopCtx == nil {
+		stopCtx = context.Background()
+	}
+
 	if mc == nil || mc.Module.ProviderRequirements == nil {
-		return c.Evaluator.Scope(data, self, source, nil)
+		scope := c.Evaluator.Scope(data, self, source, nil)
+		scope.UserFunctions = c.Evaluator.userFunctions(mc)
+		scope.PluginFunctions = pluginFunctions(stopCtx, c.Evaluator.FunctionPlugins, mc)
+		return scope
 	}
 
 	scope := c.Evaluator.Scope(data, self, source, func(ctx context.Context, pf addrs.ProviderFunction, rng tfdiags.SourceRange) (*function.Function, tfdiags.Diagnostics) {
@@ -365,6 +375,8 @@ func (c *BuiltinEvalContext) EvaluationScope(self addrs.Referenceable, source ad
 		return evalContextProviderFunction(ctx, provider, c.Evaluator.Operation, pf, rng)
 	})
 	scope.SetActiveExperiments(mc.Module.ActiveExperiments)
+	scope.UserFunctions = c.Evaluator.userFunctions(mc)
+	scope.PluginFunctions = pluginFunctions(stopCtx, c.Evaluator.FunctionPlugins, mc)
 
 	return scope
 }
@@ -454,6 +466,10 @@ func (c *BuiltinEvalContext) ImportResolver() *ImportResolver {
 	return c.ImportResolverValue
 }
 
+func (c *BuiltinEvalContext) Deferrals() *Deferrals {
+	return c.DeferralsValue
+}
+
 func (c *BuiltinEvalContext) GetEncryption() encryption.Encryption {
 	return c.Encryption
 }
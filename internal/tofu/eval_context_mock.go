@@ -126,6 +126,9 @@ type MockEvalContext struct {
 	ImportResolverCalled  bool
 	ImportResolverResults *ImportResolver
 
+	DeferralsCalled  bool
+	DeferralsResults *Deferrals
+
 	InstanceExpanderCalled   bool
 	InstanceExpanderExpander *instances.Expander
 }
@@ -343,6 +346,11 @@ func (c *MockEvalContext) ImportResolver() *ImportResolver {
 	return c.ImportResolverResults
 }
 
+func (c *MockEvalContext) Deferrals() *Deferrals {
+	c.DeferralsCalled = true
+	return c.DeferralsResults
+}
+
 func (c *MockEvalContext) InstanceExpander() *instances.Expander {
 	c.InstanceExpanderCalled = true
 	return c.InstanceExpanderExpander
@@ -307,7 +307,7 @@ func evalVariableValidations(ctx context.Context, addr addrs.AbsInputVariableIns
 
 		log.Printf("[TRACE] evalVariableValidations: %s status is now %s", addr, result.Status)
 		if result.Status == checks.StatusFail {
-			checkState.ReportCheckFailure(addr, addrs.InputValidation, ix, result.FailureMessage)
+			checkState.ReportCheckFailure(addr, addrs.InputValidation, ix, result.Failure)
 		} else {
 			checkState.ReportCheckResult(addr, addrs.InputValidation, ix, result.Status)
 		}
@@ -425,6 +425,7 @@ func evalVariableValidation(validation *configs.CheckRule, hclCtx *hcl.EvalConte
 	if status != checks.StatusFail {
 		return checkResult{Status: status}, diags
 	}
+	severity := validation.FailureSeverity(tfdiags.Error)
 
 	var errorMessage string
 	if !errorDiags.HasErrors() && !errorValue.IsKnown() {
@@ -439,8 +440,11 @@ func evalVariableValidation(validation *configs.CheckRule, hclCtx *hcl.EvalConte
 		})
 		// Return early
 		return checkResult{
-			Status:         status,
-			FailureMessage: errorMessage,
+			Status: status,
+			Failure: checks.Failure{
+				ErrorCode: validation.ErrorCode,
+				Severity:  severity,
+			},
 		}, diags
 	}
 	if !errorDiags.HasErrors() && errorValue.IsKnown() && !errorValue.IsNull() {
@@ -499,38 +503,43 @@ You can correct this by removing references to ephemeral values or by utilizing
 		errorMessage = "Failed to evaluate condition error message."
 	}
 
+	extra := &addrs.CheckRuleDiagnosticExtra{
+		CheckRule: addr.CheckRule(addrs.InputValidation, ix),
+		ErrorCode: validation.ErrorCode,
+	}
+
 	if expr != nil {
 		diags = diags.Append(&hcl.Diagnostic{
-			Severity:    hcl.DiagError,
+			Severity:    severity.ToHCL(),
 			Summary:     errInvalidValue,
 			Detail:      fmt.Sprintf("%s\n\nThis was checked by the validation rule at %s.", errorMessage, validation.DeclRange.String()),
 			Subject:     expr.Range().Ptr(),
 			Expression:  validation.Condition,
 			EvalContext: hclCtx,
-			Extra: &addrs.CheckRuleDiagnosticExtra{
-				CheckRule: addr.CheckRule(addrs.InputValidation, ix),
-			},
+			Extra:       extra,
 		})
 	} else {
 		// Since we don't have a source expression for a root module
 		// variable, we'll just report the error from the perspective
 		// of the variable declaration itself.
 		diags = diags.Append(&hcl.Diagnostic{
-			Severity:    hcl.DiagError,
+			Severity:    severity.ToHCL(),
 			Summary:     errInvalidValue,
 			Detail:      fmt.Sprintf("%s\n\nThis was checked by the validation rule at %s.", errorMessage, validation.DeclRange.String()),
 			Subject:     config.DeclRange.Ptr(),
 			Expression:  validation.Condition,
 			EvalContext: hclCtx,
-			Extra: &addrs.CheckRuleDiagnosticExtra{
-				CheckRule: addr.CheckRule(addrs.InputValidation, ix),
-			},
+			Extra:       extra,
 		})
 	}
 
 	return checkResult{
-		Status:         status,
-		FailureMessage: errorMessage,
+		Status: status,
+		Failure: checks.Failure{
+			Message:   errorMessage,
+			ErrorCode: validation.ErrorCode,
+			Severity:  severity,
+		},
 	}, diags
 }
 
@@ -564,3 +573,134 @@ func evalVariableDeprecation(
 		Extra:    marks.DeprecationCauseVariable(addr, config.Deprecated),
 	})
 }
+
+// evalVariableAttributeDeprecations returns a warning for each nested
+// attribute of the given value that the variable's type constraint marks as
+// deprecated and that the caller has set to something other than null.
+//
+// givenVal must be the value exactly as the caller provided it, before any
+// type conversion or defaults, because otherwise we'd warn about attributes
+// that were populated by optional attribute defaults.
+func evalVariableAttributeDeprecations(
+	addr addrs.AbsInputVariableInstance,
+	config *configs.Variable,
+	expr hcl.Expression,
+	givenVal cty.Value) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	// if the variable is not given in the module call, do not show a warning
+	if len(config.DeprecatedAttributes) == 0 || expr == nil || givenVal == cty.NilVal {
+		return diags
+	}
+
+	givenVal, _ = givenVal.UnmarkDeep()
+	for _, attr := range config.DeprecatedAttributes {
+		for _, path := range setValuePaths(givenVal, nil, attr.Path) {
+			log.Printf("[TRACE] evalVariableAttributeDeprecations: usage of deprecated attribute %s%s detected", addr, tfdiags.FormatCtyPath(path))
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagWarning,
+				Summary:  `Variable attribute marked as deprecated by the module author`,
+				Detail: fmt.Sprintf(
+					"Attribute %q of variable %q is marked as deprecated with the following message:\n%s",
+					strings.TrimPrefix(tfdiags.FormatCtyPath(path), "."), config.Name, attr.Message,
+				),
+				Subject: definitionRangeForPath(expr, path).Ptr(),
+				Extra:   marks.DeprecationCauseVariableAttribute(addr, path, attr.Message),
+			})
+		}
+	}
+	return diags
+}
+
+// setValuePaths returns the paths of the non-null values in val that match
+// the rest of the given path, where an index step with an unknown key
+// matches all elements of a collection.
+func setValuePaths(val cty.Value, prefix, rest cty.Path) []cty.Path {
+	if val.IsNull() {
+		return nil
+	}
+	if len(rest) == 0 {
+		return []cty.Path{prefix}
+	}
+	if !val.IsKnown() {
+		// We can't tell yet what the caller has set inside this value.
+		return nil
+	}
+
+	switch step := rest[0].(type) {
+	case cty.GetAttrStep:
+		// The caller's value isn't converted yet, so it might be a map
+		// that will later become an object.
+		ty := val.Type()
+		switch {
+		case ty.IsObjectType() && ty.HasAttribute(step.Name):
+			return setValuePaths(val.GetAttr(step.Name), append(prefix.Copy(), step), rest[1:])
+		case ty.IsMapType() && val.HasIndex(cty.StringVal(step.Name)).True():
+			return setValuePaths(val.Index(cty.StringVal(step.Name)), append(prefix.Copy(), step), rest[1:])
+		}
+	case cty.IndexStep:
+		if !val.CanIterateElements() {
+			return nil
+		}
+		if step.Key.IsKnown() {
+			if !val.Type().IsSetType() && val.HasIndex(step.Key).True() {
+				return setValuePaths(val.Index(step.Key), append(prefix.Copy(), step), rest[1:])
+			}
+			return nil
+		}
+		var paths []cty.Path
+		for it := val.ElementIterator(); it.Next(); {
+			key, elem := it.Element()
+			paths = append(paths, setValuePaths(elem, append(prefix.Copy(), cty.IndexStep{Key: key}), rest[1:])...)
+		}
+		return paths
+	}
+	return nil
+}
+
+// definitionRangeForPath returns the source range of the expression that
+// defines the value at the given path within expr, if it is written using
+// object and tuple constructors, or the range of the nearest enclosing
+// expression otherwise.
+func definitionRangeForPath(expr hcl.Expression, path cty.Path) hcl.Range {
+	for _, step := range path {
+		next := childExprForStep(expr, step)
+		if next == nil {
+			break
+		}
+		expr = next
+	}
+	return expr.Range()
+}
+
+func childExprForStep(expr hcl.Expression, step cty.PathStep) hcl.Expression {
+	switch step := step.(type) {
+	case cty.GetAttrStep:
+		items, diags := hcl.ExprMap(expr)
+		if diags.HasErrors() {
+			return nil
+		}
+		for _, item := range items {
+			key, keyDiags := item.Key.Value(nil)
+			if keyDiags.HasErrors() || !key.IsKnown() || key.IsNull() || key.Type() != cty.String {
+				continue
+			}
+			if key.AsString() == step.Name {
+				return item.Value
+			}
+		}
+	case cty.IndexStep:
+		if !step.Key.IsKnown() || step.Key.Type() != cty.Number {
+			return nil
+		}
+		idx, accuracy := step.Key.AsBigFloat().Int64()
+		if accuracy != 0 {
+			return nil
+		}
+		exprs, diags := hcl.ExprList(expr)
+		if diags.HasErrors() || idx < 0 || idx >= int64(len(exprs)) {
+			return nil
+		}
+		return exprs[idx]
+	}
+	return nil
+}
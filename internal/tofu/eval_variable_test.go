@@ -1859,3 +1859,85 @@ func TestEvalVariableValidations_ephemeralValueDiagnostics(t *testing.T) {
 		}
 	}
 }
+
+func TestSetValuePaths(t *testing.T) {
+	anyElem := cty.GetAttrPath("items").Index(cty.DynamicVal).GetAttr("old")
+	tests := map[string]struct {
+		val  cty.Value
+		path cty.Path
+		want []cty.Path
+	}{
+		"attribute set": {
+			cty.ObjectVal(map[string]cty.Value{"old": cty.StringVal("a")}),
+			cty.GetAttrPath("old"),
+			[]cty.Path{cty.GetAttrPath("old")},
+		},
+		"attribute null": {
+			cty.ObjectVal(map[string]cty.Value{"old": cty.NullVal(cty.String)}),
+			cty.GetAttrPath("old"),
+			nil,
+		},
+		"attribute absent": {
+			cty.EmptyObjectVal,
+			cty.GetAttrPath("old"),
+			nil,
+		},
+		"attribute unknown": {
+			cty.ObjectVal(map[string]cty.Value{"old": cty.UnknownVal(cty.String)}),
+			cty.GetAttrPath("old"),
+			[]cty.Path{cty.GetAttrPath("old")},
+		},
+		"map key set": {
+			cty.MapVal(map[string]cty.Value{"old": cty.StringVal("a")}),
+			cty.GetAttrPath("old"),
+			[]cty.Path{cty.GetAttrPath("old")},
+		},
+		"whole value unknown": {
+			cty.DynamicVal,
+			cty.GetAttrPath("old"),
+			nil,
+		},
+		"elements": {
+			cty.ObjectVal(map[string]cty.Value{
+				"items": cty.TupleVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{"old": cty.NullVal(cty.String)}),
+					cty.ObjectVal(map[string]cty.Value{"old": cty.True}),
+					cty.EmptyObjectVal,
+				}),
+			}),
+			anyElem,
+			[]cty.Path{cty.GetAttrPath("items").IndexInt(1).GetAttr("old")},
+		},
+		"set elements": {
+			cty.ObjectVal(map[string]cty.Value{
+				"items": cty.SetVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{"old": cty.StringVal("a")}),
+				}),
+			}),
+			anyElem,
+			[]cty.Path{cty.GetAttrPath("items").Index(cty.ObjectVal(map[string]cty.Value{"old": cty.StringVal("a")})).GetAttr("old")},
+		},
+		"tuple element": {
+			cty.TupleVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{"old": cty.StringVal("a")}),
+				cty.ObjectVal(map[string]cty.Value{"old": cty.StringVal("b")}),
+			}),
+			cty.IndexIntPath(1).GetAttr("old"),
+			[]cty.Path{cty.IndexIntPath(1).GetAttr("old")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := setValuePaths(test.val, nil, test.path)
+			if len(got) != len(test.want) {
+				t.Fatalf("wrong paths\ngot:  %#v\nwant: %#v", got, test.want)
+			}
+			for i := range got {
+				if !got[i].Equals(test.want[i]) {
+					t.Errorf("wrong path %d\ngot:  %#v\nwant: %#v", i, got[i], test.want[i])
+				}
+			}
+		})
+	}
+}
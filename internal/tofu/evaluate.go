@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/hcl/v2"
 	"github.com/opentofu/opentofu/internal/plans/objchange"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/configs"
@@ -24,6 +25,7 @@ import (
 	"github.com/opentofu/opentofu/internal/didyoumean"
 	"github.com/opentofu/opentofu/internal/instances"
 	"github.com/opentofu/opentofu/internal/lang"
+	"github.com/opentofu/opentofu/internal/lang/funcplugin"
 	"github.com/opentofu/opentofu/internal/lang/marks"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/states"
@@ -61,6 +63,17 @@ type Evaluator struct {
 	// interact with plugin instances.
 	Plugins *contextPlugins
 
+	// userFunctionsCache caches the result of userFunctions for each module in
+	// the configuration. It must be accessed only while holding
+	// userFunctionsLock.
+	userFunctionsLock  sync.Mutex
+	userFunctionsCache map[*configs.Config]map[string]*lang.UserFunction
+
+	// FunctionPlugins are the function plugins defined in the CLI
+	// configuration, keyed by name. Each module can use only the plugins
+	// that it requires, as returned by pluginFunctions.
+	FunctionPlugins map[string]*funcplugin.Plugin
+
 	// State is the current state, embedded in a wrapper that ensures that
 	// it can be safely accessed and modified concurrently.
 	State *states.SyncState
@@ -73,6 +86,10 @@ type Evaluator struct {
 	// is used to determine the set of instance keys for count and for_each.
 	InstanceExpander *instances.Expander
 
+	// Deferrals tracks the objects whose planning is deferred, if the caller
+	// allowed deferral. The values of deferred objects are unknown.
+	Deferrals *Deferrals
+
 	PlanTimestamp time.Time
 }
 
@@ -95,6 +112,56 @@ func (e *Evaluator) Scope(data lang.Data, self addrs.Referenceable, source addrs
 	}
 }
 
+// userFunctions returns the user-defined functions that expressions in the
+// given module can call, for use as [lang.Scope.UserFunctions].
+//
+// They are built only once for each module, because lang.Scope caches the
+// implementations of user-defined functions by their address.
+func (e *Evaluator) userFunctions(mc *configs.Config) map[string]*lang.UserFunction {
+	if mc == nil {
+		return nil
+	}
+	e.userFunctionsLock.Lock()
+	defer e.userFunctionsLock.Unlock()
+
+	if ret, ok := e.userFunctionsCache[mc]; ok {
+		return ret
+	}
+	if e.userFunctionsCache == nil {
+		e.userFunctionsCache = make(map[*configs.Config]map[string]*lang.UserFunction)
+	}
+	ret := mc.UserFunctions()
+	e.userFunctionsCache[mc] = ret
+	return ret
+}
+
+// pluginFunctions returns the functions of the given function plugins that
+// the given module requires, for use as [lang.Scope.PluginFunctions]. The
+// plugins' commands run under stopCtx, so that stopping the operation also
+// stops any command that is still running.
+//
+// Plugins that are missing from the CLI configuration or that failed to load
+// are left out, because checkFunctionPlugins reports those problems before
+// we evaluate anything.
+func pluginFunctions(stopCtx context.Context, plugins map[string]*funcplugin.Plugin, mc *configs.Config) map[string]map[string]function.Function {
+	if mc == nil || mc.Module == nil || len(mc.Module.RequiredFunctions) == 0 {
+		return nil
+	}
+	ret := make(map[string]map[string]function.Function, len(mc.Module.RequiredFunctions))
+	for name := range mc.Module.RequiredFunctions {
+		plugin, ok := plugins[name]
+		if !ok {
+			continue
+		}
+		funcs, err := plugin.Functions(stopCtx)
+		if err != nil {
+			continue
+		}
+		ret[name] = funcs
+	}
+	return ret
+}
+
 // evaluationStateData is an implementation of lang.Data that resolves
 // references primarily (but not exclusively) using information from a State.
 type evaluationStateData struct {
@@ -383,6 +450,12 @@ func (d *evaluationStateData) GetModule(_ context.Context, addr addrs.ModuleCall
 		return cty.DynamicVal, diags
 	}
 
+	if d.Evaluator.Deferrals.moduleCallDeferred(d.ModulePath, addr) {
+		// We don't know which instances of this module call exist yet, and
+		// so we can't say anything about its outputs either.
+		return cty.DynamicVal, diags
+	}
+
 	// We'll consult the configuration to see what output names we are
 	// expecting, so we can ensure the resulting object is of the expected
 	// type even if our data is incomplete for some reason.
@@ -744,6 +817,12 @@ func (d *evaluationStateData) GetResource(ctx context.Context, addr addrs.Resour
 	}
 	ty := schema.ImpliedType()
 
+	if d.Evaluator.Deferrals.resourceDeferred(addr.Absolute(d.ModulePath)) {
+		// We don't know which instances of this resource exist yet, and
+		// so we can't say anything about their values either.
+		return cty.DynamicVal, diags
+	}
+
 	rs := d.Evaluator.State.Resource(addr.Absolute(d.ModulePath))
 
 	if rs == nil {
@@ -80,6 +80,13 @@ func (g *Graph) walk(ctx context.Context, walker GraphWalker, backupStateForPani
 			}
 		}()
 
+		// If the planning of something this node depends on was deferred,
+		// then we might not be able to visit this node until a later round.
+		if deferrals := evalCtx.Deferrals(); deferrals != nil && deferrals.dependsOnDeferred(g, v) && skipWhenDeferred(v) {
+			log.Printf("[TRACE] vertex %q: skipping because it depends on a deferred object", dag.VertexName(v))
+			return
+		}
+
 		// vertexCtx is the context that we use when evaluating. This
 		// is normally the context of our graph but can be overridden
 		// with a GraphNodeModuleInstance impl.
@@ -150,5 +157,24 @@ func (g *Graph) walk(ctx context.Context, walker GraphWalker, backupStateForPani
 		return
 	}
 
-	return g.AcyclicGraph.Walk(walkFn)
+	// Let the hooks know about any planned changes that won't be applied
+	// because something they depend on failed. Once the operation is
+	// stopped, nothing more gets applied anyway, so there's nothing to say.
+	skipFn := func(v dag.Vertex) {
+		select {
+		case <-evalCtx.Stopped():
+			return
+		default:
+		}
+		if n, ok := v.(graphNodeAppliesChange); ok {
+			addr, gen := n.appliedObject()
+			_ = evalCtx.Hook(func(h Hook) (HookAction, error) {
+				return h.ApplySkipped(addr, gen)
+			})
+		}
+	}
+
+	w := &dag.Walker{Callback: walkFn, SkipCallback: skipFn, Reverse: true}
+	w.Update(&g.AcyclicGraph)
+	return w.Wait()
 }
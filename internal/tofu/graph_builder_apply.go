@@ -60,6 +60,11 @@ type ApplyGraphBuilder struct {
 	// actions remain consistent between plan and apply.
 	ForceReplace []addrs.AbsResourceInstance
 
+	// DefaultRetryPolicy is the policy for retrying failed changes, including
+	// destroying, to managed resources that don't have a retry policy of
+	// their own, or nil to not retry them.
+	DefaultRetryPolicy *configs.RetryPolicy
+
 	// Plan Operation this graph will be used for.
 	Operation walkOperation
 
@@ -95,6 +100,7 @@ func (b *ApplyGraphBuilder) Steps() []GraphTransformer {
 	}
 
 	concreteResourceInstance := func(a *NodeAbstractResourceInstance) dag.Vertex {
+		a.defaultRetryPolicy = b.DefaultRetryPolicy
 		return &NodeApplyableResourceInstance{
 			NodeAbstractResourceInstance: a,
 			forceReplace:                 b.ForceReplace,
@@ -124,10 +130,11 @@ func (b *ApplyGraphBuilder) Steps() []GraphTransformer {
 		// with dependency edges against the whole-resource nodes added by
 		// ConfigTransformer above.
 		&DiffTransformer{
-			Concrete: concreteResourceInstance,
-			State:    b.State,
-			Changes:  b.Changes,
-			Config:   b.Config,
+			Concrete:           concreteResourceInstance,
+			State:              b.State,
+			Changes:            b.Changes,
+			Config:             b.Config,
+			DefaultRetryPolicy: b.DefaultRetryPolicy,
 		},
 
 		// Add nodes and edges for check block assertions. Check block data
@@ -37,6 +37,7 @@ type ContextGraphWalker struct {
 	Checks                  *checks.State           // Used for safe concurrent writes of checkable objects and their check results
 	InstanceExpander        *instances.Expander     // Tracks our gradual expansion of module and resource instances
 	ImportResolver          *ImportResolver         // Tracks import targets as they are being resolved
+	Deferrals               *Deferrals              // Tracks objects whose planning is deferred, or nil if deferral isn't allowed
 	MoveResults             refactoring.MoveResults // Read-only record of earlier processing of move statements
 	Operation               walkOperation
 	StopContext             context.Context
@@ -58,6 +59,9 @@ type ContextGraphWalker struct {
 	variableValues     map[string]map[string]cty.Value
 
 	providerInputConfigLock sync.Mutex
+
+	// concurrency is nil if the walk isn't subject to concurrency limits.
+	concurrency *concurrencyLimiter
 }
 
 var _ GraphWalker = (*ContextGraphWalker)(nil)
@@ -90,10 +94,12 @@ func (w *ContextGraphWalker) EvalContext() EvalContext {
 		State:              w.State,
 		Changes:            w.Changes,
 		Plugins:            w.Context.plugins,
+		FunctionPlugins:    w.Context.functionPlugins,
 		VariableValues:     w.variableValues,
 		VariableValuesLock: &w.variableValuesLock,
 		InstanceExpander:   w.InstanceExpander,
 		PlanTimestamp:      w.PlanTimestamp,
+		Deferrals:          w.Deferrals,
 	}
 
 	ctx := &BuiltinEvalContext{
@@ -104,6 +110,7 @@ func (w *ContextGraphWalker) EvalContext() EvalContext {
 		Plugins:                 w.Context.plugins,
 		MoveResultsValue:        w.MoveResults,
 		ImportResolverValue:     w.ImportResolver,
+		DeferralsValue:          w.Deferrals,
 		ProviderInputConfigLock: &w.providerInputConfigLock,
 		ProviderInputConfig:     w.Context.providerInputConfig,
 		ChangesValue:            w.Changes,
@@ -124,6 +131,9 @@ func (w *ContextGraphWalker) EvalContext() EvalContext {
 func (w *ContextGraphWalker) init() {
 	w.contexts = make(map[string]*BuiltinEvalContext)
 	w.variableValues = make(map[string]map[string]cty.Value)
+	if concurrencyLimitedWalk(w.Operation) {
+		w.concurrency = newConcurrencyLimiter(w.Config, w.Context.providerConcurrency, w.Context.resourceTypeConcurrency)
+	}
 
 	// Populate root module variable values. Other modules will be populated
 	// during the graph walk.
@@ -134,6 +144,11 @@ func (w *ContextGraphWalker) init() {
 }
 
 func (w *ContextGraphWalker) Execute(ctx context.Context, evalCtx EvalContext, n GraphNodeExecutable) tfdiags.Diagnostics {
+	// Wait for any narrower limits first, so that a node waiting for a
+	// heavily-limited provider doesn't hold one of the overall slots.
+	release := w.concurrency.Acquire(n)
+	defer release()
+
 	// Acquire a lock on the semaphore
 	w.Context.parallelSem.Acquire()
 	defer w.Context.parallelSem.Release()
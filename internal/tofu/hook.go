@@ -6,6 +6,8 @@
 package tofu
 
 import (
+	"time"
+
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/addrs"
@@ -41,6 +43,18 @@ type Hook interface {
 	PreApply(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, priorState, plannedNewState cty.Value) (HookAction, error)
 	PostApply(addr addrs.AbsResourceInstance, gen states.Generation, newState cty.Value, err error) (HookAction, error)
 
+	// ApplyRetry is called between PreApply and PostApply when applying an
+	// action failed with an error that the resource's retry policy allows
+	// retrying. attempt is the number of the attempt that will be made after
+	// waiting for the given delay, starting at 2 for the first retry, and err
+	// is the error from the previous attempt.
+	ApplyRetry(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, attempt int, delay time.Duration, err error) (HookAction, error)
+
+	// ApplySkipped is called instead of PreApply and PostApply when an
+	// apply operation doesn't attempt to change an object because something
+	// that the change depends on failed.
+	ApplySkipped(addr addrs.AbsResourceInstance, gen states.Generation) (HookAction, error)
+
 	// PreDiff and PostDiff are called before and after a provider is given
 	// the opportunity to customize the proposed new state to produce the
 	// planned new state.
@@ -154,6 +168,14 @@ func (*NilHook) PostApply(addr addrs.AbsResourceInstance, gen states.Generation,
 	return HookActionContinue, nil
 }
 
+func (*NilHook) ApplyRetry(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, attempt int, delay time.Duration, err error) (HookAction, error) {
+	return HookActionContinue, nil
+}
+
+func (*NilHook) ApplySkipped(addr addrs.AbsResourceInstance, gen states.Generation) (HookAction, error) {
+	return HookActionContinue, nil
+}
+
 func (*NilHook) PreDiff(addr addrs.AbsResourceInstance, gen states.Generation, priorState, proposedNewState cty.Value) (HookAction, error) {
 	return HookActionContinue, nil
 }
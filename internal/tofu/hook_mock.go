@@ -7,6 +7,7 @@ package tofu
 
 import (
 	"sync"
+	"time"
 
 	"github.com/zclconf/go-cty/cty"
 
@@ -39,6 +40,20 @@ type MockHook struct {
 	PostApplyReturnError error
 	PostApplyFn          func(addrs.AbsResourceInstance, states.Generation, cty.Value, error) (HookAction, error)
 
+	ApplyRetryCalled  int
+	ApplyRetryAddr    addrs.AbsResourceInstance
+	ApplyRetryGen     states.Generation
+	ApplyRetryAction  plans.Action
+	ApplyRetryAttempt int
+	ApplyRetryDelay   time.Duration
+	ApplyRetryError   error
+	ApplyRetryReturn  HookAction
+
+	ApplySkippedCalled int
+	ApplySkippedAddr   addrs.AbsResourceInstance
+	ApplySkippedGen    states.Generation
+	ApplySkippedReturn HookAction
+
 	PreDiffCalled        bool
 	PreDiffAddr          addrs.AbsResourceInstance
 	PreDiffGen           states.Generation
@@ -220,6 +235,30 @@ func (h *MockHook) PostApply(addr addrs.AbsResourceInstance, gen states.Generati
 	return h.PostApplyReturn, h.PostApplyReturnError
 }
 
+func (h *MockHook) ApplyRetry(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, attempt int, delay time.Duration, err error) (HookAction, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.ApplyRetryCalled++
+	h.ApplyRetryAddr = addr
+	h.ApplyRetryGen = gen
+	h.ApplyRetryAction = action
+	h.ApplyRetryAttempt = attempt
+	h.ApplyRetryDelay = delay
+	h.ApplyRetryError = err
+	return h.ApplyRetryReturn, nil
+}
+
+func (h *MockHook) ApplySkipped(addr addrs.AbsResourceInstance, gen states.Generation) (HookAction, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.ApplySkippedCalled++
+	h.ApplySkippedAddr = addr
+	h.ApplySkippedGen = gen
+	return h.ApplySkippedReturn, nil
+}
+
 func (h *MockHook) PreDiff(addr addrs.AbsResourceInstance, gen states.Generation, priorState, proposedNewState cty.Value) (HookAction, error) {
 	h.Lock()
 	defer h.Unlock()
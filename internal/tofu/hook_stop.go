@@ -8,6 +8,7 @@ package tofu
 import (
 	"errors"
 	"sync/atomic"
+	"time"
 
 	"github.com/zclconf/go-cty/cty"
 
@@ -33,6 +34,14 @@ func (h *stopHook) PostApply(addr addrs.AbsResourceInstance, gen states.Generati
 	return h.hook()
 }
 
+func (h *stopHook) ApplyRetry(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, attempt int, delay time.Duration, err error) (HookAction, error) {
+	return h.hook()
+}
+
+func (h *stopHook) ApplySkipped(addr addrs.AbsResourceInstance, gen states.Generation) (HookAction, error) {
+	return h.hook()
+}
+
 func (h *stopHook) PreDiff(addr addrs.AbsResourceInstance, gen states.Generation, priorState, proposedNewState cty.Value) (HookAction, error) {
 	return h.hook()
 }
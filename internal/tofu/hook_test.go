@@ -8,6 +8,7 @@ package tofu
 import (
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/zclconf/go-cty/cty"
 
@@ -53,6 +54,20 @@ func (h *testHook) PostApply(addr addrs.AbsResourceInstance, gen states.Generati
 	return HookActionContinue, nil
 }
 
+func (h *testHook) ApplyRetry(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, attempt int, delay time.Duration, err error) (HookAction, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.Calls = append(h.Calls, &testHookCall{"ApplyRetry", addr.String()})
+	return HookActionContinue, nil
+}
+
+func (h *testHook) ApplySkipped(addr addrs.AbsResourceInstance, gen states.Generation) (HookAction, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.Calls = append(h.Calls, &testHookCall{"ApplySkipped", addr.String()})
+	return HookActionContinue, nil
+}
+
 func (h *testHook) PreDiff(addr addrs.AbsResourceInstance, gen states.Generation, priorState, proposedNewState cty.Value) (HookAction, error) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
@@ -132,6 +132,11 @@ func (n *nodeExpandModule) Execute(ctx context.Context, evalCtx EvalContext, op
 		switch {
 		case n.ModuleCall.Count != nil:
 			count, ctDiags := evaluateCountExpression(ctx, n.ModuleCall.Count, evalCtx, module)
+			if deferUnknownExpansion(evalCtx, n, module.Child(call.Name, addrs.NoKey), ctDiags) {
+				// The module call has no instances until a later round.
+				expander.SetModuleCount(module, call, 0)
+				continue
+			}
 			diags = diags.Append(ctDiags)
 			if diags.HasErrors() {
 				return diags
@@ -140,6 +145,11 @@ func (n *nodeExpandModule) Execute(ctx context.Context, evalCtx EvalContext, op
 
 		case n.ModuleCall.ForEach != nil:
 			forEach, feDiags := evaluateForEachExpression(ctx, n.ModuleCall.ForEach, evalCtx, module)
+			if deferUnknownExpansion(evalCtx, n, module.Child(call.Name, addrs.NoKey), feDiags) {
+				// The module call has no instances until a later round.
+				expander.SetModuleCount(module, call, 0)
+				continue
+			}
 			diags = diags.Append(feDiags)
 			if diags.HasErrors() {
 				return diags
@@ -234,6 +234,10 @@ func (n *nodeModuleVariable) evalModuleVariable(ctx context.Context, evalCtx Eva
 		}
 		givenVal = val
 		errSourceRange = tfdiags.SourceRangeFromHCL(expr.Range())
+
+		// We check for deprecated nested attributes before the value is
+		// converted, so that we only warn about the ones the caller set.
+		diags = diags.Append(evalVariableAttributeDeprecations(n.Addr, n.Config, expr, givenVal))
 	} else {
 		// We'll use cty.NilVal to represent the variable not being set at all.
 		givenVal = cty.NilVal
@@ -94,6 +94,11 @@ type NodeAbstractResourceInstance struct {
 
 	ResolvedProviderKey addrs.InstanceKey
 
+	// defaultRetryPolicy is the policy for retrying failed changes to use
+	// when the resource configuration doesn't have its own, or nil if
+	// failed changes should not be retried by default.
+	defaultRetryPolicy *configs.RetryPolicy
+
 	// These are the fields that should be strictly used when this node is acting upon an ephemeral resource.
 	// The ephemeralCloseFn is initialized right before scheduling the renewal process.
 	//
@@ -642,7 +647,7 @@ func (n *NodeAbstractResourceInstance) preApplyHook(evalCtx EvalContext, change
 }
 
 // postApplyHook calls the post-Apply hook
-func (n *NodeAbstractResourceInstance) postApplyHook(evalCtx EvalContext, state *states.ResourceInstanceObject, err error) tfdiags.Diagnostics {
+func (n *NodeAbstractResourceInstance) postApplyHook(evalCtx EvalContext, gen states.Generation, state *states.ResourceInstanceObject, err error) tfdiags.Diagnostics {
 	var diags tfdiags.Diagnostics
 
 	// Only managed resources have user-visible apply actions.
@@ -654,7 +659,7 @@ func (n *NodeAbstractResourceInstance) postApplyHook(evalCtx EvalContext, state
 			newState = cty.NullVal(cty.DynamicPseudoType)
 		}
 		diags = diags.Append(evalCtx.Hook(func(h Hook) (HookAction, error) {
-			return h.PostApply(n.Addr, nil, newState, err)
+			return h.PostApply(n.Addr, gen, newState, err)
 		}))
 	}
 
@@ -2341,7 +2346,10 @@ func (n *NodeAbstractResourceInstance) planDataSource(ctx context.Context, evalC
 
 			// We still want to report the check as failed even if we are still
 			// letting it run again during the apply stage.
-			evalCtx.Checks().ReportCheckFailure(addr, addrs.CheckDataResource, 0, readDiags.Err().Error())
+			evalCtx.Checks().ReportCheckFailure(addr, addrs.CheckDataResource, 0, checks.Failure{
+				Message:  readDiags.Err().Error(),
+				Severity: tfdiags.Warning,
+			})
 		}
 
 		// Any warning or error diagnostics we'll wrap with some special checks
@@ -2525,7 +2533,10 @@ func (n *NodeAbstractResourceInstance) applyDataSource(ctx context.Context, eval
 		// We're just going to jump in here and hide away any errors for nested
 		// data blocks.
 		if readDiags.HasErrors() {
-			evalCtx.Checks().ReportCheckFailure(addr, addrs.CheckDataResource, 0, readDiags.Err().Error())
+			evalCtx.Checks().ReportCheckFailure(addr, addrs.CheckDataResource, 0, checks.Failure{
+				Message:  readDiags.Err().Error(),
+				Severity: tfdiags.Warning,
+			})
 			diags = diags.Append(tfdiags.OverrideAll(readDiags, tfdiags.Warning, func() tfdiags.DiagnosticExtraWrapper {
 				return &addrs.CheckRuleDiagnosticExtra{
 					CheckRule: addrs.NewCheckRule(addr, addrs.CheckDataResource, 0),
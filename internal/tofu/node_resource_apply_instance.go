@@ -9,6 +9,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/configs"
@@ -53,8 +56,20 @@ var (
 	_ GraphNodeDeposer            = (*NodeApplyableResourceInstance)(nil)
 	_ GraphNodeExecutable         = (*NodeApplyableResourceInstance)(nil)
 	_ GraphNodeAttachDependencies = (*NodeApplyableResourceInstance)(nil)
+	_ graphNodeAppliesChange      = (*NodeApplyableResourceInstance)(nil)
 )
 
+// graphNodeAppliesChange is implemented by the apply graph nodes that apply
+// a planned change to a single resource instance object, so that the hooks
+// can be told when the change is skipped because of an upstream failure.
+type graphNodeAppliesChange interface {
+	appliedObject() (addrs.AbsResourceInstance, states.Generation)
+}
+
+func (n *NodeApplyableResourceInstance) appliedObject() (addrs.AbsResourceInstance, states.Generation) {
+	return n.Addr, states.CurrentGen
+}
+
 // CreateBeforeDestroy returns this node's CreateBeforeDestroy status.
 func (n *NodeApplyableResourceInstance) CreateBeforeDestroy() bool {
 	if n.ForceCreateBeforeDestroy {
@@ -336,40 +351,61 @@ func (n *NodeApplyableResourceInstance) managedResourceExecute(ctx context.Conte
 		return diags
 	}
 
-	// Make a new diff, in case we've learned new values in the state
-	// during apply which we can now incorporate.
-	diffApply, _, repeatData, planDiags := n.plan(ctx, evalCtx, diff, state, false, n.forceReplace)
-	diags = diags.Append(planDiags)
-	if diags.HasErrors() {
-		return diags
-	}
+	priorState := state
+	retryPolicy := n.retryPolicy()
+	var repeatData instances.RepetitionData
+	var applyDiags tfdiags.Diagnostics
+	for attempt := 1; ; attempt++ {
+		// Make a new diff, in case we've learned new values in the state
+		// during apply which we can now incorporate.
+		var planDiags tfdiags.Diagnostics
+		diffApply, _, repeatData, planDiags = n.plan(ctx, evalCtx, diff, priorState, false, n.forceReplace)
+		if attempt == 1 || planDiags.HasErrors() {
+			// Any warnings were already reported by the first attempt.
+			diags = diags.Append(planDiags)
+		}
+		if diags.HasErrors() {
+			return diags
+		}
 
-	// Compare the diffs
-	diags = diags.Append(n.checkPlannedChange(evalCtx, diff, diffApply, providerSchema))
-	if diags.HasErrors() {
-		return diags
-	}
+		// Compare the diffs
+		diags = diags.Append(n.checkPlannedChange(evalCtx, diff, diffApply, providerSchema))
+		if diags.HasErrors() {
+			return diags
+		}
 
-	diffApply = reducePlan(addr, diffApply, false)
-	// reducePlan may have simplified our planned change
-	// into a NoOp if it only requires destroying, since destroying
-	// is handled by NodeDestroyResourceInstance. If so, we'll
-	// still run through most of the logic here because we do still
-	// need to deal with other book-keeping such as marking the
-	// change as "complete", and running the author's postconditions.
+		diffApply = reducePlan(addr, diffApply, false)
+		// reducePlan may have simplified our planned change
+		// into a NoOp if it only requires destroying, since destroying
+		// is handled by NodeDestroyResourceInstance. If so, we'll
+		// still run through most of the logic here because we do still
+		// need to deal with other book-keeping such as marking the
+		// change as "complete", and running the author's postconditions.
+
+		if attempt == 1 {
+			diags = diags.Append(n.preApplyHook(evalCtx, diffApply))
+			if diags.HasErrors() {
+				return diags
+			}
+		}
 
-	diags = diags.Append(n.preApplyHook(evalCtx, diffApply))
-	if diags.HasErrors() {
-		return diags
-	}
+		// If there is no change, there was nothing to apply, and we don't need to
+		// re-write the state, but we do need to re-evaluate postconditions.
+		if diffApply.Action == plans.NoOp {
+			return diags.Append(n.managedResourcePostconditions(ctx, evalCtx, repeatData))
+		}
 
-	// If there is no change, there was nothing to apply, and we don't need to
-	// re-write the state, but we do need to re-evaluate postconditions.
-	if diffApply.Action == plans.NoOp {
-		return diags.Append(n.managedResourcePostconditions(ctx, evalCtx, repeatData))
-	}
+		state, applyDiags = n.apply(ctx, evalCtx, priorState, diffApply, n.Config, repeatData, n.CreateBeforeDestroy())
+		if !shouldRetryApply(retryPolicy, attempt, priorState, state, applyDiags) {
+			break
+		}
 
-	state, applyDiags := n.apply(ctx, evalCtx, state, diffApply, n.Config, repeatData, n.CreateBeforeDestroy())
+		retry, hookDiags := n.waitToRetryApply(evalCtx, retryPolicy, diffApply, attempt, applyDiags)
+		diags = diags.Append(hookDiags)
+		if !retry {
+			break
+		}
+	}
 	diags = diags.Append(applyDiags)
 
 	// We clear the change out here so that future nodes don't see a change
@@ -437,7 +473,7 @@ func (n *NodeApplyableResourceInstance) managedResourceExecute(ctx context.Conte
 		}
 	}
 
-	diags = diags.Append(n.postApplyHook(evalCtx, state, diags.Err()))
+	diags = diags.Append(n.postApplyHook(evalCtx, states.CurrentGen, state, diags.Err()))
 	diags = diags.Append(updateStateHook(evalCtx, n.Addr))
 
 	// Post-conditions might block further progress. We intentionally do this
@@ -556,6 +592,106 @@ func maybeTainted(addr addrs.AbsResourceInstance, state *states.ResourceInstance
 	return state
 }
 
+// retryPolicy returns the policy for retrying failed changes to this resource
+// instance, or nil if they should not be retried.
+func (n *NodeAbstractResourceInstance) retryPolicy() *configs.RetryPolicy {
+	if n.Config != nil && n.Config.Managed != nil && n.Config.Managed.Retry != nil {
+		return n.Config.Managed.Retry
+	}
+	return n.defaultRetryPolicy
+}
+
+// shouldRetryApply decides whether to make another attempt at applying a
+// change after the given attempt produced the given result.
+//
+// We retry only if all of the errors are ones that the policy allows
+// retrying, and only if the provider reported that the remote object was
+// left unchanged, because otherwise the change we planned is no longer
+// valid for whatever the failed attempt left behind.
+func shouldRetryApply(policy *configs.RetryPolicy, attempt int, prior, result *states.ResourceInstanceObject, diags tfdiags.Diagnostics) bool {
+	if policy == nil || attempt >= policy.MaxAttempts || !diags.HasErrors() {
+		return false
+	}
+	for _, diag := range diags {
+		if diag.Severity() != tfdiags.Error {
+			continue
+		}
+		desc := diag.Description()
+		if !policy.MatchesError(desc.Summary, desc.Detail) {
+			return false
+		}
+	}
+
+	objectValue := func(obj *states.ResourceInstanceObject) cty.Value {
+		if obj == nil || obj.Value == cty.NilVal {
+			return cty.NullVal(cty.DynamicPseudoType)
+		}
+		v, _ := obj.Value.UnmarkDeep()
+		return v
+	}
+	priorVal, resultVal := objectValue(prior), objectValue(result)
+	if priorVal.IsNull() || resultVal.IsNull() {
+		return priorVal.IsNull() && resultVal.IsNull()
+	}
+	eq := priorVal.Equals(resultVal)
+	return eq.IsKnown() && eq.True()
+}
+
+// waitToRetryApply decides whether to make another attempt at applying the
+// given change after the given attempt failed with the given diagnostics,
+// and if so notifies the hooks and waits for the policy's delay before
+// returning true.
+func (n *NodeAbstractResourceInstance) waitToRetryApply(evalCtx EvalContext, policy *configs.RetryPolicy, change *plans.ResourceInstanceChange, attempt int, applyDiags tfdiags.Diagnostics) (bool, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	delay := policy.Delay(attempt)
+	log.Printf("[WARN] %s failed to %s on attempt %d of %d, retrying in %s: %s", n.Addr, change.Action, attempt, policy.MaxAttempts, delay, applyDiags.Err())
+	hookErr := evalCtx.Hook(func(h Hook) (HookAction, error) {
+		return h.ApplyRetry(n.Addr, change.DeposedKey.Generation(), change.Action, attempt+1, delay, applyDiags.Err())
+	})
+	if hookErr != nil {
+		return false, diags.Append(hookErr)
+	}
+	if !sleepUnlessStopped(evalCtx, delay) {
+		log.Printf("[WARN] Not retrying %s because the operation is stopping", n.Addr)
+		return false, diags
+	}
+	return true, diags
+}
+
+// applyDestroy destroys the given object, retrying according to the
+// resource's retry policy if the provider fails to destroy it.
+func (n *NodeAbstractResourceInstance) applyDestroy(ctx context.Context, evalCtx EvalContext, state *states.ResourceInstanceObject, change *plans.ResourceInstanceChange) (*states.ResourceInstanceObject, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	retryPolicy := n.retryPolicy()
+	for attempt := 1; ; attempt++ {
+		// we pass a nil configuration to apply because we are destroying
+		newState, applyDiags := n.apply(ctx, evalCtx, state, change, nil, instances.RepetitionData{}, false)
+		if !shouldRetryApply(retryPolicy, attempt, state, newState, applyDiags) {
+			return newState, diags.Append(applyDiags)
+		}
+		retry, hookDiags := n.waitToRetryApply(evalCtx, retryPolicy, change, attempt, applyDiags)
+		diags = diags.Append(hookDiags)
+		if !retry {
+			return newState, diags.Append(applyDiags)
+		}
+	}
+}
+
+// sleepUnlessStopped waits for the given duration, returning false without
+// waiting for all of it if the operation is stopped in the meantime.
+func sleepUnlessStopped(evalCtx EvalContext, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-evalCtx.Stopped():
+		return false
+	}
+}
+
 // Close implements closableResource
 func (n *NodeApplyableResourceInstance) Close() (diags tfdiags.Diagnostics) {
 	if n.Addr.Resource.Resource.Mode != addrs.EphemeralResourceMode {
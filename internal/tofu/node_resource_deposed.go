@@ -14,7 +14,6 @@ import (
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/dag"
-	"github.com/opentofu/opentofu/internal/instances"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/refactoring"
 	"github.com/opentofu/opentofu/internal/states"
@@ -252,6 +251,7 @@ var (
 	_ GraphNodeExecutable                    = (*NodeDestroyDeposedResourceInstanceObject)(nil)
 	_ GraphNodeProviderConsumer              = (*NodeDestroyDeposedResourceInstanceObject)(nil)
 	_ GraphNodeProvisionerConsumer           = (*NodeDestroyDeposedResourceInstanceObject)(nil)
+	_ graphNodeAppliesChange                 = (*NodeDestroyDeposedResourceInstanceObject)(nil)
 )
 
 func (n *NodeDestroyDeposedResourceInstanceObject) Name() string {
@@ -262,6 +262,10 @@ func (n *NodeDestroyDeposedResourceInstanceObject) DeposedInstanceObjectKey() st
 	return n.DeposedKey
 }
 
+func (n *NodeDestroyDeposedResourceInstanceObject) appliedObject() (addrs.AbsResourceInstance, states.Generation) {
+	return n.Addr, n.DeposedKey
+}
+
 // GraphNodeReferenceable implementation, overriding the one from NodeAbstractResourceInstance
 func (n *NodeDestroyDeposedResourceInstanceObject) ReferenceableAddrs() []addrs.Referenceable {
 	// Deposed objects don't participate in references.
@@ -329,8 +333,7 @@ func (n *NodeDestroyDeposedResourceInstanceObject) Execute(ctx context.Context,
 		return diags
 	}
 
-	// we pass a nil configuration to apply because we are destroying
-	state, applyDiags := n.apply(ctx, evalCtx, state, change, nil, instances.RepetitionData{}, false)
+	state, applyDiags := n.applyDestroy(ctx, evalCtx, state, change)
 	diags = diags.Append(applyDiags)
 	// don't return immediately on errors, we need to handle the state
 
@@ -343,7 +346,7 @@ func (n *NodeDestroyDeposedResourceInstanceObject) Execute(ctx context.Context,
 		return diags
 	}
 
-	diags = diags.Append(n.postApplyHook(evalCtx, state, diags.Err()))
+	diags = diags.Append(n.postApplyHook(evalCtx, n.DeposedKey, state, diags.Err()))
 
 	return diags.Append(updateStateHook(evalCtx, n.Addr))
 }
@@ -13,7 +13,6 @@ import (
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/communicator/shared"
 	"github.com/opentofu/opentofu/internal/configs"
-	"github.com/opentofu/opentofu/internal/instances"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -39,6 +38,7 @@ var (
 	_ GraphNodeDestroyer           = (*NodeDestroyResourceInstance)(nil)
 	_ GraphNodeDestroyerCBD        = (*NodeDestroyResourceInstance)(nil)
 	_ GraphNodeReferenceable       = (*NodeDestroyResourceInstance)(nil)
+	_ graphNodeAppliesChange       = (*NodeDestroyResourceInstance)(nil)
 	_ GraphNodeReferencer          = (*NodeDestroyResourceInstance)(nil)
 	_ GraphNodeExecutable          = (*NodeDestroyResourceInstance)(nil)
 	_ GraphNodeProviderConsumer    = (*NodeDestroyResourceInstance)(nil)
@@ -52,6 +52,10 @@ func (n *NodeDestroyResourceInstance) Name() string {
 	return n.ResourceInstanceAddr().String() + " (destroy)"
 }
 
+func (n *NodeDestroyResourceInstance) appliedObject() (addrs.AbsResourceInstance, states.Generation) {
+	return n.Addr, n.DeposedKey.Generation()
+}
+
 func (n *NodeDestroyResourceInstance) ProvidedBy() RequestedProvider {
 	switch n.Addr.Resource.Resource.Mode {
 	case addrs.DataResourceMode:
@@ -248,15 +252,14 @@ func (n *NodeDestroyResourceInstance) managedResourceExecute(ctx context.Context
 		if diags.HasErrors() {
 			// If we have a provisioning error, then we just call
 			// the post-apply hook now.
-			diags = diags.Append(n.postApplyHook(evalCtx, state, diags.Err()))
+			diags = diags.Append(n.postApplyHook(evalCtx, n.DeposedKey.Generation(), state, diags.Err()))
 			return diags
 		}
 	}
 
 	// Managed resources need to be destroyed, while data sources
 	// are only removed from state.
-	// we pass a nil configuration to apply because we are destroying
-	s, d := n.apply(ctx, evalCtx, state, changeApply, nil, instances.RepetitionData{}, false)
+	s, d := n.applyDestroy(ctx, evalCtx, state, changeApply)
 	state, diags = s, diags.Append(d)
 	// we don't return immediately here on error, so that the state can be
 	// finalized
@@ -267,7 +270,7 @@ func (n *NodeDestroyResourceInstance) managedResourceExecute(ctx context.Context
 	}
 
 	// create the err value for postApplyHook
-	diags = diags.Append(n.postApplyHook(evalCtx, state, diags.Err()))
+	diags = diags.Append(n.postApplyHook(evalCtx, n.DeposedKey.Generation(), state, diags.Err()))
 	diags = diags.Append(updateStateHook(evalCtx, n.Addr))
 	return diags
 }
@@ -237,6 +237,11 @@ func (n *nodeExpandPlannableResource) expandResourceInstances(ctx context.Contex
 	// repetition mode this resource has, which allows expander.ExpandResource
 	// to work below.
 	moreDiags := n.writeResourceState(ctx, moduleCtx, resAddr)
+	if deferUnknownExpansion(moduleCtx, n, resAddr, moreDiags) {
+		// We'll plan this resource's instances in a later round, once we
+		// know which ones should exist.
+		return nil
+	}
 	diags = diags.Append(moreDiags)
 	if moreDiags.HasErrors() {
 		return diags.ErrWithWarnings()
@@ -193,9 +193,14 @@ func (tc *TestContext) evaluate(state *states.SyncState, changes *plans.ChangesS
 		}
 
 		if runVal.False() {
-			run.Status = run.Status.Merge(moduletest.Fail)
+			// An assertion with severity = warning reports its failure
+			// without failing the run.
+			severity := rule.FailureSeverity(tfdiags.Error)
+			if severity == tfdiags.Error {
+				run.Status = run.Status.Merge(moduletest.Fail)
+			}
 			run.Diagnostics = run.Diagnostics.Append(&hcl.Diagnostic{
-				Severity:    hcl.DiagError,
+				Severity:    severity.ToHCL(),
 				Summary:     "Test assertion failed",
 				Detail:      errorMessage,
 				Subject:     rule.Condition.Range().Ptr(),
@@ -26,6 +26,10 @@ type DiffTransformer struct {
 	State    *states.State
 	Changes  *plans.Changes
 	Config   *configs.Config
+
+	// DefaultRetryPolicy is given to the nodes that destroy objects, which
+	// are not created using Concrete.
+	DefaultRetryPolicy *configs.RetryPolicy
 }
 
 // return true if the given resource instance has either Preconditions or
@@ -226,6 +230,7 @@ func (t *DiffTransformer) Transform(_ context.Context, g *Graph) error {
 			// or a deposed object.
 			var node GraphNodeResourceInstance
 			abstract := NewNodeAbstractResourceInstance(addr)
+			abstract.defaultRetryPolicy = t.DefaultRetryPolicy
 			if dk == states.NotDeposed {
 				// If any removed block is targeting the resource in this node, ensure that any provisioners defined in that block are going to be
 				// executed before actual resource destruction.